@@ -1,18 +1,509 @@
 package main
 
 import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/a14e/gogreement/src/analyzer"
+	"github.com/a14e/gogreement/src/annotations"
+	"github.com/a14e/gogreement/src/assertions"
+	"github.com/a14e/gogreement/src/config"
+	"github.com/a14e/gogreement/src/inventory"
+	"github.com/a14e/gogreement/src/output"
+	"github.com/a14e/gogreement/src/reporting"
+	"github.com/a14e/gogreement/src/rewrite"
+	"github.com/a14e/gogreement/src/stats"
+	"github.com/a14e/gogreement/src/trace"
 
+	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/multichecker"
+	"golang.org/x/tools/go/packages"
 )
 
+// childEnv marks the child process started by runChild
+const childEnv = "GOGREEMENT_CHILD"
+
 func main() {
 	// If no arguments provided, add --help to show multichecker help
 	if len(os.Args) == 1 {
 		os.Args = append(os.Args, "--help")
 	}
 
+	// Subcommands are handled before multichecker, which treats every
+	// positional argument as a package pattern
+	if os.Args[1] == "init" {
+		os.Exit(runInit(os.Args[2:]))
+	}
+	if os.Args[1] == "merge" {
+		os.Exit(runMerge(os.Args[2:]))
+	}
+	if os.Args[1] == "-emit-assertions" || os.Args[1] == "--emit-assertions" {
+		os.Exit(runEmitAssertions(os.Args[2:]))
+	}
+	if os.Args[1] == "-rewrite" || os.Args[1] == "--rewrite" {
+		os.Exit(runRewrite(os.Args[2:]))
+	}
+
+	// A misconfiguration is reported once here rather than by every package
+	cfg := commandLineConfig(os.Args[1:])
+	if err := cfg.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "gogreement: %v\n", err)
+		os.Exit(2)
+	}
+
+	// multichecker exits as soon as analysis finishes, so --stats,
+	// --trace-contracts, --emit-inventory and non-text output formats run the
+	// analysis in a child process and post-process what it produced
+	if os.Getenv(childEnv) == "" {
+		if cfg.Stats || cfg.TraceContracts || cfg.EmitInventory || cfg.OutputFormat != config.OutputFormatText {
+			os.Exit(runChild(cfg))
+		}
+	}
+
+	reporting.ResetRun()
 	multichecker.Main(analyzer.AllAnalyzers()...)
 }
+
+// runInit writes a starter config file into the current directory
+func runInit(args []string) int {
+	fs := flag.NewFlagSet("init", flag.ContinueOnError)
+	force := fs.Bool("force", false, "Overwrite an existing "+config.FileName)
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+
+	path, err := config.WriteStarterFile(".", *force)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gogreement init: %v\n", err)
+		return 1
+	}
+
+	fmt.Printf("wrote %s\n", path)
+	return 0
+}
+
+// runMerge combines the json-v2 reports named by its arguments, such as the
+// shards of a CI run, into one report on stdout. Findings reported by several
+// shards are kept once. The exit code follows the merged findings.
+func runMerge(args []string) int {
+	fs := flag.NewFlagSet("merge", flag.ContinueOnError)
+	warningsExitCode := fs.Int("warnings-exit-code", -1, "Exit code when all findings are warnings or infos (-1 keeps the findings exit code)")
+	if err := fs.Parse(args); err != nil {
+		return 2
+	}
+	if fs.NArg() == 0 {
+		fmt.Fprintln(os.Stderr, "gogreement merge: no reports given")
+		return 2
+	}
+
+	var reports [][]output.Finding
+	for _, path := range fs.Args() {
+		findings, err := readReport(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "gogreement merge: %s: %v\n", path, err)
+			return 1
+		}
+		reports = append(reports, findings)
+	}
+
+	findings := output.MergeFindings(reports...)
+	if err := output.WriteJSONV2(os.Stdout, findings); err != nil {
+		fmt.Fprintf(os.Stderr, "gogreement merge: %v\n", err)
+		return 1
+	}
+	return output.ExitCode(findings, *warningsExitCode)
+}
+
+// readReport reads the json-v2 report at path
+func readReport(path string) ([]output.Finding, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return output.ReadJSONV2(file)
+}
+
+// runEmitAssertions writes a file of compile-time assertions of the @implements
+// annotations into every matched package that has any. Arguments are package
+// patterns (default ".") and "config."-prefixed flags.
+func runEmitAssertions(args []string) int {
+	cfg := commandLineConfig(args)
+	patterns := packagePatterns(args)
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+
+	mode := packages.NeedName | packages.NeedFiles | packages.NeedSyntax |
+		packages.NeedTypes | packages.NeedTypesInfo | packages.NeedImports | packages.NeedDeps
+	pkgs, err := packages.Load(&packages.Config{Mode: mode}, patterns...)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gogreement --emit-assertions: %v\n", err)
+		return 1
+	}
+
+	exitCode := 0
+	for _, pkg := range pkgs {
+		if len(pkg.Errors) > 0 {
+			for _, pkgErr := range pkg.Errors {
+				fmt.Fprintf(os.Stderr, "gogreement --emit-assertions: %v\n", pkgErr)
+			}
+			exitCode = 1
+			continue
+		}
+		if len(pkg.GoFiles) == 0 || cfg.ShouldSkipPackage(pkg.PkgPath) {
+			continue
+		}
+
+		pass := &analysis.Pass{Fset: pkg.Fset, Files: pkg.Syntax, Pkg: pkg.Types, TypesInfo: pkg.TypesInfo}
+		source, err := assertions.Generate(pass, annotations.ReadAllAnnotations(cfg, pass).ImplementsAnnotations)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "gogreement --emit-assertions: %s: %v\n", pkg.PkgPath, err)
+			exitCode = 1
+			continue
+		}
+		if source == nil {
+			continue
+		}
+
+		path := filepath.Join(filepath.Dir(pkg.GoFiles[0]), assertions.FileName)
+		if err := os.WriteFile(path, source, 0o644); err != nil {
+			fmt.Fprintf(os.Stderr, "gogreement --emit-assertions: %v\n", err)
+			exitCode = 1
+			continue
+		}
+		fmt.Printf("wrote %s\n", path)
+	}
+
+	return exitCode
+}
+
+// runRewrite migrates the annotations of every matched package to their
+// current compact form in place. Arguments are package patterns (default ".")
+// and "config."-prefixed flags.
+func runRewrite(args []string) int {
+	cfg := commandLineConfig(args)
+	patterns := packagePatterns(args)
+	if len(patterns) == 0 {
+		patterns = []string{"."}
+	}
+
+	mode := packages.NeedName | packages.NeedFiles | packages.NeedSyntax
+	pkgs, err := packages.Load(&packages.Config{Mode: mode, Tests: cfg.ScanTests}, patterns...)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gogreement --rewrite: %v\n", err)
+		return 1
+	}
+
+	exitCode := 0
+	seen := make(map[string]bool)
+	for _, pkg := range pkgs {
+		if cfg.ShouldSkipPackage(pkg.PkgPath) {
+			continue
+		}
+
+		// Test variants repeat the files of their package
+		pass := &analysis.Pass{Fset: pkg.Fset, Files: pkg.Syntax}
+		for file := range cfg.FilterFiles(pass) {
+			path := pkg.Fset.File(file.Pos()).Name()
+			if seen[path] {
+				continue
+			}
+			seen[path] = true
+
+			src, err := os.ReadFile(path)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "gogreement --rewrite: %v\n", err)
+				exitCode = 1
+				continue
+			}
+			edits := rewrite.Annotations(pkg.Fset, file, src)
+			if len(edits) == 0 {
+				continue
+			}
+			if err := os.WriteFile(path, rewrite.Apply(src, edits), 0o644); err != nil {
+				fmt.Fprintf(os.Stderr, "gogreement --rewrite: %v\n", err)
+				exitCode = 1
+				continue
+			}
+			fmt.Printf("rewrote %s\n", path)
+		}
+	}
+
+	return exitCode
+}
+
+// packagePatterns returns the arguments that are not flags or the values of
+// "config."-prefixed flags given as a separate argument
+func packagePatterns(args []string) []string {
+	fs := config.CreateFlagSet()
+
+	var patterns []string
+	for i := 0; i < len(args); i++ {
+		if !strings.HasPrefix(args[i], "-") {
+			patterns = append(patterns, args[i])
+			continue
+		}
+
+		name, ok := strings.CutPrefix(strings.TrimLeft(args[i], "-"), "config.")
+		if !ok || strings.Contains(name, "=") {
+			continue
+		}
+		if f := fs.Lookup(name); f != nil {
+			if boolFlag, ok := f.Value.(interface{ IsBoolFlag() bool }); !ok || !boolFlag.IsBoolFlag() {
+				i++
+			}
+		}
+	}
+
+	return patterns
+}
+
+// commandLineConfig returns the configuration the config analyzer will see,
+// applying the "config."-prefixed flags found in args. Other flags and package
+// patterns are left to multichecker.
+func commandLineConfig(args []string) *config.Config {
+	fs := config.CreateFlagSet()
+
+	for i := 0; i < len(args); i++ {
+		name, ok := strings.CutPrefix(strings.TrimLeft(args[i], "-"), "config.")
+		if !ok || !strings.HasPrefix(args[i], "-") {
+			continue
+		}
+
+		name, value, hasValue := strings.Cut(name, "=")
+		f := fs.Lookup(name)
+		if f == nil {
+			continue
+		}
+
+		if !hasValue {
+			if boolFlag, ok := f.Value.(interface{ IsBoolFlag() bool }); ok && boolFlag.IsBoolFlag() {
+				value = "true"
+			} else if i+1 < len(args) {
+				i++
+				value = args[i]
+			}
+		}
+		_ = fs.Set(name, value)
+	}
+
+	return config.ParseFlagsFromFlagSet(fs)
+}
+
+// runChild re-runs the command in a child process, then post-processes what
+// it produced: the counters it recorded for --stats, the annotations and
+// findings it recorded for --trace-contracts and the annotations it recorded
+// for --emit-inventory (printed to stderr) and its findings for a non-text
+// --output-format (printed to stdout)
+func runChild(cfg *config.Config) int {
+	executable, err := os.Executable()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gogreement: %v\n", err)
+		return 1
+	}
+
+	args := os.Args[1:]
+	var findingsOutput bytes.Buffer
+	reportFindings := cfg.OutputFormat != config.OutputFormatText
+	if reportFindings {
+		args = append([]string{"-json"}, args...)
+	}
+
+	cmd := exec.Command(executable, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	cmd.Env = append(os.Environ(), childEnv+"=1")
+	if reportFindings {
+		cmd.Stdout = &findingsOutput
+	}
+
+	var sinkPath string
+	if cfg.Stats {
+		sink, err := os.CreateTemp("", "gogreement-stats-*.jsonl")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "gogreement: stats: %v\n", err)
+			return 1
+		}
+		sinkPath = sink.Name()
+		_ = sink.Close()
+		defer os.Remove(sinkPath)
+		cmd.Env = append(cmd.Env, stats.SinkEnv+"="+sinkPath)
+	}
+
+	var traceSinkPath string
+	if cfg.TraceContracts {
+		sink, err := os.CreateTemp("", "gogreement-trace-*.jsonl")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "gogreement: trace: %v\n", err)
+			return 1
+		}
+		traceSinkPath = sink.Name()
+		_ = sink.Close()
+		defer os.Remove(traceSinkPath)
+		cmd.Env = append(cmd.Env, trace.SinkEnv+"="+traceSinkPath)
+	}
+
+	var inventorySinkPath string
+	if cfg.EmitInventory {
+		sink, err := os.CreateTemp("", "gogreement-inventory-*.jsonl")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "gogreement: inventory: %v\n", err)
+			return 1
+		}
+		inventorySinkPath = sink.Name()
+		_ = sink.Close()
+		defer os.Remove(inventorySinkPath)
+		cmd.Env = append(cmd.Env, inventory.SinkEnv+"="+inventorySinkPath)
+	}
+
+	exitCode := 0
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) {
+			fmt.Fprintf(os.Stderr, "gogreement: %v\n", err)
+			return 1
+		}
+		exitCode = exitErr.ExitCode()
+	}
+
+	if reportFindings {
+		exitCode = writeFindings(cfg, &findingsOutput, exitCode)
+	}
+	if cfg.Stats {
+		writeStats(sinkPath, cfg.StatsFormat)
+	}
+	if cfg.TraceContracts {
+		writeTrace(traceSinkPath)
+	}
+	if cfg.EmitInventory {
+		writeInventory(inventorySinkPath)
+	}
+	return exitCode
+}
+
+// pathBase returns the directory --path-base renders file paths relative to,
+// or false for absolute paths
+func pathBase(base string) (string, bool) {
+	if base == config.PathBaseAbsolute {
+		return "", false
+	}
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", false
+	}
+	if base == config.PathBaseCwd {
+		return cwd, true
+	}
+	return output.FindModuleRoot(cwd)
+}
+
+// writeFindings converts the child's -json output to the report selected by
+// --output-format on stdout, or hands them to the reporter registered for it
+// with output.RegisterReporter. Findings exit with 3 like the text output does,
+// or with --warnings-exit-code when none is an error; multichecker always exits
+// with 0 in JSON mode.
+func writeFindings(cfg *config.Config, jsonOutput io.Reader, exitCode int) int {
+	findings, analysisErrors, err := output.ReadJSONTree(jsonOutput)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gogreement: %s: %v\n", cfg.OutputFormat, err)
+		return 1
+	}
+	for _, analysisError := range analysisErrors {
+		fmt.Fprintln(os.Stderr, analysisError)
+	}
+	// Notes such as the --max-findings-per-file summary carry no code and
+	// are not findings
+	findings = slices.DeleteFunc(findings, func(finding output.Finding) bool { return finding.Code == "" })
+
+	var owners output.Owners
+	if cfg.OwnersFile != "" {
+		if owners, err = output.ReadOwnersFile(cfg.OwnersFile); err != nil {
+			fmt.Fprintf(os.Stderr, "gogreement: owners: %v\n", err)
+			return 1
+		}
+	}
+	for i := range findings {
+		findings[i].Severity = cfg.SeverityOf(findings[i].Code)
+		findings[i].Owner = owners.Resolve(findings[i].Code, findings[i].File)
+	}
+	if base, ok := pathBase(cfg.PathBase); ok {
+		output.RebasePaths(findings, base)
+	}
+	output.LinkDocumentation(findings, cfg.DocsBaseURL)
+	write := output.WriteCheckstyle
+	switch cfg.OutputFormat {
+	case config.OutputFormatJSONV2:
+		write = output.WriteJSONV2
+	case config.OutputFormatCSV:
+		write = output.WriteCSV
+	}
+	if report, ok := output.LookupReporter(cfg.OutputFormat); ok {
+		err = report(findings)
+	} else {
+		err = write(os.Stdout, findings)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gogreement: %s: %v\n", cfg.OutputFormat, err)
+		return 1
+	}
+
+	switch {
+	case exitCode != 0:
+		return exitCode
+	case len(analysisErrors) > 0:
+		return 1
+	}
+	return output.ExitCode(findings, cfg.WarningsExitCode)
+}
+
+// writeStats prints the counters the child recorded in the sink to stderr
+func writeStats(sinkPath, format string) {
+	counters, err := stats.ReadSink(sinkPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gogreement: stats: %v\n", err)
+		return
+	}
+
+	if format == config.StatsFormatJSON {
+		err = stats.WriteJSON(os.Stderr, counters)
+	} else {
+		err = stats.WriteText(os.Stderr, counters)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gogreement: stats: %v\n", err)
+	}
+}
+
+// writeTrace prints the annotations the child recorded in the sink, with the
+// findings attributed to each, as JSON to stderr
+func writeTrace(sinkPath string) {
+	contracts, err := trace.ReadSink(sinkPath)
+	if err == nil {
+		err = trace.WriteJSON(os.Stderr, contracts)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gogreement: trace: %v\n", err)
+	}
+}
+
+// writeInventory prints the annotations the child recorded in the sink as
+// JSON to stderr
+func writeInventory(sinkPath string) {
+	entries, err := inventory.ReadSink(sinkPath)
+	if err == nil {
+		err = inventory.WriteJSON(os.Stderr, entries)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gogreement: inventory: %v\n", err)
+	}
+}
@@ -1,31 +1,57 @@
 package analyzer
 
 import (
+	"go/token"
 	"reflect"
+	"strings"
 
 	config "github.com/a14e/gogreement/src/config"
 
 	"golang.org/x/tools/go/analysis"
 
 	"github.com/a14e/gogreement/src/annotations"
+	"github.com/a14e/gogreement/src/codes"
 	"github.com/a14e/gogreement/src/constructor"
+	"github.com/a14e/gogreement/src/deprecated"
+	"github.com/a14e/gogreement/src/enum"
+	"github.com/a14e/gogreement/src/experimental"
 	"github.com/a14e/gogreement/src/ignore"
 	"github.com/a14e/gogreement/src/immutable"
 	"github.com/a14e/gogreement/src/implements"
+	"github.com/a14e/gogreement/src/indexing"
+	"github.com/a14e/gogreement/src/inventory"
+	"github.com/a14e/gogreement/src/mustreturn"
 	"github.com/a14e/gogreement/src/packageonly"
+	"github.com/a14e/gogreement/src/placement"
+	"github.com/a14e/gogreement/src/reporting"
+	"github.com/a14e/gogreement/src/required"
+	"github.com/a14e/gogreement/src/since"
+	"github.com/a14e/gogreement/src/singleton"
+	"github.com/a14e/gogreement/src/stats"
 	"github.com/a14e/gogreement/src/testonly"
+	"github.com/a14e/gogreement/src/trace"
 )
 
 // runConfig reads configuration from environment variables and command line flags.
 // It parses per pass (rather than caching in a process global via sync.Once) so
 // the configuration reflects the current flags/env. Caching globally froze the
 // config for the whole process and defeated env-only reloads — a test-isolation
-// footgun. Parsing is cheap.
+// footgun. Parsing is cheap. CheckFile passes its configuration in directly,
+// keyed by the file set of the packages it loaded.
+// An invalid configuration fails the pass; the gogreement command validates it
+// once before the analysis starts, so this only reports it for other drivers.
 //
 // Note: multichecker automatically adds the "config." prefix to all flag names
 // (e.g. "scan-tests" becomes "config.scan-tests" on the command line).
 func runConfig(pass *analysis.Pass) (interface{}, error) {
-	return config.ParseFlagsFromFlagSet(&pass.Analyzer.Flags), nil
+	if cfg, ok := fileConfigs.Load(pass.Fset); ok {
+		return cfg, nil
+	}
+	cfg := config.ParseFlagsFromFlagSet(&pass.Analyzer.Flags)
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
 }
 
 // ConfigReader reads configuration from environment variables and command line flags
@@ -40,6 +66,14 @@ var ConfigReader = &analysis.Analyzer{
 	Flags:      *config.CreateFlagSet(),
 }
 
+// skipDiagnostics reports whether the checkers produce no diagnostics for the
+// package of pass: it matches --skip-packages, or a --fail-fast run already
+// reported its finding. Checkers call it once their facts are exported, since
+// later packages still depend on them.
+func skipDiagnostics(cfg *config.Config, pass *analysis.Pass) bool {
+	return cfg.ShouldSkipPackage(pass.Pkg.Path()) || reporting.FailFastTriggered(cfg)
+}
+
 // AnnotationReader reads annotations from code and exports them as facts
 var AnnotationReader = &analysis.Analyzer{
 	Name: "annotationreader",
@@ -54,17 +88,176 @@ var AnnotationReader = &analysis.Analyzer{
 	ResultType: reflect.TypeOf(annotations.PackageAnnotations{}),
 }
 
+// ContractsReader exports the compact ContractsFact of each package. Its
+// result holds the indices of the package and its transitive imports, so
+// downstream analyzers can require it instead of reading per-checker facts;
+// ConstructorChecker does.
+var ContractsReader = &analysis.Analyzer{
+	Name: "contractsreader",
+	Doc:  "Indexes @immutable, @constructor, @testonly and @packageonly contracts of a package and its imports",
+	Run:  runContractsReader,
+	Requires: []*analysis.Analyzer{
+		ConfigReader,
+		AnnotationReader,
+	},
+	FactTypes: []analysis.Fact{
+		(*annotations.ContractsFact)(nil),
+	},
+	ResultType: reflect.TypeOf(indexing.Contracts{}),
+}
+
+func runContractsReader(pass *analysis.Pass) (interface{}, error) {
+	cfg := pass.ResultOf[ConfigReader].(*config.Config)
+	localAnnotations := pass.ResultOf[AnnotationReader].(annotations.PackageAnnotations)
+
+	// The fact carries only the exported kinds; the local indices are built
+	// from every annotation of the package, as the checkers' indices are
+	exported := localAnnotations.Exported(cfg)
+	fact := annotations.NewContractsFact(&exported)
+	pass.ExportPackageFact(&fact)
+
+	local := annotations.NewContractsFact(&localAnnotations)
+	return indexing.BuildContracts(pass, &local), nil
+}
+
 func runAnnotationReader(pass *analysis.Pass) (interface{}, error) {
 	cfg := pass.ResultOf[ConfigReader].(*config.Config)
 	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
 
+	if cfg.Stats {
+		stats.Record(annotationStats(cfg, pass, &packageAnnotations))
+	}
+	if cfg.TraceContracts {
+		trace.Add(annotationTrace(pass, &packageAnnotations)...)
+	}
+	if cfg.EmitInventory {
+		inventory.Add(inventory.Collect(pass, &packageAnnotations)...)
+	}
+
 	// Export facts before isProjectPackage check so dependencies can use them
-	fact := annotations.AnnotationReaderFact(packageAnnotations)
+	fact := annotations.AnnotationReaderFact(packageAnnotations.Exported(cfg))
 	pass.ExportPackageFact(&fact)
 
 	return packageAnnotations, nil
 }
 
+// annotationStats counts the scanned files and parsed annotations of a package
+func annotationStats(cfg *config.Config, pass *analysis.Pass, ann *annotations.PackageAnnotations) stats.Counters {
+	files := 0
+	for range cfg.FilterFiles(pass) {
+		files++
+	}
+
+	return stats.Counters{
+		Files: files,
+		Annotations: map[string]int{
+			"implements":       len(ann.ImplementsAnnotations),
+			"implements-oneof": len(ann.ImplementsOneOfAnnotations),
+			"implementedby":    len(ann.ImplementedByAnnotations),
+			"notimplements":    len(ann.NotImplementsAnnotations),
+			"constructor":      len(ann.ConstructorAnnotations),
+			"immutable":        len(ann.ImmutableAnnotations),
+			"testonly":         len(ann.TestonlyAnnotations),
+			"mutable":          len(ann.MutableAnnotations),
+			"packageonly":      len(ann.PackageOnlyAnnotations),
+			"since":            len(ann.SinceAnnotations),
+			"enum":             len(ann.EnumAnnotations),
+			"required":         len(ann.RequiredAnnotations),
+			"singleton":        len(ann.SingletonAnnotations),
+			"mustreturn":       len(ann.MustReturnAnnotations),
+			"experimental":     len(ann.ExperimentalAnnotations),
+			"deprecated":       len(ann.DeprecatedPackages),
+		},
+	}
+}
+
+// annotationTrace lists the annotations of a package with the positions of
+// the declarations they are written on, for --trace-contracts
+func annotationTrace(pass *analysis.Pass, ann *annotations.PackageAnnotations) []trace.Record {
+	var records []trace.Record
+	add := func(kind, name, argument string, pos token.Pos) {
+		position := pass.Fset.Position(pos)
+		records = append(records, trace.Record{
+			Key:      trace.Key{Kind: kind, Package: pass.Pkg.Path(), Name: name, Argument: argument},
+			Declared: &trace.Position{File: position.Filename, Line: position.Line, Column: position.Column},
+		})
+	}
+	memberName := func(receiver, name string) string {
+		if receiver == "" {
+			return name
+		}
+		return receiver + "." + name
+	}
+	interfaceName := func(annot annotations.ImplementsAnnotation) string {
+		if annot.PackageName == "" {
+			return annot.InterfaceName
+		}
+		return annot.PackageName + "." + annot.InterfaceName
+	}
+
+	for _, annot := range ann.ImplementsAnnotations {
+		add("implements", annot.OnType, interfaceName(annot), annot.OnTypePos)
+	}
+	for _, annot := range ann.ImplementsOneOfAnnotations {
+		var alternatives []string
+		for _, alternative := range annot.Alternatives {
+			alternatives = append(alternatives, interfaceName(alternative))
+		}
+		add("implements-oneof", annot.OnType, strings.Join(alternatives, ", "), annot.OnTypePos)
+	}
+	for _, annot := range ann.ImplementedByAnnotations {
+		var implementers []string
+		for _, implementer := range annot.Implementers {
+			implementers = append(implementers, implementer.Spelling)
+		}
+		add("implementedby", annot.OnInterface, strings.Join(implementers, ", "), annot.OnInterfacePos)
+	}
+	for _, annot := range ann.NotImplementsAnnotations {
+		argument := annot.InterfaceName
+		if annot.PackageName != "" {
+			argument = annot.PackageName + "." + argument
+		}
+		add("notimplements", annot.OnType, argument, annot.OnTypePos)
+	}
+	for _, annot := range ann.ConstructorAnnotations {
+		add("constructor", annot.OnType, strings.Join(annot.ConstructorNames, ", "), annot.OnTypePos)
+	}
+	for _, annot := range ann.ImmutableAnnotations {
+		add("immutable", annot.OnType, "", annot.OnTypePos)
+	}
+	for _, annot := range ann.MutableAnnotations {
+		add("mutable", annot.OnType+"."+annot.FieldName, "", annot.Pos)
+	}
+	for _, annot := range ann.TestonlyAnnotations {
+		add("testonly", memberName(annot.ReceiverType, annot.ObjectName), "", annot.Pos)
+	}
+	for _, annot := range ann.PackageOnlyAnnotations {
+		add("packageonly", memberName(annot.ReceiverType, annot.ObjectName), "", annot.Pos)
+	}
+	for _, annot := range ann.SinceAnnotations {
+		add("since", memberName(annot.ReceiverType, annot.ObjectName), annot.Version, annot.Pos)
+	}
+	for _, annot := range ann.EnumAnnotations {
+		add("enum", annot.OnType, "", annot.OnTypePos)
+	}
+	for _, annot := range ann.RequiredAnnotations {
+		add("required", annot.OnType+"."+annot.FieldName, "", annot.Pos)
+	}
+	for _, annot := range ann.SingletonAnnotations {
+		add("singleton", annot.OnType, "", annot.OnTypePos)
+	}
+	for _, annot := range ann.MustReturnAnnotations {
+		add("mustreturn", memberName(annot.ReceiverType, annot.ObjectName), "", annot.Pos)
+	}
+	for _, annot := range ann.ExperimentalAnnotations {
+		add("experimental", memberName(annot.ReceiverType, annot.ObjectName), "", annot.Pos)
+	}
+	for _, annot := range ann.DeprecatedPackages {
+		add("deprecated", "", annot.Replacement, annot.Pos)
+	}
+	return records
+}
+
 // IgnoreReader reads @ignore annotations from code
 var IgnoreReader = &analysis.Analyzer{
 	Name: "ignorereader",
@@ -112,31 +305,65 @@ func runImplementsChecker(pass *analysis.Pass) (interface{}, error) {
 		return nil, nil
 	}
 
+	cfg := pass.ResultOf[ConfigReader].(*config.Config)
+
 	// Export facts before isProjectPackage check so dependencies can use them
-	fact := annotations.ImplementsCheckerFact(localAnnotations)
+	fact := annotations.ImplementsCheckerFact(localAnnotations.Exported(cfg))
 	pass.ExportPackageFact(&fact)
 
-	if len(localAnnotations.ImplementsAnnotations) == 0 {
+	if len(localAnnotations.ImplementsAnnotations) == 0 && len(localAnnotations.ImplementsOneOfAnnotations) == 0 &&
+		len(localAnnotations.ImplementedByAnnotations) == 0 && !cfg.RequireImplementsAnnotation {
+		return nil, nil
+	}
+
+	if skipDiagnostics(cfg, pass) {
 		return nil, nil
 	}
 
 	// Get ignore set from IgnoreReader
 	ignoreSet := pass.ResultOf[IgnoreReader].(ignore.IgnoreResult).IgnoreSet
 
-	// Load interfaces and types
-	interfaceQueries := localAnnotations.ToInterfaceQuery()
-	interfaces := implements.LoadInterfaces(pass, interfaceQueries)
+	// Load the interfaces and types the annotations name. The loader builds
+	// no other models and is released once this package is checked.
+	loader := implements.NewLoader(pass)
+	interfaces, err := loader.Interfaces(localAnnotations.ToInterfaceQuery())
+	if err != nil {
+		return nil, err
+	}
+
+	types := loader.Types(localAnnotations.ToTypeQuery())
+
+	if cfg.Stats {
+		stats.Record(stats.Counters{Interfaces: len(interfaces), Types: len(types)})
+	}
+
+	// Validate. Unresolved @implements-oneof alternatives are reported like
+	// unresolved @implements targets.
+	allImplements := localAnnotations.AllImplementsAnnotations()
+	missingPackages := implements.FindMissingPackages(allImplements)
+	mismatchedReceivers := implements.FindMismatchedReceivers(allImplements)
+	missingInterfaces := implements.FindMissingInterfaces(allImplements, interfaces)
+	var missingMethods []implements.MissingMethodsReport
+	if cfg.VerboseImplements {
+		missingMethods = implements.FindMissingMethodsVerbose(localAnnotations.ImplementsAnnotations, interfaces, types)
+	} else {
+		missingMethods = implements.FindMissingMethods(localAnnotations.ImplementsAnnotations, interfaces, types)
+	}
 
-	typeQueries := localAnnotations.ToTypeQuery()
-	types := implements.LoadTypes(pass, typeQueries)
+	embeddedInterfaces := implements.FindEmbeddedImplementations(localAnnotations.ImplementsAnnotations, interfaces, types)
+	unsatisfiedOneOf := implements.FindUnsatisfiedOneOf(localAnnotations.ImplementsOneOfAnnotations, interfaces, types)
+	unimplementedBy := implements.FindUnimplementedBy(pass, localAnnotations.ImplementedByAnnotations, interfaces)
+	typeSets := implements.FindTypeSetInterfaces(allImplements, interfaces)
 
-	// Validate
-	missingPackages := implements.FindMissingPackages(localAnnotations.ImplementsAnnotations)
-	missingInterfaces := implements.FindMissingInterfaces(localAnnotations.ImplementsAnnotations, interfaces)
-	missingMethods := implements.FindMissingMethods(localAnnotations.ImplementsAnnotations, interfaces, types)
+	// Exported types implementing a required interface must declare it
+	var missingAnnotations []implements.MissingAnnotationReport
+	if cfg.RequireImplementsAnnotation {
+		files := cfg.FilterFilesForCheck(pass, codes.ImplementsCategoryPrefix)
+		missingAnnotations = implements.FindMissingAnnotations(pass, files, cfg.RequiredInterfaces, allImplements)
+	}
 
 	// Report problems (filtered by ignore set)
-	implements.ReportProblems(pass, missingPackages, missingInterfaces, missingMethods, ignoreSet)
+	implements.ReportProblems(cfg, pass, missingPackages, mismatchedReceivers, missingInterfaces, missingMethods, embeddedInterfaces, unsatisfiedOneOf, unimplementedBy, typeSets, missingAnnotations, ignoreSet)
 
 	return nil, nil
 }
@@ -168,9 +395,13 @@ func runImmutableChecker(pass *analysis.Pass) (interface{}, error) {
 	cfg := pass.ResultOf[ConfigReader].(*config.Config)
 
 	// Export facts before isProjectPackage check so dependencies can use them
-	fact := annotations.ImmutableCheckerFact(localAnnotations)
+	fact := annotations.ImmutableCheckerFact(localAnnotations.Exported(cfg))
 	pass.ExportPackageFact(&fact)
 
+	if skipDiagnostics(cfg, pass) {
+		return nil, nil
+	}
+
 	// Note: We still run the checker even if there are no local @immutable annotations,
 	// because we need to check for violations of @immutable types from imported packages
 
@@ -181,7 +412,7 @@ func runImmutableChecker(pass *analysis.Pass) (interface{}, error) {
 	violations := immutable.CheckImmutable(cfg, pass, &localAnnotations)
 
 	// Report violations (filtered by ignore set)
-	immutable.ReportViolations(pass, violations, ignoreSet)
+	immutable.ReportViolations(cfg, pass, violations, ignoreSet)
 
 	return nil, nil
 }
@@ -194,11 +425,9 @@ var ConstructorChecker = &analysis.Analyzer{
 	Requires: []*analysis.Analyzer{
 		ConfigReader,
 		AnnotationReader,
+		ContractsReader,
 		IgnoreReader,
 	},
-	FactTypes: []analysis.Fact{
-		(*annotations.ConstructorCheckerFact)(nil),
-	},
 }
 
 func runConstructorChecker(pass *analysis.Pass) (interface{}, error) {
@@ -212,21 +441,25 @@ func runConstructorChecker(pass *analysis.Pass) (interface{}, error) {
 	}
 	cfg := pass.ResultOf[ConfigReader].(*config.Config)
 
-	// Export facts before isProjectPackage check so dependencies can use them
-	fact := annotations.ConstructorCheckerFact(localAnnotations)
-	pass.ExportPackageFact(&fact)
+	// The constructors of imported packages come from their ContractsFact,
+	// exported by ContractsReader, so this checker exports no fact of its own
+
+	if skipDiagnostics(cfg, pass) {
+		return nil, nil
+	}
 
 	// Note: We still run the checker even if there are no local @constructor annotations,
 	// because we need to check for violations of @constructor types from imported packages
 
 	// Get ignore set from IgnoreReader
 	ignoreSet := pass.ResultOf[IgnoreReader].(ignore.IgnoreResult).IgnoreSet
+	contracts := pass.ResultOf[ContractsReader].(indexing.Contracts)
 
 	// Check constructor violations
-	violations := constructor.CheckConstructor(cfg, pass, &localAnnotations)
+	violations := constructor.CheckConstructorWithIndex(cfg, pass, &localAnnotations, contracts.ConstructorEnforcement)
 
 	// Report violations (filtered by ignore set)
-	constructor.ReportViolations(pass, violations, ignoreSet)
+	constructor.ReportViolations(cfg, pass, violations, ignoreSet)
 
 	return nil, nil
 }
@@ -258,9 +491,13 @@ func runTestOnlyChecker(pass *analysis.Pass) (interface{}, error) {
 	cfg := pass.ResultOf[ConfigReader].(*config.Config)
 
 	// Export facts before isProjectPackage check so dependencies can use them
-	fact := annotations.TestOnlyCheckerFact(localAnnotations)
+	fact := annotations.TestOnlyCheckerFact(localAnnotations.Exported(cfg))
 	pass.ExportPackageFact(&fact)
 
+	if skipDiagnostics(cfg, pass) {
+		return nil, nil
+	}
+
 	// Note: We still run the checker even if there are no local @testonly annotations,
 	// because we need to check for violations of @testonly items from imported packages
 
@@ -276,7 +513,7 @@ func runTestOnlyChecker(pass *analysis.Pass) (interface{}, error) {
 	violations := testonly.CheckTestOnly(cfg, pass, &localAnnotations, ignoreSet)
 
 	// Report violations (already filtered by ignoreSet in CheckTestOnly)
-	testonly.ReportViolations(pass, violations)
+	testonly.ReportViolations(cfg, pass, violations)
 
 	return nil, nil
 }
@@ -308,9 +545,13 @@ func runPackageOnlyChecker(pass *analysis.Pass) (interface{}, error) {
 	cfg := pass.ResultOf[ConfigReader].(*config.Config)
 
 	// Export facts before isProjectPackage check so dependencies can use them
-	fact := annotations.PackageOnlyCheckerFact(localAnnotations)
+	fact := annotations.PackageOnlyCheckerFact(localAnnotations.Exported(cfg))
 	pass.ExportPackageFact(&fact)
 
+	if skipDiagnostics(cfg, pass) {
+		return nil, nil
+	}
+
 	// Note: We still run the checker even if there are no local @packageonly annotations,
 	// because we need to check for violations of @packageonly items from imported packages
 
@@ -321,8 +562,458 @@ func runPackageOnlyChecker(pass *analysis.Pass) (interface{}, error) {
 	violations := packageonly.CheckPackageOnly(cfg, pass, &localAnnotations, ignoreSet)
 
 	// Report violations (filtered by ignore set)
-	packageonly.ReportViolations(pass, violations)
+	packageonly.ReportViolations(cfg, pass, violations)
+
+	return nil, nil
+}
+
+// SinceChecker validates @since annotations
+var SinceChecker = &analysis.Analyzer{
+	Name: "sincechecker",
+	Doc:  "Checks that @since annotations carry valid semantic versions",
+	Run:  runSinceChecker,
+	Requires: []*analysis.Analyzer{
+		ConfigReader,
+		AnnotationReader,
+		IgnoreReader,
+	},
+	FactTypes: []analysis.Fact{
+		(*annotations.SinceCheckerFact)(nil),
+	},
+}
+
+func runSinceChecker(pass *analysis.Pass) (interface{}, error) {
+	result := pass.ResultOf[AnnotationReader]
+	if result == nil {
+		return nil, nil
+	}
+	localAnnotations, ok := result.(annotations.PackageAnnotations)
+	if !ok {
+		return nil, nil
+	}
+	cfg := pass.ResultOf[ConfigReader].(*config.Config)
+
+	// Export facts even when validation is disabled so the parsed versions are
+	// available to dependent packages
+	fact := annotations.SinceCheckerFact(localAnnotations.Exported(cfg))
+	pass.ExportPackageFact(&fact)
+
+	if skipDiagnostics(cfg, pass) {
+		return nil, nil
+	}
+
+	// Get ignore set from IgnoreReader
+	ignoreSet := pass.ResultOf[IgnoreReader].(ignore.IgnoreResult).IgnoreSet
+
+	// Validate @since versions (no-op unless --check-since is set)
+	violations := since.CheckSince(cfg, pass, &localAnnotations)
+
+	// Report violations (filtered by ignore set)
+	since.ReportViolations(cfg, pass, violations, ignoreSet)
+
+	return nil, nil
+}
+
+// EnumChecker checks @enum annotations
+var EnumChecker = &analysis.Analyzer{
+	Name: "enumchecker",
+	Doc:  "Checks that values of @enum types are only created from their declared constants",
+	Run:  runEnumChecker,
+	Requires: []*analysis.Analyzer{
+		ConfigReader,
+		AnnotationReader,
+		IgnoreReader,
+	},
+	FactTypes: []analysis.Fact{
+		(*annotations.EnumCheckerFact)(nil),
+	},
+}
+
+func runEnumChecker(pass *analysis.Pass) (interface{}, error) {
+	result := pass.ResultOf[AnnotationReader]
+	if result == nil {
+		return nil, nil
+	}
+	localAnnotations, ok := result.(annotations.PackageAnnotations)
+	if !ok {
+		return nil, nil
+	}
+	cfg := pass.ResultOf[ConfigReader].(*config.Config)
+
+	// Export facts before isProjectPackage check so dependencies can use them
+	fact := annotations.EnumCheckerFact(localAnnotations.Exported(cfg))
+	pass.ExportPackageFact(&fact)
+
+	if skipDiagnostics(cfg, pass) {
+		return nil, nil
+	}
+
+	// Note: We still run the checker even if there are no local @enum annotations,
+	// because only other packages' @enum types are checked
+
+	// Get ignore set from IgnoreReader
+	ignoreSet := pass.ResultOf[IgnoreReader].(ignore.IgnoreResult).IgnoreSet
+
+	// Check conversions to @enum types
+	violations := enum.CheckEnum(cfg, pass, &localAnnotations)
+
+	// Report violations (filtered by ignore set)
+	enum.ReportViolations(cfg, pass, violations, ignoreSet)
+
+	return nil, nil
+}
+
+// RequiredChecker checks @required field annotations
+var RequiredChecker = &analysis.Analyzer{
+	Name: "requiredchecker",
+	Doc:  "Checks that composite literals set every @required field of their type",
+	Run:  runRequiredChecker,
+	Requires: []*analysis.Analyzer{
+		ConfigReader,
+		AnnotationReader,
+		IgnoreReader,
+	},
+	FactTypes: []analysis.Fact{
+		(*annotations.RequiredCheckerFact)(nil),
+	},
+}
+
+func runRequiredChecker(pass *analysis.Pass) (interface{}, error) {
+	result := pass.ResultOf[AnnotationReader]
+	if result == nil {
+		return nil, nil
+	}
+	localAnnotations, ok := result.(annotations.PackageAnnotations)
+	if !ok {
+		return nil, nil
+	}
+	cfg := pass.ResultOf[ConfigReader].(*config.Config)
+
+	// Export facts before isProjectPackage check so dependencies can use them
+	fact := annotations.RequiredCheckerFact(localAnnotations.Exported(cfg))
+	pass.ExportPackageFact(&fact)
+
+	if skipDiagnostics(cfg, pass) {
+		return nil, nil
+	}
+
+	// Get ignore set from IgnoreReader
+	ignoreSet := pass.ResultOf[IgnoreReader].(ignore.IgnoreResult).IgnoreSet
+
+	// Check composite literals of types with @required fields
+	violations := required.CheckRequired(cfg, pass, &localAnnotations)
+
+	// Report violations (filtered by ignore set)
+	required.ReportViolations(cfg, pass, violations, ignoreSet)
+
+	return nil, nil
+}
+
+// SingletonChecker checks @singleton annotations
+var SingletonChecker = &analysis.Analyzer{
+	Name: "singletonchecker",
+	Doc:  "Checks that types with @singleton are constructed at most once",
+	Run:  runSingletonChecker,
+	Requires: []*analysis.Analyzer{
+		ConfigReader,
+		AnnotationReader,
+		IgnoreReader,
+	},
+	FactTypes: []analysis.Fact{
+		(*annotations.SingletonCheckerFact)(nil),
+		(*singleton.SitesFact)(nil),
+	},
+}
+
+func runSingletonChecker(pass *analysis.Pass) (interface{}, error) {
+	result := pass.ResultOf[AnnotationReader]
+	if result == nil {
+		return nil, nil
+	}
+	localAnnotations, ok := result.(annotations.PackageAnnotations)
+	if !ok {
+		return nil, nil
+	}
+	cfg := pass.ResultOf[ConfigReader].(*config.Config)
+
+	// Export facts before isProjectPackage check so dependencies can use them
+	fact := annotations.SingletonCheckerFact(localAnnotations.Exported(cfg))
+	pass.ExportPackageFact(&fact)
+
+	// Construction sites are collected in every package, skipped ones included,
+	// so that importers count all of them
+	violations, sites := singleton.CheckSingleton(cfg, pass, &localAnnotations)
+	pass.ExportPackageFact(sites)
+
+	if skipDiagnostics(cfg, pass) {
+		return nil, nil
+	}
+
+	// Get ignore set from IgnoreReader
+	ignoreSet := pass.ResultOf[IgnoreReader].(ignore.IgnoreResult).IgnoreSet
+
+	// Report violations (filtered by ignore set)
+	singleton.ReportViolations(cfg, pass, violations, ignoreSet)
+
+	return nil, nil
+}
+
+// PlacementChecker reports annotations on declarations they do not apply to
+var PlacementChecker = &analysis.Analyzer{
+	Name: "placementchecker",
+	Doc:  "Checks that annotations are written on the kinds of declarations they apply to and do not contradict each other",
+	Run:  runPlacementChecker,
+	Requires: []*analysis.Analyzer{
+		ConfigReader,
+		AnnotationReader,
+		IgnoreReader,
+	},
+}
+
+func runPlacementChecker(pass *analysis.Pass) (interface{}, error) {
+	result := pass.ResultOf[AnnotationReader]
+	if result == nil {
+		return nil, nil
+	}
+	localAnnotations, ok := result.(annotations.PackageAnnotations)
+	if !ok {
+		return nil, nil
+	}
+	cfg := pass.ResultOf[ConfigReader].(*config.Config)
+
+	// Misplaced annotations are local to the package, there is no fact to export
+	if skipDiagnostics(cfg, pass) {
+		return nil, nil
+	}
+
+	// Get ignore set from IgnoreReader
+	ignoreSet := pass.ResultOf[IgnoreReader].(ignore.IgnoreResult).IgnoreSet
+
+	// Check the annotations noted as misplaced while reading and those
+	// contradicting each other
+	var violations []reporting.Violation
+	for _, violation := range placement.CheckPlacement(cfg, pass, &localAnnotations) {
+		violations = append(violations, violation)
+	}
+	for _, violation := range placement.CheckConflicts(cfg, pass, &localAnnotations) {
+		violations = append(violations, violation)
+	}
+
+	// Report violations (filtered by ignore set)
+	placement.ReportViolations(cfg, pass, violations, ignoreSet)
+
+	return nil, nil
+}
+
+// IgnoreChecker reports @ignore codes that do not exist
+var IgnoreChecker = &analysis.Analyzer{
+	Name: "ignorechecker",
+	Doc:  "Checks that @ignore markers name existing codes",
+	Run:  runIgnoreChecker,
+	Requires: []*analysis.Analyzer{
+		ConfigReader,
+		IgnoreReader,
+	},
+}
+
+func runIgnoreChecker(pass *analysis.Pass) (interface{}, error) {
+	cfg := pass.ResultOf[ConfigReader].(*config.Config)
+
+	// Ignore markers are local to the package, there is no fact to export
+	if skipDiagnostics(cfg, pass) {
+		return nil, nil
+	}
+
+	// Get ignore set from IgnoreReader
+	ignoreSet := pass.ResultOf[IgnoreReader].(ignore.IgnoreResult).IgnoreSet
+
+	// Check the codes of the @ignore markers (no-op unless --validate-ignore-codes is set)
+	violations := ignore.CheckIgnoreCodes(cfg, pass)
+
+	// Report violations (filtered by ignore set). The ignore package cannot
+	// depend on reporting, whose tests read @ignore markers.
+	generic := make([]reporting.Violation, 0, len(violations))
+	for _, violation := range violations {
+		generic = append(generic, violation)
+	}
+	reporting.NewReporter(cfg, pass, ignoreSet).ReportViolations(generic)
+
+	return nil, nil
+}
+
+// MustReturnChecker checks @mustreturn annotations
+var MustReturnChecker = &analysis.Analyzer{
+	Name: "mustreturnchecker",
+	Doc:  "Checks that results of functions and methods with @mustreturn are not discarded",
+	Run:  runMustReturnChecker,
+	Requires: []*analysis.Analyzer{
+		ConfigReader,
+		AnnotationReader,
+		IgnoreReader,
+	},
+	FactTypes: []analysis.Fact{
+		(*annotations.MustReturnCheckerFact)(nil),
+	},
+}
+
+func runMustReturnChecker(pass *analysis.Pass) (interface{}, error) {
+	result := pass.ResultOf[AnnotationReader]
+	if result == nil {
+		return nil, nil
+	}
+	localAnnotations, ok := result.(annotations.PackageAnnotations)
+	if !ok {
+		return nil, nil
+	}
+	cfg := pass.ResultOf[ConfigReader].(*config.Config)
 
+	// Export facts before isProjectPackage check so dependencies can use them
+	fact := annotations.MustReturnCheckerFact(localAnnotations.Exported(cfg))
+	pass.ExportPackageFact(&fact)
+
+	if skipDiagnostics(cfg, pass) {
+		return nil, nil
+	}
+
+	// Get ignore set from IgnoreReader
+	ignoreSet := pass.ResultOf[IgnoreReader].(ignore.IgnoreResult).IgnoreSet
+
+	// Check calls whose results are discarded
+	violations := mustreturn.CheckMustReturn(cfg, pass, &localAnnotations)
+
+	// Report violations (filtered by ignore set)
+	mustreturn.ReportViolations(cfg, pass, violations, ignoreSet)
+
+	return nil, nil
+}
+
+// ExperimentalChecker checks @experimental annotations
+var ExperimentalChecker = &analysis.Analyzer{
+	Name: "experimentalchecker",
+	Doc:  "Checks that declarations with @experimental are only used in files built with the opt-in tag",
+	Run:  runExperimentalChecker,
+	Requires: []*analysis.Analyzer{
+		ConfigReader,
+		AnnotationReader,
+		IgnoreReader,
+	},
+	FactTypes: []analysis.Fact{
+		(*annotations.ExperimentalCheckerFact)(nil),
+	},
+}
+
+func runExperimentalChecker(pass *analysis.Pass) (interface{}, error) {
+	result := pass.ResultOf[AnnotationReader]
+	if result == nil {
+		return nil, nil
+	}
+	localAnnotations, ok := result.(annotations.PackageAnnotations)
+	if !ok {
+		return nil, nil
+	}
+	cfg := pass.ResultOf[ConfigReader].(*config.Config)
+
+	// Export facts before isProjectPackage check so dependencies can use them
+	fact := annotations.ExperimentalCheckerFact(localAnnotations.Exported(cfg))
+	pass.ExportPackageFact(&fact)
+
+	if skipDiagnostics(cfg, pass) {
+		return nil, nil
+	}
+
+	// Note: We still run the checker even if there are no local @experimental annotations,
+	// because only other packages' @experimental declarations are checked
+
+	// Get ignore set from IgnoreReader
+	ignoreSet := pass.ResultOf[IgnoreReader].(ignore.IgnoreResult).IgnoreSet
+
+	// Check uses of @experimental declarations
+	violations := experimental.CheckExperimental(cfg, pass, &localAnnotations)
+
+	// Report violations (filtered by ignore set)
+	experimental.ReportViolations(cfg, pass, violations, ignoreSet)
+
+	return nil, nil
+}
+
+// DeprecatedPackageChecker checks //gogreement:deprecated package directives
+var DeprecatedPackageChecker = &analysis.Analyzer{
+	Name: "deprecatedpackagechecker",
+	Doc:  "Checks that packages deprecated with //gogreement:deprecated are not imported",
+	Run:  runDeprecatedPackageChecker,
+	Requires: []*analysis.Analyzer{
+		ConfigReader,
+		AnnotationReader,
+		IgnoreReader,
+	},
+	FactTypes: []analysis.Fact{
+		(*annotations.DeprecatedPackageCheckerFact)(nil),
+	},
+}
+
+func runDeprecatedPackageChecker(pass *analysis.Pass) (interface{}, error) {
+	result := pass.ResultOf[AnnotationReader]
+	if result == nil {
+		return nil, nil
+	}
+	localAnnotations, ok := result.(annotations.PackageAnnotations)
+	if !ok {
+		return nil, nil
+	}
+	cfg := pass.ResultOf[ConfigReader].(*config.Config)
+
+	// Export facts before isProjectPackage check so dependencies can use them
+	fact := annotations.DeprecatedPackageCheckerFact(localAnnotations.Exported(cfg))
+	pass.ExportPackageFact(&fact)
+
+	if skipDiagnostics(cfg, pass) {
+		return nil, nil
+	}
+
+	// Get ignore set from IgnoreReader
+	ignoreSet := pass.ResultOf[IgnoreReader].(ignore.IgnoreResult).IgnoreSet
+
+	// Check imports of deprecated packages
+	violations := deprecated.CheckDeprecatedPackages(cfg, pass, &localAnnotations)
+
+	// Report violations (filtered by ignore set)
+	deprecated.ReportViolations(cfg, pass, violations, ignoreSet)
+
+	return nil, nil
+}
+
+// FindingLimiter reports the findings of a --max-findings-per-file run. The
+// checkers hold their findings back when the limit is set, as they run in no
+// fixed order; once all of them are done for a package, the first findings of
+// each of its files are reported here.
+var FindingLimiter = &analysis.Analyzer{
+	Name: "findinglimiter",
+	Doc:  "Reports at most --max-findings-per-file findings per file across all checkers",
+	Run:  runFindingLimiter,
+	Requires: []*analysis.Analyzer{
+		ConfigReader,
+		ImplementsChecker,
+		ImmutableChecker,
+		ConstructorChecker,
+		TestOnlyChecker,
+		PackageOnlyChecker,
+		SinceChecker,
+		EnumChecker,
+		RequiredChecker,
+		SingletonChecker,
+		MustReturnChecker,
+		ExperimentalChecker,
+		DeprecatedPackageChecker,
+		PlacementChecker,
+		IgnoreChecker,
+	},
+}
+
+func runFindingLimiter(pass *analysis.Pass) (interface{}, error) {
+	cfg := pass.ResultOf[ConfigReader].(*config.Config)
+	if cfg.MaxFindingsPerFile > 0 {
+		reporting.LimitFindings(pass, cfg.MaxFindingsPerFile)
+	}
 	return nil, nil
 }
 
@@ -331,11 +1022,22 @@ func AllAnalyzers() []*analysis.Analyzer {
 	return []*analysis.Analyzer{
 		ConfigReader,
 		AnnotationReader,
+		ContractsReader,
 		IgnoreReader,
 		ImplementsChecker,
 		ImmutableChecker,
 		ConstructorChecker,
 		TestOnlyChecker,
 		PackageOnlyChecker,
+		SinceChecker,
+		EnumChecker,
+		RequiredChecker,
+		SingletonChecker,
+		MustReturnChecker,
+		ExperimentalChecker,
+		DeprecatedPackageChecker,
+		PlacementChecker,
+		IgnoreChecker,
+		FindingLimiter,
 	}
 }
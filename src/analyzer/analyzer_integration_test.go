@@ -2,12 +2,20 @@ package analyzer
 
 import (
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/analysistest"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
 
+	"github.com/a14e/gogreement/src/config"
 	"github.com/a14e/gogreement/src/ignore"
+	"github.com/a14e/gogreement/src/indexing"
 	"github.com/a14e/gogreement/src/testutil"
 )
 
@@ -61,6 +69,66 @@ func TestImmutableCheckerCrossModule(t *testing.T) {
 	analysistest.Run(t, testdata, ImmutableChecker, "multimodule_immutable/modA", "multimodule_immutable/modB")
 }
 
+// TestEnumCheckerCrossModule tests @enum checking across modules
+func TestEnumCheckerCrossModule(t *testing.T) {
+	defer setupTestEnv()()
+
+	testdata := testutil.GetRootTestdataPath() + "/integration"
+	analysistest.Run(t, testdata, EnumChecker, "multimodule_enum/modA", "multimodule_enum/modB")
+}
+
+// TestRequiredCheckerCrossPackage tests @required checking across packages of one module
+func TestRequiredCheckerCrossPackage(t *testing.T) {
+	defer setupTestEnv()()
+
+	testdata := testutil.GetRootTestdataPath() + "/integration"
+	analysistest.Run(t, testdata, RequiredChecker, "multimodule_required/modA", "multimodule_required/modB")
+}
+
+// TestSingletonCheckerCrossPackage tests that construction sites of @singleton
+// types add up across packages
+func TestSingletonCheckerCrossPackage(t *testing.T) {
+	defer setupTestEnv()()
+
+	testdata := testutil.GetRootTestdataPath() + "/integration"
+	analysistest.Run(t, testdata, SingletonChecker, "multimodule_singleton/modA", "multimodule_singleton/modB")
+}
+
+// TestMustReturnCheckerCrossPackage tests that discarded results of
+// @mustreturn methods of other packages are reported
+func TestMustReturnCheckerCrossPackage(t *testing.T) {
+	defer setupTestEnv()()
+
+	testdata := testutil.GetRootTestdataPath() + "/integration"
+	analysistest.Run(t, testdata, MustReturnChecker, "multimodule_mustreturn/modA", "multimodule_mustreturn/modB")
+}
+
+// TestExperimentalCheckerCrossPackage tests that uses of @experimental
+// declarations of other packages are reported in files without the opt-in tag
+func TestExperimentalCheckerCrossPackage(t *testing.T) {
+	defer setupTestEnv()()
+
+	testdata := testutil.GetRootTestdataPath() + "/integration"
+	analysistest.Run(t, testdata, ExperimentalChecker, "multimodule_experimental/modA", "multimodule_experimental/modB")
+}
+
+// TestDeprecatedPackageCheckerCrossPackage tests that imports of packages
+// deprecated with //gogreement:deprecated are reported with the replacement
+func TestDeprecatedPackageCheckerCrossPackage(t *testing.T) {
+	defer setupTestEnv()()
+
+	testdata := testutil.GetRootTestdataPath() + "/integration"
+	analysistest.Run(t, testdata, DeprecatedPackageChecker, "multimodule_deprecated/modA", "multimodule_deprecated/modB")
+}
+
+// TestPlacementChecker tests that misplaced annotations are reported and can be ignored
+func TestPlacementChecker(t *testing.T) {
+	defer setupTestEnv()()
+
+	testdata := testutil.GetRootTestdataPath() + "/integration"
+	analysistest.Run(t, testdata, PlacementChecker, "misplaced_annotations/modA")
+}
+
 // TestConstructorCheckerCrossModule tests constructor checking across modules
 func TestConstructorCheckerCrossModule(t *testing.T) {
 	defer setupTestEnv()()
@@ -115,3 +183,109 @@ func TestPackageOnlyCheckerCrossModule(t *testing.T) {
 	testdata := testutil.GetRootTestdataPath() + "/integration"
 	analysistest.Run(t, testdata, PackageOnlyChecker, "multimodule_packageonly/modA", "multimodule_packageonly/modB")
 }
+
+// TestSkipPackages tests that a package matching SkipPackages produces no
+// diagnostics while its sibling is checked normally
+func TestSkipPackages(t *testing.T) {
+	defer setupTestEnv()()
+	t.Setenv("GOGREEMENT_SKIP_PACKAGES", "multimodule_skip/generated/...")
+
+	testdata := testutil.GetRootTestdataPath() + "/integration"
+	analysistest.Run(t, testdata, ImmutableChecker, "multimodule_skip/modA", "multimodule_skip/generated/client", "multimodule_skip/modB")
+}
+
+// TestLocalOnlyAnnotations tests that a local-only @testonly is enforced in
+// its own package but not exported to the packages importing it
+func TestLocalOnlyAnnotations(t *testing.T) {
+	defer setupTestEnv()()
+	t.Setenv("GOGREEMENT_LOCAL_ONLY_ANNOTATIONS", "testonly")
+
+	testdata := testutil.GetRootTestdataPath() + "/integration"
+	analysistest.Run(t, testdata, TestOnlyChecker, "multimodule_localonly/modA", "multimodule_localonly/modB")
+}
+
+// TestImplementsTestOnlyImport tests that @implements in a test file resolves
+// interfaces from packages only the test files import. analysistest expects
+// facts to be matched in every package, including the generated test main, so
+// the test variant is analyzed directly.
+func TestImplementsTestOnlyImport(t *testing.T) {
+	defer setupTestEnv()()
+	t.Setenv("GOGREEMENT_SCAN_TESTS", "true")
+
+	dir := filepath.Join(testutil.GetRootTestdataPath(), "integration", "src", "multimodule_testimports")
+	pkgs, err := packages.Load(&packages.Config{Mode: packages.LoadAllSyntax, Dir: dir, Tests: true}, "./modA")
+	require.NoError(t, err)
+
+	graph, err := checker.Analyze([]*analysis.Analyzer{ImplementsChecker}, pkgs, nil)
+	require.NoError(t, err)
+
+	var messages []string
+	for action := range graph.All() {
+		if action.Analyzer != ImplementsChecker || action.Package.PkgPath != "multimodule_testimports/modA" {
+			continue
+		}
+		require.NoError(t, action.Err)
+		for _, diagnostic := range action.Diagnostics {
+			headline, _, _ := strings.Cut(diagnostic.Message, "\n")
+			messages = append(messages, headline)
+		}
+	}
+
+	// fixedClock resolves fakes.Clock and implements it; brokenClock resolves
+	// it too, so it is reported for the missing method instead of IMPL01
+	require.Equal(t, []string{
+		`error: [IMPL03] type "brokenClock" does not implement interface "fakes.Clock"`,
+	}, messages)
+}
+
+// TestContractsReaderCrossModule tests that the contracts of modA reach modB
+// through the consolidated fact
+func TestContractsReaderCrossModule(t *testing.T) {
+	defer setupTestEnv()()
+
+	dir := filepath.Join(testutil.GetRootTestdataPath(), "integration", "src", "multimodule_immutable")
+	pkgs, err := packages.Load(&packages.Config{Mode: packages.LoadAllSyntax, Dir: dir}, "./modB")
+	require.NoError(t, err)
+
+	graph, err := checker.Analyze([]*analysis.Analyzer{ContractsReader}, pkgs, &checker.Options{SanityCheck: true})
+	require.NoError(t, err)
+
+	var contracts *indexing.Contracts
+	for action := range graph.All() {
+		if action.Analyzer == ContractsReader && action.Package.PkgPath == "multimodule_immutable/modB" {
+			require.NoError(t, action.Err)
+			result := action.Result.(indexing.Contracts)
+			contracts = &result
+		}
+	}
+	require.NotNil(t, contracts, "expected a result for modB")
+
+	assert.True(t, contracts.ImmutableTypes.Contains("multimodule_immutable/modA", "User"))
+	assert.Equal(t, []string{"NewUser"}, contracts.Constructors.GetAssociated("multimodule_immutable/modA", "User"))
+	assert.Equal(t, []string{"Cache"}, contracts.MutableFields.GetAssociated("multimodule_immutable/modA", "User"))
+}
+
+// TestRequireImplementsAnnotation tests that exported types implementing a
+// required interface without @implements are reported under the flag
+func TestRequireImplementsAnnotation(t *testing.T) {
+	defer setupTestEnv()()
+	t.Setenv("GOGREEMENT_REQUIRE_IMPLEMENTS_ANNOTATION", "true")
+
+	testdata := testutil.GetRootTestdataPath() + "/integration"
+	analysistest.Run(t, testdata, ImplementsChecker, "multimodule_requireimpl/modA")
+}
+
+// TestCheckScopes tests that a checker scoped to pkg/domain/** in the config
+// file reports mutations there and ignores the same mutation elsewhere
+func TestCheckScopes(t *testing.T) {
+	defer setupTestEnv()()
+
+	testdata := testutil.GetRootTestdataPath() + "/integration"
+
+	dir := t.TempDir()
+	content := `{"excludePaths": [], "checkScopes": {"immutable": ["pkg/domain/**"]}}`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, config.FileName), []byte(content), 0o644))
+	t.Chdir(dir)
+
+	analysistest.Run(t, testdata, ImmutableChecker, "multimodule_scopes/pkg/domain", "multimodule_scopes/internal/handlers")
+}
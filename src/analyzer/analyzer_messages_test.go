@@ -0,0 +1,109 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/a14e/gogreement/src/reporting"
+	"github.com/a14e/gogreement/src/testutil"
+)
+
+// TestMessagesHaveNoAbsolutePaths runs every analyzer, with all opt-in checks
+// enabled, over the unit testdata and asserts that no message mentions a
+// machine-specific directory. Messages end up in golden files and CI reports,
+// so they must be identical on every checkout.
+func TestMessagesHaveNoAbsolutePaths(t *testing.T) {
+	if testing.Short() {
+		t.Skip("loads and analyzes all unit testdata packages")
+	}
+
+	defer setupTestEnv()()
+	for _, env := range []string{
+		"GOGREEMENT_CHECK_SINCE",
+		"GOGREEMENT_CHECK_POINTEES",
+		"GOGREEMENT_REQUIRE_IMPLEMENTS_ANNOTATION",
+		"GOGREEMENT_VERBOSE_IMPLEMENTS",
+	} {
+		t.Setenv(env, "true")
+	}
+
+	root := filepath.Dir(testutil.GetRootTestdataPath())
+	machineDirs := []string{root, runtime.GOROOT(), os.TempDir()}
+	if home, err := os.UserHomeDir(); err == nil {
+		machineDirs = append(machineDirs, home)
+	}
+
+	pkgs, err := packages.Load(&packages.Config{Mode: packages.LoadAllSyntax, Dir: root}, "./testdata/unit/...")
+	require.NoError(t, err)
+	require.NotEmpty(t, pkgs)
+
+	graph, err := checker.Analyze(AllAnalyzers(), pkgs, nil)
+	require.NoError(t, err)
+
+	reported := 0
+	for action := range graph.All() {
+		for _, diagnostic := range action.Diagnostics {
+			reported++
+			for _, dir := range machineDirs {
+				if dir != "" && strings.Contains(diagnostic.Message, filepath.ToSlash(dir)) {
+					t.Errorf("%s: message mentions %s:\n%s", action.Package.Fset.Position(diagnostic.Pos), dir, diagnostic.Message)
+				}
+			}
+		}
+	}
+	require.NotZero(t, reported, "the testdata is expected to produce findings")
+}
+
+// TestMaxFindingsPerFile runs every analyzer with a limit of one finding per
+// file and asserts that each file gets one finding and at most one note, no
+// matter in which order the checkers ran.
+func TestMaxFindingsPerFile(t *testing.T) {
+	if testing.Short() {
+		t.Skip("loads and analyzes unit testdata packages")
+	}
+
+	defer setupTestEnv()()
+	t.Setenv("GOGREEMENT_MAX_FINDINGS_PER_FILE", "1")
+	reporting.ResetRun()
+	t.Cleanup(reporting.ResetRun)
+
+	root := filepath.Dir(testutil.GetRootTestdataPath())
+	pkgs, err := packages.Load(&packages.Config{Mode: packages.LoadAllSyntax, Dir: root},
+		"./testdata/unit/immutabletests", "./testdata/unit/constructortests")
+	require.NoError(t, err)
+	require.NotEmpty(t, pkgs)
+
+	graph, err := checker.Analyze(AllAnalyzers(), pkgs, nil)
+	require.NoError(t, err)
+
+	findings := make(map[string]int)
+	notes := make(map[string][]string)
+	for _, action := range graph.Roots {
+		for _, diagnostic := range action.Diagnostics {
+			file := action.Package.Fset.Position(diagnostic.Pos).Filename
+			if diagnostic.Category == "" {
+				notes[file] = append(notes[file], diagnostic.Message)
+				continue
+			}
+			findings[file]++
+		}
+	}
+
+	require.NotEmpty(t, findings, "the testdata is expected to produce findings")
+	for file, count := range findings {
+		assert.Equal(t, 1, count, "findings of %s", file)
+	}
+	require.NotEmpty(t, notes, "the testdata is expected to produce more findings than the limit")
+	for file, messages := range notes {
+		require.Len(t, messages, 1, "notes of %s", file)
+		assert.Regexp(t, `^\.\.\. and \d+ more findings? in this file$`, messages[0])
+	}
+}
@@ -0,0 +1,49 @@
+package analyzer
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/a14e/gogreement/src/testutil"
+)
+
+// TestSelfCheck runs every analyzer over gogreement's own packages with the
+// default configuration. The tool annotates its own types (@immutable,
+// @implements, @constructor, @testonly, ...), so any diagnostic here means it
+// breaks its own contracts.
+func TestSelfCheck(t *testing.T) {
+	if testing.Short() {
+		t.Skip("loads and analyzes the whole module")
+	}
+
+	// Testdata packages violate on purpose; the go tool skips them for ./...
+	// anyway, but keep the default exclusion explicit
+	t.Setenv("GOGREEMENT_EXCLUDE_PATHS", "testdata")
+
+	root := filepath.Dir(testutil.GetRootTestdataPath())
+	pkgs, err := packages.Load(&packages.Config{Mode: packages.LoadAllSyntax, Dir: root}, "./...")
+	require.NoError(t, err)
+	require.NotEmpty(t, pkgs)
+	for _, pkg := range pkgs {
+		for _, pkgErr := range pkg.Errors {
+			t.Fatalf("load %s: %v", pkg.PkgPath, pkgErr)
+		}
+	}
+
+	graph, err := checker.Analyze(AllAnalyzers(), pkgs, nil)
+	require.NoError(t, err)
+
+	for action := range graph.All() {
+		if action.Err != nil {
+			t.Errorf("%s on %s: %v", action.Analyzer.Name, action.Package.PkgPath, action.Err)
+			continue
+		}
+		for _, diagnostic := range action.Diagnostics {
+			t.Errorf("%s: %s", action.Package.Fset.Position(diagnostic.Pos), diagnostic.Message)
+		}
+	}
+}
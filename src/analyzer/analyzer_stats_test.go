@@ -0,0 +1,64 @@
+package analyzer
+
+import (
+	"maps"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/a14e/gogreement/src/annotations"
+	"github.com/a14e/gogreement/src/config"
+	"github.com/a14e/gogreement/src/stats"
+	"github.com/a14e/gogreement/src/testutil"
+)
+
+// TestStatsCountsAnnotations checks that --stats counters reflect the
+// annotations and violations of the multimodule_immutable testdata
+func TestStatsCountsAnnotations(t *testing.T) {
+	defer setupTestEnv()()
+	t.Setenv("GOGREEMENT_STATS", "true")
+	t.Setenv(stats.SinkEnv, "")
+
+	stats.Reset()
+	defer stats.Reset()
+
+	testdata := testutil.GetRootTestdataPath() + "/integration"
+	analysistest.Run(t, testdata, ImmutableChecker, "multimodule_immutable/modA", "multimodule_immutable/modB")
+
+	total := stats.Total()
+	assert.Positive(t, total.Files)
+	assert.Equal(t, 2, total.Annotations["immutable"])
+	assert.Equal(t, 2, total.Annotations["constructor"])
+	assert.Equal(t, 2, total.Annotations["mutable"])
+	assert.Equal(t, 2, total.Annotations["testonly"])
+	assert.Zero(t, total.Annotations["implements"])
+	assert.Positive(t, total.Violations["IMM01"], "reported IMM01 violations are counted")
+}
+
+// TestStatsCountsEveryAnnotationKind gives every annotation slice of
+// PackageAnnotations a different length and expects each length among the
+// counters, so a kind added to PackageAnnotations without a counter fails here
+func TestStatsCountsEveryAnnotationKind(t *testing.T) {
+	var ann annotations.PackageAnnotations
+	value := reflect.ValueOf(&ann).Elem()
+	var want []int
+	for i := range value.NumField() {
+		field := value.Type().Field(i)
+		if field.Name == "MisplacedAnnotations" {
+			continue // not an annotation kind, only reported by the placement checker
+		}
+		require.Equal(t, reflect.Slice, field.Type.Kind(), "field %s", field.Name)
+		length := len(want) + 1
+		value.Field(i).Set(reflect.MakeSlice(field.Type, length, length))
+		want = append(want, length)
+	}
+
+	counters := annotationStats(config.Empty(), &analysis.Pass{}, &ann)
+
+	assert.ElementsMatch(t, want, slices.Collect(maps.Values(counters.Annotations)))
+}
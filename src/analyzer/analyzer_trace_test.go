@@ -0,0 +1,51 @@
+package analyzer
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis/analysistest"
+
+	"github.com/a14e/gogreement/src/testutil"
+	"github.com/a14e/gogreement/src/trace"
+)
+
+// TestTraceContractsListsFindings checks that the --trace-contracts entry of
+// an @immutable type lists the mutations reported against it, in its own
+// package and in importers
+func TestTraceContractsListsFindings(t *testing.T) {
+	defer setupTestEnv()()
+	t.Setenv("GOGREEMENT_TRACE_CONTRACTS", "true")
+	t.Setenv(trace.SinkEnv, "")
+
+	trace.Reset()
+	defer trace.Reset()
+
+	testdata := testutil.GetRootTestdataPath() + "/integration"
+	analysistest.Run(t, testdata, ImmutableChecker, "multimodule_immutable/modA", "multimodule_immutable/modB")
+
+	var user *trace.Contract
+	for _, contract := range trace.Total() {
+		if contract.Kind == "immutable" && contract.Target == "multimodule_immutable/modA.User" {
+			user = &contract
+		}
+	}
+	require.NotNil(t, user, "the @immutable annotation of User is listed")
+	assert.Equal(t, "types.go", filepath.Base(user.Position.File))
+	assert.Equal(t, 6, user.Position.Line)
+
+	var found []string
+	for _, finding := range user.Findings {
+		found = append(found, fmt.Sprintf("%s:%d %s", filepath.Base(finding.Position.File), finding.Position.Line, finding.Code))
+	}
+	assert.Equal(t, []string{
+		"types.go:64 IMM01",
+		"immutable_violations.go:6 IMM01",
+		"immutable_violations.go:44 IMM03",
+		"immutable_violations.go:50 IMM01",
+		"immutable_violations.go:72 IMM01",
+	}, found, "mutations in the declaring package and in importers; ignored ones are left out")
+}
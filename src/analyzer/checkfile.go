@@ -0,0 +1,143 @@
+package analyzer
+
+import (
+	"cmp"
+	"fmt"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/analysis/checker"
+	"golang.org/x/tools/go/packages"
+
+	"github.com/a14e/gogreement/src/config"
+	"github.com/a14e/gogreement/src/reporting"
+)
+
+// Diagnostic is a finding reported by CheckFile
+// @immutable
+type Diagnostic struct {
+	// Analyzer is the name of the analyzer that reported the finding
+	Analyzer string
+	Code     string
+	Message  string
+	Position token.Position
+}
+
+// fileConfigs holds the configuration of the running CheckFile calls, keyed
+// by the file set their packages were loaded into. runConfig returns it
+// instead of parsing flags for the passes of those packages, so concurrent
+// calls each see their own configuration.
+var fileConfigs sync.Map // *token.FileSet -> *config.Config
+
+// CheckFile runs all analyzers on the package containing the file at path
+// and returns the findings in that file only. The package's dependencies are
+// analyzed too, so contracts declared in imported packages are still
+// enforced; only the reporting is restricted to the file. With FailFast only
+// the first finding of the file is returned. This is meant for editors
+// checking the current file on save. An invalid cfg is rejected
+// with the error of Config.Validate before anything is loaded.
+func CheckFile(path string, cfg *config.Config) ([]Diagnostic, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	target, err := os.Stat(absPath)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	pkgs, err := packages.Load(&packages.Config{
+		Mode:  packages.LoadAllSyntax,
+		Dir:   filepath.Dir(absPath),
+		Fset:  fset,
+		Tests: cfg.ScanTests,
+	}, "file="+absPath)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) == 0 {
+		return nil, fmt.Errorf("no package contains %s", absPath)
+	}
+	for _, pkg := range pkgs {
+		if len(pkg.Errors) > 0 {
+			return nil, fmt.Errorf("load %s: %v", pkg.PkgPath, pkg.Errors[0])
+		}
+	}
+
+	// Fail-fast is applied below, once the findings are restricted to the
+	// file: the analyzers would otherwise spend the single finding on one in
+	// another file of the package or in a dependency
+	fileConfigs.Store(fset, cfg.WithFailFast(false))
+	defer fileConfigs.Delete(fset)
+
+	graph, err := checker.Analyze(AllAnalyzers(), pkgs, nil)
+	if err != nil {
+		return nil, err
+	}
+	// Dependencies are only analyzed for their facts, so the findings held
+	// for them by --max-findings-per-file are never reported
+	for action := range graph.All() {
+		reporting.DiscardFindings(action.Package.Types)
+	}
+
+	// The file may be reached through a symlink or under another spelling of
+	// its path, so positions are matched by the file they name
+	inFile := make(map[string]bool)
+	isTarget := func(filename string) bool {
+		same, ok := inFile[filename]
+		if !ok {
+			info, err := os.Stat(filename)
+			same = err == nil && os.SameFile(info, target)
+			inFile[filename] = same
+		}
+		return same
+	}
+
+	var diagnostics []Diagnostic
+	seen := make(map[Diagnostic]bool) // test variants repeat the package's files
+	for _, action := range graph.Roots {
+		if action.Err != nil {
+			return nil, fmt.Errorf("%s: %w", action.Analyzer.Name, action.Err)
+		}
+		for _, diagnostic := range action.Diagnostics {
+			position := action.Package.Fset.Position(diagnostic.Pos)
+			if !isTarget(position.Filename) {
+				continue
+			}
+			found := newDiagnostic(action.Analyzer, diagnostic, position)
+			if seen[found] {
+				continue
+			}
+			seen[found] = true
+			diagnostics = append(diagnostics, found)
+		}
+	}
+
+	if cfg.FailFast && len(diagnostics) > 1 {
+		first := slices.MinFunc(diagnostics, func(a, b Diagnostic) int {
+			return cmp.Or(cmp.Compare(a.Position.Offset, b.Position.Offset), cmp.Compare(a.Code, b.Code))
+		})
+		diagnostics = []Diagnostic{first}
+	}
+
+	return diagnostics, nil
+}
+
+func newDiagnostic(a *analysis.Analyzer, diagnostic analysis.Diagnostic, position token.Position) Diagnostic {
+	return Diagnostic{
+		Analyzer: a.Name,
+		Code:     diagnostic.Category,
+		Message:  diagnostic.Message,
+		Position: position,
+	}
+}
@@ -0,0 +1,103 @@
+package analyzer
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a14e/gogreement/src/codes"
+	"github.com/a14e/gogreement/src/config"
+	"github.com/a14e/gogreement/src/testutil"
+)
+
+func TestCheckFile(t *testing.T) {
+	dir := filepath.Join(testutil.GetRootTestdataPath(), "integration", "src", "checkfile")
+	path := filepath.Join(dir, "modB", "current.go")
+
+	diagnostics, err := CheckFile(path, config.Empty())
+	require.NoError(t, err)
+
+	require.Len(t, diagnostics, 1, "only the requested file is reported")
+	found := diagnostics[0]
+	assert.Equal(t, ImmutableChecker.Name, found.Analyzer)
+	assert.Equal(t, codes.ImmutableFieldAssignment, found.Code)
+	assert.Contains(t, found.Message, `external package mutates immutable type "checkfile/modA.Point"`)
+	assert.Equal(t, path, found.Position.Filename)
+	assert.Equal(t, 7, found.Position.Line)
+}
+
+func TestCheckFileConfig(t *testing.T) {
+	path := filepath.Join(testutil.GetRootTestdataPath(), "integration", "src", "checkfile", "modB", "current.go")
+
+	diagnostics, err := CheckFile(path, config.Empty().WithExcludeChecks([]string{codes.ImmutableCategoryPrefix}))
+	require.NoError(t, err)
+	assert.Empty(t, diagnostics, "the given configuration is used instead of flags")
+}
+
+func TestCheckFileInvalidConfig(t *testing.T) {
+	path := filepath.Join(testutil.GetRootTestdataPath(), "integration", "src", "checkfile", "modB", "current.go")
+
+	diagnostics, err := CheckFile(path, config.Empty().WithExcludeChecks([]string{"IMM99"}))
+
+	var validationErr *config.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []string{`excludeChecks: unknown code "IMM99"`}, validationErr.Problems)
+	assert.Nil(t, diagnostics)
+}
+
+func TestCheckFileFailFast(t *testing.T) {
+	path := filepath.Join(testutil.GetRootTestdataPath(), "integration", "src", "checkfile", "modB", "current.go")
+	cfg := config.Empty().WithFailFast(true)
+
+	// Findings in other files of the package or in dependencies must not take
+	// the place of the file's first finding, on the first call or later ones
+	for call := 1; call <= 2; call++ {
+		diagnostics, err := CheckFile(path, cfg)
+		require.NoError(t, err)
+		require.Len(t, diagnostics, 1, "call %d", call)
+		assert.Equal(t, codes.ImmutableFieldAssignment, diagnostics[0].Code)
+		assert.Equal(t, 7, diagnostics[0].Position.Line)
+	}
+}
+
+func TestCheckFileThroughSymlink(t *testing.T) {
+	dir := filepath.Join(testutil.GetRootTestdataPath(), "integration", "src", "checkfile")
+	link := filepath.Join(t.TempDir(), "checkfile")
+	if err := os.Symlink(dir, link); err != nil {
+		t.Skipf("symlinks are not available: %v", err)
+	}
+
+	diagnostics, err := CheckFile(filepath.Join(link, "modB", "current.go"), config.Empty())
+	require.NoError(t, err)
+	require.Len(t, diagnostics, 1, "the file is found when reached through a symlink")
+	assert.Equal(t, codes.ImmutableFieldAssignment, diagnostics[0].Code)
+	assert.Equal(t, 7, diagnostics[0].Position.Line)
+}
+
+func TestCheckFileConcurrentConfigs(t *testing.T) {
+	path := filepath.Join(testutil.GetRootTestdataPath(), "integration", "src", "checkfile", "modB", "current.go")
+	excluded := config.Empty().WithExcludeChecks([]string{codes.ImmutableCategoryPrefix})
+
+	// Each call is analyzed with its own configuration, however the calls
+	// interleave
+	var wg sync.WaitGroup
+	counts := make([]int, 6)
+	for i := range counts {
+		wg.Go(func() {
+			cfg := config.Empty()
+			if i%2 == 1 {
+				cfg = excluded
+			}
+			diagnostics, err := CheckFile(path, cfg)
+			assert.NoError(t, err)
+			counts[i] = len(diagnostics)
+		})
+	}
+	wg.Wait()
+
+	assert.Equal(t, []int{1, 0, 1, 0, 1, 0}, counts)
+}
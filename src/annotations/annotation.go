@@ -1,11 +1,15 @@
 package annotations
 
 import (
+	"cmp"
 	"go/ast"
 	"go/token"
 	"go/types"
+	"iter"
 	"regexp"
+	"slices"
 	"strings"
+	"unicode"
 
 	"github.com/cloudflare/ahocorasick"
 	"golang.org/x/tools/go/analysis"
@@ -18,12 +22,26 @@ import (
 // @implements &analysis.Fact
 // @immutable
 type PackageAnnotations struct {
-	ImplementsAnnotations  []ImplementsAnnotation
-	ConstructorAnnotations []ConstructorAnnotation
-	ImmutableAnnotations   []ImmutableAnnotation
-	TestonlyAnnotations    []TestOnlyAnnotation
-	MutableAnnotations     []MutableAnnotation
-	PackageOnlyAnnotations []PackageOnlyAnnotation
+	ImplementsAnnotations      []ImplementsAnnotation
+	ImplementsOneOfAnnotations []ImplementsOneOfAnnotation
+	ImplementedByAnnotations   []ImplementedByAnnotation
+	NotImplementsAnnotations   []NotImplementsAnnotation
+	ConstructorAnnotations     []ConstructorAnnotation
+	ImmutableAnnotations       []ImmutableAnnotation
+	TestonlyAnnotations        []TestOnlyAnnotation
+	MutableAnnotations         []MutableAnnotation
+	PackageOnlyAnnotations     []PackageOnlyAnnotation
+	SinceAnnotations           []SinceAnnotation
+	EnumAnnotations            []EnumAnnotation
+	RequiredAnnotations        []RequiredAnnotation
+	SingletonAnnotations       []SingletonAnnotation
+	MustReturnAnnotations      []MustReturnAnnotation
+	ExperimentalAnnotations    []ExperimentalAnnotation
+	DeprecatedPackages         []DeprecatedPackageAnnotation
+
+	// MisplacedAnnotations are reported in their own package only and never
+	// exported as facts
+	MisplacedAnnotations []MisplacedAnnotation
 }
 
 func (*PackageAnnotations) AFact() {}
@@ -85,21 +103,6 @@ func (*ImmutableCheckerFact) CreateEmpty() AnnotationWrapper {
 	return &ImmutableCheckerFact{}
 }
 
-// ConstructorCheckerFact is used by ConstructorChecker analyzer
-// @implements &analysis.Fact
-// @implements &AnnotationWrapper
-type ConstructorCheckerFact PackageAnnotations
-
-func (*ConstructorCheckerFact) AFact() {}
-
-func (f *ConstructorCheckerFact) GetAnnotations() *PackageAnnotations {
-	return (*PackageAnnotations)(f)
-}
-
-func (*ConstructorCheckerFact) CreateEmpty() AnnotationWrapper {
-	return &ConstructorCheckerFact{}
-}
-
 // TestOnlyCheckerFact is used by TestOnlyChecker analyzer
 // @implements &analysis.Fact
 // @implements &AnnotationWrapper
@@ -130,6 +133,133 @@ func (*PackageOnlyCheckerFact) CreateEmpty() AnnotationWrapper {
 	return &PackageOnlyCheckerFact{}
 }
 
+// SinceCheckerFact is used by SinceChecker analyzer. It carries the parsed
+// @since versions of a package for consumers in other modules.
+// @implements &analysis.Fact
+// @implements &AnnotationWrapper
+type SinceCheckerFact PackageAnnotations
+
+func (*SinceCheckerFact) AFact() {}
+
+func (f *SinceCheckerFact) GetAnnotations() *PackageAnnotations {
+	return (*PackageAnnotations)(f)
+}
+
+func (*SinceCheckerFact) CreateEmpty() AnnotationWrapper {
+	return &SinceCheckerFact{}
+}
+
+// EnumCheckerFact is used by EnumChecker analyzer
+// @implements &analysis.Fact
+// @implements &AnnotationWrapper
+type EnumCheckerFact PackageAnnotations
+
+func (*EnumCheckerFact) AFact() {}
+
+func (f *EnumCheckerFact) GetAnnotations() *PackageAnnotations {
+	return (*PackageAnnotations)(f)
+}
+
+func (*EnumCheckerFact) CreateEmpty() AnnotationWrapper {
+	return &EnumCheckerFact{}
+}
+
+// RequiredCheckerFact is used by RequiredChecker analyzer
+// @implements &analysis.Fact
+// @implements &AnnotationWrapper
+type RequiredCheckerFact PackageAnnotations
+
+func (*RequiredCheckerFact) AFact() {}
+
+func (f *RequiredCheckerFact) GetAnnotations() *PackageAnnotations {
+	return (*PackageAnnotations)(f)
+}
+
+func (*RequiredCheckerFact) CreateEmpty() AnnotationWrapper {
+	return &RequiredCheckerFact{}
+}
+
+// SingletonCheckerFact is used by SingletonChecker analyzer
+// @implements &analysis.Fact
+// @implements &AnnotationWrapper
+type SingletonCheckerFact PackageAnnotations
+
+func (*SingletonCheckerFact) AFact() {}
+
+func (f *SingletonCheckerFact) GetAnnotations() *PackageAnnotations {
+	return (*PackageAnnotations)(f)
+}
+
+func (*SingletonCheckerFact) CreateEmpty() AnnotationWrapper {
+	return &SingletonCheckerFact{}
+}
+
+// MustReturnCheckerFact is used by MustReturnChecker analyzer
+// @implements &analysis.Fact
+// @implements &AnnotationWrapper
+type MustReturnCheckerFact PackageAnnotations
+
+func (*MustReturnCheckerFact) AFact() {}
+
+func (f *MustReturnCheckerFact) GetAnnotations() *PackageAnnotations {
+	return (*PackageAnnotations)(f)
+}
+
+func (*MustReturnCheckerFact) CreateEmpty() AnnotationWrapper {
+	return &MustReturnCheckerFact{}
+}
+
+// ExperimentalCheckerFact is used by ExperimentalChecker analyzer
+// @implements &analysis.Fact
+// @implements &AnnotationWrapper
+type ExperimentalCheckerFact PackageAnnotations
+
+func (*ExperimentalCheckerFact) AFact() {}
+
+func (f *ExperimentalCheckerFact) GetAnnotations() *PackageAnnotations {
+	return (*PackageAnnotations)(f)
+}
+
+func (*ExperimentalCheckerFact) CreateEmpty() AnnotationWrapper {
+	return &ExperimentalCheckerFact{}
+}
+
+// DeprecatedPackageCheckerFact is used by DeprecatedPackageChecker analyzer
+// @implements &analysis.Fact
+// @implements &AnnotationWrapper
+type DeprecatedPackageCheckerFact PackageAnnotations
+
+func (*DeprecatedPackageCheckerFact) AFact() {}
+
+func (f *DeprecatedPackageCheckerFact) GetAnnotations() *PackageAnnotations {
+	return (*PackageAnnotations)(f)
+}
+
+func (*DeprecatedPackageCheckerFact) CreateEmpty() AnnotationWrapper {
+	return &DeprecatedPackageCheckerFact{}
+}
+
+// ContractsFact is a compact summary of the annotations other packages
+// enforce: the annotated type, function and method names, without positions
+// or the annotation kinds they are not indexed by. It is exported once per
+// package by the ContractsReader analyzer, so an analyzer that requires it
+// builds all its indices from a single fact instead of one per checker.
+// NewContractsFact builds it.
+// @implements &analysis.Fact
+// @immutable
+type ContractsFact struct {
+	ImmutableTypes []string
+	// MutableFields are the @mutable fields of ImmutableTypes
+	MutableFields   []TypeMember
+	Constructors    []ConstructorContract
+	TestOnlyTypes   []string
+	TestOnlyFuncs   []string
+	TestOnlyMethods []TypeMember
+	PackageOnly     []PackageOnlyContract
+}
+
+func (*ContractsFact) AFact() {}
+
 // ImplementsAnnotation
 // parse result of "@implements MyStruct" annotation
 // @constructor parseImplementsAnnotation
@@ -144,11 +274,97 @@ type ImplementsAnnotation struct {
 	PackageName   string // "" for the current package, "io" for imported (short name from annotation)
 	IsPointer     bool   // true if "@implements &Interface"
 
+	// Explicit receiver form from "@implements Interface via *MyStruct".
+	// When set, it decides value/pointer method-set satisfaction instead of IsPointer.
+	HasReceiverOverride bool
+	ReceiverIsPointer   bool // true for "via *MyStruct", false for "via MyStruct"
+
+	// Receiver named by a "via" clause that is not the annotated type, e.g.
+	// "*Old" after a rename. The clause is then ignored and the annotation is
+	// checked as if it had none.
+	MismatchedReceiver string
+
 	// Resolved package information (only available after ReadAllAnnotations)
 	// NOTE: This is the only place where we have access to both AST (for comments)
 	// and package imports (for resolution). Other loaders are file-agnostic.
 	PackageFullPath string // Full import path: "io", "github.com/user/pkg"
 	PackageNotFound bool   // true if package was referenced but not found in imports
+
+	// TargetPos is the position of the interface in the annotation comment,
+	// "&" included, so fixes can rewrite it. It is only set for line comments;
+	// block comments are normalized before parsing.
+	TargetPos token.Pos
+}
+
+// ImplementsOneOfAnnotation
+// parse result of "@implements-oneof io.Reader, io.Writer" annotation:
+// the type must implement at least one of the listed interfaces
+// @constructor parseImplementsOneOfAnnotation
+// @immutable
+type ImplementsOneOfAnnotation struct {
+	// Type on which annotation is placed
+	OnType    string // "MyStruct"
+	OnTypePos token.Pos
+
+	// One resolved annotation per listed interface, in source order
+	Alternatives []ImplementsAnnotation
+}
+
+// NotImplementsAnnotation
+// parse result of "@notimplements io.Reader": the type deliberately does not
+// implement the interface. It is checked against the @implements annotations
+// of the same type, which must not claim the opposite.
+// @constructor parseNotImplementsAnnotation
+// @immutable
+type NotImplementsAnnotation struct {
+	// Type on which annotation is placed
+	OnType    string // "MyStruct"
+	OnTypePos token.Pos
+
+	// Interface that must not be implemented
+	InterfaceName string // "Reader"
+	PackageName   string // "" for the current package, "io" for imported (short name from annotation)
+	IsPointer     bool   // true if "@notimplements &Interface": not even *T implements it
+
+	// Resolved package information, as for ImplementsAnnotation
+	PackageFullPath string
+	PackageNotFound bool
+
+	// TargetPos is the position of the interface in the annotation comment,
+	// or of the comment itself for block comments
+	TargetPos token.Pos
+}
+
+// ImplementedByAnnotation
+// parse result of "@implementedby FileStore, *MemStore" on an interface:
+// every listed type must implement the interface
+// @constructor parseImplementedByAnnotation
+// @immutable
+type ImplementedByAnnotation struct {
+	// Interface on which annotation is placed
+	OnInterface    string // "Store"
+	OnInterfacePos token.Pos
+
+	// Listed types, in source order
+	Implementers []Implementer
+}
+
+// Implementer is a type listed by @implementedby
+// @constructor parseImplementedByAnnotation
+// @immutable
+type Implementer struct {
+	// Spelling is the type as written, e.g. "*MemStore" or "store.FileStore"
+	Spelling    string
+	TypeName    string // "FileStore"
+	PackageName string // "" for the current package, "store" for imported
+
+	// Resolved package information, as for ImplementsAnnotation
+	PackageFullPath string
+	PackageNotFound bool
+
+	// Check is the @implements annotation the listed type would carry:
+	// "*MemStore" checks the pointer method set like "@implements &Store"
+	Check ImplementsAnnotation
 }
 
 // ConstructorAnnotation
@@ -160,6 +376,10 @@ type ConstructorAnnotation struct {
 	OnTypePos token.Pos
 
 	ConstructorNames []string // ["New", "Create"]
+
+	// LocalOnly is set by "@constructor-local": construction is only
+	// restricted inside the declaring package, other packages may construct freely
+	LocalOnly bool
 }
 
 // ImmutableAnnotation
@@ -230,6 +450,122 @@ type PackageOnlyAnnotation struct {
 	AllowedPackages []string
 }
 
+// SinceAnnotation
+// parse result of "@since v1.2.0" annotation
+// The version is kept verbatim, even when malformed, so it can be validated and
+// carried in facts for cross-module availability checks.
+// @immutable
+// @constructor parseSinceAnnotation
+type SinceAnnotation struct {
+	// Kind of declaration: type, func, or method
+	Kind TestOnlyKind
+
+	// Name of the object: type name, function name, or method name
+	ObjectName string
+	Pos        token.Pos
+
+	// Receiver type (only for methods, empty otherwise)
+	ReceiverType string
+
+	// Version as written after @since ("v1.2.0"), empty when missing
+	Version string
+}
+
+// EnumAnnotation
+// parse result of "@enum" annotation: values of the type must be one of the
+// constants of that type declared in its package
+// @immutable
+// @constructor parseEnumAnnotation
+type EnumAnnotation struct {
+	// Type on which annotation is placed
+	OnType    string // "Color"
+	OnTypePos token.Pos
+}
+
+// RequiredAnnotation
+// parse result of "@required" on a struct field: composite literals of the
+// type must set the field
+// @immutable
+// @constructor parseRequiredAnnotation
+type RequiredAnnotation struct {
+	// Type on which the field is defined
+	OnType string // "Server"
+
+	// Field name that is marked as required
+	FieldName string // "Addr"
+
+	// Position of the field declaration
+	Pos token.Pos
+}
+
+// SingletonAnnotation
+// parse result of "@singleton" annotation: the type must be constructed at
+// most once in the module
+// @immutable
+// @constructor parseSingletonAnnotation
+type SingletonAnnotation struct {
+	// Type on which annotation is placed
+	OnType    string // "Registry"
+	OnTypePos token.Pos
+}
+
+// MustReturnAnnotation
+// parse result of "@mustreturn" annotation: callers must use the result of
+// the function or method
+// @immutable
+// @constructor parseMustReturnAnnotation
+type MustReturnAnnotation struct {
+	// Kind of declaration: func or method
+	Kind TestOnlyKind
+
+	// Name of the function or method
+	ObjectName string
+	Pos        token.Pos
+
+	// Receiver type (only for methods, empty otherwise)
+	ReceiverType string
+}
+
+// ExperimentalAnnotation
+// parse result of "@experimental" annotation: the declaration is unstable
+// API that other packages use only in files built with the opt-in tag
+// @immutable
+// @constructor parseExperimentalAnnotation
+type ExperimentalAnnotation struct {
+	// Kind of declaration: type, func, or method
+	Kind TestOnlyKind
+
+	// Name of the object: type name, function name, or method name
+	ObjectName string
+	Pos        token.Pos
+
+	// Receiver type (only for methods, empty otherwise)
+	ReceiverType string
+}
+
+// DeprecatedPackageAnnotation
+// parse result of a "//gogreement:deprecated use newpkg" directive in a
+// package doc comment: the whole package is deprecated, and importers are
+// pointed to the replacement
+// @immutable
+// @constructor parseDeprecatedPackageDirective
+type DeprecatedPackageAnnotation struct {
+	// Replacement is the import path given after "use", empty when none is
+	Replacement string
+	Pos         token.Pos
+}
+
+// MisplacedAnnotation
+// an annotation written on a kind of declaration it does not apply to, such
+// as @implements on a function. Without a report it would be ignored silently.
+// @immutable
+type MisplacedAnnotation struct {
+	Annotation  string // "implements"
+	Declaration string // "functions"
+	BelongsOn   string // "types"
+	Pos         token.Pos
+}
+
 // TypeQuery represents what type we're looking for
 // @immutable
 type TypeQuery struct {
@@ -244,11 +580,58 @@ type InterfaceQuery struct {
 	PackageName   string // empty string means current package
 }
 
+// AllImplementsAnnotations returns the @implements annotations followed by
+// every alternative of the @implements-oneof groups
+func (p *PackageAnnotations) AllImplementsAnnotations() []ImplementsAnnotation {
+	result := slices.Clone(p.ImplementsAnnotations)
+	for _, group := range p.ImplementsOneOfAnnotations {
+		result = append(result, group.Alternatives...)
+	}
+	return result
+}
+
+// Exported returns the annotations exported as facts to other packages,
+// leaving out the kinds cfg keeps local with LocalOnlyAnnotations. The
+// immutable kind covers the @mutable fields of immutable types too.
+func (p *PackageAnnotations) Exported(cfg *config.Config) PackageAnnotations {
+	return PackageAnnotations{
+		ImplementsAnnotations:      exportedKind(cfg, "implements", p.ImplementsAnnotations),
+		ImplementsOneOfAnnotations: exportedKind(cfg, "implements", p.ImplementsOneOfAnnotations),
+		ImplementedByAnnotations:   exportedKind(cfg, "implements", p.ImplementedByAnnotations),
+		NotImplementsAnnotations:   exportedKind(cfg, "implements", p.NotImplementsAnnotations),
+		ConstructorAnnotations:     exportedKind(cfg, "constructor", p.ConstructorAnnotations),
+		ImmutableAnnotations:       exportedKind(cfg, "immutable", p.ImmutableAnnotations),
+		TestonlyAnnotations:        exportedKind(cfg, "testonly", p.TestonlyAnnotations),
+		MutableAnnotations:         exportedKind(cfg, "immutable", p.MutableAnnotations),
+		PackageOnlyAnnotations:     exportedKind(cfg, "packageonly", p.PackageOnlyAnnotations),
+		SinceAnnotations:           exportedKind(cfg, "since", p.SinceAnnotations),
+		EnumAnnotations:            exportedKind(cfg, "enum", p.EnumAnnotations),
+		RequiredAnnotations:        exportedKind(cfg, "required", p.RequiredAnnotations),
+		SingletonAnnotations:       exportedKind(cfg, "singleton", p.SingletonAnnotations),
+		MustReturnAnnotations:      exportedKind(cfg, "mustreturn", p.MustReturnAnnotations),
+		ExperimentalAnnotations:    exportedKind(cfg, "experimental", p.ExperimentalAnnotations),
+		DeprecatedPackages:         exportedKind(cfg, "deprecated", p.DeprecatedPackages),
+	}
+}
+
+// exportedKind returns annotations unless their kind is local-only
+func exportedKind[T any](cfg *config.Config, kind string, annotations []T) []T {
+	if cfg.IsLocalOnly(kind) {
+		return nil
+	}
+	return annotations
+}
+
 func (p *PackageAnnotations) ToInterfaceQuery() []InterfaceQuery {
-	input := p.ImplementsAnnotations
+	input := p.AllImplementsAnnotations()
 
 	var result []InterfaceQuery
 
+	// Interfaces annotated with @implementedby are declared in this package
+	for _, v := range p.ImplementedByAnnotations {
+		result = append(result, InterfaceQuery{InterfaceName: v.OnInterface})
+	}
+
 	for _, v := range input {
 		if v.PackageNotFound {
 			continue
@@ -264,7 +647,7 @@ func (p *PackageAnnotations) ToInterfaceQuery() []InterfaceQuery {
 }
 
 func (p *PackageAnnotations) ToTypeQuery() []TypeQuery {
-	input := p.ImplementsAnnotations
+	input := p.AllImplementsAnnotations()
 
 	var result []TypeQuery
 
@@ -291,17 +674,29 @@ func (p *PackageAnnotations) ToTypeQuery() []TypeQuery {
 
 // Compile regex once
 var implementsRegex = regexp.MustCompile(
-	`^\s*//\s*@implements\s+(&)?(?:(\w+)\.)?(\w+)(?:\s+.*)?$`,
-	//                           ^1   ^2         ^3
+	`^\s*//\s*@implements\s+(&)?(?:([A-Za-z_]\w*)\.)?([A-Za-z_]\w*)(?:\s+via\s+(\*)?([A-Za-z_]\w*))?(?:\s+.*)?$`,
+	//                           ^1   ^2                  ^3                      ^4   ^5
+	// 1: pointer (optional)
+	// 2: package (optional)
+	// 3: interface name (required)
+	// 4: pointer receiver in "via" clause (optional)
+	// 5: receiver type name in "via" clause (optional)
+	// Names are Go identifiers, so "@implements 1Reader" is not an annotation.
+)
+
+var notImplementsRegex = regexp.MustCompile(
+	`^\s*//\s*@notimplements\s+(&)?(?:([A-Za-z_]\w*)\.)?([A-Za-z_]\w*)(?:\s+.*)?$`,
+	//                              ^1   ^2                  ^3
 	// 1: pointer (optional)
 	// 2: package (optional)
 	// 3: interface name (required)
 )
 
 var constructorRegex = regexp.MustCompile(
-	`^\s*//\s*@constructor(?:\s+([a-zA-Z_][a-zA-Z0-9_]*(?:\s*,\s*[a-zA-Z_][a-zA-Z0-9_]*)*(?:\s*,)?))?(?:\s+.*)?$`,
-	//                              ^1
-	// 1: comma-separated constructor names (only valid Go identifiers, optional trailing comma)
+	`^\s*//\s*@constructor(-local)?(?:\s+([a-zA-Z_][a-zA-Z0-9_]*(?:\s*,\s*[a-zA-Z_][a-zA-Z0-9_]*)*(?:\s*,)?))?(?:\s+.*)?$`,
+	//                      ^1                 ^2
+	// 1: "-local" suffix (optional)
+	// 2: comma-separated constructor names (only valid Go identifiers, optional trailing comma)
 )
 
 var immutableRegex = regexp.MustCompile(
@@ -326,12 +721,128 @@ var packageOnlyRegex = regexp.MustCompile(
 	// 1: comma-separated package names (valid package paths with slashes, dots, optional trailing comma)
 )
 
+var sinceRegex = regexp.MustCompile(
+	`^\s*//\s*@since(?:\s+(\S+))?(?:\s+.*)?$`,
+	//                  ^1
+	// 1: version (optional here; a missing version is reported by validation)
+)
+
+var enumRegex = regexp.MustCompile(
+	`^\s*//\s*@enum(?:\s+.*)?$`,
+)
+
+var requiredRegex = regexp.MustCompile(
+	`^\s*//\s*@required(?:\s+.*)?$`,
+)
+
+var singletonRegex = regexp.MustCompile(
+	`^\s*//\s*@singleton(?:\s+.*)?$`,
+)
+
+var mustReturnRegex = regexp.MustCompile(
+	`^\s*//\s*@mustreturn(?:\s+.*)?$`,
+)
+
+var experimentalRegex = regexp.MustCompile(
+	`^\s*//\s*@experimental(?:\s+.*)?$`,
+)
+
+// deprecatedPackageRegex matches the //gogreement:deprecated directive with
+// an optional "use <import path>"; directives have no space after "//"
+var deprecatedPackageRegex = regexp.MustCompile(
+	`^//gogreement:deprecated(?:\s+use\s+(\S+))?(?:\s+.*)?$`,
+)
+
+// annotationKindRegex matches the kind of annotation a comment line starts with
+var annotationKindRegex = regexp.MustCompile(
+	`^\s*//\s*@([a-z]+(?:-[a-z]+)*)\b`,
+	//          ^1
+	// 1: annotation kind ("implements", "implements-oneof")
+)
+
+// Declaration kinds an annotation can be written on
+const (
+	onTypes      = "types"
+	onInterfaces = "interfaces"
+	onFunctions  = "functions"
+	onMethods    = "methods"
+	onFields     = "struct fields"
+)
+
+// annotationPlacements lists, for every annotation kind, the declaration kinds
+// it applies to. @ignore applies everywhere and is not listed.
+var annotationPlacements = map[string][]string{
+	"implements":       {onTypes, onInterfaces},
+	"implements-oneof": {onTypes, onInterfaces},
+	"implementedby":    {onInterfaces},
+	"notimplements":    {onTypes, onInterfaces},
+	"constructor":      {onTypes, onInterfaces},
+	"immutable":        {onTypes, onInterfaces},
+	"enum":             {onTypes, onInterfaces},
+	"singleton":        {onTypes, onInterfaces},
+	"testonly":         {onTypes, onInterfaces, onFunctions, onMethods},
+	"packageonly":      {onTypes, onInterfaces, onFunctions, onMethods},
+	"since":            {onTypes, onInterfaces, onFunctions, onMethods},
+	"mustreturn":       {onFunctions, onMethods},
+	"experimental":     {onTypes, onInterfaces, onFunctions, onMethods},
+	"mutable":          {onFields},
+	"required":         {onFields},
+}
+
+// belongsOn describes where annotations of a kind belong, for messages
+var belongsOn = map[string]string{
+	"implementedby": "interfaces",
+	"testonly":      "types, functions and methods",
+	"packageonly":   "types, functions and methods",
+	"since":         "types, functions and methods",
+	"mustreturn":    "functions and methods",
+	"experimental":  "types, functions and methods",
+	"mutable":       "struct fields of @immutable types",
+	"required":      "struct fields",
+}
+
+// semverRegex matches a Go-style semantic version: vMAJOR.MINOR.PATCH with
+// optional pre-release and build metadata
+var semverRegex = regexp.MustCompile(
+	`^v(0|[1-9]\d*)\.(0|[1-9]\d*)\.(0|[1-9]\d*)(?:-[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*)?(?:\+[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*)?$`,
+)
+
+// HasValidVersion reports whether the @since version is a full semantic version
+// (e.g. "v1.2.0", "v2.0.0-rc.1")
+func (a *SinceAnnotation) HasValidVersion() bool {
+	return semverRegex.MatchString(a.Version)
+}
+
+var implementsOneOfRegex = regexp.MustCompile(
+	`^\s*//\s*@implements-oneof\s+(&?(?:[A-Za-z_]\w*\.)?[A-Za-z_]\w*(?:\s*,\s*&?(?:[A-Za-z_]\w*\.)?[A-Za-z_]\w*)*)(?:\s+.*)?$`,
+	//                                 ^1
+	// 1: comma-separated interface list, each "[&][pkg.]Interface"
+)
+
+var implementedByRegex = regexp.MustCompile(
+	`^\s*//\s*@implementedby\s+(\*?(?:[A-Za-z_]\w*\.)?[A-Za-z_]\w*(?:\s*,\s*\*?(?:[A-Za-z_]\w*\.)?[A-Za-z_]\w*)*)(?:\s+.*)?$`,
+	//                               ^1
+	// 1: comma-separated type list, each "[*][pkg.]Type"
+)
+
+// RequiresPointerMethodSet reports whether the pointer method set (*T) is checked
+// against the interface. An explicit "via" receiver takes priority over "&".
+func (a *ImplementsAnnotation) RequiresPointerMethodSet() bool {
+	if a.HasReceiverOverride {
+		return a.ReceiverIsPointer
+	}
+	return a.IsPointer
+}
+
 // parseImplementsAnnotation parses string "@implements &pkg.Interface" or "@implements Interface"
-// and resolves package path immediately using importMap
+// (optionally followed by "via *Type" / "via Type")
+// and resolves package path immediately using importMap. targetPos is the
+// position of the interface in the source comment, if known.
 func parseImplementsAnnotation(
 	commentText string,
 	typeName string,
 	pos token.Pos,
+	targetPos token.Pos,
 	imports *util.ImportMap,
 	currentPkgPath string,
 ) *ImplementsAnnotation {
@@ -343,13 +854,27 @@ func parseImplementsAnnotation(
 	// match[1] = "&" or ""
 	// match[2] = "pkg" or ""
 	// match[3] = "Interface"
+	// match[4] = "*" or ""
+	// match[5] = "MyStruct" or ""
 
 	annotation := &ImplementsAnnotation{
-		IsPointer:     match[1] == "&",
-		PackageName:   match[2],
-		InterfaceName: match[3],
-		OnType:        typeName,
-		OnTypePos:     pos,
+		IsPointer:           match[1] == "&",
+		PackageName:         match[2],
+		InterfaceName:       match[3],
+		HasReceiverOverride: match[5] != "",
+		ReceiverIsPointer:   match[4] == "*",
+		OnType:              typeName,
+		OnTypePos:           pos,
+		TargetPos:           targetPos,
+	}
+
+	// The "via" clause names the receiver form of the annotated type itself.
+	// Naming any other type is reported, and the contract is still checked
+	// without the clause rather than dropped.
+	if match[5] != "" && match[5] != typeName {
+		annotation.HasReceiverOverride = false
+		annotation.ReceiverIsPointer = false
+		annotation.MismatchedReceiver = match[4] + match[5]
 	}
 
 	// Resolve package path immediately
@@ -372,6 +897,132 @@ func parseImplementsAnnotation(
 	return annotation
 }
 
+// parseNotImplementsAnnotation parses string "@notimplements &pkg.Interface"
+// or "@notimplements Interface" and resolves the package path like
+// parseImplementsAnnotation
+func parseNotImplementsAnnotation(
+	commentText string,
+	typeName string,
+	pos token.Pos,
+	targetPos token.Pos,
+	imports *util.ImportMap,
+	currentPkgPath string,
+) *NotImplementsAnnotation {
+	match := notImplementsRegex.FindStringSubmatch(commentText)
+	if match == nil {
+		return nil
+	}
+
+	annotation := &NotImplementsAnnotation{
+		OnType:          typeName,
+		OnTypePos:       pos,
+		InterfaceName:   match[3],
+		PackageName:     match[2],
+		IsPointer:       match[1] == "&",
+		PackageFullPath: currentPkgPath,
+		TargetPos:       targetPos,
+	}
+	if annotation.PackageName != "" {
+		if imp := imports.Find(annotation.PackageName); imp != nil {
+			annotation.PackageFullPath = imp.FullPath
+		} else {
+			annotation.PackageFullPath = ""
+			annotation.PackageNotFound = true
+		}
+	}
+
+	return annotation
+}
+
+// parseImplementsOneOfAnnotation parses string "@implements-oneof io.Reader, &Writer".
+// Every listed interface is parsed and resolved as its own @implements annotation.
+func parseImplementsOneOfAnnotation(
+	commentText string,
+	typeName string,
+	pos token.Pos,
+	imports *util.ImportMap,
+	currentPkgPath string,
+) *ImplementsOneOfAnnotation {
+	match := implementsOneOfRegex.FindStringSubmatch(commentText)
+	if match == nil {
+		return nil
+	}
+
+	var alternatives []ImplementsAnnotation
+	for _, item := range strings.Split(match[1], ",") {
+		alternative := parseImplementsAnnotation("// @implements "+strings.TrimSpace(item), typeName, pos, token.NoPos, imports, currentPkgPath)
+		if alternative == nil {
+			return nil
+		}
+		alternatives = append(alternatives, *alternative)
+	}
+
+	return &ImplementsOneOfAnnotation{
+		OnType:       typeName,
+		OnTypePos:    pos,
+		Alternatives: alternatives,
+	}
+}
+
+// parseImplementedByAnnotation parses string "@implementedby FileStore, *store.MemStore"
+// on an interface. Packages of qualified types are resolved through imports.
+func parseImplementedByAnnotation(
+	commentText string,
+	interfaceName string,
+	pos token.Pos,
+	imports *util.ImportMap,
+	currentPkgPath string,
+) *ImplementedByAnnotation {
+	match := implementedByRegex.FindStringSubmatch(commentText)
+	if match == nil {
+		return nil
+	}
+
+	var implementers []Implementer
+	for _, item := range strings.Split(match[1], ",") {
+		spelling := strings.TrimSpace(item)
+		name, isPointer := strings.CutPrefix(spelling, "*")
+		pkgName, typeName, qualified := strings.Cut(name, ".")
+		if !qualified {
+			pkgName, typeName = "", name
+		}
+
+		pkgPath := currentPkgPath
+		pkgNotFound := false
+		if pkgName != "" {
+			if imp := imports.Find(pkgName); imp != nil {
+				pkgPath = imp.FullPath
+			} else {
+				pkgPath, pkgNotFound = "", true
+			}
+		}
+
+		pointer := ""
+		if isPointer {
+			pointer = "&"
+		}
+		check := parseImplementsAnnotation("// @implements "+pointer+interfaceName, typeName, pos, token.NoPos, imports, currentPkgPath)
+		if check == nil {
+			return nil
+		}
+
+		implementers = append(implementers, Implementer{
+			Spelling:        spelling,
+			TypeName:        typeName,
+			PackageName:     pkgName,
+			PackageFullPath: pkgPath,
+			PackageNotFound: pkgNotFound,
+			Check:           *check,
+		})
+	}
+
+	return &ImplementedByAnnotation{
+		OnInterface:    interfaceName,
+		OnInterfacePos: pos,
+		Implementers:   implementers,
+	}
+}
+
 // parseConstructorAnnotation parses string "@constructor New" or "@constructor New, Create"
 func parseConstructorAnnotation(commentText string, typeName string, pos token.Pos) *ConstructorAnnotation {
 	match := constructorRegex.FindStringSubmatch(commentText)
@@ -379,8 +1030,8 @@ func parseConstructorAnnotation(commentText string, typeName string, pos token.P
 		return nil
 	}
 
-	// match[1] = "New,Create" or "" (regex now captures only valid identifiers)
-	namesStr := strings.TrimSpace(match[1])
+	// match[2] = "New,Create" or "" (regex now captures only valid identifiers)
+	namesStr := strings.TrimSpace(match[2])
 
 	// If no names provided, return nil (user must specify constructor names explicitly)
 	if namesStr == "" {
@@ -406,7 +1057,48 @@ func parseConstructorAnnotation(commentText string, typeName string, pos token.P
 		OnType:           typeName,
 		OnTypePos:        pos,
 		ConstructorNames: names,
+		LocalOnly:        match[1] != "",
+	}
+}
+
+// NormalizeConstructorComment returns a @constructor comment line in its
+// compact form, "// @constructor New, Create": one space after "//", names
+// separated by ", " and no trailing comma. Text after the names is kept. It
+// reports false for a line that is not a @constructor annotation with names.
+func NormalizeConstructorComment(commentText string) (string, bool) {
+	match := constructorRegex.FindStringSubmatchIndex(commentText)
+	if match == nil || match[4] < 0 {
+		return "", false
+	}
+
+	var names []string
+	for _, part := range strings.Split(commentText[match[4]:match[5]], ",") {
+		if name := strings.TrimSpace(part); name != "" {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return "", false
+	}
+
+	local := ""
+	if match[2] >= 0 {
+		local = "-local"
+	}
+	rest := strings.TrimRightFunc(commentText[match[5]:], unicode.IsSpace)
+	return "// @constructor" + local + " " + strings.Join(names, ", ") + rest, true
+}
+
+// ImplementsKey identifies the contract of an @implements comment line: two
+// lines of one type with the same key declare the same contract. It reports
+// false for a line that is not an @implements annotation.
+func ImplementsKey(commentText string) (string, bool) {
+	match := implementsRegex.FindStringSubmatch(commentText)
+	if match == nil {
+		return "", false
 	}
+	// pointer, package, interface and the "via" receiver form
+	return strings.Join(match[1:6], "|"), true
 }
 
 func parseImmutableAnnotation(commentText string, typeName string, pos token.Pos) *ImmutableAnnotation {
@@ -435,6 +1127,135 @@ func parseTestOnlyAnnotation(commentText string, objectName string, pos token.Po
 	}
 }
 
+// commentLines returns the lines of comments in line-comment form, splitting
+// multi-line block comments (see util.CommentLines)
+func commentLines(comments []*ast.Comment) []string {
+	var lines []string
+	for _, comment := range comments {
+		lines = append(lines, util.CommentLines(comment.Text)...)
+	}
+	return lines
+}
+
+// commentLinesOf is commentLines paired with the comment each line comes from
+func commentLinesOf(comments []*ast.Comment) iter.Seq2[*ast.Comment, string] {
+	return func(yield func(*ast.Comment, string) bool) {
+		for _, comment := range comments {
+			for _, line := range util.CommentLines(comment.Text) {
+				if !yield(comment, line) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// implementsTargetPos returns the position of the first word after
+// "@implements" in a line comment, or token.NoPos for a block comment
+func implementsTargetPos(comment *ast.Comment) token.Pos {
+	return annotationTargetPos(comment, "@implements")
+}
+
+// annotationTargetPos returns the position of the first word after marker in
+// a line comment, or token.NoPos for a block comment
+func annotationTargetPos(comment *ast.Comment, marker string) token.Pos {
+	if !strings.HasPrefix(comment.Text, "//") {
+		return token.NoPos
+	}
+	_, rest, found := strings.Cut(comment.Text, marker)
+	if !found {
+		return token.NoPos
+	}
+	target := strings.TrimLeft(rest, " \t")
+	return comment.Pos() + token.Pos(len(comment.Text)-len(target))
+}
+
+// IsTestOnlyFile reports whether the file's package doc comment carries
+// @testonly, which marks every exported top-level declaration of the file
+func IsTestOnlyFile(file *ast.File) bool {
+	if file.Doc == nil {
+		return false
+	}
+	for _, text := range commentLines(file.Doc.List) {
+		if testonlyRegex.MatchString(text) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseDeprecatedPackageDirective parses a //gogreement:deprecated directive
+// in the package doc comment of file, or returns nil when there is none
+func parseDeprecatedPackageDirective(file *ast.File) *DeprecatedPackageAnnotation {
+	if file.Doc == nil {
+		return nil
+	}
+	for _, comment := range file.Doc.List {
+		match := deprecatedPackageRegex.FindStringSubmatch(strings.TrimSpace(comment.Text))
+		if match == nil {
+			continue
+		}
+		return &DeprecatedPackageAnnotation{
+			Replacement: match[1],
+			Pos:         comment.Pos(),
+		}
+	}
+	return nil
+}
+
+// IsPublicDeclaration reports whether the type or function name, or the
+// method name of receiverType, belongs to the exported API of its package:
+// the name and, for methods, the receiver type must both be exported
+func IsPublicDeclaration(name string, receiverType string) bool {
+	return ast.IsExported(name) && (receiverType == "" || ast.IsExported(receiverType))
+}
+
+// fileTestOnlyAnnotations synthesizes a TestOnlyAnnotation for every exported
+// type, function and method declared in a file-level @testonly file. Symbols
+// listed in existing are skipped so an explicit annotation is not duplicated.
+func fileTestOnlyAnnotations(file *ast.File, existing []TestOnlyAnnotation) []TestOnlyAnnotation {
+	seen := make(map[TestOnlyAnnotation]bool)
+	key := func(kind TestOnlyKind, name string, receiverType string) TestOnlyAnnotation {
+		return TestOnlyAnnotation{Kind: kind, ObjectName: name, ReceiverType: receiverType}
+	}
+	for _, annotation := range existing {
+		seen[key(annotation.Kind, annotation.ObjectName, annotation.ReceiverType)] = true
+	}
+
+	var result []TestOnlyAnnotation
+	add := func(kind TestOnlyKind, name *ast.Ident, receiverType string, pos token.Pos) {
+		if !name.IsExported() || seen[key(kind, name.Name, receiverType)] {
+			return
+		}
+		seen[key(kind, name.Name, receiverType)] = true
+		result = append(result, TestOnlyAnnotation{
+			Kind:         kind,
+			ObjectName:   name.Name,
+			Pos:          pos,
+			ReceiverType: receiverType,
+		})
+	}
+
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.GenDecl:
+			if d.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range d.Specs {
+				if typeSpec, ok := spec.(*ast.TypeSpec); ok {
+					add(TestOnlyOnType, typeSpec.Name, "", typeSpec.Pos())
+				}
+			}
+		case *ast.FuncDecl:
+			kind, receiverType := getFuncKindAndReceiver(d)
+			add(kind, d.Name, receiverType, d.Pos())
+		}
+	}
+
+	return result
+}
+
 func parseMutableAnnotation(commentText string, typeName string, fieldName string, pos token.Pos) *MutableAnnotation {
 	match := mutableRegex.FindStringSubmatch(commentText)
 	if match == nil {
@@ -481,6 +1302,97 @@ func parsePackageOnlyAnnotation(commentText string, objectName string, pos token
 	}
 }
 
+// parseSinceAnnotation parses string "@since v1.2.0". Unlike other annotations a
+// malformed version still yields an annotation so it can be reported.
+func parseSinceAnnotation(commentText string, objectName string, pos token.Pos, kind TestOnlyKind, receiverType string) *SinceAnnotation {
+	match := sinceRegex.FindStringSubmatch(commentText)
+	if match == nil {
+		return nil
+	}
+
+	return &SinceAnnotation{
+		Kind:         kind,
+		ObjectName:   objectName,
+		Pos:          pos,
+		ReceiverType: receiverType,
+		Version:      match[1],
+	}
+}
+
+func parseEnumAnnotation(commentText string, typeName string, pos token.Pos) *EnumAnnotation {
+	match := enumRegex.FindStringSubmatch(commentText)
+	if match == nil {
+		return nil
+	}
+
+	return &EnumAnnotation{
+		OnType:    typeName,
+		OnTypePos: pos,
+	}
+}
+
+// parseMisplacedAnnotation returns the annotation of commentText when it does
+// not apply to the declaration kind it is written on, nil otherwise
+func parseMisplacedAnnotation(commentText string, declaration string, pos token.Pos) *MisplacedAnnotation {
+	match := annotationKindRegex.FindStringSubmatch(commentText)
+	if match == nil {
+		return nil
+	}
+
+	kind := match[1]
+	placements, known := annotationPlacements[kind]
+	if !known || slices.Contains(placements, declaration) {
+		return nil
+	}
+
+	return &MisplacedAnnotation{
+		Annotation:  kind,
+		Declaration: declaration,
+		BelongsOn:   cmp.Or(belongsOn[kind], onTypes),
+		Pos:         pos,
+	}
+}
+
+func parseSingletonAnnotation(commentText string, typeName string, pos token.Pos) *SingletonAnnotation {
+	match := singletonRegex.FindStringSubmatch(commentText)
+	if match == nil {
+		return nil
+	}
+
+	return &SingletonAnnotation{
+		OnType:    typeName,
+		OnTypePos: pos,
+	}
+}
+
+func parseMustReturnAnnotation(commentText string, objectName string, pos token.Pos, kind TestOnlyKind, receiverType string) *MustReturnAnnotation {
+	match := mustReturnRegex.FindStringSubmatch(commentText)
+	if match == nil {
+		return nil
+	}
+
+	return &MustReturnAnnotation{
+		Kind:         kind,
+		ObjectName:   objectName,
+		Pos:          pos,
+		ReceiverType: receiverType,
+	}
+}
+
+func parseExperimentalAnnotation(commentText string, objectName string, pos token.Pos, kind TestOnlyKind, receiverType string) *ExperimentalAnnotation {
+	match := experimentalRegex.FindStringSubmatch(commentText)
+	if match == nil {
+		return nil
+	}
+
+	return &ExperimentalAnnotation{
+		Kind:         kind,
+		ObjectName:   objectName,
+		Pos:          pos,
+		ReceiverType: receiverType,
+	}
+}
+
 // getFuncKindAndReceiver determines if a function declaration is a method or function
 // Returns: (kind, receiverType)
 // - For methods: (TestOnlyOnMethod, "MyStruct")
@@ -518,11 +1430,19 @@ func ExtractReceiverType(expr ast.Expr) string {
 
 var matcher = ahocorasick.NewStringMatcher([]string{
 	"@implements",
+	"@notimplements",
+	"@implementedby",
 	"@constructor",
 	"@immutable",
 	"@testonly",
 	"@mutable",
 	"@packageonly",
+	"@since",
+	"@enum",
+	"@required",
+	"@singleton",
+	"@mustreturn",
+	"@experimental",
 })
 
 func ReadAllAnnotations(
@@ -530,18 +1450,31 @@ func ReadAllAnnotations(
 	pass *analysis.Pass,
 ) PackageAnnotations {
 	var implements []ImplementsAnnotation
+	var implementsOneOf []ImplementsOneOfAnnotation
+	var implementedBy []ImplementedByAnnotation
+	var notImplements []NotImplementsAnnotation
 	var constructors []ConstructorAnnotation
 	var immutables []ImmutableAnnotation
 	var testonly []TestOnlyAnnotation
 	var mutables []MutableAnnotation
 	var packageonly []PackageOnlyAnnotation
+	var since []SinceAnnotation
+	var enums []EnumAnnotation
+	var required []RequiredAnnotation
+	var singletons []SingletonAnnotation
+	var mustReturns []MustReturnAnnotation
+	var experimentals []ExperimentalAnnotation
+	var deprecatedPackages []DeprecatedPackageAnnotation
+	var misplaced []MisplacedAnnotation
 
 	currentPkgPath := pass.Pkg.Path()
 
 	// Resolve each direct import path to its actual package so the import map
 	// records the imported package's real name. Passing pass.Pkg would store the
 	// current package's name for every import and break resolution of versioned
-	// (/vN) and renamed packages in @implements.
+	// (/vN) and renamed packages in @implements. In the test variant of a
+	// package the imports include those of its _test.go files, so annotations
+	// there resolve test-only dependencies as well.
 	importsByPath := make(map[string]*types.Package)
 	for _, imported := range pass.Pkg.Imports() {
 		importsByPath[imported.Path()] = imported
@@ -580,9 +1513,19 @@ func ReadAllAnnotations(
 				typeName := typeSpec.Name.Name
 				pos := typeSpec.Pos()
 
+				if cfg.PublicOnly && !IsPublicDeclaration(typeName, "") {
+					continue
+				}
+
+				// @required applies to fields of any struct type, annotated or not
+				required = append(required, readRequiredFieldsForType(typeSpec, typeName)...)
+				misplaced = append(misplaced, readMisplacedFieldAnnotations(typeSpec)...)
+
 				// Annotations may live on the genDecl (above `type (`) or on the
 				// individual spec; gather both so a group-level annotation is not
-				// lost when the spec also has its own doc comment. Identical
+				// lost when the spec also has its own doc comment. A group-level
+				// annotation applies to every spec of the group, a spec-level one
+				// to its own spec only. Identical
 				// comment lines are de-duplicated so an annotation written on both
 				// the group and the spec is not processed twice.
 				var comments []*ast.Comment
@@ -605,22 +1548,56 @@ func ReadAllAnnotations(
 					continue
 				}
 
-				for _, comment := range comments {
-					text := util.NormalizeCommentText(comment.Text)
+				declaration := onTypes
+				if _, isInterface := typeSpec.Type.(*ast.InterfaceType); isInterface {
+					declaration = onInterfaces
+				}
+
+				for comment, text := range commentLinesOf(comments) {
 
 					// Micro-optimization: skip comments without annotations
 					if !matcher.Contains([]byte(text)) {
 						continue
 					}
 
+					if annotation := parseMisplacedAnnotation(text, declaration, pos); annotation != nil {
+						misplaced = append(misplaced, *annotation)
+						continue
+					}
+
 					// Parse @implements
 					if strings.Contains(text, "@implements") {
-						annotation := parseImplementsAnnotation(text, typeName, pos, imports, currentPkgPath)
+						annotation := parseImplementsAnnotation(text, typeName, pos, implementsTargetPos(comment), imports, currentPkgPath)
 						if annotation != nil {
 							implements = append(implements, *annotation)
 						}
 					}
 
+					// Parse @notimplements
+					if strings.Contains(text, "@notimplements") {
+						targetPos := cmp.Or(annotationTargetPos(comment, "@notimplements"), comment.Pos())
+						annotation := parseNotImplementsAnnotation(text, typeName, pos, targetPos, imports, currentPkgPath)
+						if annotation != nil {
+							notImplements = append(notImplements, *annotation)
+						}
+					}
+
+					// Parse @implements-oneof
+					if strings.Contains(text, "@implements-oneof") {
+						annotation := parseImplementsOneOfAnnotation(text, typeName, pos, imports, currentPkgPath)
+						if annotation != nil {
+							implementsOneOf = append(implementsOneOf, *annotation)
+						}
+					}
+
+					// Parse @implementedby, which only applies to interfaces
+					if _, isInterface := typeSpec.Type.(*ast.InterfaceType); isInterface && strings.Contains(text, "@implementedby") {
+						annotation := parseImplementedByAnnotation(text, typeName, pos, imports, currentPkgPath)
+						if annotation != nil {
+							implementedBy = append(implementedBy, *annotation)
+						}
+					}
+
 					// Parse @constructor
 					if strings.Contains(text, "@constructor") {
 						annotation := parseConstructorAnnotation(text, typeName, pos)
@@ -656,11 +1633,43 @@ func ReadAllAnnotations(
 							packageonly = append(packageonly, *annotation)
 						}
 					}
+
+					// Parse @since
+					if strings.Contains(text, "@since") {
+						annotation := parseSinceAnnotation(text, typeName, pos, TestOnlyOnType, "")
+						if annotation != nil {
+							since = append(since, *annotation)
+						}
+					}
+
+					// Parse @experimental
+					if strings.Contains(text, "@experimental") {
+						annotation := parseExperimentalAnnotation(text, typeName, pos, TestOnlyOnType, "")
+						if annotation != nil {
+							experimentals = append(experimentals, *annotation)
+						}
+					}
+
+					// Parse @enum
+					if strings.Contains(text, "@enum") {
+						annotation := parseEnumAnnotation(text, typeName, pos)
+						if annotation != nil {
+							enums = append(enums, *annotation)
+						}
+					}
+
+					// Parse @singleton
+					if strings.Contains(text, "@singleton") {
+						annotation := parseSingletonAnnotation(text, typeName, pos)
+						if annotation != nil {
+							singletons = append(singletons, *annotation)
+						}
+					}
 				}
 			}
 		}
 
-		// Process function and method declarations for @testonly and @packageonly
+		// Process function and method declarations for @testonly, @packageonly and @since
 		for _, n := range file.Decls {
 			funcDecl, ok := n.(*ast.FuncDecl)
 			if !ok {
@@ -677,14 +1686,27 @@ func ReadAllAnnotations(
 			// Determine if it's a method or function
 			kind, receiverType := getFuncKindAndReceiver(funcDecl)
 
-			for _, comment := range funcDecl.Doc.List {
-				text := util.NormalizeCommentText(comment.Text)
+			if cfg.PublicOnly && !IsPublicDeclaration(funcName, receiverType) {
+				continue
+			}
+
+			declaration := onFunctions
+			if kind == TestOnlyOnMethod {
+				declaration = onMethods
+			}
+
+			for _, text := range commentLines(funcDecl.Doc.List) {
 
 				// Micro-optimization: skip comments without annotations
 				if !matcher.Contains([]byte(text)) {
 					continue
 				}
 
+				if annotation := parseMisplacedAnnotation(text, declaration, pos); annotation != nil {
+					misplaced = append(misplaced, *annotation)
+					continue
+				}
+
 				// Parse @testonly
 				if strings.Contains(text, "@testonly") {
 					annotation := parseTestOnlyAnnotation(text, funcName, pos, kind, receiverType)
@@ -700,18 +1722,70 @@ func ReadAllAnnotations(
 						packageonly = append(packageonly, *annotation)
 					}
 				}
+
+				// Parse @since
+				if strings.Contains(text, "@since") {
+					annotation := parseSinceAnnotation(text, funcName, pos, kind, receiverType)
+					if annotation != nil {
+						since = append(since, *annotation)
+					}
+				}
+
+				// Parse @mustreturn
+				if strings.Contains(text, "@mustreturn") {
+					annotation := parseMustReturnAnnotation(text, funcName, pos, kind, receiverType)
+					if annotation != nil {
+						mustReturns = append(mustReturns, *annotation)
+					}
+				}
+
+				// Parse @experimental
+				if strings.Contains(text, "@experimental") {
+					annotation := parseExperimentalAnnotation(text, funcName, pos, kind, receiverType)
+					if annotation != nil {
+						experimentals = append(experimentals, *annotation)
+					}
+				}
+			}
+		}
+
+		// A @testonly package doc comment marks the whole file
+		if IsTestOnlyFile(file) {
+			for _, annotation := range fileTestOnlyAnnotations(file, testonly) {
+				if cfg.PublicOnly && !IsPublicDeclaration(annotation.ObjectName, annotation.ReceiverType) {
+					continue
+				}
+				testonly = append(testonly, annotation)
 			}
 		}
 
+		// A //gogreement:deprecated directive deprecates the whole package;
+		// one file carrying it is enough
+		if len(deprecatedPackages) == 0 {
+			if annotation := parseDeprecatedPackageDirective(file); annotation != nil {
+				deprecatedPackages = append(deprecatedPackages, *annotation)
+			}
+		}
 	}
 
 	return PackageAnnotations{
-		ImplementsAnnotations:  implements,
-		ConstructorAnnotations: constructors,
-		ImmutableAnnotations:   immutables,
-		TestonlyAnnotations:    testonly,
-		MutableAnnotations:     mutables,
-		PackageOnlyAnnotations: packageonly,
+		ImplementsAnnotations:      implements,
+		ImplementsOneOfAnnotations: implementsOneOf,
+		ImplementedByAnnotations:   implementedBy,
+		NotImplementsAnnotations:   notImplements,
+		ConstructorAnnotations:     constructors,
+		ImmutableAnnotations:       immutables,
+		TestonlyAnnotations:        testonly,
+		MutableAnnotations:         mutables,
+		PackageOnlyAnnotations:     packageonly,
+		SinceAnnotations:           since,
+		EnumAnnotations:            enums,
+		RequiredAnnotations:        required,
+		SingletonAnnotations:       singletons,
+		MustReturnAnnotations:      mustReturns,
+		ExperimentalAnnotations:    experimentals,
+		DeprecatedPackages:         deprecatedPackages,
+		MisplacedAnnotations:       misplaced,
 	}
 }
 
@@ -743,8 +1817,7 @@ func readFieldAnnotationsForType(typeSpec *ast.TypeSpec, typeName string) []Muta
 			pos := fieldName.Pos()
 
 			// Check each comment for @mutable annotation
-			for _, comment := range field.Doc.List {
-				text := util.NormalizeCommentText(comment.Text)
+			for _, text := range commentLines(field.Doc.List) {
 
 				// Micro-optimization: skip comments without annotations
 				if !matcher.Contains([]byte(text)) {
@@ -764,3 +1837,74 @@ func readFieldAnnotationsForType(typeSpec *ast.TypeSpec, typeName string) []Muta
 
 	return mutables
 }
+
+func parseRequiredAnnotation(commentText string, typeName string, fieldName string, pos token.Pos) *RequiredAnnotation {
+	match := requiredRegex.FindStringSubmatch(commentText)
+	if match == nil {
+		return nil
+	}
+
+	return &RequiredAnnotation{
+		OnType:    typeName,
+		FieldName: fieldName,
+		Pos:       pos,
+	}
+}
+
+// readRequiredFieldsForType returns the @required fields of a struct type.
+// Embedded fields have no field name of their own and are skipped.
+func readRequiredFieldsForType(typeSpec *ast.TypeSpec, typeName string) []RequiredAnnotation {
+	var required []RequiredAnnotation
+
+	structType, ok := typeSpec.Type.(*ast.StructType)
+	if !ok {
+		return required
+	}
+
+	for _, field := range structType.Fields.List {
+		if len(field.Names) == 0 || field.Doc == nil {
+			continue
+		}
+
+		for _, fieldName := range field.Names {
+			for _, text := range commentLines(field.Doc.List) {
+				if !strings.Contains(text, "@required") {
+					continue
+				}
+				annotation := parseRequiredAnnotation(text, typeName, fieldName.Name, fieldName.Pos())
+				if annotation != nil {
+					required = append(required, *annotation)
+				}
+			}
+		}
+	}
+
+	return required
+}
+
+// readMisplacedFieldAnnotations returns the annotations on the fields of a
+// struct type that do not apply to fields, such as @immutable
+func readMisplacedFieldAnnotations(typeSpec *ast.TypeSpec) []MisplacedAnnotation {
+	var misplaced []MisplacedAnnotation
+
+	structType, ok := typeSpec.Type.(*ast.StructType)
+	if !ok {
+		return misplaced
+	}
+
+	for _, field := range structType.Fields.List {
+		if field.Doc == nil {
+			continue
+		}
+		for _, text := range commentLines(field.Doc.List) {
+			if !matcher.Contains([]byte(text)) {
+				continue
+			}
+			if annotation := parseMisplacedAnnotation(text, onFields, field.Pos()); annotation != nil {
+				misplaced = append(misplaced, *annotation)
+			}
+		}
+	}
+
+	return misplaced
+}
@@ -145,3 +145,25 @@ func TestPackageAnnotationsGobSerialization(t *testing.T) {
 		}
 	})
 }
+
+func TestContractsFactGobSerialization(t *testing.T) {
+	original := ContractsFact{
+		ImmutableTypes:  []string{"Config", "Point"},
+		MutableFields:   []TypeMember{{Type: "Config", Name: "cache"}},
+		Constructors:    []ConstructorContract{{Type: "Config", Constructors: []string{"NewConfig", "Load"}, LocalOnly: true}},
+		TestOnlyTypes:   []string{"Fixture"},
+		TestOnlyFuncs:   []string{"Reset"},
+		TestOnlyMethods: []TypeMember{{Type: "Worker", Name: "Drain"}},
+		PackageOnly: []PackageOnlyContract{
+			{Kind: TestOnlyOnMethod, Object: "Flush", Receiver: "Buffer", AllowedPackages: []string{"example.com/app", "io"}},
+		},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, gob.NewEncoder(&buf).Encode(&original))
+
+	var decoded ContractsFact
+	require.NoError(t, gob.NewDecoder(&buf).Decode(&decoded))
+
+	assert.Equal(t, original, decoded)
+}
@@ -76,6 +76,59 @@ func TestNormalizeCommentText(t *testing.T) {
 	}
 }
 
+func TestCommentLines(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want []string
+	}{
+		{"line comment", "// @immutable", []string{"// @immutable"}},
+		{"single-line block comment", "/* @immutable */", []string{"// @immutable"}},
+		{"multi-line block comment", "/*\nConfig holds settings.\n\n@immutable\n@constructor New\n*/",
+			[]string{"// Config holds settings.", "// @immutable", "// @constructor New"}},
+		{"boxed block comment", "/*\n * Config holds settings.\n * @immutable\n */",
+			[]string{"// Config holds settings.", "// @immutable"}},
+		{"annotation on the opening line", "/* @immutable\n   more text */",
+			[]string{"// @immutable", "// more text"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, util.CommentLines(tt.in))
+		})
+	}
+}
+
+func TestAnnotationsWithUnicodeSpaces(t *testing.T) {
+	parse := func(comment string) string {
+		lines := util.CommentLines(comment)
+		require.Len(t, lines, 1)
+		return lines[0]
+	}
+
+	t.Run("@immutable", func(t *testing.T) {
+		for _, comment := range []string{
+			"//\u00a0@immutable",         // no-break space
+			"// \u200b@immutable",        // zero-width space
+			"//\u2009@immutable\u00a0",   // thin space, trailing no-break space
+			"/*\u00a0@immutable\u00a0*/", // block comment
+			"\ufeff// @immutable",        // byte order mark
+		} {
+			assert.NotNil(t, parseImmutableAnnotation(parse(comment), "Config", token.NoPos), "%q", comment)
+		}
+	})
+
+	t.Run("@constructor keeps its names", func(t *testing.T) {
+		result := parseConstructorAnnotation(parse("//\u00a0@constructor\u00a0New,\u200bCreate"), "Config", token.NoPos)
+		require.NotNil(t, result)
+		assert.Equal(t, []string{"New", "Create"}, result.ConstructorNames)
+	})
+
+	t.Run("other characters are kept", func(t *testing.T) {
+		assert.Equal(t, "// @since v1.2 — café", parse("//\u00a0@since v1.2 — café"))
+	})
+}
+
 func TestReadAllAnnotationsEdgeCases(t *testing.T) {
 	pass := testutil.CreateTestPass(t, "annotationedgecases")
 	cfg := config.Empty()
@@ -95,11 +148,48 @@ func TestReadAllAnnotationsEdgeCases(t *testing.T) {
 			"group @immutable should apply even when the spec has its own doc comment")
 	})
 
+	t.Run("group-level @immutable applies to every spec of the group", func(t *testing.T) {
+		assert.True(t, isImmutable("GroupedFirst"))
+		assert.True(t, isImmutable("GroupedSecond"))
+	})
+
+	t.Run("spec-level @immutable does not leak to the rest of the group", func(t *testing.T) {
+		assert.True(t, isImmutable("GroupedAnnotatedSpec"))
+		assert.False(t, isImmutable("GroupedPlainSpec"))
+	})
+
 	t.Run("@immutable in a block comment is parsed", func(t *testing.T) {
 		assert.True(t, isImmutable("BlockCommented"),
 			"block-comment @immutable should be parsed")
 	})
 
+	t.Run("annotations in multi-line block comments are parsed", func(t *testing.T) {
+		assert.True(t, isImmutable("MultiLineBlock"), "@immutable on its own line of a block comment")
+		assert.True(t, isImmutable("BoxedBlock"), "@immutable after the leading asterisk of a boxed comment")
+
+		var constructors []string
+		for _, a := range ann.ConstructorAnnotations {
+			if a.OnType == "MultiLineBlock" {
+				constructors = append(constructors, a.ConstructorNames...)
+			}
+		}
+		assert.Equal(t, []string{"NewMultiLineBlock"}, constructors, "a second annotation line of the same block")
+
+		var mutableFields, testonlyFuncs []string
+		for _, a := range ann.MutableAnnotations {
+			if a.OnType == "MultiLineBlock" {
+				mutableFields = append(mutableFields, a.FieldName)
+			}
+		}
+		for _, a := range ann.TestonlyAnnotations {
+			if a.Kind == TestOnlyOnFunc {
+				testonlyFuncs = append(testonlyFuncs, a.ObjectName)
+			}
+		}
+		assert.Equal(t, []string{"Hits"}, mutableFields, "block comment on a field")
+		assert.Contains(t, testonlyFuncs, "NewMultiLineBlock", "multi-line block comment on a function")
+	})
+
 	t.Run("annotation on both group and spec is counted once", func(t *testing.T) {
 		count := 0
 		for _, a := range ann.ImmutableAnnotations {
@@ -251,6 +341,12 @@ func TestParseImplementsAnnotation(t *testing.T) {
 			typeName:  "MyStruct",
 			expectNil: true,
 		},
+		{
+			name:      "invalid format - interface name starts with a digit",
+			comment:   "// @implements 000",
+			typeName:  "MyStruct",
+			expectNil: true,
+		},
 		{
 			name:      "not an annotation",
 			comment:   "// This is a regular comment",
@@ -267,7 +363,7 @@ func TestParseImplementsAnnotation(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := parseImplementsAnnotation(tt.comment, tt.typeName, 0, imports, currentPkgPath)
+			result := parseImplementsAnnotation(tt.comment, tt.typeName, 0, token.NoPos, imports, currentPkgPath)
 
 			if tt.expectNil {
 				assert.Nil(t, result)
@@ -284,6 +380,153 @@ func TestParseImplementsAnnotation(t *testing.T) {
 	}
 }
 
+func TestParseImplementsAnnotationVia(t *testing.T) {
+	imports := &util.ImportMap{}
+	imports.Add(&ast.ImportSpec{
+		Path: &ast.BasicLit{Value: `"io"`},
+	}, nil)
+
+	tests := []struct {
+		name            string
+		comment         string
+		expectNil       bool
+		hasOverride     bool
+		receiverPointer bool
+		requiresPointer bool
+	}{
+		{"no via clause", "// @implements io.Reader", false, false, false, false},
+		{"no via clause with &", "// @implements &io.Reader", false, false, false, true},
+		{"via pointer receiver", "// @implements io.Reader via *MyStruct", false, true, true, true},
+		{"via value receiver overrides &", "// @implements &io.Reader via MyStruct", false, true, false, false},
+		{"via with trailing text", "// @implements io.Reader via *MyStruct since v2", false, true, true, true},
+		{"via names another type", "// @implements io.Reader via *Other", false, false, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := parseImplementsAnnotation(tt.comment, "MyStruct", 0, token.NoPos, imports, "mypackage/path")
+
+			if tt.expectNil {
+				assert.Nil(t, result)
+				return
+			}
+			require.NotNil(t, result)
+			assert.Equal(t, "Reader", result.InterfaceName)
+			assert.Equal(t, tt.hasOverride, result.HasReceiverOverride)
+			assert.Equal(t, tt.receiverPointer, result.ReceiverIsPointer)
+			assert.Equal(t, tt.requiresPointer, result.RequiresPointerMethodSet())
+		})
+	}
+
+	t.Run("mismatched receiver is kept", func(t *testing.T) {
+		result := parseImplementsAnnotation("// @implements &io.Reader via *Other", "MyStruct", 0, token.NoPos, imports, "mypackage/path")
+		require.NotNil(t, result)
+		assert.Equal(t, "*Other", result.MismatchedReceiver)
+		assert.True(t, result.RequiresPointerMethodSet(), "the annotation is checked as if it had no via clause")
+
+		result = parseImplementsAnnotation("// @implements io.Reader via MyStruct", "MyStruct", 0, token.NoPos, imports, "mypackage/path")
+		require.NotNil(t, result)
+		assert.Empty(t, result.MismatchedReceiver)
+	})
+}
+
+func TestParseImplementsOneOfAnnotation(t *testing.T) {
+	imports := &util.ImportMap{}
+	imports.Add(&ast.ImportSpec{
+		Path: &ast.BasicLit{Value: `"io"`},
+	}, nil)
+
+	tests := []struct {
+		name              string
+		comment           string
+		expectNil         bool
+		expectInterfaces  []string
+		expectPointer     []bool
+		expectPkgNotFound []bool
+	}{
+		{"two imported interfaces", "// @implements-oneof io.Reader, io.Writer", false,
+			[]string{"Reader", "Writer"}, []bool{false, false}, []bool{false, false}},
+		{"pointer and local interface", "// @implements-oneof &io.Reader,Local", false,
+			[]string{"Reader", "Local"}, []bool{true, false}, []bool{false, false}},
+		{"single interface", "// @implements-oneof io.Closer", false,
+			[]string{"Closer"}, []bool{false}, []bool{false}},
+		{"unknown package", "// @implements-oneof io.Reader, fmt.Stringer", false,
+			[]string{"Reader", "Stringer"}, []bool{false, false}, []bool{false, true}},
+		{"trailing text", "// @implements-oneof io.Reader, io.Writer for plugins", false,
+			[]string{"Reader", "Writer"}, []bool{false, false}, []bool{false, false}},
+		{"empty list", "// @implements-oneof", true, nil, nil, nil},
+		{"plain @implements", "// @implements io.Reader", true, nil, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := parseImplementsOneOfAnnotation(tt.comment, "MyStruct", 0, imports, "mypackage/path")
+
+			if tt.expectNil {
+				assert.Nil(t, result)
+				return
+			}
+			require.NotNil(t, result)
+			assert.Equal(t, "MyStruct", result.OnType)
+			require.Len(t, result.Alternatives, len(tt.expectInterfaces))
+			for i, alt := range result.Alternatives {
+				assert.Equal(t, tt.expectInterfaces[i], alt.InterfaceName)
+				assert.Equal(t, tt.expectPointer[i], alt.IsPointer)
+				assert.Equal(t, tt.expectPkgNotFound[i], alt.PackageNotFound)
+				assert.Equal(t, "MyStruct", alt.OnType)
+			}
+		})
+	}
+}
+
+func TestParseImplementedByAnnotation(t *testing.T) {
+	imports := &util.ImportMap{}
+	imports.Add(&ast.ImportSpec{
+		Path: &ast.BasicLit{Value: `"io"`},
+	}, nil)
+
+	tests := []struct {
+		name              string
+		comment           string
+		expectNil         bool
+		expectTypes       []string
+		expectPointer     []bool
+		expectPkgPaths    []string
+		expectPkgNotFound []bool
+	}{
+		{"local types", "// @implementedby FileStore, *MemStore", false,
+			[]string{"FileStore", "MemStore"}, []bool{false, true}, []string{"mypackage/path", "mypackage/path"}, []bool{false, false}},
+		{"qualified types", "// @implementedby *io.PipeWriter,fmt.Stringer", false,
+			[]string{"PipeWriter", "Stringer"}, []bool{true, false}, []string{"io", ""}, []bool{false, true}},
+		{"trailing text", "// @implementedby FileStore for storage backends", false,
+			[]string{"FileStore"}, []bool{false}, []string{"mypackage/path"}, []bool{false}},
+		{"empty list", "// @implementedby", true, nil, nil, nil, nil},
+		{"not an identifier", "// @implementedby 1Store", true, nil, nil, nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := parseImplementedByAnnotation(tt.comment, "Store", 0, imports, "mypackage/path")
+
+			if tt.expectNil {
+				assert.Nil(t, result)
+				return
+			}
+			require.NotNil(t, result)
+			assert.Equal(t, "Store", result.OnInterface)
+			require.Len(t, result.Implementers, len(tt.expectTypes))
+			for i, implementer := range result.Implementers {
+				assert.Equal(t, tt.expectTypes[i], implementer.TypeName)
+				assert.Equal(t, tt.expectPkgPaths[i], implementer.PackageFullPath)
+				assert.Equal(t, tt.expectPkgNotFound[i], implementer.PackageNotFound)
+				assert.Equal(t, tt.expectPointer[i], implementer.Check.RequiresPointerMethodSet())
+				assert.Equal(t, "Store", implementer.Check.InterfaceName)
+				assert.Equal(t, "mypackage/path", implementer.Check.PackageFullPath)
+			}
+		})
+	}
+}
+
 func TestParseConstructorAnnotation(t *testing.T) {
 	tests := []struct {
 		name          string
@@ -291,6 +534,7 @@ func TestParseConstructorAnnotation(t *testing.T) {
 		typeName      string
 		expectNil     bool
 		expectedNames []string
+		expectLocal   bool
 	}{
 		{
 			name:          "single constructor",
@@ -339,6 +583,26 @@ func TestParseConstructorAnnotation(t *testing.T) {
 			typeName:  "MyStruct",
 			expectNil: true,
 		},
+		{
+			name:          "local constructor",
+			comment:       "// @constructor-local New, Create",
+			typeName:      "MyStruct",
+			expectNil:     false,
+			expectedNames: []string{"New", "Create"},
+			expectLocal:   true,
+		},
+		{
+			name:      "local without names - should return nil",
+			comment:   "// @constructor-local",
+			typeName:  "MyStruct",
+			expectNil: true,
+		},
+		{
+			name:      "unknown suffix - should return nil",
+			comment:   "// @constructor-global New",
+			typeName:  "MyStruct",
+			expectNil: true,
+		},
 		{
 			name:      "not an annotation",
 			comment:   "// This is a regular comment",
@@ -363,6 +627,7 @@ func TestParseConstructorAnnotation(t *testing.T) {
 				require.NotNil(t, result)
 				assert.Equal(t, tt.typeName, result.OnType)
 				assert.Equal(t, tt.expectedNames, result.ConstructorNames)
+				assert.Equal(t, tt.expectLocal, result.LocalOnly)
 			}
 		})
 	}
@@ -1210,3 +1475,405 @@ func TestReadPackageOnlyAnnotations(t *testing.T) {
 		assert.Equal(t, 4, len(annotations.PackageOnlyAnnotations), "should have exactly 4 @packageonly annotations")
 	})
 }
+
+func TestParseSinceAnnotation(t *testing.T) {
+	tests := []struct {
+		name            string
+		comment         string
+		expectNil       bool
+		expectedVersion string
+		expectValid     bool
+	}{
+		{
+			name:            "release version",
+			comment:         "// @since v1.2.0",
+			expectedVersion: "v1.2.0",
+			expectValid:     true,
+		},
+		{
+			name:            "pre-release and build metadata",
+			comment:         "// @since v2.0.0-rc.1+build.5",
+			expectedVersion: "v2.0.0-rc.1+build.5",
+			expectValid:     true,
+		},
+		{
+			name:            "trailing text is ignored",
+			comment:         "//   @since   v0.9.1   added for the CLI",
+			expectedVersion: "v0.9.1",
+			expectValid:     true,
+		},
+		{
+			name:            "missing v prefix",
+			comment:         "// @since 1.2.0",
+			expectedVersion: "1.2.0",
+			expectValid:     false,
+		},
+		{
+			name:            "shorthand version",
+			comment:         "// @since v1.2",
+			expectedVersion: "v1.2",
+			expectValid:     false,
+		},
+		{
+			name:            "leading zero",
+			comment:         "// @since v1.02.0",
+			expectedVersion: "v1.02.0",
+			expectValid:     false,
+		},
+		{
+			name:            "not a version",
+			comment:         "// @since yesterday",
+			expectedVersion: "yesterday",
+			expectValid:     false,
+		},
+		{
+			name:            "missing version is kept for validation",
+			comment:         "// @since",
+			expectedVersion: "",
+			expectValid:     false,
+		},
+		{
+			name:      "other annotation",
+			comment:   "// @sincere v1.0.0",
+			expectNil: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := parseSinceAnnotation(tt.comment, "Object", token.Pos(1), TestOnlyOnFunc, "")
+
+			if tt.expectNil {
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NotNil(t, result)
+			assert.Equal(t, "Object", result.ObjectName)
+			assert.Equal(t, tt.expectedVersion, result.Version)
+			assert.Equal(t, tt.expectValid, result.HasValidVersion())
+		})
+	}
+}
+
+func TestReadSinceAnnotations(t *testing.T) {
+	pass := testutil.CreateTestPass(t, "sincetests")
+	annotations := ReadAllAnnotations(config.Empty(), pass)
+
+	versions := make(map[string]string)
+	for _, a := range annotations.SinceAnnotations {
+		key := a.ObjectName
+		if a.ReceiverType != "" {
+			key = a.ReceiverType + "." + a.ObjectName
+		}
+		versions[key] = a.Version
+	}
+
+	assert.Equal(t, map[string]string{
+		"Client":       "v1.0.0",
+		"NewClient":    "v1.0.0",
+		"Client.Ping":  "v1.2.0-rc.1",
+		"Client.Retry": "1.3.0",
+		"Timeout":      "v1.4",
+		"Options":      "",
+	}, versions)
+}
+
+func TestParseEnumAnnotation(t *testing.T) {
+	tests := []struct {
+		name      string
+		comment   string
+		expectNil bool
+	}{
+		{name: "plain", comment: "// @enum"},
+		{name: "trailing text is ignored", comment: "//   @enum  colors of the palette"},
+		{name: "other annotation", comment: "// @enumerate", expectNil: true},
+		{name: "text before annotation", comment: "// see @enum", expectNil: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := parseEnumAnnotation(tt.comment, "Color", token.Pos(1))
+
+			if tt.expectNil {
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NotNil(t, result)
+			assert.Equal(t, "Color", result.OnType)
+			assert.Equal(t, token.Pos(1), result.OnTypePos)
+		})
+	}
+}
+
+func TestParseRequiredAnnotation(t *testing.T) {
+	tests := []struct {
+		name      string
+		comment   string
+		expectNil bool
+	}{
+		{name: "plain", comment: "// @required"},
+		{name: "trailing text is ignored", comment: "//   @required  set by every caller"},
+		{name: "other annotation", comment: "// @requires", expectNil: true},
+		{name: "text before annotation", comment: "// see @required", expectNil: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := parseRequiredAnnotation(tt.comment, "Server", "Addr", token.Pos(1))
+
+			if tt.expectNil {
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NotNil(t, result)
+			assert.Equal(t, "Server", result.OnType)
+			assert.Equal(t, "Addr", result.FieldName)
+			assert.Equal(t, token.Pos(1), result.Pos)
+		})
+	}
+}
+
+// isIdentifier reports whether s is an ASCII Go identifier, the only kind the
+// annotation regexes accept
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, r := range s {
+		letter := r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+		if !letter && (i == 0 || r < '0' || r > '9') {
+			return false
+		}
+	}
+	return true
+}
+
+func FuzzParseImplements(f *testing.F) {
+	for _, seed := range []string{
+		"// @implements MyInterface",
+		"// @implements &io.Reader",
+		"// @implements &io.Writer via *MyStruct",
+		"// @implements Stringer via MyStruct trailing text",
+		"//@implements context.Context",
+		"// @implements",
+		"// @implements @implements &@io.Reader",
+		"// @implements Интерфейс",
+		"// @implements-oneof io.Reader, &io.Writer",
+	} {
+		f.Add(seed)
+	}
+
+	imports := &util.ImportMap{}
+	imports.Add(&ast.ImportSpec{Path: &ast.BasicLit{Value: `"io"`}}, nil)
+
+	f.Fuzz(func(t *testing.T, comment string) {
+		result := parseImplementsAnnotation(comment, "MyStruct", 0, token.NoPos, imports, "mypackage/path")
+		assert.Equal(t, result, parseImplementsAnnotation(comment, "MyStruct", 0, token.NoPos, imports, "mypackage/path"))
+		if result == nil {
+			return
+		}
+		assert.Contains(t, comment, "@implements")
+		assert.True(t, isIdentifier(result.InterfaceName), "interface %q", result.InterfaceName)
+		assert.Equal(t, result.PackageNotFound, result.PackageFullPath == "")
+
+		oneOf := parseImplementsOneOfAnnotation(comment, "MyStruct", 0, imports, "mypackage/path")
+		if oneOf != nil {
+			assert.NotEmpty(t, oneOf.Alternatives)
+		}
+	})
+}
+
+func FuzzParseConstructor(f *testing.F) {
+	for _, seed := range []string{
+		"// @constructor New",
+		"// @constructor New, Create",
+		"// @constructor New,Create,",
+		"// @constructor-local NewLocal",
+		"// @constructor New some explanation",
+		"// @constructor",
+		"// @constructor 123, -New",
+		"// @constructor @constructor New",
+		"// @constructor Новый",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, comment string) {
+		result := parseConstructorAnnotation(comment, "MyStruct", 0)
+		assert.Equal(t, result, parseConstructorAnnotation(comment, "MyStruct", 0))
+		if result == nil {
+			return
+		}
+		assert.Contains(t, comment, "@constructor")
+		require.NotEmpty(t, result.ConstructorNames)
+		for _, name := range result.ConstructorNames {
+			assert.True(t, isIdentifier(name), "constructor %q", name)
+		}
+	})
+}
+
+func TestParseSingletonAnnotation(t *testing.T) {
+	tests := []struct {
+		name      string
+		comment   string
+		expectNil bool
+	}{
+		{name: "plain", comment: "// @singleton"},
+		{name: "trailing text is ignored", comment: "//   @singleton  built in main"},
+		{name: "other annotation", comment: "// @singletons", expectNil: true},
+		{name: "text before annotation", comment: "// see @singleton", expectNil: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := parseSingletonAnnotation(tt.comment, "Registry", token.Pos(1))
+
+			if tt.expectNil {
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NotNil(t, result)
+			assert.Equal(t, "Registry", result.OnType)
+			assert.Equal(t, token.Pos(1), result.OnTypePos)
+		})
+	}
+}
+
+func TestParseMustReturnAnnotation(t *testing.T) {
+	tests := []struct {
+		name      string
+		comment   string
+		expectNil bool
+	}{
+		{name: "plain", comment: "// @mustreturn"},
+		{name: "trailing text is ignored", comment: "//   @mustreturn  the error must be checked"},
+		{name: "other annotation", comment: "// @mustreturned", expectNil: true},
+		{name: "text before annotation", comment: "// see @mustreturn", expectNil: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := parseMustReturnAnnotation(tt.comment, "Close", token.Pos(1), TestOnlyOnMethod, "Conn")
+
+			if tt.expectNil {
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NotNil(t, result)
+			assert.Equal(t, TestOnlyOnMethod, result.Kind)
+			assert.Equal(t, "Close", result.ObjectName)
+			assert.Equal(t, "Conn", result.ReceiverType)
+			assert.Equal(t, token.Pos(1), result.Pos)
+		})
+	}
+}
+
+func TestParseExperimentalAnnotation(t *testing.T) {
+	tests := []struct {
+		name      string
+		comment   string
+		expectNil bool
+	}{
+		{name: "plain", comment: "// @experimental"},
+		{name: "trailing text is ignored", comment: "//   @experimental  the shape may still change"},
+		{name: "other annotation", comment: "// @experimentally", expectNil: true},
+		{name: "text before annotation", comment: "// not @experimental", expectNil: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := parseExperimentalAnnotation(tt.comment, "Subscribe", token.Pos(1), TestOnlyOnMethod, "Client")
+
+			if tt.expectNil {
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NotNil(t, result)
+			assert.Equal(t, TestOnlyOnMethod, result.Kind)
+			assert.Equal(t, "Subscribe", result.ObjectName)
+			assert.Equal(t, "Client", result.ReceiverType)
+			assert.Equal(t, token.Pos(1), result.Pos)
+		})
+	}
+}
+
+func TestParseDeprecatedPackageDirective(t *testing.T) {
+	tests := []struct {
+		name        string
+		src         string
+		expectNil   bool
+		replacement string
+	}{
+		{name: "with replacement", src: "// Package old is old\n//\n//gogreement:deprecated use example.com/new\npackage old\n", replacement: "example.com/new"},
+		{name: "without replacement", src: "//gogreement:deprecated\npackage old\n"},
+		{name: "trailing reason", src: "//gogreement:deprecated use example.com/new until v2 ships\npackage old\n", replacement: "example.com/new"},
+		{name: "reason only", src: "//gogreement:deprecated the API moved\npackage old\n"},
+		{name: "space after slashes is not a directive", src: "// gogreement:deprecated use example.com/new\npackage old\n", expectNil: true},
+		{name: "other directive", src: "//gogreement:deprecatedx\npackage old\n", expectNil: true},
+		{name: "not in package doc", src: "package old\n\n//gogreement:deprecated use example.com/new\nfunc F() {}\n", expectNil: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := parser.ParseFile(token.NewFileSet(), "p.go", tt.src, parser.ParseComments)
+			require.NoError(t, err)
+
+			result := parseDeprecatedPackageDirective(file)
+
+			if tt.expectNil {
+				assert.Nil(t, result)
+				return
+			}
+
+			require.NotNil(t, result)
+			assert.Equal(t, tt.replacement, result.Replacement)
+			assert.True(t, result.Pos.IsValid())
+		})
+	}
+}
+
+func TestReadAllAnnotationsPublicOnly(t *testing.T) {
+	pass := testutil.CreateTestPass(t, "publiconlytests")
+
+	onTypes := func(ann PackageAnnotations) []string {
+		var result []string
+		for _, a := range ann.ImmutableAnnotations {
+			result = append(result, a.OnType)
+		}
+		return result
+	}
+	testOnly := func(ann PackageAnnotations) []string {
+		var result []string
+		for _, a := range ann.TestonlyAnnotations {
+			name := a.ObjectName
+			if a.ReceiverType != "" {
+				name = a.ReceiverType + "." + name
+			}
+			result = append(result, name)
+		}
+		return result
+	}
+
+	all := ReadAllAnnotations(config.Empty(), pass)
+	assert.Equal(t, []string{"Config", "settings"}, onTypes(all))
+	assert.Equal(t, []string{"settings.Reset", "Build", "build"}, testOnly(all))
+
+	public := ReadAllAnnotations(config.Empty().WithPublicOnly(true), pass)
+	assert.Equal(t, []string{"Config"}, onTypes(public), "annotations on unexported types are skipped")
+	assert.Equal(t, []string{"Build"}, testOnly(public),
+		"annotations on unexported functions and on methods of unexported types are skipped")
+}
+
+func TestIsPublicDeclaration(t *testing.T) {
+	assert.True(t, IsPublicDeclaration("Config", ""))
+	assert.True(t, IsPublicDeclaration("Reset", "Config"))
+	assert.False(t, IsPublicDeclaration("config", ""))
+	assert.False(t, IsPublicDeclaration("reset", "Config"))
+	assert.False(t, IsPublicDeclaration("Reset", "config"))
+}
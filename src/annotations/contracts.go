@@ -0,0 +1,83 @@
+package annotations
+
+// TypeMember names a field or method of a type
+// @immutable
+type TypeMember struct {
+	Type string
+	Name string
+}
+
+// ConstructorContract lists the constructors of a type
+// @immutable
+type ConstructorContract struct {
+	Type         string
+	Constructors []string
+	// LocalOnly mirrors ConstructorAnnotation.LocalOnly
+	LocalOnly bool
+}
+
+// PackageOnlyContract lists the packages allowed to use a type, function or
+// method. Receiver is only set for methods.
+// @immutable
+type PackageOnlyContract struct {
+	Kind            TestOnlyKind
+	Object          string
+	Receiver        string
+	AllowedPackages []string
+}
+
+// NewContractsFact summarizes annotations. Pass the Exported annotations of a
+// package, so local-only kinds stay out of the fact.
+func NewContractsFact(annotations *PackageAnnotations) ContractsFact {
+	var immutableTypes []string
+	for _, annot := range annotations.ImmutableAnnotations {
+		immutableTypes = append(immutableTypes, annot.OnType)
+	}
+
+	var mutableFields []TypeMember
+	for _, annot := range annotations.MutableAnnotations {
+		mutableFields = append(mutableFields, TypeMember{Type: annot.OnType, Name: annot.FieldName})
+	}
+
+	var constructors []ConstructorContract
+	for _, annot := range annotations.ConstructorAnnotations {
+		constructors = append(constructors, ConstructorContract{
+			Type:         annot.OnType,
+			Constructors: annot.ConstructorNames,
+			LocalOnly:    annot.LocalOnly,
+		})
+	}
+
+	var testOnlyTypes, testOnlyFuncs []string
+	var testOnlyMethods []TypeMember
+	for _, annot := range annotations.TestonlyAnnotations {
+		switch annot.Kind {
+		case TestOnlyOnType:
+			testOnlyTypes = append(testOnlyTypes, annot.ObjectName)
+		case TestOnlyOnFunc:
+			testOnlyFuncs = append(testOnlyFuncs, annot.ObjectName)
+		case TestOnlyOnMethod:
+			testOnlyMethods = append(testOnlyMethods, TypeMember{Type: annot.ReceiverType, Name: annot.ObjectName})
+		}
+	}
+
+	var packageOnly []PackageOnlyContract
+	for _, annot := range annotations.PackageOnlyAnnotations {
+		packageOnly = append(packageOnly, PackageOnlyContract{
+			Kind:            annot.Kind,
+			Object:          annot.ObjectName,
+			Receiver:        annot.ReceiverType,
+			AllowedPackages: annot.AllowedPackages,
+		})
+	}
+
+	return ContractsFact{
+		ImmutableTypes:  immutableTypes,
+		MutableFields:   mutableFields,
+		Constructors:    constructors,
+		TestOnlyTypes:   testOnlyTypes,
+		TestOnlyFuncs:   testOnlyFuncs,
+		TestOnlyMethods: testOnlyMethods,
+		PackageOnly:     packageOnly,
+	}
+}
@@ -0,0 +1,144 @@
+// Package assertions renders @implements annotations as Go assertions such as
+// `var _ io.Writer = (*Buffer)(nil)`, so the compiler enforces the contracts
+// even where the linter does not run.
+package assertions
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/types"
+	"strconv"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/a14e/gogreement/src/annotations"
+)
+
+// FileName is the name of the generated file written into each package
+const FileName = "gogreement_assertions.go"
+
+// generatedHeader marks the file as generated, so tools and reviewers skip it
+const generatedHeader = "// Code generated by gogreement --emit-assertions. DO NOT EDIT."
+
+// Generate returns the source of the assertions file for the package of pass,
+// one assertion per @implements annotation. The pointer form asserts
+// (*T)(nil), the value form *new(T), which works for every kind of type.
+// Annotations that cannot be written as an assertion are skipped: unresolved
+// packages, types missing from the package and generic types, which have no
+// type arguments to instantiate. Generate returns nil when nothing is left.
+func Generate(pass *analysis.Pass, implements []annotations.ImplementsAnnotation) ([]byte, error) {
+	imports := newImportSet(pass.Pkg)
+
+	var lines []string
+	seen := make(map[string]bool)
+	for _, ann := range implements {
+		if ann.PackageNotFound {
+			continue
+		}
+
+		typeName, ok := pass.Pkg.Scope().Lookup(ann.OnType).(*types.TypeName)
+		if !ok {
+			continue
+		}
+		if named, ok := typeName.Type().(*types.Named); ok && named.TypeParams().Len() > 0 {
+			continue
+		}
+
+		iface := ann.InterfaceName
+		if ann.PackageFullPath != pass.Pkg.Path() {
+			name, ok := imports.name(ann.PackageFullPath)
+			if !ok {
+				continue
+			}
+			iface = name + "." + ann.InterfaceName
+		}
+
+		value := "*new(" + ann.OnType + ")"
+		if ann.RequiresPointerMethodSet() {
+			value = "(*" + ann.OnType + ")(nil)"
+		}
+
+		line := "_ " + iface + " = " + value
+		if seen[line] {
+			continue
+		}
+		seen[line] = true
+		lines = append(lines, line)
+	}
+
+	if len(lines) == 0 {
+		return nil, nil
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "%s\n\npackage %s\n\n", generatedHeader, pass.Pkg.Name())
+	if len(imports.order) > 0 {
+		buf.WriteString("import (\n")
+		for _, path := range imports.order {
+			if imports.names[path] != imports.pkgNames[path] {
+				buf.WriteString(imports.names[path] + " ")
+			}
+			buf.WriteString(strconv.Quote(path) + "\n")
+		}
+		buf.WriteString(")\n\n")
+	}
+	buf.WriteString("// Compile-time checks of the @implements annotations\nvar (\n")
+	for _, line := range lines {
+		buf.WriteString(line + "\n")
+	}
+	buf.WriteString(")\n")
+
+	return format.Source(buf.Bytes())
+}
+
+// importSet assigns file-level names to the imported packages referenced by
+// the assertions
+type importSet struct {
+	pkg      *types.Package
+	names    map[string]string // path -> name used in the file
+	pkgNames map[string]string // path -> declared package name
+	used     map[string]bool
+	order    []string
+}
+
+func newImportSet(pkg *types.Package) *importSet {
+	return &importSet{
+		pkg:      pkg,
+		names:    make(map[string]string),
+		pkgNames: make(map[string]string),
+		used:     make(map[string]bool),
+	}
+}
+
+// name returns the name under which the import with the given path is
+// referenced. It is the package's own name unless a declaration of the
+// package or an earlier import already uses it. Only imports of the package
+// are resolved.
+func (s *importSet) name(path string) (string, bool) {
+	if name, ok := s.names[path]; ok {
+		return name, true
+	}
+
+	var imported *types.Package
+	for _, imp := range s.pkg.Imports() {
+		if imp.Path() == path {
+			imported = imp
+			break
+		}
+	}
+	if imported == nil {
+		return "", false
+	}
+
+	name := imported.Name()
+	for i := 2; s.used[name] || s.pkg.Scope().Lookup(name) != nil; i++ {
+		name = imported.Name() + strconv.Itoa(i)
+	}
+
+	s.used[name] = true
+	s.names[path] = name
+	s.pkgNames[path] = imported.Name()
+	s.order = append(s.order, path)
+	return name, true
+}
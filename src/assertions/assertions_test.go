@@ -0,0 +1,98 @@
+package assertions
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/a14e/gogreement/src/annotations"
+	"github.com/a14e/gogreement/src/config"
+	"github.com/a14e/gogreement/src/testutil"
+)
+
+// typeCheckWith type-checks the package of pass together with the generated file
+func typeCheckWith(pass *analysis.Pass, source []byte) error {
+	generated, err := parser.ParseFile(pass.Fset, FileName, source, 0)
+	if err != nil {
+		return err
+	}
+
+	imports := make(map[string]*types.Package)
+	for _, imp := range pass.Pkg.Imports() {
+		imports[imp.Path()] = imp
+	}
+	conf := types.Config{Importer: importerFunc(func(path string) (*types.Package, error) {
+		if imp, ok := imports[path]; ok {
+			return imp, nil
+		}
+		return nil, fmt.Errorf("package %s is not imported", path)
+	})}
+
+	files := append([]*ast.File{generated}, pass.Files...)
+	_, err = conf.Check(pass.Pkg.Path(), pass.Fset, files, nil)
+	return err
+}
+
+type importerFunc func(path string) (*types.Package, error)
+
+func (f importerFunc) Import(path string) (*types.Package, error) { return f(path) }
+
+func TestGenerate(t *testing.T) {
+	pass := testutil.CreateTestPass(t, "emitassertions")
+	ann := annotations.ReadAllAnnotations(config.Empty(), pass)
+
+	source, err := Generate(pass, ann.ImplementsAnnotations)
+	require.NoError(t, err)
+
+	assert.Equal(t, `// Code generated by gogreement --emit-assertions. DO NOT EDIT.
+
+package emitassertions
+
+import (
+	"fmt"
+	"io"
+)
+
+// Compile-time checks of the @implements annotations
+var (
+	_ io.Writer    = (*Buffer)(nil)
+	_ fmt.Stringer = *new(Name)
+	_ Sizer        = *new(Name)
+	_ fmt.Stringer = (*Upper)(nil)
+)
+`, string(source), "the generic Box and the @implements-oneof of Either are skipped")
+
+	require.NoError(t, typeCheckWith(pass, source), "the generated file compiles with the package")
+}
+
+func TestGenerateBrokenContractDoesNotCompile(t *testing.T) {
+	pass := testutil.CreateTestPass(t, "emitassertions")
+
+	// Name has no Write method
+	source, err := Generate(pass, []annotations.ImplementsAnnotation{
+		{OnType: "Name", InterfaceName: "Writer", PackageName: "io", PackageFullPath: "io"},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, source)
+
+	err = typeCheckWith(pass, source)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not implement io.Writer")
+}
+
+func TestGenerateNothingToAssert(t *testing.T) {
+	pass := testutil.CreateTestPass(t, "emitassertions")
+
+	source, err := Generate(pass, []annotations.ImplementsAnnotation{
+		{OnType: "Name", InterfaceName: "Missing", PackageName: "missing", PackageNotFound: true},
+		{OnType: "Box", InterfaceName: "Sizer", PackageFullPath: pass.Pkg.Path()},
+	})
+	require.NoError(t, err)
+	assert.Nil(t, source)
+}
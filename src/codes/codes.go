@@ -13,11 +13,24 @@ type Code struct {
 
 // Error code constants for immutable violations
 const (
-	ImmutableFieldAssignment     = "IMM01"
-	ImmutableFieldCompoundAssign = "IMM02"
-	ImmutableFieldIncDec         = "IMM03"
-	ImmutableIndexAssignment     = "IMM04"
-	ImmutableCategoryPrefix      = "IMM"
+	ImmutableFieldAssignment      = "IMM01"
+	ImmutableFieldCompoundAssign  = "IMM02"
+	ImmutableFieldIncDec          = "IMM03"
+	ImmutableIndexAssignment      = "IMM04"
+	ImmutablePointeeMutation      = "IMM130"
+	ImmutableIdentityMethodWrite  = "IMM140"
+	ImmutablePooled               = "IMM150"
+	ImmutableMutatorStored        = "IMM160"
+	ImmutableFieldAddressPassed   = "IMM170"
+	ImmutableFieldAddressReturned = "IMM180"
+	ImmutableAnonymousAssignment  = "IMM190"
+	ImmutableCategoryPrefix       = "IMM"
+)
+
+// Error code constants for @mutable field findings
+const (
+	MutableAllFields      = "MUT03"
+	MutableCategoryPrefix = "MUT"
 )
 
 // Error code constants for constructor violations
@@ -26,23 +39,33 @@ const (
 	ConstructorNewCall          = "CTOR02"
 	ConstructorVarDeclaration   = "CTOR03"
 	ConstructorConversion       = "CTOR04"
+	ConstructorDelegatedBuilder = "CTOR20"
+	ConstructorNotFound         = "CTOR25"
 	ConstructorCategoryPrefix   = "CTOR"
 )
 
 // Error code constants for testonly violations
 const (
-	TestOnlyTypeUsage      = "TONL01"
-	TestOnlyFunctionCall   = "TONL02"
-	TestOnlyMethodCall     = "TONL03"
-	TestOnlyCategoryPrefix = "TONL"
+	TestOnlyTypeUsage       = "TONL01"
+	TestOnlyFunctionCall    = "TONL02"
+	TestOnlyMethodCall      = "TONL03"
+	TestOnlyInterfaceMethod = "TONL20"
+	TestOnlyCategoryPrefix  = "TONL"
 )
 
 // Error code constants for implements violations
 const (
-	ImplementsPackageNotFound   = "IMPL01"
-	ImplementsInterfaceNotFound = "IMPL02"
-	ImplementsMissingMethods    = "IMPL03"
-	ImplementsCategoryPrefix    = "IMPL"
+	ImplementsPackageNotFound    = "IMPL01"
+	ImplementsInterfaceNotFound  = "IMPL02"
+	ImplementsMissingMethods     = "IMPL03"
+	ImplementsSignatureMismatch  = "IMPL04"
+	ImplementsNoneOf             = "IMPL05"
+	ImplementsAnnotationRequired = "IMPL06"
+	ImplementsReceiverMismatch   = "IMPL07"
+	ImplementsTypeSet            = "IMPL80"
+	ImplementsImplementedBy      = "IMPL70"
+	ImplementsEmbeddedInterface  = "IMPL50"
+	ImplementsCategoryPrefix     = "IMPL"
 )
 
 // Error code constants for package-only violations
@@ -50,9 +73,69 @@ const (
 	PackageOnlyTypeUsage      = "PKGO01"
 	PackageOnlyFunctionCall   = "PKGO02"
 	PackageOnlyMethodCall     = "PKGO03"
+	PackageOnlyFunctionEscape = "PKGO04"
 	PackageOnlyCategoryPrefix = "PKGO"
 )
 
+// Error code constants for since violations
+const (
+	SinceMalformedVersion = "SINCE01"
+	SinceCategoryPrefix   = "SINCE"
+)
+
+// Error code constants for enum violations
+const (
+	EnumValueNotDeclared = "ENUM01"
+	EnumCategoryPrefix   = "ENUM"
+)
+
+// Error code constants for required field violations
+const (
+	RequiredFieldMissing   = "REQ01"
+	RequiredCategoryPrefix = "REQ"
+)
+
+// Error code constants for singleton violations
+const (
+	SingletonConstructedAgain = "SGL01"
+	SingletonCategoryPrefix   = "SGL"
+)
+
+// Error code constants for mustreturn violations
+const (
+	MustReturnResultDiscarded = "RET01"
+	MustReturnCategoryPrefix  = "RET"
+)
+
+// Error code constants for experimental violations
+const (
+	ExperimentalUsedWithoutTag = "EXP01"
+	ExperimentalCategoryPrefix = "EXP"
+)
+
+// Error code constants for deprecated package violations
+const (
+	DeprecatedPackageImported       = "DEPPKG01"
+	DeprecatedPackageCategoryPrefix = "DEPPKG"
+)
+
+// Error code constants for annotations written on the wrong kind of
+// declaration or contradicting each other
+const (
+	AnnotationMisplaced      = "ANN01"
+	AnnotationConflict       = "ANN02"
+	AnnotationCategoryPrefix = "ANN"
+)
+
+// Error code constants for @ignore markers
+const (
+	IgnoreUnknownCode    = "IGN01"
+	IgnoreCategoryPrefix = "IGN"
+)
+
+// AllCodes is the sentinel that @ignore accepts for every code
+const AllCodes = "ALL"
+
 // CodesByCategory contains all error codes grouped by their category prefix.
 // This structure is easy to read, format, and validate in tests.
 // Key: category prefix (e.g., "IMM")
@@ -63,27 +146,76 @@ var CodesByCategory = map[string][]Code{
 		{ImmutableFieldCompoundAssign, "Compound assignment to immutable field (e.g., +=, -=)"},
 		{ImmutableFieldIncDec, "Increment/decrement of immutable field (e.g., ++, --)"},
 		{ImmutableIndexAssignment, "Index assignment to immutable collection (slice/map element)"},
+		{ImmutablePointeeMutation, "Write through a pointer field of an immutable type (advisory, opt-in)"},
+		{ImmutableIdentityMethodWrite, "Field written through a method that returns its receiver (advisory)"},
+		{ImmutablePooled, "Immutable values stored in a sync.Pool (advisory)"},
+		{ImmutableMutatorStored, "Mutator method value stored in a collection (advisory)"},
+		{ImmutableFieldAddressPassed, "Address of an immutable field passed to a mutating function (advisory)"},
+		{ImmutableFieldAddressReturned, "Method of an immutable type returns the address of a field (advisory)"},
+		{ImmutableAnonymousAssignment, "Anonymous struct value assigned to a variable of an immutable type (advisory, opt-in)"},
+	},
+	MutableCategoryPrefix: {
+		{MutableAllFields, "Every field of an immutable type is marked @mutable (advisory)"},
 	},
 	ConstructorCategoryPrefix: {
 		{ConstructorCompositeLiteral, "Composite literal used outside allowed constructor functions"},
 		{ConstructorNewCall, "new() call used outside allowed constructor functions"},
 		{ConstructorVarDeclaration, "Variable declaration creates zero-initialized instance outside allowed constructor functions"},
 		{ConstructorConversion, "Type conversion used outside allowed constructor functions"},
+		{ConstructorDelegatedBuilder, "Constructor obtains its value from a function that is not a declared constructor (advisory)"},
+		{ConstructorNotFound, "@constructor names a function that is not declared at package level"},
 	},
 	TestOnlyCategoryPrefix: {
 		{TestOnlyTypeUsage, "TestOnly type used outside test context"},
 		{TestOnlyFunctionCall, "TestOnly function called outside test context"},
 		{TestOnlyMethodCall, "TestOnly method called outside test context"},
+		{TestOnlyInterfaceMethod, "TestOnly method implements a method of an interface the type is declared to implement (advisory)"},
 	},
 	PackageOnlyCategoryPrefix: {
 		{PackageOnlyTypeUsage, "PackageOnly type used outside allowed packages"},
 		{PackageOnlyFunctionCall, "PackageOnly function called outside allowed packages"},
 		{PackageOnlyMethodCall, "PackageOnly method called outside allowed packages"},
+		{PackageOnlyFunctionEscape, "PackageOnly function value stored in an exported package-level variable"},
 	},
 	ImplementsCategoryPrefix: {
 		{ImplementsPackageNotFound, "Package not found in imports"},
 		{ImplementsInterfaceNotFound, "Interface not found in package"},
 		{ImplementsMissingMethods, "Type does not implement all required methods"},
+		{ImplementsSignatureMismatch, "Type has a method of the interface with a different signature"},
+		{ImplementsNoneOf, "Type implements none of the @implements-oneof interfaces"},
+		{ImplementsAnnotationRequired, "Exported type implements a required interface without declaring it (opt-in)"},
+		{ImplementsReceiverMismatch, "The via clause of @implements names a type other than the annotated one"},
+		{ImplementsEmbeddedInterface, "Interface satisfied only through an embedded interface field (advisory)"},
+		{ImplementsImplementedBy, "Type listed by @implementedby does not implement the interface"},
+		{ImplementsTypeSet, "@implements names an interface with a type set, which only constrains type parameters"},
+	},
+	SinceCategoryPrefix: {
+		{SinceMalformedVersion, "@since version is missing or not a semantic version"},
+	},
+	EnumCategoryPrefix: {
+		{EnumValueNotDeclared, "Conversion to an @enum type creates a value that is not one of its declared constants"},
+	},
+	RequiredCategoryPrefix: {
+		{RequiredFieldMissing, "Composite literal does not set a @required field"},
+	},
+	SingletonCategoryPrefix: {
+		{SingletonConstructedAgain, "@singleton type is constructed more than once"},
+	},
+	MustReturnCategoryPrefix: {
+		{MustReturnResultDiscarded, "Result of a @mustreturn function or method is discarded"},
+	},
+	ExperimentalCategoryPrefix: {
+		{ExperimentalUsedWithoutTag, "@experimental declaration is used in a file without the opt-in build tag"},
+	},
+	DeprecatedPackageCategoryPrefix: {
+		{DeprecatedPackageImported, "Package deprecated with //gogreement:deprecated is imported"},
+	},
+	AnnotationCategoryPrefix: {
+		{AnnotationMisplaced, "Annotation on a kind of declaration it does not apply to"},
+		{AnnotationConflict, "Type carries both @implements and @notimplements for the same interface"},
+	},
+	IgnoreCategoryPrefix: {
+		{IgnoreUnknownCode, "@ignore names a code that does not exist (opt-in)"},
 	},
 }
 
@@ -140,12 +272,32 @@ func GetCodesForCheck(code string) iter.Seq[string] {
 	}
 }
 
+// IsKnown reports whether an @ignore marker can name code: a registered code,
+// a category prefix or ALL
+func IsKnown(code string) bool {
+	_, ok := codeToCheckList[code]
+	return ok || code == AllCodes
+}
+
+// Lookup returns the registered code with the given ID and the category it
+// belongs to
+func Lookup(id string) (Code, string, bool) {
+	for category, codes := range CodesByCategory {
+		for _, code := range codes {
+			if code.ID == id {
+				return code, category, true
+			}
+		}
+	}
+	return Code{}, "", false
+}
+
 // GetDocumentationURL returns the documentation URL for the given error code
 func GetDocumentationURL(code string) string {
 	baseURL := "https://a14e.github.io/gogreement/"
 
 	switch {
-	case strings.HasPrefix(code, "IMM"):
+	case strings.HasPrefix(code, "IMM"), strings.HasPrefix(code, "MUT"):
 		return baseURL + "02_02_immutable.html"
 	case strings.HasPrefix(code, "CTOR"):
 		return baseURL + "02_03_constructor.html"
@@ -155,7 +307,34 @@ func GetDocumentationURL(code string) string {
 		return baseURL + "02_05_packageonly.html"
 	case strings.HasPrefix(code, "IMPL"):
 		return baseURL + "02_01_implements.html"
+	case strings.HasPrefix(code, "SINCE"):
+		return baseURL + "02_07_since.html"
+	case strings.HasPrefix(code, "ENUM"):
+		return baseURL + "02_08_enum.html"
+	case strings.HasPrefix(code, "REQ"):
+		return baseURL + "02_09_required.html"
+	case strings.HasPrefix(code, "SGL"):
+		return baseURL + "02_10_singleton.html"
+	case strings.HasPrefix(code, "RET"):
+		return baseURL + "02_11_mustreturn.html"
+	case strings.HasPrefix(code, "EXP"):
+		return baseURL + "02_12_experimental.html"
+	case strings.HasPrefix(code, "DEPPKG"):
+		return baseURL + "02_13_deprecated.html"
+	case strings.HasPrefix(code, "ANN"):
+		return baseURL + "02_annotations.html"
+	case strings.HasPrefix(code, "IGN"):
+		return baseURL + "02_06_ignore.html"
 	default:
 		return baseURL
 	}
 }
+
+// DocumentationURL returns "<baseURL>/<code>", or the page of GetDocumentationURL
+// when baseURL is empty
+func DocumentationURL(baseURL string, code string) string {
+	if baseURL == "" {
+		return GetDocumentationURL(code)
+	}
+	return strings.TrimSuffix(baseURL, "/") + "/" + code
+}
@@ -134,6 +134,11 @@ func TestGetDocumentationURL(t *testing.T) {
 			code:     ImmutableFieldCompoundAssign,
 			expected: "https://a14e.github.io/gogreement/02_02_immutable.html",
 		},
+		{
+			name:     "MUT03 returns immutable documentation",
+			code:     MutableAllFields,
+			expected: "https://a14e.github.io/gogreement/02_02_immutable.html",
+		},
 		{
 			name:     "CTOR01 returns constructor documentation",
 			code:     ConstructorCompositeLiteral,
@@ -149,6 +154,41 @@ func TestGetDocumentationURL(t *testing.T) {
 			code:     ImplementsPackageNotFound,
 			expected: "https://a14e.github.io/gogreement/02_01_implements.html",
 		},
+		{
+			name:     "ENUM01 returns enum documentation",
+			code:     EnumValueNotDeclared,
+			expected: "https://a14e.github.io/gogreement/02_08_enum.html",
+		},
+		{
+			name:     "REQ01 returns required documentation",
+			code:     RequiredFieldMissing,
+			expected: "https://a14e.github.io/gogreement/02_09_required.html",
+		},
+		{
+			name:     "SGL01 returns singleton documentation",
+			code:     SingletonConstructedAgain,
+			expected: "https://a14e.github.io/gogreement/02_10_singleton.html",
+		},
+		{
+			name:     "RET01 returns mustreturn documentation",
+			code:     MustReturnResultDiscarded,
+			expected: "https://a14e.github.io/gogreement/02_11_mustreturn.html",
+		},
+		{
+			name:     "EXP01 returns experimental documentation",
+			code:     ExperimentalUsedWithoutTag,
+			expected: "https://a14e.github.io/gogreement/02_12_experimental.html",
+		},
+		{
+			name:     "DEPPKG01 returns deprecated package documentation",
+			code:     DeprecatedPackageImported,
+			expected: "https://a14e.github.io/gogreement/02_13_deprecated.html",
+		},
+		{
+			name:     "ANN01 returns annotations overview",
+			code:     AnnotationMisplaced,
+			expected: "https://a14e.github.io/gogreement/02_annotations.html",
+		},
 		{
 			name:     "Unknown code returns base documentation",
 			code:     "UNKNOWN",
@@ -163,3 +203,18 @@ func TestGetDocumentationURL(t *testing.T) {
 		})
 	}
 }
+
+func TestDocumentationURL(t *testing.T) {
+	assert.Equal(t, "https://wiki.example.com/gogreement/IMM01", DocumentationURL("https://wiki.example.com/gogreement", ImmutableFieldAssignment))
+	assert.Equal(t, "https://wiki.example.com/gogreement/IMM01", DocumentationURL("https://wiki.example.com/gogreement/", ImmutableFieldAssignment))
+	assert.Equal(t, GetDocumentationURL(ImmutableFieldAssignment), DocumentationURL("", ImmutableFieldAssignment), "the book without a base")
+}
+
+func TestIsKnown(t *testing.T) {
+	for _, code := range []string{"IMM01", "CTOR04", "IMM", "ANN", "ALL"} {
+		assert.True(t, IsKnown(code), code)
+	}
+	for _, code := range []string{"IMM99", "CODE1", "all", ""} {
+		assert.False(t, IsKnown(code), code)
+	}
+}
@@ -1,20 +1,26 @@
 package config
 
 import (
+	"cmp"
 	"flag"
 	"go/ast"
 	"iter"
 	"os"
+	"path"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 
 	"golang.org/x/tools/go/analysis"
 )
 
 // Config holds the configuration for gogreement analyzers
+// Values are layered: built-in defaults < config file < environment < command line flags
 // @immutable
-// @constructor New
+// @constructor New, cloneWith
 type Config struct {
 	// ScanTests determines whether test files should be analyzed
 	// By default, test files (*_test.go) are excluded from analysis
@@ -35,6 +41,332 @@ type Config struct {
 	// Command line flag: --exclude-checks=IMM01,CTOR,TONL
 	// Default: [] (no exclusions)
 	ExcludeChecks []string
+
+	// GlobalIgnoreCodes is a list of violation codes suppressed in every package,
+	// as if each file carried a file-level @ignore for them
+	// Supports the @ignore hierarchy: codes (IMM01), categories (IMM) or ALL
+	// Environment variable: GOGREEMENT_GLOBAL_IGNORE_CODES=IMM,CTOR01
+	// Command line flag: --global-ignore-codes=IMM,CTOR01
+	// Config file: "globalIgnoreCodes": ["IMM", "CTOR01"]
+	// Default: [] (nothing ignored)
+	GlobalIgnoreCodes []string
+
+	// SkipPackages is a list of package path patterns whose packages produce no
+	// diagnostics. Annotations declared there are still read and exported, so
+	// other packages keep seeing them. A pattern is a path.Match glob
+	// ("example.com/gen/*") or ends in "/..." to match a package and all
+	// packages below it ("example.com/mirror/...")
+	// Environment variable: GOGREEMENT_SKIP_PACKAGES=example.com/gen/...,example.com/mirror
+	// Command line flag: --skip-packages=example.com/gen/...,example.com/mirror
+	// Config file: "skipPackages": ["example.com/gen/..."]
+	// Default: [] (no packages skipped)
+	SkipPackages []string
+
+	// LocalOnlyAnnotations is a list of annotation kinds ("testonly",
+	// "immutable", ...; the checker names also used by CheckScopes) that are
+	// checked only within their own package. They are left out of the facts
+	// exported to other packages, which keeps facts small when such contracts
+	// never cross a package boundary.
+	// Environment variable: GOGREEMENT_LOCAL_ONLY_ANNOTATIONS=testonly,since
+	// Command line flag: --local-only-annotations=testonly,since
+	// Config file: "localOnlyAnnotations": ["testonly"]
+	// Default: [] (every annotation is exported)
+	LocalOnlyAnnotations []string
+
+	// IgnoreMessagePatterns is a list of regular expressions; findings whose
+	// message matches any of them are suppressed wherever they are reported,
+	// e.g. every finding about a legacy field during a migration. Patterns
+	// are matched against the headline message without the code prefix. The
+	// flag and environment variable split on commas, so patterns containing
+	// commas belong in the config file. Invalid patterns match nothing.
+	// Environment variable: GOGREEMENT_IGNORE_MESSAGE_PATTERNS=field "legacy.*"
+	// Command line flag: --ignore-message-patterns='field "legacy.*"'
+	// Config file: "ignoreMessagePatterns": ["field \"legacy.*\""]
+	// Default: [] (nothing ignored)
+	IgnoreMessagePatterns []string
+
+	// VerboseImplements makes @implements failures list the full method sets of
+	// both the interface and the type next to the missing methods
+	// Environment variable: GOGREEMENT_VERBOSE_IMPLEMENTS=true|false
+	// Command line flag: --verbose-implements=true|false
+	// Default: false
+	VerboseImplements bool
+
+	// CheckSince enables validation of @since versions
+	// Environment variable: GOGREEMENT_CHECK_SINCE=true|false
+	// Command line flag: --check-since=true|false
+	// Default: false
+	CheckSince bool
+
+	// ValidateIgnoreCodes reports @ignore codes that are neither a registered
+	// code, a category prefix nor ALL (IGN01). Such a marker suppresses nothing.
+	// Environment variable: GOGREEMENT_VALIDATE_IGNORE_CODES=true|false
+	// Command line flag: --validate-ignore-codes=true|false
+	// Default: false
+	ValidateIgnoreCodes bool
+
+	// CheckPointees enables the IMM130 advisory for writes through a pointer
+	// field of an immutable type (*cfg.counter += 1). Immutability is shallow,
+	// so the pointee is not protected by default.
+	// Environment variable: GOGREEMENT_CHECK_POINTEES=true|false
+	// Command line flag: --check-pointees=true|false
+	// Default: false
+	CheckPointees bool
+
+	// WarnAnonymousImmutableAssignment enables the IMM190 advisory for a
+	// variable of an immutable type assigned an anonymous struct value. The
+	// anonymous value converts implicitly, and its own fields are not protected.
+	// Environment variable: GOGREEMENT_WARN_ANONYMOUS_IMMUTABLE_ASSIGNMENT=true|false
+	// Command line flag: --warn-anonymous-immutable-assignment=true|false
+	// Default: false
+	WarnAnonymousImmutableAssignment bool
+
+	// PublicOnly restricts the checks to the exported API: annotations on
+	// unexported types, functions and methods (or methods of unexported
+	// types) are not read, and findings inside such declarations are not
+	// reported
+	// Environment variable: GOGREEMENT_PUBLIC_ONLY=true|false
+	// Command line flag: --public-only=true|false
+	// Default: false
+	PublicOnly bool
+
+	// RequireImplementsAnnotation reports exported types that satisfy one of
+	// RequiredInterfaces without declaring it with @implements (IMPL06)
+	// Environment variable: GOGREEMENT_REQUIRE_IMPLEMENTS_ANNOTATION=true|false
+	// Command line flag: --require-implements-annotation=true|false
+	// Default: false
+	RequireImplementsAnnotation bool
+
+	// RequiredInterfaces lists the interfaces, as "importpath.Name", that
+	// RequireImplementsAnnotation asks implementers to declare
+	// Environment variable: GOGREEMENT_REQUIRED_INTERFACES=io.Reader,example.com/api.Handler
+	// Command line flag: --required-interfaces=io.Reader,example.com/api.Handler
+	// Config file: "requiredInterfaces": ["io.Reader"]
+	// Default: fmt.Stringer, io.Reader, io.Writer, io.Closer
+	RequiredInterfaces []string
+
+	// MutatingFunctions lists the functions, by their full name, that write
+	// through a pointer argument. Passing them the address of a field of an
+	// immutable type is reported as IMM170. Methods are written
+	// "(*importpath.Type).Method".
+	// Environment variable: GOGREEMENT_MUTATING_FUNCTIONS=encoding/json.Unmarshal,fmt.Sscan
+	// Command line flag: --mutating-functions=encoding/json.Unmarshal,fmt.Sscan
+	// Config file: "mutatingFunctions": ["encoding/json.Unmarshal"]
+	// Default: the decoding functions of encoding/json, encoding/xml,
+	// encoding/gob and encoding/binary, and the fmt scanning functions
+	MutatingFunctions []string
+
+	// InPlaceFunctions lists the functions, by their full name, that reorder
+	// or overwrite the elements of a slice argument in place. Passing them a
+	// slice field of an immutable type is reported as IMM04.
+	// Environment variable: GOGREEMENT_IN_PLACE_FUNCTIONS=slices.Sort,sort.Strings
+	// Command line flag: --in-place-functions=slices.Sort,sort.Strings
+	// Config file: "inPlaceFunctions": ["slices.Sort"]
+	// Default: the sorting and reversing functions of slices and sort
+	InPlaceFunctions []string
+
+	// GeneratedConstructorPatterns lists globs, matched like CheckScopes, of
+	// files holding generated code. A package-level function declared in such
+	// a file may construct the @constructor types it returns, as if it were
+	// listed in their annotation.
+	// Environment variable: GOGREEMENT_GENERATED_CONSTRUCTOR_PATTERNS=**/*_gen.go,zz_generated.*.go
+	// Command line flag: --generated-constructor-patterns=**/*_gen.go
+	// Config file: "generatedConstructorPatterns": ["**/*_gen.go"]
+	// Default: none
+	GeneratedConstructorPatterns []string
+
+	// ExperimentalTag is the build tag that opts a file in to @experimental
+	// declarations of other packages. Using one in a file that is not built
+	// only with this tag is reported as EXP01, a warning by default.
+	// Environment variable: GOGREEMENT_EXPERIMENTAL_TAG=unstable
+	// Command line flag: --experimental-tag=unstable
+	// Config file: "experimentalTag": "unstable"
+	// Default: "experimental"
+	ExperimentalTag string
+
+	// SuggestIgnores attaches a suggested fix to every reported violation that
+	// inserts an inline @ignore comment for its code
+	// Environment variable: GOGREEMENT_SUGGEST_IGNORES=true|false
+	// Command line flag: --suggest-ignores=true|false
+	// Default: false
+	SuggestIgnores bool
+
+	// StableMessages reports every violation as its single headline without the
+	// source snippet and help link, in position order, for golden files
+	// Environment variable: GOGREEMENT_STABLE_MESSAGES=true|false
+	// Command line flag: --stable-messages=true|false
+	// Default: false
+	StableMessages bool
+
+	// TagTestViolations marks the findings in _test.go files with a [test]
+	// tag ahead of their message, so they can be triaged apart from findings
+	// in production code. Only relevant with ScanTests.
+	// Environment variable: GOGREEMENT_TAG_TEST_VIOLATIONS=true|false
+	// Command line flag: --tag-test-violations=true|false
+	// Default: false
+	TagTestViolations bool
+
+	// FailFast stops reporting after the first finding of the run that is not
+	// ignored, for quick pre-commit checks. Facts are still computed for every
+	// package, since later packages depend on them; only the checks themselves
+	// are skipped once a finding was reported.
+	// Environment variable: GOGREEMENT_FAIL_FAST=true|false
+	// Command line flag: --fail-fast=true|false
+	// Default: false
+	FailFast bool
+
+	// MaxFindingsPerFile limits how many findings are reported per file, counted
+	// across all checks once all of them are done; the first findings by position
+	// are kept and the rest are counted in a single note. 0 means unlimited
+	// Environment variable: GOGREEMENT_MAX_FINDINGS_PER_FILE=20
+	// Command line flag: --max-findings-per-file=20
+	// Default: 0
+	MaxFindingsPerFile int
+
+	// WarningsExitCode is the exit code of runs whose findings all have the
+	// warning or info severity. -1 keeps the exit code of error findings
+	// Environment variable: GOGREEMENT_WARNINGS_EXIT_CODE=0
+	// Command line flag: --warnings-exit-code=0
+	// Default: -1
+	WarningsExitCode int
+
+	// Stats prints run counters (files scanned, annotations per kind, interfaces
+	// and types loaded, violations per code) after the run
+	// Environment variable: GOGREEMENT_STATS=true|false
+	// Command line flag: --stats=true|false
+	// Default: false
+	Stats bool
+
+	// StatsFormat selects the --stats output: "text" or "json"
+	// Environment variable: GOGREEMENT_STATS_FORMAT=json
+	// Command line flag: --stats-format=json
+	// Default: "text"
+	StatsFormat string
+
+	// TraceContracts prints, after the run, every annotation with its kind,
+	// target and position and the findings attributed to it, as JSON on stderr
+	// Environment variable: GOGREEMENT_TRACE_CONTRACTS=true|false
+	// Command line flag: --trace-contracts=true|false
+	// Default: false
+	TraceContracts bool
+
+	// EmitInventory prints, after the run, every annotation with its kind,
+	// target, package, position and arguments, as JSON on stderr
+	// Environment variable: GOGREEMENT_EMIT_INVENTORY=true|false
+	// Command line flag: --emit-inventory=true|false
+	// Default: false
+	EmitInventory bool
+
+	// OutputFormat selects how the gogreement command prints findings: "text"
+	// (the analysis driver's default output), "checkstyle" (Checkstyle XML on
+	// stdout), "json-v2" (versioned JSON with rule metadata on stdout) or "csv"
+	// (one row per finding on stdout). "json" is an alias of "json-v2". Formats
+	// added with RegisterOutputFormat are accepted too.
+	// Environment variable: GOGREEMENT_OUTPUT_FORMAT=checkstyle
+	// Command line flag: --output-format=checkstyle
+	// Config file: "outputFormat": "checkstyle"
+	// Default: "text"
+	OutputFormat string
+
+	// OwnersFile is the path of a JSON file mapping codes and path globs to
+	// owners (teams). The owner of each finding is added to the json-v2 report.
+	// Environment variable: GOGREEMENT_OWNERS=.gogreement-owners.json
+	// Command line flag: --owners=.gogreement-owners.json
+	// Config file: "owners": ".gogreement-owners.json"
+	// Default: "" (findings have no owner)
+	OwnersFile string
+
+	// DocsBaseURL replaces the documentation pages of the rules with
+	// "<base>/<code>", e.g. https://wiki.example.com/gogreement/IMM01, in the
+	// help line of the text output and the json-v2 report.
+	// Environment variable: GOGREEMENT_DOCS_BASE_URL=https://wiki.example.com/gogreement
+	// Command line flag: --docs-base-url=https://wiki.example.com/gogreement
+	// Config file: "docsBaseUrl": "https://wiki.example.com/gogreement"
+	// Default: "" (the pages of the gogreement book)
+	DocsBaseURL string
+
+	// PathBase selects how the gogreement command renders file paths in the
+	// reports of a non-text OutputFormat: "module" (relative to the directory
+	// of the go.mod found from the working directory), "cwd" (relative to the
+	// working directory) or "absolute". Files outside the base stay absolute.
+	// Environment variable: GOGREEMENT_PATH_BASE=absolute
+	// Command line flag: --path-base=absolute
+	// Config file: "pathBase": "absolute"
+	// Default: "module"
+	PathBase string
+
+	// Severities maps a check code or category (e.g. "IMM01", "CTOR") to a
+	// severity name ("error", "warning", "info") for reports that carry one
+	// Config file: "severities": {"IMPL50": "info"}
+	// Default: {} (every finding is an error, except the warnings listed in
+	// defaultSeverities)
+	Severities map[string]string
+
+	// CheckScopes restricts checks to files matching path globs. Keys are
+	// checker names ("immutable", "testonly", ...), categories ("IMM") or codes
+	// ("IMM01"); "**" matches any number of directories. Files excluded by
+	// ExcludePaths stay excluded.
+	// Config file: "checkScopes": {"immutable": ["pkg/domain/**"]}
+	// Default: {} (every check applies everywhere)
+	CheckScopes map[string][]string
+
+	// fileErr is why the config file could not be read or parsed; the built-in
+	// defaults stand in for it and Validate reports it
+	fileErr error
+}
+
+// Output formats accepted by StatsFormat
+const (
+	StatsFormatText = "text"
+	StatsFormatJSON = "json"
+)
+
+// Output formats accepted by OutputFormat
+const (
+	OutputFormatText       = "text"
+	OutputFormatCheckstyle = "checkstyle"
+	OutputFormatJSONV2     = "json-v2"
+	OutputFormatCSV        = "csv"
+
+	// OutputFormatJSON is an alias of OutputFormatJSONV2
+	OutputFormatJSON = "json"
+)
+
+// Path bases accepted by PathBase
+const (
+	PathBaseModule   = "module"
+	PathBaseCwd      = "cwd"
+	PathBaseAbsolute = "absolute"
+)
+
+// Severity names accepted in Severities
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+)
+
+// DefaultExperimentalTag is the default ExperimentalTag
+const DefaultExperimentalTag = "experimental"
+
+// defaultSeverities are the severities of categories that are not errors
+// unless Severities says otherwise
+var defaultSeverities = map[string]string{
+	"EXP":    SeverityWarning,
+	"DEPPKG": SeverityWarning,
+}
+
+// configFields has the same fields as Config but carries none of its annotations.
+// cloneWith edits a configFields copy and converts it back to Config.
+type configFields Config
+
+// cloneWith returns a copy of c with edit applied to the copy.
+// All With* methods go through it so every other field is preserved.
+func cloneWith(c *Config, edit func(*configFields)) *Config {
+	fields := configFields(*c)
+	edit(&fields)
+	result := Config(fields)
+	return &result
 }
 
 // Default returns the default configuration
@@ -47,14 +379,64 @@ func Empty() *Config {
 }
 
 // New creates a new Config with specified settings
+// Settings without a parameter here start empty and are set with the With* methods
 func New(scanTests bool, excludePaths []string, excludeChecks []string) *Config {
 	return &Config{
-		ScanTests:     scanTests,
-		ExcludePaths:  excludePaths,
-		ExcludeChecks: excludeChecks,
+		ScanTests:                    scanTests,
+		ExcludePaths:                 excludePaths,
+		ExcludeChecks:                excludeChecks,
+		GlobalIgnoreCodes:            []string{},
+		SkipPackages:                 []string{},
+		LocalOnlyAnnotations:         []string{},
+		IgnoreMessagePatterns:        []string{},
+		RequiredInterfaces:           []string{"fmt.Stringer", "io.Reader", "io.Writer", "io.Closer"},
+		MutatingFunctions:            slices.Clone(defaultMutatingFunctions),
+		InPlaceFunctions:             slices.Clone(defaultInPlaceFunctions),
+		GeneratedConstructorPatterns: []string{},
+		ExperimentalTag:              DefaultExperimentalTag,
+		WarningsExitCode:             -1,
+		StatsFormat:                  StatsFormatText,
+		OutputFormat:                 OutputFormatText,
+		PathBase:                     PathBaseModule,
+		Severities:                   map[string]string{},
+		CheckScopes:                  map[string][]string{},
 	}
 }
 
+// defaultMutatingFunctions are the standard library functions that decode or
+// scan into their pointer arguments
+var defaultMutatingFunctions = []string{
+	"encoding/json.Unmarshal",
+	"(*encoding/json.Decoder).Decode",
+	"encoding/xml.Unmarshal",
+	"(*encoding/xml.Decoder).Decode",
+	"(*encoding/gob.Decoder).Decode",
+	"encoding/binary.Read",
+	"fmt.Scan",
+	"fmt.Scanf",
+	"fmt.Scanln",
+	"fmt.Sscan",
+	"fmt.Sscanf",
+	"fmt.Sscanln",
+	"fmt.Fscan",
+	"fmt.Fscanf",
+	"fmt.Fscanln",
+}
+
+// defaultInPlaceFunctions are the standard library functions that sort or
+// reverse their slice argument in place
+var defaultInPlaceFunctions = []string{
+	"slices.Sort",
+	"slices.SortFunc",
+	"slices.SortStableFunc",
+	"slices.Reverse",
+	"sort.Ints",
+	"sort.Strings",
+	"sort.Float64s",
+	"sort.Slice",
+	"sort.SliceStable",
+}
+
 // CreateFlagSet creates and returns a flagset with gogreement-specific flags.
 // This allows the flags to be registered in the analyzer and appear in help.
 // IMPORTANT: Flag names are automatically prefixed with "config" by multichecker framework
@@ -69,6 +451,36 @@ func CreateFlagSet() *flag.FlagSet {
 	fs.Bool("scan-tests", defaultConfig.ScanTests, "Enable analysis of test files")
 	fs.String("exclude-paths", strings.Join(defaultConfig.ExcludePaths, ","), "Comma-separated list of paths to exclude from analysis")
 	fs.String("exclude-checks", strings.Join(defaultConfig.ExcludeChecks, ","), "Comma-separated list of check codes to exclude from analysis")
+	fs.String("global-ignore-codes", strings.Join(defaultConfig.GlobalIgnoreCodes, ","), "Comma-separated list of violation codes to ignore in every package")
+	fs.String("skip-packages", strings.Join(defaultConfig.SkipPackages, ","), "Comma-separated list of package path patterns that produce no diagnostics")
+	fs.String("local-only-annotations", strings.Join(defaultConfig.LocalOnlyAnnotations, ","), "Comma-separated list of annotation kinds (e.g. testonly) checked only in their own package and not exported as facts")
+	fs.String("ignore-message-patterns", strings.Join(defaultConfig.IgnoreMessagePatterns, ","), "Comma-separated list of regular expressions; findings whose message matches one are suppressed")
+	fs.Bool("verbose-implements", defaultConfig.VerboseImplements, "Print full interface and type method sets for @implements failures")
+	fs.Bool("check-since", defaultConfig.CheckSince, "Validate @since versions")
+	fs.Bool("validate-ignore-codes", defaultConfig.ValidateIgnoreCodes, "Report @ignore codes that do not exist (IGN01)")
+	fs.Bool("check-pointees", defaultConfig.CheckPointees, "Report writes through pointer fields of immutable types (IMM130)")
+	fs.Bool("public-only", defaultConfig.PublicOnly, "Check only exported types, functions and methods")
+	fs.Bool("warn-anonymous-immutable-assignment", defaultConfig.WarnAnonymousImmutableAssignment, "Report anonymous struct values assigned to variables of immutable types (IMM190)")
+	fs.Bool("require-implements-annotation", defaultConfig.RequireImplementsAnnotation, "Report exported types implementing a required interface without @implements (IMPL06)")
+	fs.String("required-interfaces", strings.Join(defaultConfig.RequiredInterfaces, ","), "Comma-separated list of interfaces (importpath.Name) checked by --require-implements-annotation")
+	fs.String("mutating-functions", strings.Join(defaultConfig.MutatingFunctions, ","), "Comma-separated list of functions writing through pointer arguments, checked for immutable field addresses (IMM170)")
+	fs.String("in-place-functions", strings.Join(defaultConfig.InPlaceFunctions, ","), "Comma-separated list of functions reordering or overwriting a slice argument in place, checked for immutable slice fields (IMM04)")
+	fs.String("generated-constructor-patterns", strings.Join(defaultConfig.GeneratedConstructorPatterns, ","), "Comma-separated list of globs of generated files whose functions may construct the @constructor types they return")
+	fs.String("experimental-tag", defaultConfig.ExperimentalTag, "Build tag of files allowed to use @experimental declarations of other packages")
+	fs.Bool("suggest-ignores", defaultConfig.SuggestIgnores, "Attach suggested fixes that add an inline @ignore for each violation")
+	fs.Bool("stable-messages", defaultConfig.StableMessages, "Report single-line messages without source snippets, sorted by position")
+	fs.Bool("tag-test-violations", defaultConfig.TagTestViolations, "Tag findings in _test.go files with [test]")
+	fs.Bool("fail-fast", defaultConfig.FailFast, "Stop after the first reported finding")
+	fs.Int("max-findings-per-file", defaultConfig.MaxFindingsPerFile, "Maximum number of findings reported per file (0 = unlimited)")
+	fs.Int("warnings-exit-code", defaultConfig.WarningsExitCode, "Exit code of runs with only warning or info findings (-1 = same as errors)")
+	fs.Bool("stats", defaultConfig.Stats, "Print run counters after the run")
+	fs.String("stats-format", defaultConfig.StatsFormat, "Output format of --stats: text or json")
+	fs.Bool("trace-contracts", defaultConfig.TraceContracts, "Print every annotation with the findings attributed to it as JSON after the run")
+	fs.Bool("emit-inventory", defaultConfig.EmitInventory, "Print every annotation with its target, position and arguments as JSON after the run")
+	fs.String("output-format", defaultConfig.OutputFormat, "Output format of findings: text, checkstyle, json-v2 or csv")
+	fs.String("owners", defaultConfig.OwnersFile, "JSON file mapping codes and path globs to the owners of findings")
+	fs.String("docs-base-url", defaultConfig.DocsBaseURL, "Base URL of the rule documentation; findings link to <base>/<code>")
+	fs.String("path-base", defaultConfig.PathBase, "File paths in non-text output: module, cwd or absolute")
 
 	return fs
 }
@@ -91,14 +503,143 @@ func ParseFlagsFromFlagSet(fs *flag.FlagSet) *Config {
 	scanTestsFlag := fs.Lookup("scan-tests")
 	excludePathsFlag := fs.Lookup("exclude-paths")
 	excludeChecksFlag := fs.Lookup("exclude-checks")
+	globalIgnoreCodesFlag := fs.Lookup("global-ignore-codes")
+	skipPackagesFlag := fs.Lookup("skip-packages")
+	localOnlyAnnotationsFlag := fs.Lookup("local-only-annotations")
+	ignoreMessagePatternsFlag := fs.Lookup("ignore-message-patterns")
+	verboseImplementsFlag := fs.Lookup("verbose-implements")
+	checkSinceFlag := fs.Lookup("check-since")
+	validateIgnoreCodesFlag := fs.Lookup("validate-ignore-codes")
+	checkPointeesFlag := fs.Lookup("check-pointees")
+	warnAnonymousFlag := fs.Lookup("warn-anonymous-immutable-assignment")
+	publicOnlyFlag := fs.Lookup("public-only")
+	requireImplementsFlag := fs.Lookup("require-implements-annotation")
+	requiredInterfacesFlag := fs.Lookup("required-interfaces")
+	mutatingFunctionsFlag := fs.Lookup("mutating-functions")
+	inPlaceFunctionsFlag := fs.Lookup("in-place-functions")
+	generatedConstructorPatternsFlag := fs.Lookup("generated-constructor-patterns")
+	experimentalTagFlag := fs.Lookup("experimental-tag")
+	suggestIgnoresFlag := fs.Lookup("suggest-ignores")
+	stableMessagesFlag := fs.Lookup("stable-messages")
+	tagTestViolationsFlag := fs.Lookup("tag-test-violations")
+	failFastFlag := fs.Lookup("fail-fast")
+	maxFindingsPerFileFlag := fs.Lookup("max-findings-per-file")
+	warningsExitCodeFlag := fs.Lookup("warnings-exit-code")
+	statsFlag := fs.Lookup("stats")
+	statsFormatFlag := fs.Lookup("stats-format")
+	traceContractsFlag := fs.Lookup("trace-contracts")
+	emitInventoryFlag := fs.Lookup("emit-inventory")
+	outputFormatFlag := fs.Lookup("output-format")
+	ownersFlag := fs.Lookup("owners")
+	docsBaseURLFlag := fs.Lookup("docs-base-url")
+	pathBaseFlag := fs.Lookup("path-base")
 
-	var scanTests bool
-	var excludePathsStr, excludeChecksStr string
+	var scanTests, verboseImplements, checkSince, validateIgnoreCodes, checkPointees, warnAnonymous, publicOnly, requireImplements, suggestIgnores, stableMessages, tagTestViolations, failFast, stats, traceContracts, emitInventory bool
+	var excludePathsStr, excludeChecksStr, globalIgnoreCodesStr, skipPackagesStr, localOnlyAnnotationsStr, ignoreMessagePatternsStr, requiredInterfacesStr, mutatingFunctionsStr, inPlaceFunctionsStr, generatedConstructorPatternsStr, ownersFile, docsBaseURL string
+	statsFormat := StatsFormatText
+	outputFormat := OutputFormatText
+	pathBase := PathBaseModule
+	experimentalTag := DefaultExperimentalTag
+	var maxFindingsPerFile int
+	warningsExitCode := -1
 
 	if scanTestsFlag != nil {
 		scanTests = scanTestsFlag.Value.(flag.Getter).Get().(bool)
 	}
 
+	if verboseImplementsFlag != nil {
+		verboseImplements = verboseImplementsFlag.Value.(flag.Getter).Get().(bool)
+	}
+
+	if checkSinceFlag != nil {
+		checkSince = checkSinceFlag.Value.(flag.Getter).Get().(bool)
+	}
+
+	if validateIgnoreCodesFlag != nil {
+		validateIgnoreCodes = validateIgnoreCodesFlag.Value.(flag.Getter).Get().(bool)
+	}
+
+	if checkPointeesFlag != nil {
+		checkPointees = checkPointeesFlag.Value.(flag.Getter).Get().(bool)
+	}
+
+	if warnAnonymousFlag != nil {
+		warnAnonymous = warnAnonymousFlag.Value.(flag.Getter).Get().(bool)
+	}
+
+	if publicOnlyFlag != nil {
+		publicOnly = publicOnlyFlag.Value.(flag.Getter).Get().(bool)
+	}
+
+	if requireImplementsFlag != nil {
+		requireImplements = requireImplementsFlag.Value.(flag.Getter).Get().(bool)
+	}
+
+	if suggestIgnoresFlag != nil {
+		suggestIgnores = suggestIgnoresFlag.Value.(flag.Getter).Get().(bool)
+	}
+
+	if stableMessagesFlag != nil {
+		stableMessages = stableMessagesFlag.Value.(flag.Getter).Get().(bool)
+	}
+
+	if tagTestViolationsFlag != nil {
+		tagTestViolations = tagTestViolationsFlag.Value.(flag.Getter).Get().(bool)
+	}
+
+	if failFastFlag != nil {
+		failFast = failFastFlag.Value.(flag.Getter).Get().(bool)
+	}
+
+	if maxFindingsPerFileFlag != nil {
+		maxFindingsPerFile = maxFindingsPerFileFlag.Value.(flag.Getter).Get().(int)
+	}
+
+	if warningsExitCodeFlag != nil {
+		warningsExitCode = warningsExitCodeFlag.Value.(flag.Getter).Get().(int)
+	}
+
+	if statsFlag != nil {
+		stats = statsFlag.Value.(flag.Getter).Get().(bool)
+	}
+
+	if statsFormatFlag != nil {
+		statsFormat = parseStatsFormat(statsFormatFlag.Value.String())
+	}
+
+	if traceContractsFlag != nil {
+		traceContracts = traceContractsFlag.Value.(flag.Getter).Get().(bool)
+	}
+
+	if emitInventoryFlag != nil {
+		emitInventory = emitInventoryFlag.Value.(flag.Getter).Get().(bool)
+	}
+
+	if outputFormatFlag != nil {
+		outputFormat = parseOutputFormat(outputFormatFlag.Value.String())
+	}
+
+	if ownersFlag != nil {
+		ownersFile = strings.TrimSpace(ownersFlag.Value.String())
+	}
+
+	if docsBaseURLFlag != nil {
+		docsBaseURL = strings.TrimSpace(docsBaseURLFlag.Value.String())
+	}
+
+	if pathBaseFlag != nil {
+		pathBase = parsePathBase(pathBaseFlag.Value.String())
+	}
+
+	if experimentalTagFlag != nil {
+		experimentalTag = parseExperimentalTag(experimentalTagFlag.Value.String())
+	}
+
+	// Severities and check scopes have no flag; they only come from the config file
+	fileDefaults, fileErr := loadFileDefaults(FileName)
+	severities := fileDefaults.Severities
+	checkScopes := fileDefaults.CheckScopes
+
 	if excludePathsFlag != nil {
 		excludePathsStr = excludePathsFlag.Value.String()
 	}
@@ -107,28 +648,269 @@ func ParseFlagsFromFlagSet(fs *flag.FlagSet) *Config {
 		excludeChecksStr = excludeChecksFlag.Value.String()
 	}
 
+	if globalIgnoreCodesFlag != nil {
+		globalIgnoreCodesStr = globalIgnoreCodesFlag.Value.String()
+	}
+
+	if skipPackagesFlag != nil {
+		skipPackagesStr = skipPackagesFlag.Value.String()
+	}
+
+	if localOnlyAnnotationsFlag != nil {
+		localOnlyAnnotationsStr = localOnlyAnnotationsFlag.Value.String()
+	}
+
+	if ignoreMessagePatternsFlag != nil {
+		ignoreMessagePatternsStr = ignoreMessagePatternsFlag.Value.String()
+	}
+
+	if requiredInterfacesFlag != nil {
+		requiredInterfacesStr = requiredInterfacesFlag.Value.String()
+	}
+
+	if mutatingFunctionsFlag != nil {
+		mutatingFunctionsStr = mutatingFunctionsFlag.Value.String()
+	}
+
+	if inPlaceFunctionsFlag != nil {
+		inPlaceFunctionsStr = inPlaceFunctionsFlag.Value.String()
+	}
+
+	if generatedConstructorPatternsFlag != nil {
+		generatedConstructorPatternsStr = generatedConstructorPatternsFlag.Value.String()
+	}
+
 	// Parse flag values
 	finalExcludePaths := parseStringList(excludePathsStr, false)
 	finalExcludeChecks := parseStringList(excludeChecksStr, true)
+	finalGlobalIgnoreCodes := parseStringList(globalIgnoreCodesStr, true)
+	finalSkipPackages := parseStringList(skipPackagesStr, false)
+	finalLocalOnlyAnnotations := parseStringList(localOnlyAnnotationsStr, false)
+	finalIgnoreMessagePatterns := parseStringList(ignoreMessagePatternsStr, false)
+	finalRequiredInterfaces := parseStringList(requiredInterfacesStr, false)
+	finalMutatingFunctions := parseStringList(mutatingFunctionsStr, false)
+	finalInPlaceFunctions := parseStringList(inPlaceFunctionsStr, false)
+	finalGeneratedConstructorPatterns := parseStringList(generatedConstructorPatternsStr, false)
 
-	return New(scanTests, finalExcludePaths, finalExcludeChecks)
+	return New(scanTests, finalExcludePaths, finalExcludeChecks).
+		WithGlobalIgnoreCodes(finalGlobalIgnoreCodes).
+		WithSkipPackages(finalSkipPackages).
+		WithLocalOnlyAnnotations(finalLocalOnlyAnnotations).
+		WithIgnoreMessagePatterns(finalIgnoreMessagePatterns).
+		WithRequiredInterfaces(finalRequiredInterfaces).
+		WithMutatingFunctions(finalMutatingFunctions).
+		WithInPlaceFunctions(finalInPlaceFunctions).
+		WithGeneratedConstructorPatterns(finalGeneratedConstructorPatterns).
+		WithExperimentalTag(experimentalTag).
+		WithVerboseImplements(verboseImplements).
+		WithCheckSince(checkSince).
+		WithValidateIgnoreCodes(validateIgnoreCodes).
+		WithCheckPointees(checkPointees).
+		WithWarnAnonymousImmutableAssignment(warnAnonymous).
+		WithPublicOnly(publicOnly).
+		WithRequireImplementsAnnotation(requireImplements).
+		WithSuggestIgnores(suggestIgnores).
+		WithStableMessages(stableMessages).
+		WithTagTestViolations(tagTestViolations).
+		WithFailFast(failFast).
+		WithMaxFindingsPerFile(maxFindingsPerFile).
+		WithWarningsExitCode(warningsExitCode).
+		WithStats(stats).
+		WithStatsFormat(statsFormat).
+		WithTraceContracts(traceContracts).
+		WithEmitInventory(emitInventory).
+		WithOutputFormat(outputFormat).
+		WithOwnersFile(ownersFile).
+		WithDocsBaseURL(docsBaseURL).
+		WithPathBase(pathBase).
+		WithSeverities(severities).
+		WithCheckScopes(checkScopes).
+		withFileError(fileErr)
 }
 
 // FromEnv creates a new Config from environment variables.
+// Values not set in the environment come from the config file in the current
+// directory (see FileName), or the built-in defaults when there is none.
 func FromEnv() *Config {
-	// Get environment values
-	scanTests := false
-	excludePaths := []string{"testdata"} // Default
-	excludeChecks := []string{}          // Default - no exclusions
+	// Defaults: config file values, falling back to built-in defaults
+	defaults, fileErr := loadFileDefaults(FileName)
+	scanTests := defaults.ScanTests
+	excludePaths := defaults.ExcludePaths
+	excludeChecks := defaults.ExcludeChecks
+	globalIgnoreCodes := defaults.GlobalIgnoreCodes
+	skipPackages := defaults.SkipPackages
+	localOnlyAnnotations := defaults.LocalOnlyAnnotations
+	ignoreMessagePatterns := defaults.IgnoreMessagePatterns
+	requiredInterfaces := defaults.RequiredInterfaces
+	mutatingFunctions := defaults.MutatingFunctions
+	inPlaceFunctions := defaults.InPlaceFunctions
+	generatedConstructorPatterns := defaults.GeneratedConstructorPatterns
+	experimentalTag := parseExperimentalTag(defaults.ExperimentalTag)
+	requireImplements := defaults.RequireImplementsAnnotation
+	verboseImplements := defaults.VerboseImplements
+	checkSince := defaults.CheckSince
+	validateIgnoreCodes := defaults.ValidateIgnoreCodes
+	checkPointees := defaults.CheckPointees
+	warnAnonymous := defaults.WarnAnonymousImmutableAssignment
+	publicOnly := defaults.PublicOnly
+	suggestIgnores := defaults.SuggestIgnores
+	stableMessages := defaults.StableMessages
+	tagTestViolations := defaults.TagTestViolations
+	failFast := defaults.FailFast
+	maxFindingsPerFile := defaults.MaxFindingsPerFile
+	warningsExitCode := defaults.WarningsExitCode
+	stats := defaults.Stats
+	statsFormat := parseStatsFormat(defaults.StatsFormat)
+	traceContracts := defaults.TraceContracts
+	emitInventory := defaults.EmitInventory
+	outputFormat := parseOutputFormat(defaults.OutputFormat)
+	ownersFile := defaults.OwnersFile
+	docsBaseURL := strings.TrimSpace(defaults.DocsBaseURL)
+	pathBase := parsePathBase(defaults.PathBase)
+	severities := defaults.Severities
+	checkScopes := defaults.CheckScopes
 
 	if envVal := os.Getenv("GOGREEMENT_SCAN_TESTS"); envVal != "" {
 		scanTests = parseBool(envVal)
 	}
 
+	if envVal := os.Getenv("GOGREEMENT_VERBOSE_IMPLEMENTS"); envVal != "" {
+		verboseImplements = parseBool(envVal)
+	}
+
+	if envVal := os.Getenv("GOGREEMENT_CHECK_SINCE"); envVal != "" {
+		checkSince = parseBool(envVal)
+	}
+
+	if envVal := os.Getenv("GOGREEMENT_VALIDATE_IGNORE_CODES"); envVal != "" {
+		validateIgnoreCodes = parseBool(envVal)
+	}
+
+	if envVal := os.Getenv("GOGREEMENT_CHECK_POINTEES"); envVal != "" {
+		checkPointees = parseBool(envVal)
+	}
+
+	if envVal := os.Getenv("GOGREEMENT_WARN_ANONYMOUS_IMMUTABLE_ASSIGNMENT"); envVal != "" {
+		warnAnonymous = parseBool(envVal)
+	}
+
+	if envVal := os.Getenv("GOGREEMENT_PUBLIC_ONLY"); envVal != "" {
+		publicOnly = parseBool(envVal)
+	}
+
+	if envVal := os.Getenv("GOGREEMENT_REQUIRE_IMPLEMENTS_ANNOTATION"); envVal != "" {
+		requireImplements = parseBool(envVal)
+	}
+
+	if envVal := os.Getenv("GOGREEMENT_SUGGEST_IGNORES"); envVal != "" {
+		suggestIgnores = parseBool(envVal)
+	}
+
+	if envVal := os.Getenv("GOGREEMENT_STABLE_MESSAGES"); envVal != "" {
+		stableMessages = parseBool(envVal)
+	}
+
+	if envVal := os.Getenv("GOGREEMENT_TAG_TEST_VIOLATIONS"); envVal != "" {
+		tagTestViolations = parseBool(envVal)
+	}
+
+	if envVal := os.Getenv("GOGREEMENT_FAIL_FAST"); envVal != "" {
+		failFast = parseBool(envVal)
+	}
+
+	if envVal := os.Getenv("GOGREEMENT_MAX_FINDINGS_PER_FILE"); envVal != "" {
+		if n, err := strconv.Atoi(strings.TrimSpace(envVal)); err == nil {
+			maxFindingsPerFile = n
+		}
+	}
+
+	if envVal := os.Getenv("GOGREEMENT_WARNINGS_EXIT_CODE"); envVal != "" {
+		if n, err := strconv.Atoi(strings.TrimSpace(envVal)); err == nil {
+			warningsExitCode = n
+		}
+	}
+
+	if envVal := os.Getenv("GOGREEMENT_STATS"); envVal != "" {
+		stats = parseBool(envVal)
+	}
+
+	if envVal := os.Getenv("GOGREEMENT_STATS_FORMAT"); envVal != "" {
+		statsFormat = parseStatsFormat(envVal)
+	}
+
+	if envVal := os.Getenv("GOGREEMENT_TRACE_CONTRACTS"); envVal != "" {
+		traceContracts = parseBool(envVal)
+	}
+
+	if envVal := os.Getenv("GOGREEMENT_EMIT_INVENTORY"); envVal != "" {
+		emitInventory = parseBool(envVal)
+	}
+
+	if envVal := os.Getenv("GOGREEMENT_OUTPUT_FORMAT"); envVal != "" {
+		outputFormat = parseOutputFormat(envVal)
+	}
+
+	if envVal := os.Getenv("GOGREEMENT_OWNERS"); envVal != "" {
+		ownersFile = strings.TrimSpace(envVal)
+	}
+
+	if envVal := os.Getenv("GOGREEMENT_DOCS_BASE_URL"); envVal != "" {
+		docsBaseURL = strings.TrimSpace(envVal)
+	}
+
+	if envVal := os.Getenv("GOGREEMENT_PATH_BASE"); envVal != "" {
+		pathBase = parsePathBase(envVal)
+	}
+
+	if envVal := os.Getenv("GOGREEMENT_EXPERIMENTAL_TAG"); envVal != "" {
+		experimentalTag = parseExperimentalTag(envVal)
+	}
+
 	excludePaths = parseEnvValue("GOGREEMENT_EXCLUDE_PATHS", false, excludePaths)
 	excludeChecks = parseEnvValue("GOGREEMENT_EXCLUDE_CHECKS", true, excludeChecks)
+	globalIgnoreCodes = parseEnvValue("GOGREEMENT_GLOBAL_IGNORE_CODES", true, globalIgnoreCodes)
+	skipPackages = parseEnvValue("GOGREEMENT_SKIP_PACKAGES", false, skipPackages)
+	localOnlyAnnotations = parseEnvValue("GOGREEMENT_LOCAL_ONLY_ANNOTATIONS", false, localOnlyAnnotations)
+	ignoreMessagePatterns = parseEnvValue("GOGREEMENT_IGNORE_MESSAGE_PATTERNS", false, ignoreMessagePatterns)
+	requiredInterfaces = parseEnvValue("GOGREEMENT_REQUIRED_INTERFACES", false, requiredInterfaces)
+	mutatingFunctions = parseEnvValue("GOGREEMENT_MUTATING_FUNCTIONS", false, mutatingFunctions)
+	inPlaceFunctions = parseEnvValue("GOGREEMENT_IN_PLACE_FUNCTIONS", false, inPlaceFunctions)
+	generatedConstructorPatterns = parseEnvValue("GOGREEMENT_GENERATED_CONSTRUCTOR_PATTERNS", false, generatedConstructorPatterns)
 
-	return New(scanTests, excludePaths, excludeChecks)
+	return New(scanTests, excludePaths, excludeChecks).
+		WithGlobalIgnoreCodes(globalIgnoreCodes).
+		WithSkipPackages(skipPackages).
+		WithLocalOnlyAnnotations(localOnlyAnnotations).
+		WithIgnoreMessagePatterns(ignoreMessagePatterns).
+		WithRequiredInterfaces(requiredInterfaces).
+		WithMutatingFunctions(mutatingFunctions).
+		WithInPlaceFunctions(inPlaceFunctions).
+		WithGeneratedConstructorPatterns(generatedConstructorPatterns).
+		WithExperimentalTag(experimentalTag).
+		WithVerboseImplements(verboseImplements).
+		WithCheckSince(checkSince).
+		WithValidateIgnoreCodes(validateIgnoreCodes).
+		WithCheckPointees(checkPointees).
+		WithWarnAnonymousImmutableAssignment(warnAnonymous).
+		WithPublicOnly(publicOnly).
+		WithRequireImplementsAnnotation(requireImplements).
+		WithSuggestIgnores(suggestIgnores).
+		WithStableMessages(stableMessages).
+		WithTagTestViolations(tagTestViolations).
+		WithFailFast(failFast).
+		WithMaxFindingsPerFile(maxFindingsPerFile).
+		WithWarningsExitCode(warningsExitCode).
+		WithStats(stats).
+		WithStatsFormat(statsFormat).
+		WithTraceContracts(traceContracts).
+		WithEmitInventory(emitInventory).
+		WithOutputFormat(outputFormat).
+		WithOwnersFile(ownersFile).
+		WithDocsBaseURL(docsBaseURL).
+		WithPathBase(pathBase).
+		WithSeverities(severities).
+		WithCheckScopes(checkScopes).
+		withFileError(fileErr)
 }
 
 // parseStringList parses a comma-separated string into a slice of strings
@@ -162,17 +944,262 @@ func parseEnvValue(key string, toUpper bool, defaultValue []string) []string {
 
 // WithScanTests returns a new Config with ScanTests set to the specified value
 func (c *Config) WithScanTests(scanTests bool) *Config {
-	return New(scanTests, c.ExcludePaths, c.ExcludeChecks)
+	return cloneWith(c, func(f *configFields) { f.ScanTests = scanTests })
 }
 
 // WithExcludePaths returns a new Config with ExcludePaths set to the specified value
 func (c *Config) WithExcludePaths(excludePaths []string) *Config {
-	return New(c.ScanTests, excludePaths, c.ExcludeChecks)
+	return cloneWith(c, func(f *configFields) { f.ExcludePaths = excludePaths })
 }
 
 // WithExcludeChecks returns a new Config with ExcludeChecks set to the specified value
 func (c *Config) WithExcludeChecks(excludeChecks []string) *Config {
-	return New(c.ScanTests, c.ExcludePaths, excludeChecks)
+	return cloneWith(c, func(f *configFields) { f.ExcludeChecks = excludeChecks })
+}
+
+// WithGlobalIgnoreCodes returns a new Config with GlobalIgnoreCodes set to the specified value
+func (c *Config) WithGlobalIgnoreCodes(globalIgnoreCodes []string) *Config {
+	return cloneWith(c, func(f *configFields) { f.GlobalIgnoreCodes = globalIgnoreCodes })
+}
+
+// WithLocalOnlyAnnotations returns a new Config with LocalOnlyAnnotations set to the specified value
+func (c *Config) WithLocalOnlyAnnotations(kinds []string) *Config {
+	return cloneWith(c, func(f *configFields) { f.LocalOnlyAnnotations = kinds })
+}
+
+// WithSkipPackages returns a new Config with SkipPackages set to the specified value
+func (c *Config) WithSkipPackages(skipPackages []string) *Config {
+	return cloneWith(c, func(f *configFields) { f.SkipPackages = skipPackages })
+}
+
+// WithIgnoreMessagePatterns returns a new Config with IgnoreMessagePatterns set to the specified value
+func (c *Config) WithIgnoreMessagePatterns(ignoreMessagePatterns []string) *Config {
+	return cloneWith(c, func(f *configFields) { f.IgnoreMessagePatterns = ignoreMessagePatterns })
+}
+
+// WithVerboseImplements returns a new Config with VerboseImplements set to the specified value
+func (c *Config) WithVerboseImplements(verboseImplements bool) *Config {
+	return cloneWith(c, func(f *configFields) { f.VerboseImplements = verboseImplements })
+}
+
+// WithCheckSince returns a new Config with CheckSince set to the specified value
+func (c *Config) WithCheckSince(checkSince bool) *Config {
+	return cloneWith(c, func(f *configFields) { f.CheckSince = checkSince })
+}
+
+// WithValidateIgnoreCodes returns a new Config with ValidateIgnoreCodes set to the specified value
+func (c *Config) WithValidateIgnoreCodes(validateIgnoreCodes bool) *Config {
+	return cloneWith(c, func(f *configFields) { f.ValidateIgnoreCodes = validateIgnoreCodes })
+}
+
+// WithCheckPointees returns a new Config with CheckPointees set to the specified value
+func (c *Config) WithCheckPointees(checkPointees bool) *Config {
+	return cloneWith(c, func(f *configFields) { f.CheckPointees = checkPointees })
+}
+
+// WithWarnAnonymousImmutableAssignment returns a new Config with
+// WarnAnonymousImmutableAssignment set to the specified value
+func (c *Config) WithWarnAnonymousImmutableAssignment(warn bool) *Config {
+	return cloneWith(c, func(f *configFields) { f.WarnAnonymousImmutableAssignment = warn })
+}
+
+// WithPublicOnly returns a new Config with PublicOnly set to the specified value
+func (c *Config) WithPublicOnly(publicOnly bool) *Config {
+	return cloneWith(c, func(f *configFields) { f.PublicOnly = publicOnly })
+}
+
+// WithRequireImplementsAnnotation returns a new Config with RequireImplementsAnnotation set to the specified value
+func (c *Config) WithRequireImplementsAnnotation(requireImplementsAnnotation bool) *Config {
+	return cloneWith(c, func(f *configFields) { f.RequireImplementsAnnotation = requireImplementsAnnotation })
+}
+
+// WithRequiredInterfaces returns a new Config with RequiredInterfaces set to the specified value
+func (c *Config) WithRequiredInterfaces(requiredInterfaces []string) *Config {
+	return cloneWith(c, func(f *configFields) { f.RequiredInterfaces = requiredInterfaces })
+}
+
+// WithMutatingFunctions returns a new Config with MutatingFunctions set to the specified value
+func (c *Config) WithMutatingFunctions(mutatingFunctions []string) *Config {
+	return cloneWith(c, func(f *configFields) { f.MutatingFunctions = mutatingFunctions })
+}
+
+// WithInPlaceFunctions returns a new Config with InPlaceFunctions set to the specified value
+func (c *Config) WithInPlaceFunctions(inPlaceFunctions []string) *Config {
+	return cloneWith(c, func(f *configFields) { f.InPlaceFunctions = inPlaceFunctions })
+}
+
+// WithGeneratedConstructorPatterns returns a new Config with GeneratedConstructorPatterns set to the specified value
+func (c *Config) WithGeneratedConstructorPatterns(generatedConstructorPatterns []string) *Config {
+	return cloneWith(c, func(f *configFields) { f.GeneratedConstructorPatterns = generatedConstructorPatterns })
+}
+
+// WithSuggestIgnores returns a new Config with SuggestIgnores set to the specified value
+func (c *Config) WithSuggestIgnores(suggestIgnores bool) *Config {
+	return cloneWith(c, func(f *configFields) { f.SuggestIgnores = suggestIgnores })
+}
+
+// WithStableMessages returns a new Config with StableMessages set to the specified value
+func (c *Config) WithStableMessages(stableMessages bool) *Config {
+	return cloneWith(c, func(f *configFields) { f.StableMessages = stableMessages })
+}
+
+// WithTagTestViolations returns a new Config with TagTestViolations set to the specified value
+func (c *Config) WithTagTestViolations(tagTestViolations bool) *Config {
+	return cloneWith(c, func(f *configFields) { f.TagTestViolations = tagTestViolations })
+}
+
+// withFileError returns a new Config recording err as the error of reading
+// the config file
+func (c *Config) withFileError(err error) *Config {
+	return cloneWith(c, func(f *configFields) { f.fileErr = err })
+}
+
+// WithFailFast returns a new Config with FailFast set to the specified value
+func (c *Config) WithFailFast(failFast bool) *Config {
+	return cloneWith(c, func(f *configFields) { f.FailFast = failFast })
+}
+
+// WithMaxFindingsPerFile returns a new Config with MaxFindingsPerFile set to the specified value
+func (c *Config) WithMaxFindingsPerFile(maxFindingsPerFile int) *Config {
+	return cloneWith(c, func(f *configFields) { f.MaxFindingsPerFile = maxFindingsPerFile })
+}
+
+// WithWarningsExitCode returns a new Config with WarningsExitCode set to the specified value
+func (c *Config) WithWarningsExitCode(warningsExitCode int) *Config {
+	return cloneWith(c, func(f *configFields) { f.WarningsExitCode = warningsExitCode })
+}
+
+// WithStats returns a new Config with Stats set to the specified value
+func (c *Config) WithStats(stats bool) *Config {
+	return cloneWith(c, func(f *configFields) { f.Stats = stats })
+}
+
+// WithStatsFormat returns a new Config with StatsFormat set to the specified value
+func (c *Config) WithStatsFormat(statsFormat string) *Config {
+	return cloneWith(c, func(f *configFields) { f.StatsFormat = statsFormat })
+}
+
+// WithTraceContracts returns a new Config with TraceContracts set to the specified value
+func (c *Config) WithTraceContracts(traceContracts bool) *Config {
+	return cloneWith(c, func(f *configFields) { f.TraceContracts = traceContracts })
+}
+
+// WithEmitInventory returns a new Config with EmitInventory set to the specified value
+func (c *Config) WithEmitInventory(emitInventory bool) *Config {
+	return cloneWith(c, func(f *configFields) { f.EmitInventory = emitInventory })
+}
+
+// WithOutputFormat returns a new Config with OutputFormat set to the specified value
+func (c *Config) WithOutputFormat(outputFormat string) *Config {
+	return cloneWith(c, func(f *configFields) { f.OutputFormat = outputFormat })
+}
+
+// WithExperimentalTag returns a new Config with ExperimentalTag set to the specified value
+func (c *Config) WithExperimentalTag(experimentalTag string) *Config {
+	return cloneWith(c, func(f *configFields) { f.ExperimentalTag = experimentalTag })
+}
+
+// WithPathBase returns a new Config with PathBase set to the specified value
+func (c *Config) WithPathBase(pathBase string) *Config {
+	return cloneWith(c, func(f *configFields) { f.PathBase = pathBase })
+}
+
+// WithOwnersFile returns a new Config with OwnersFile set to the specified value
+func (c *Config) WithOwnersFile(ownersFile string) *Config {
+	return cloneWith(c, func(f *configFields) { f.OwnersFile = ownersFile })
+}
+
+// WithDocsBaseURL returns a new Config with DocsBaseURL set to the specified value
+func (c *Config) WithDocsBaseURL(docsBaseURL string) *Config {
+	return cloneWith(c, func(f *configFields) { f.DocsBaseURL = docsBaseURL })
+}
+
+// WithSeverities returns a new Config with Severities set to the specified value
+func (c *Config) WithSeverities(severities map[string]string) *Config {
+	return cloneWith(c, func(f *configFields) { f.Severities = severities })
+}
+
+// WithCheckScopes returns a new Config with CheckScopes set to the specified value
+func (c *Config) WithCheckScopes(checkScopes map[string][]string) *Config {
+	return cloneWith(c, func(f *configFields) { f.CheckScopes = checkScopes })
+}
+
+// SeverityOf returns the configured severity of code. An entry for the code
+// itself wins over one for its category (the code without its digits), which
+// wins over "ALL"; unknown severities are errors. Codes without an entry get
+// the default severity of their category, error for most.
+func (c *Config) SeverityOf(code string) string {
+	category := strings.TrimRight(code, "0123456789")
+	for _, key := range []string{code, category, "ALL"} {
+		severity, ok := c.Severities[key]
+		if !ok {
+			continue
+		}
+		switch severity = strings.ToLower(strings.TrimSpace(severity)); severity {
+		case SeverityWarning, SeverityInfo:
+			return severity
+		default:
+			return SeverityError
+		}
+	}
+	return cmp.Or(defaultSeverities[category], SeverityError)
+}
+
+// customOutputFormats holds the output formats added by RegisterOutputFormat
+var customOutputFormats sync.Map
+
+// RegisterOutputFormat makes name an accepted OutputFormat besides the built-in
+// ones. Names are case-insensitive. It reports false when name is empty or
+// already accepted.
+func RegisterOutputFormat(name string) bool {
+	format := strings.ToLower(strings.TrimSpace(name))
+	if format == "" || format == OutputFormatJSON || isOutputFormat(format) {
+		return false
+	}
+	customOutputFormats.Store(format, true)
+	return true
+}
+
+// parseOutputFormat normalizes an --output-format value; an empty value is
+// text and unknown formats are kept for Validate to report
+func parseOutputFormat(s string) string {
+	switch format := strings.ToLower(strings.TrimSpace(s)); format {
+	case "":
+		return OutputFormatText
+	case OutputFormatJSON:
+		return OutputFormatJSONV2
+	default:
+		return format
+	}
+}
+
+// isOutputFormat reports whether format is a built-in or registered output
+// format
+func isOutputFormat(format string) bool {
+	switch format {
+	case OutputFormatText, OutputFormatCheckstyle, OutputFormatJSONV2, OutputFormatCSV:
+		return true
+	}
+	_, ok := customOutputFormats.Load(format)
+	return ok
+}
+
+// parseExperimentalTag trims an --experimental-tag value; an empty tag falls
+// back to DefaultExperimentalTag
+func parseExperimentalTag(s string) string {
+	return cmp.Or(strings.TrimSpace(s), DefaultExperimentalTag)
+}
+
+// parsePathBase normalizes a --path-base value; an empty value is module and
+// unknown bases are kept for Validate to report
+func parsePathBase(s string) string {
+	return cmp.Or(strings.ToLower(strings.TrimSpace(s)), PathBaseModule)
+}
+
+// parseStatsFormat normalizes a --stats-format value; an empty value is text
+// and unknown formats are kept for Validate to report
+func parseStatsFormat(s string) string {
+	return cmp.Or(strings.ToLower(strings.TrimSpace(s)), StatsFormatText)
 }
 
 // parseBool parses a string to boolean
@@ -191,12 +1218,24 @@ func parseBool(s string) bool {
 
 // ShouldSkipFile returns true if the file should be skipped based on configuration
 func (c *Config) ShouldSkipFile(pass *analysis.Pass, file *ast.File) bool {
+	if file == nil || !file.Pos().IsValid() {
+		return true
+	}
 	position := pass.Fset.Position(file.Pos())
 	filename := position.Filename
 
+	// Only Go sources are analyzed. The files cgo generates for a package
+	// (_cgo_gotypes.go and the like) are named after their build cache
+	// entry, without the .go suffix; files cgo rewrites keep the name of
+	// their source through //line directives and are analyzed as usual.
+	if !strings.HasSuffix(filename, ".go") {
+		return true
+	}
+	matchPath := normalizePathForMatch(ModuleRoot(pass), filename)
+
 	// Check exclude paths first (always exclude testdata by default)
 	for _, excludePath := range c.ExcludePaths {
-		if pathContainsSegments(filename, excludePath) {
+		if pathContainsSegments(matchPath, excludePath) {
 			return true // Skip files in excluded paths
 		}
 	}
@@ -239,6 +1278,237 @@ func pathContainsSegments(filename, excludePath string) bool {
 	return false
 }
 
+// ModuleRoot is the directory ExcludePaths, CheckScopes and
+// GeneratedConstructorPatterns are matched relative to: the root of the
+// module pass analyzes. The analysis driver reports the module path but not
+// its directory, so the root is the directory of the package's files with the
+// package path below the module path removed. It is empty when the driver
+// does not report the module or the files do not lie in a directory matching
+// the package path (a vendored or replaced copy, say); paths are then matched
+// as the file set reports them.
+func ModuleRoot(pass *analysis.Pass) string {
+	if pass == nil || pass.Module == nil || pass.Module.Path == "" || pass.Pkg == nil {
+		return ""
+	}
+
+	// An external test package lives in the directory of the package it tests
+	pkgPath := strings.TrimSuffix(pass.Pkg.Path(), "_test")
+	var rel string
+	switch {
+	case pkgPath == pass.Module.Path:
+	case strings.HasPrefix(pkgPath, pass.Module.Path+"/"):
+		rel = strings.TrimPrefix(pkgPath, pass.Module.Path)
+	default:
+		return ""
+	}
+
+	for _, file := range pass.Files {
+		tokFile := pass.Fset.File(file.Pos())
+		if tokFile == nil || !strings.HasSuffix(tokFile.Name(), ".go") || !filepath.IsAbs(tokFile.Name()) {
+			continue
+		}
+		dir := filepath.ToSlash(filepath.Dir(tokFile.Name()))
+		if !strings.HasSuffix(dir, rel) {
+			return ""
+		}
+		return filepath.FromSlash(strings.TrimSuffix(dir, rel))
+	}
+	return ""
+}
+
+// normalizePathForMatch returns filename, as reported by the file set, in the
+// slash-separated form ExcludePaths and CheckScopes are matched against. The
+// path is cleaned but never resolved: a file reached through a symlinked
+// directory is matched under the name the build used, and no link is
+// followed, so link cycles cannot make matching loop. Files below root (when
+// not empty) are made relative to it, so the directories above the module
+// root, such as a checkout in /home/u/testdata/app, never match.
+func normalizePathForMatch(root, filename string) string {
+	cleaned := filepath.Clean(filename)
+	if root != "" && filepath.IsAbs(cleaned) {
+		rel, err := filepath.Rel(filepath.Clean(root), cleaned)
+		if err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			cleaned = rel
+		}
+	}
+	return filepath.ToSlash(cleaned)
+}
+
+// ShouldSkipPackage returns true if diagnostics for the package at pkgPath are
+// disabled by SkipPackages
+func (c *Config) ShouldSkipPackage(pkgPath string) bool {
+	for _, pattern := range c.SkipPackages {
+		if matchPackagePattern(pattern, pkgPath) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsLocalOnly reports whether annotations of kind (a checker name such as
+// "testonly") are kept out of exported facts by LocalOnlyAnnotations. Kinds
+// match case-insensitively, with or without a leading "@".
+func (c *Config) IsLocalOnly(kind string) bool {
+	for _, localOnly := range c.LocalOnlyAnnotations {
+		if strings.EqualFold(strings.TrimPrefix(strings.TrimSpace(localOnly), "@"), kind) {
+			return true
+		}
+	}
+	return false
+}
+
+// IgnoresMessage returns true if message matches one of IgnoreMessagePatterns
+func (c *Config) IgnoresMessage(message string) bool {
+	for _, pattern := range c.IgnoreMessagePatterns {
+		if re := compileMessagePattern(pattern); re != nil && re.MatchString(message) {
+			return true
+		}
+	}
+	return false
+}
+
+// messagePatterns caches compiled IgnoreMessagePatterns, so each pattern is
+// compiled once per process however many findings and packages consult it.
+// An invalid pattern is cached as nil.
+var messagePatterns sync.Map // pattern -> *regexp.Regexp
+
+func compileMessagePattern(pattern string) *regexp.Regexp {
+	if cached, ok := messagePatterns.Load(pattern); ok {
+		return cached.(*regexp.Regexp)
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		re = nil
+	}
+	messagePatterns.Store(pattern, re)
+	return re
+}
+
+// matchPackagePattern matches pkgPath against a "/..." suffix pattern (the
+// package itself and every package below it) or a path.Match glob
+func matchPackagePattern(pattern, pkgPath string) bool {
+	pattern = strings.TrimSpace(pattern)
+	if prefix, ok := strings.CutSuffix(pattern, "/..."); ok {
+		return pkgPath == prefix || strings.HasPrefix(pkgPath, prefix+"/")
+	}
+	matched, err := path.Match(pattern, pkgPath)
+	return err == nil && matched
+}
+
+// checkerNames maps check categories to the checker names accepted as
+// CheckScopes keys
+var checkerNames = map[string]string{
+	"IMM":    "immutable",
+	"MUT":    "immutable",
+	"CTOR":   "constructor",
+	"TONL":   "testonly",
+	"PKGO":   "packageonly",
+	"IMPL":   "implements",
+	"SINCE":  "since",
+	"ENUM":   "enum",
+	"REQ":    "required",
+	"SGL":    "singleton",
+	"RET":    "mustreturn",
+	"EXP":    "experimental",
+	"DEPPKG": "deprecated",
+	"ANN":    "placement",
+}
+
+// InCheckScope reports whether CheckScopes lets code be reported in filename,
+// matched relative to root (see ModuleRoot). Scopes given for the code, its
+// category and its checker name all apply, so a code entry narrows the scope
+// of its checker; without entries a check applies everywhere.
+func (c *Config) InCheckScope(root, code, filename string) bool {
+	if len(c.CheckScopes) == 0 {
+		return true
+	}
+	filename = normalizePathForMatch(root, filename)
+
+	category := strings.TrimRight(code, "0123456789")
+	keys := []string{code}
+	if category != code {
+		keys = append(keys, category)
+	}
+	if name, ok := checkerNames[category]; ok {
+		keys = append(keys, name, "@"+name)
+	}
+
+	for key, globs := range c.CheckScopes {
+		key = strings.TrimSpace(key)
+		if !slices.ContainsFunc(keys, func(k string) bool { return strings.EqualFold(k, key) }) {
+			continue
+		}
+		if !slices.ContainsFunc(globs, func(glob string) bool { return MatchPathGlob(glob, filename) }) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsGeneratedConstructorFile reports whether filename matches one of the
+// GeneratedConstructorPatterns, matched relative to root (see ModuleRoot)
+func (c *Config) IsGeneratedConstructorFile(root, filename string) bool {
+	if len(c.GeneratedConstructorPatterns) == 0 {
+		return false
+	}
+	filename = normalizePathForMatch(root, filename)
+	return slices.ContainsFunc(c.GeneratedConstructorPatterns, func(glob string) bool { return MatchPathGlob(glob, filename) })
+}
+
+// MatchPathGlob matches filename against a slash-separated glob. The glob may
+// start at any directory of filename but must match up to its end; a "**"
+// segment matches any number of segments and other segments use path.Match.
+// "pkg/domain/**" thus matches every file below a pkg/domain directory.
+func MatchPathGlob(glob, filename string) bool {
+	glob = strings.Trim(filepath.ToSlash(strings.TrimSpace(glob)), "/")
+	if glob == "" {
+		return false
+	}
+
+	globSegs := strings.Split(glob, "/")
+	fileSegs := strings.Split(filepath.ToSlash(filename), "/")
+	for start := range fileSegs {
+		if matchSegments(globSegs, fileSegs[start:]) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchSegments(globSegs, fileSegs []string) bool {
+	if len(globSegs) == 0 {
+		return len(fileSegs) == 0
+	}
+	if globSegs[0] == "**" {
+		for skip := 0; skip <= len(fileSegs); skip++ {
+			if matchSegments(globSegs[1:], fileSegs[skip:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(fileSegs) == 0 {
+		return false
+	}
+	matched, err := path.Match(globSegs[0], fileSegs[0])
+	return err == nil && matched && matchSegments(globSegs[1:], fileSegs[1:])
+}
+
+// FilterFilesForCheck is FilterFiles further restricted to the files inside
+// the CheckScopes of the check category
+func (c *Config) FilterFilesForCheck(pass *analysis.Pass, category string) iter.Seq[*ast.File] {
+	return func(yield func(*ast.File) bool) {
+		for file := range c.FilterFiles(pass) {
+			if !c.InCheckScope(ModuleRoot(pass), category, pass.Fset.Position(file.Pos()).Filename) {
+				continue
+			}
+			if !yield(file) {
+				return
+			}
+		}
+	}
+}
+
 // FilterFiles returns only the files that should be analyzed based on configuration
 func (c *Config) FilterFiles(pass *analysis.Pass) iter.Seq[*ast.File] {
 
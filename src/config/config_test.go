@@ -3,9 +3,18 @@ package config
 import (
 	"bytes"
 	"encoding/gob"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis"
 )
 
 func TestPathContainsSegments(t *testing.T) {
@@ -31,6 +40,112 @@ func TestPathContainsSegments(t *testing.T) {
 	}
 }
 
+func TestNormalizePathForMatch(t *testing.T) {
+	sep := string(filepath.Separator)
+	root := filepath.Join(sep, "home", "u", "testdata", "app")
+
+	assert.Equal(t, "pkg/x.go", normalizePathForMatch(root, filepath.Join(root, "pkg", "x.go")))
+	assert.Equal(t, "pkg/x.go", normalizePathForMatch(root+sep, filepath.Join(root, "pkg", ".", "sub", "..", "x.go")))
+	assert.Equal(t, "/other/x.go", normalizePathForMatch(root, filepath.Join(sep, "other", "x.go")), "files outside the root keep their path")
+	assert.Equal(t, "/home/u/testdata/appx/x.go", normalizePathForMatch(root, root+"x"+sep+"x.go"), "a sibling sharing the prefix is outside the root")
+	assert.Equal(t, "/home/u/testdata/app/x.go", normalizePathForMatch("", filepath.Join(root, "x.go")), "no root leaves the path absolute")
+
+	assert.False(t, pathContainsSegments(normalizePathForMatch(root, filepath.Join(root, "pkg", "x.go")), "testdata"),
+		"directories above the module root do not match")
+}
+
+func TestNormalizePathForMatchSymlinks(t *testing.T) {
+	root := t.TempDir()
+	target := filepath.Join(t.TempDir(), "generated")
+	require.NoError(t, os.MkdirAll(filepath.Join(target, "models"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(target, "models", "x.go"), []byte("package models\n"), 0o644))
+
+	if err := os.Symlink(target, filepath.Join(root, "gen")); err != nil {
+		t.Skipf("symlinks not supported: %v", err)
+	}
+	// A link back to its own directory: resolving paths below it never ends
+	require.NoError(t, os.Symlink(root, filepath.Join(root, "loop")))
+
+	t.Run("symlinked directory matches under its link name", func(t *testing.T) {
+		filename := filepath.Join(root, "gen", "models", "x.go")
+		matchPath := normalizePathForMatch(root, filename)
+
+		assert.Equal(t, "gen/models/x.go", matchPath)
+		assert.True(t, pathContainsSegments(matchPath, "gen"))
+		assert.True(t, pathContainsSegments(matchPath, "gen/models"))
+		assert.False(t, pathContainsSegments(matchPath, "generated"), "the link target is not consulted")
+	})
+
+	t.Run("link cycles are not followed", func(t *testing.T) {
+		filename := filepath.Join(root, "loop", "loop", "loop", "gen", "models", "x.go")
+		matchPath := normalizePathForMatch(root, filename)
+
+		assert.Equal(t, "loop/loop/loop/gen/models/x.go", matchPath)
+		assert.True(t, pathContainsSegments(matchPath, "gen/models"))
+		assert.True(t, MatchPathGlob("loop/**/models/*.go", matchPath))
+	})
+}
+
+// modulePass returns a pass over one file at filename of package pkgPath in
+// module modPath; modPath "" leaves the module unreported
+func modulePass(t *testing.T, modPath, pkgPath, filename string) *analysis.Pass {
+	t.Helper()
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, filepath.FromSlash(filename), "package "+path.Base(pkgPath)+"\n", 0)
+	require.NoError(t, err)
+
+	pass := &analysis.Pass{Fset: fset, Files: []*ast.File{file}, Pkg: types.NewPackage(pkgPath, file.Name.Name)}
+	if modPath != "" {
+		pass.Module = &analysis.Module{Path: modPath}
+	}
+	return pass
+}
+
+func TestModuleRoot(t *testing.T) {
+	tests := []struct {
+		name     string
+		modPath  string
+		pkgPath  string
+		filename string
+		want     string
+	}{
+		{"nested package", "example.com/app", "example.com/app/pkg/domain", "/work/app/pkg/domain/x.go", "/work/app"},
+		{"root package", "example.com/app", "example.com/app", "/work/app/main.go", "/work/app"},
+		{"external test package", "example.com/app", "example.com/app/pkg_test", "/work/app/pkg/x_test.go", "/work/app"},
+		{"no module reported", "", "example.com/app/pkg", "/work/app/pkg/x.go", ""},
+		{"package outside the module", "example.com/app", "example.com/other", "/work/app/other/x.go", ""},
+		{"directory not matching the package path", "example.com/app", "example.com/app/pkg", "/vendor/copy/x.go", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pass := modulePass(t, tt.modPath, tt.pkgPath, tt.filename)
+			assert.Equal(t, filepath.FromSlash(tt.want), ModuleRoot(pass))
+		})
+	}
+	assert.Empty(t, ModuleRoot(nil))
+}
+
+func TestShouldSkipFileMatchesFromModuleRoot(t *testing.T) {
+	// The module sits below a testdata directory and the working directory
+	// is elsewhere: only the path inside the module is matched
+	cfg := Empty().WithExcludePaths([]string{"testdata"})
+	pass := modulePass(t, "example.com/app", "example.com/app/pkg", "/home/u/testdata/app/pkg/x.go")
+	assert.False(t, cfg.ShouldSkipFile(pass, pass.Files[0]))
+
+	pass = modulePass(t, "example.com/app", "example.com/app/pkg/testdata", "/home/u/testdata/app/pkg/testdata/x.go")
+	assert.True(t, cfg.ShouldSkipFile(pass, pass.Files[0]))
+
+	// Without the module the raw path is matched
+	pass = modulePass(t, "", "example.com/app/pkg", "/home/u/testdata/app/pkg/x.go")
+	assert.True(t, cfg.ShouldSkipFile(pass, pass.Files[0]))
+
+	scoped := Empty().WithCheckScopes(map[string][]string{"immutable": {"pkg/*.go"}})
+	pass = modulePass(t, "example.com/app", "example.com/app/pkg", "/work/app/pkg/x.go")
+	assert.True(t, scoped.InCheckScope(ModuleRoot(pass), "IMM01", "/work/app/pkg/x.go"))
+	assert.False(t, scoped.InCheckScope(ModuleRoot(pass), "IMM01", "/work/app/pkg/sub/x.go"))
+}
+
 func TestDefault(t *testing.T) {
 	cfg := Default()
 
@@ -222,6 +337,437 @@ func TestFromEnv(t *testing.T) {
 	})
 }
 
+func TestGlobalIgnoreCodes(t *testing.T) {
+	t.Run("defaults to empty", func(t *testing.T) {
+		assert.Equal(t, []string{}, FromEnv().GlobalIgnoreCodes)
+		assert.Equal(t, []string{}, Empty().GlobalIgnoreCodes)
+	})
+
+	t.Run("parsed from env and uppercased", func(t *testing.T) {
+		t.Setenv("GOGREEMENT_GLOBAL_IGNORE_CODES", "imm, ctor01")
+
+		cfg := FromEnv()
+		assert.Equal(t, []string{"IMM", "CTOR01"}, cfg.GlobalIgnoreCodes)
+	})
+
+	t.Run("parsed from flag", func(t *testing.T) {
+		fs := CreateFlagSet()
+		require.NoError(t, fs.Set("global-ignore-codes", "tonl,pkgo01"))
+
+		cfg := ParseFlagsFromFlagSet(fs)
+		assert.Equal(t, []string{"TONL", "PKGO01"}, cfg.GlobalIgnoreCodes)
+	})
+
+	t.Run("With methods preserve other fields", func(t *testing.T) {
+		cfg := New(false, []string{"vendor"}, []string{"IMM01"}).WithGlobalIgnoreCodes([]string{"CTOR"})
+		modified := cfg.WithScanTests(true).WithExcludePaths([]string{"gen"}).WithExcludeChecks([]string{})
+
+		assert.Equal(t, []string{"CTOR"}, modified.GlobalIgnoreCodes)
+		assert.True(t, modified.ScanTests)
+		assert.Equal(t, []string{"gen"}, modified.ExcludePaths)
+		assert.Equal(t, []string{"IMM01"}, cfg.ExcludeChecks, "original should remain unchanged")
+	})
+}
+
+func TestVerboseImplements(t *testing.T) {
+	assert.False(t, FromEnv().VerboseImplements, "verbose output is off by default")
+
+	t.Run("parsed from env", func(t *testing.T) {
+		t.Setenv("GOGREEMENT_VERBOSE_IMPLEMENTS", "yes")
+		assert.True(t, FromEnv().VerboseImplements)
+	})
+
+	t.Run("parsed from flag", func(t *testing.T) {
+		fs := CreateFlagSet()
+		require.NoError(t, fs.Set("verbose-implements", "true"))
+		assert.True(t, ParseFlagsFromFlagSet(fs).VerboseImplements)
+	})
+}
+
+func TestCheckSince(t *testing.T) {
+	assert.False(t, FromEnv().CheckSince, "@since validation is off by default")
+
+	t.Run("parsed from env", func(t *testing.T) {
+		t.Setenv("GOGREEMENT_CHECK_SINCE", "on")
+		assert.True(t, FromEnv().CheckSince)
+	})
+
+	t.Run("parsed from flag", func(t *testing.T) {
+		fs := CreateFlagSet()
+		require.NoError(t, fs.Set("check-since", "true"))
+		assert.True(t, ParseFlagsFromFlagSet(fs).CheckSince)
+	})
+}
+
+func TestValidateIgnoreCodes(t *testing.T) {
+	assert.False(t, FromEnv().ValidateIgnoreCodes, "@ignore code validation is off by default")
+
+	t.Run("parsed from env", func(t *testing.T) {
+		t.Setenv("GOGREEMENT_VALIDATE_IGNORE_CODES", "true")
+		assert.True(t, FromEnv().ValidateIgnoreCodes)
+	})
+
+	t.Run("parsed from flag", func(t *testing.T) {
+		fs := CreateFlagSet()
+		require.NoError(t, fs.Set("validate-ignore-codes", "true"))
+		assert.True(t, ParseFlagsFromFlagSet(fs).ValidateIgnoreCodes)
+	})
+}
+
+func TestSuggestIgnores(t *testing.T) {
+	assert.False(t, FromEnv().SuggestIgnores, "@ignore suggestions are off by default")
+
+	t.Run("parsed from env", func(t *testing.T) {
+		t.Setenv("GOGREEMENT_SUGGEST_IGNORES", "true")
+		assert.True(t, FromEnv().SuggestIgnores)
+	})
+
+	t.Run("parsed from flag", func(t *testing.T) {
+		fs := CreateFlagSet()
+		require.NoError(t, fs.Set("suggest-ignores", "true"))
+		assert.True(t, ParseFlagsFromFlagSet(fs).SuggestIgnores)
+	})
+}
+
+func TestMaxFindingsPerFile(t *testing.T) {
+	assert.Equal(t, 0, FromEnv().MaxFindingsPerFile, "findings are unlimited by default")
+
+	t.Run("parsed from env", func(t *testing.T) {
+		t.Setenv("GOGREEMENT_MAX_FINDINGS_PER_FILE", "5")
+		assert.Equal(t, 5, FromEnv().MaxFindingsPerFile)
+	})
+
+	t.Run("invalid env value keeps the default", func(t *testing.T) {
+		t.Setenv("GOGREEMENT_MAX_FINDINGS_PER_FILE", "many")
+		assert.Equal(t, 0, FromEnv().MaxFindingsPerFile)
+	})
+
+	t.Run("parsed from flag", func(t *testing.T) {
+		fs := CreateFlagSet()
+		require.NoError(t, fs.Set("max-findings-per-file", "3"))
+		assert.Equal(t, 3, ParseFlagsFromFlagSet(fs).MaxFindingsPerFile)
+	})
+}
+
+func TestWarningsExitCode(t *testing.T) {
+	assert.Equal(t, -1, FromEnv().WarningsExitCode, "warnings exit like errors by default")
+
+	t.Run("parsed from env", func(t *testing.T) {
+		t.Setenv("GOGREEMENT_WARNINGS_EXIT_CODE", "0")
+		assert.Equal(t, 0, FromEnv().WarningsExitCode)
+	})
+
+	t.Run("invalid env value keeps the default", func(t *testing.T) {
+		t.Setenv("GOGREEMENT_WARNINGS_EXIT_CODE", "none")
+		assert.Equal(t, -1, FromEnv().WarningsExitCode)
+	})
+
+	t.Run("parsed from flag", func(t *testing.T) {
+		fs := CreateFlagSet()
+		require.NoError(t, fs.Set("warnings-exit-code", "4"))
+		assert.Equal(t, 4, ParseFlagsFromFlagSet(fs).WarningsExitCode)
+	})
+}
+
+func TestCheckPointees(t *testing.T) {
+	assert.False(t, FromEnv().CheckPointees, "pointee advisories are opt-in")
+
+	t.Setenv("GOGREEMENT_CHECK_POINTEES", "true")
+	assert.True(t, FromEnv().CheckPointees)
+
+	fs := CreateFlagSet()
+	require.NoError(t, fs.Set("check-pointees", "false"))
+	assert.False(t, ParseFlagsFromFlagSet(fs).CheckPointees)
+}
+
+func TestWarnAnonymousImmutableAssignment(t *testing.T) {
+	assert.False(t, FromEnv().WarnAnonymousImmutableAssignment, "anonymous assignment advisories are opt-in")
+
+	t.Setenv("GOGREEMENT_WARN_ANONYMOUS_IMMUTABLE_ASSIGNMENT", "true")
+	assert.True(t, FromEnv().WarnAnonymousImmutableAssignment)
+
+	fs := CreateFlagSet()
+	require.NoError(t, fs.Set("warn-anonymous-immutable-assignment", "true"))
+	assert.True(t, ParseFlagsFromFlagSet(fs).WarnAnonymousImmutableAssignment)
+}
+
+func TestPublicOnly(t *testing.T) {
+	assert.False(t, FromEnv().PublicOnly, "every declaration is checked by default")
+
+	t.Setenv("GOGREEMENT_PUBLIC_ONLY", "true")
+	assert.True(t, FromEnv().PublicOnly)
+
+	fs := CreateFlagSet()
+	require.NoError(t, fs.Set("public-only", "true"))
+	assert.True(t, ParseFlagsFromFlagSet(fs).PublicOnly)
+}
+
+func TestRequireImplementsAnnotation(t *testing.T) {
+	cfg := FromEnv()
+	assert.False(t, cfg.RequireImplementsAnnotation, "required annotations are opt-in")
+	assert.Equal(t, []string{"fmt.Stringer", "io.Reader", "io.Writer", "io.Closer"}, cfg.RequiredInterfaces)
+
+	t.Setenv("GOGREEMENT_REQUIRE_IMPLEMENTS_ANNOTATION", "true")
+	t.Setenv("GOGREEMENT_REQUIRED_INTERFACES", "io.Reader, example.com/api.Handler")
+	cfg = FromEnv()
+	assert.True(t, cfg.RequireImplementsAnnotation)
+	assert.Equal(t, []string{"io.Reader", "example.com/api.Handler"}, cfg.RequiredInterfaces)
+
+	fs := CreateFlagSet()
+	require.NoError(t, fs.Set("require-implements-annotation", "true"))
+	require.NoError(t, fs.Set("required-interfaces", "error"))
+	cfg = ParseFlagsFromFlagSet(fs)
+	assert.True(t, cfg.RequireImplementsAnnotation)
+	assert.Equal(t, []string{"error"}, cfg.RequiredInterfaces)
+}
+
+func TestMutatingFunctions(t *testing.T) {
+	cfg := FromEnv()
+	assert.Contains(t, cfg.MutatingFunctions, "encoding/json.Unmarshal")
+	assert.Contains(t, cfg.MutatingFunctions, "(*encoding/json.Decoder).Decode")
+
+	t.Setenv("GOGREEMENT_MUTATING_FUNCTIONS", "fmt.Sscan, example.com/codec.Decode")
+	assert.Equal(t, []string{"fmt.Sscan", "example.com/codec.Decode"}, FromEnv().MutatingFunctions)
+
+	fs := CreateFlagSet()
+	require.NoError(t, fs.Set("mutating-functions", "encoding/binary.Read"))
+	assert.Equal(t, []string{"encoding/binary.Read"}, ParseFlagsFromFlagSet(fs).MutatingFunctions)
+}
+
+func TestInPlaceFunctions(t *testing.T) {
+	cfg := FromEnv()
+	assert.Contains(t, cfg.InPlaceFunctions, "slices.Sort")
+	assert.Contains(t, cfg.InPlaceFunctions, "sort.Strings")
+
+	t.Setenv("GOGREEMENT_IN_PLACE_FUNCTIONS", "slices.Reverse, example.com/order.Shuffle")
+	assert.Equal(t, []string{"slices.Reverse", "example.com/order.Shuffle"}, FromEnv().InPlaceFunctions)
+
+	fs := CreateFlagSet()
+	require.NoError(t, fs.Set("in-place-functions", "sort.Ints"))
+	assert.Equal(t, []string{"sort.Ints"}, ParseFlagsFromFlagSet(fs).InPlaceFunctions)
+}
+
+func TestFailFast(t *testing.T) {
+	assert.False(t, FromEnv().FailFast, "every finding is reported by default")
+
+	t.Setenv("GOGREEMENT_FAIL_FAST", "true")
+	assert.True(t, FromEnv().FailFast)
+
+	fs := CreateFlagSet()
+	require.NoError(t, fs.Set("fail-fast", "false"))
+	assert.False(t, ParseFlagsFromFlagSet(fs).FailFast)
+}
+
+func TestDocsBaseURL(t *testing.T) {
+	assert.Empty(t, FromEnv().DocsBaseURL, "findings link to the book by default")
+
+	t.Setenv("GOGREEMENT_DOCS_BASE_URL", " https://wiki.example.com/gogreement ")
+	assert.Equal(t, "https://wiki.example.com/gogreement", FromEnv().DocsBaseURL)
+
+	fs := CreateFlagSet()
+	require.NoError(t, fs.Set("docs-base-url", "https://docs.example.com/rules"))
+	assert.Equal(t, "https://docs.example.com/rules", ParseFlagsFromFlagSet(fs).DocsBaseURL)
+}
+
+func TestOwnersFile(t *testing.T) {
+	assert.Empty(t, FromEnv().OwnersFile, "findings have no owner by default")
+
+	t.Setenv("GOGREEMENT_OWNERS", " owners.json ")
+	assert.Equal(t, "owners.json", FromEnv().OwnersFile)
+
+	fs := CreateFlagSet()
+	require.NoError(t, fs.Set("owners", "teams.json"))
+	assert.Equal(t, "teams.json", ParseFlagsFromFlagSet(fs).OwnersFile)
+}
+
+func TestPathBase(t *testing.T) {
+	assert.Equal(t, PathBaseModule, FromEnv().PathBase, "module-relative paths by default")
+
+	t.Setenv("GOGREEMENT_PATH_BASE", "CWD")
+	assert.Equal(t, PathBaseCwd, FromEnv().PathBase)
+
+	fs := CreateFlagSet()
+	require.NoError(t, fs.Set("path-base", "absolute"))
+	assert.Equal(t, PathBaseAbsolute, ParseFlagsFromFlagSet(fs).PathBase)
+
+	require.NoError(t, fs.Set("path-base", "repo"))
+	assert.Equal(t, "repo", ParseFlagsFromFlagSet(fs).PathBase, "unknown bases are kept for Validate to report")
+}
+
+func TestStableMessages(t *testing.T) {
+	assert.False(t, FromEnv().StableMessages, "pretty messages by default")
+
+	t.Setenv("GOGREEMENT_STABLE_MESSAGES", "true")
+	assert.True(t, FromEnv().StableMessages)
+
+	fs := CreateFlagSet()
+	require.NoError(t, fs.Set("stable-messages", "false"))
+	assert.False(t, ParseFlagsFromFlagSet(fs).StableMessages)
+}
+
+func TestTagTestViolations(t *testing.T) {
+	assert.False(t, FromEnv().TagTestViolations, "findings are not tagged by default")
+
+	t.Setenv("GOGREEMENT_TAG_TEST_VIOLATIONS", "true")
+	assert.True(t, FromEnv().TagTestViolations)
+
+	fs := CreateFlagSet()
+	require.NoError(t, fs.Set("tag-test-violations", "false"))
+	assert.False(t, ParseFlagsFromFlagSet(fs).TagTestViolations)
+}
+
+func TestExperimentalTag(t *testing.T) {
+	assert.Equal(t, DefaultExperimentalTag, FromEnv().ExperimentalTag)
+
+	t.Setenv("GOGREEMENT_EXPERIMENTAL_TAG", " unstable ")
+	assert.Equal(t, "unstable", FromEnv().ExperimentalTag)
+
+	fs := CreateFlagSet()
+	require.NoError(t, fs.Set("experimental-tag", "preview"))
+	assert.Equal(t, "preview", ParseFlagsFromFlagSet(fs).ExperimentalTag)
+
+	require.NoError(t, fs.Set("experimental-tag", ""))
+	assert.Equal(t, DefaultExperimentalTag, ParseFlagsFromFlagSet(fs).ExperimentalTag, "an empty tag falls back to the default")
+}
+
+func TestStats(t *testing.T) {
+	cfg := FromEnv()
+	assert.False(t, cfg.Stats, "stats are off by default")
+	assert.Equal(t, StatsFormatText, cfg.StatsFormat)
+
+	t.Run("parsed from env", func(t *testing.T) {
+		t.Setenv("GOGREEMENT_STATS", "true")
+		t.Setenv("GOGREEMENT_STATS_FORMAT", "JSON")
+		cfg := FromEnv()
+		assert.True(t, cfg.Stats)
+		assert.Equal(t, StatsFormatJSON, cfg.StatsFormat)
+	})
+
+	t.Run("unknown format is kept for Validate to report", func(t *testing.T) {
+		t.Setenv("GOGREEMENT_STATS_FORMAT", "YAML")
+		assert.Equal(t, "yaml", FromEnv().StatsFormat)
+	})
+
+	t.Run("parsed from flag", func(t *testing.T) {
+		fs := CreateFlagSet()
+		require.NoError(t, fs.Set("stats", "true"))
+		require.NoError(t, fs.Set("stats-format", "json"))
+		cfg := ParseFlagsFromFlagSet(fs)
+		assert.True(t, cfg.Stats)
+		assert.Equal(t, StatsFormatJSON, cfg.StatsFormat)
+	})
+}
+
+func TestTraceContracts(t *testing.T) {
+	assert.False(t, FromEnv().TraceContracts, "tracing is off by default")
+
+	t.Setenv("GOGREEMENT_TRACE_CONTRACTS", "true")
+	assert.True(t, FromEnv().TraceContracts)
+
+	fs := CreateFlagSet()
+	require.NoError(t, fs.Set("trace-contracts", "true"))
+	assert.True(t, ParseFlagsFromFlagSet(fs).TraceContracts)
+}
+
+func TestEmitInventory(t *testing.T) {
+	assert.False(t, FromEnv().EmitInventory, "the inventory is off by default")
+
+	t.Setenv("GOGREEMENT_EMIT_INVENTORY", "true")
+	assert.True(t, FromEnv().EmitInventory)
+
+	fs := CreateFlagSet()
+	require.NoError(t, fs.Set("emit-inventory", "true"))
+	assert.True(t, ParseFlagsFromFlagSet(fs).EmitInventory)
+}
+
+func TestShouldSkipPackage(t *testing.T) {
+	cfg := Empty().WithSkipPackages([]string{"example.com/gen/...", "example.com/mirror/*", "example.com/exact"})
+
+	tests := []struct {
+		pkgPath  string
+		expected bool
+	}{
+		{"example.com/gen", true},
+		{"example.com/gen/client", true},
+		{"example.com/gen/client/v2", true},
+		{"example.com/generator", false},
+		{"example.com/mirror/lib", true},
+		{"example.com/mirror/lib/sub", false},
+		{"example.com/exact", true},
+		{"example.com/exact/sub", false},
+		{"example.com/app", false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, cfg.ShouldSkipPackage(tt.pkgPath), tt.pkgPath)
+	}
+
+	assert.False(t, Default().ShouldSkipPackage("example.com/gen"), "no packages are skipped by default")
+
+	t.Run("parsed from env", func(t *testing.T) {
+		t.Setenv("GOGREEMENT_SKIP_PACKAGES", "example.com/gen/..., example.com/mirror")
+		assert.Equal(t, []string{"example.com/gen/...", "example.com/mirror"}, FromEnv().SkipPackages)
+	})
+
+	t.Run("parsed from flag", func(t *testing.T) {
+		fs := CreateFlagSet()
+		require.NoError(t, fs.Set("skip-packages", "example.com/gen/..."))
+		assert.True(t, ParseFlagsFromFlagSet(fs).ShouldSkipPackage("example.com/gen/client"))
+	})
+}
+
+func TestIsLocalOnly(t *testing.T) {
+	cfg := Empty().WithLocalOnlyAnnotations([]string{"TestOnly", " @since "})
+
+	assert.True(t, cfg.IsLocalOnly("testonly"))
+	assert.True(t, cfg.IsLocalOnly("since"))
+	assert.False(t, cfg.IsLocalOnly("immutable"))
+	assert.False(t, Default().IsLocalOnly("testonly"), "every annotation is exported by default")
+
+	t.Run("parsed from env", func(t *testing.T) {
+		t.Setenv("GOGREEMENT_LOCAL_ONLY_ANNOTATIONS", "testonly, enum")
+		assert.Equal(t, []string{"testonly", "enum"}, FromEnv().LocalOnlyAnnotations)
+	})
+
+	t.Run("parsed from flag", func(t *testing.T) {
+		fs := CreateFlagSet()
+		require.NoError(t, fs.Set("local-only-annotations", "constructor"))
+		assert.True(t, ParseFlagsFromFlagSet(fs).IsLocalOnly("constructor"))
+	})
+}
+
+func TestIgnoresMessage(t *testing.T) {
+	cfg := Empty().WithIgnoreMessagePatterns([]string{`field "legacy.*"`, `^advisory:`, `(unclosed`})
+
+	tests := []struct {
+		message  string
+		expected bool
+	}{
+		{`immutability violation in type "User": cannot assign to field "legacyName" of immutable type`, true},
+		{`advisory: every field is marked @mutable`, true},
+		{`immutability violation in type "User": cannot assign to field "name" of immutable type`, false},
+		{`not an advisory: anchored patterns match the start only`, false},
+		{`(unclosed`, false},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, cfg.IgnoresMessage(tt.message), tt.message)
+	}
+
+	assert.False(t, Default().IgnoresMessage(`cannot assign to field "legacyName"`), "no messages are ignored by default")
+
+	t.Run("parsed from env", func(t *testing.T) {
+		t.Setenv("GOGREEMENT_IGNORE_MESSAGE_PATTERNS", `field "legacy.*", ^advisory:`)
+		assert.Equal(t, []string{`field "legacy.*"`, `^advisory:`}, FromEnv().IgnoreMessagePatterns)
+	})
+
+	t.Run("parsed from flag", func(t *testing.T) {
+		fs := CreateFlagSet()
+		require.NoError(t, fs.Set("ignore-message-patterns", `field "legacy.*"`))
+		assert.True(t, ParseFlagsFromFlagSet(fs).IgnoresMessage(`cannot assign to field "legacyName"`))
+	})
+}
+
 func TestParseBool(t *testing.T) {
 	tests := []struct {
 		input    string
@@ -358,3 +904,124 @@ func TestConfigGobSerialization(t *testing.T) {
 		assert.Equal(t, []string(nil), deserialized.ExcludeChecks) // gob converts empty slice to nil
 	})
 }
+
+func TestOutputFormat(t *testing.T) {
+	assert.Equal(t, OutputFormatText, FromEnv().OutputFormat)
+
+	t.Run("parsed from env", func(t *testing.T) {
+		t.Setenv("GOGREEMENT_OUTPUT_FORMAT", "Checkstyle")
+		assert.Equal(t, OutputFormatCheckstyle, FromEnv().OutputFormat)
+	})
+
+	t.Run("unknown format is kept for Validate to report", func(t *testing.T) {
+		t.Setenv("GOGREEMENT_OUTPUT_FORMAT", "Sarif")
+		assert.Equal(t, "sarif", FromEnv().OutputFormat)
+	})
+
+	t.Run("parsed from flag", func(t *testing.T) {
+		fs := CreateFlagSet()
+		require.NoError(t, fs.Set("output-format", "checkstyle"))
+		assert.Equal(t, OutputFormatCheckstyle, ParseFlagsFromFlagSet(fs).OutputFormat)
+	})
+
+	t.Run("json-v2", func(t *testing.T) {
+		fs := CreateFlagSet()
+		require.NoError(t, fs.Set("output-format", "JSON-v2"))
+		assert.Equal(t, OutputFormatJSONV2, ParseFlagsFromFlagSet(fs).OutputFormat)
+	})
+
+	t.Run("json is an alias of json-v2", func(t *testing.T) {
+		t.Setenv("GOGREEMENT_OUTPUT_FORMAT", "json")
+		assert.Equal(t, OutputFormatJSONV2, FromEnv().OutputFormat)
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		fs := CreateFlagSet()
+		require.NoError(t, fs.Set("output-format", "csv"))
+		assert.Equal(t, OutputFormatCSV, ParseFlagsFromFlagSet(fs).OutputFormat)
+	})
+
+	t.Run("registered format", func(t *testing.T) {
+		assert.True(t, RegisterOutputFormat("Config-Test-Format"))
+		assert.False(t, RegisterOutputFormat("config-test-format"), "already registered")
+		assert.False(t, RegisterOutputFormat("checkstyle"), "built in")
+		assert.False(t, RegisterOutputFormat("json"), "alias of a built-in format")
+		assert.False(t, RegisterOutputFormat(""))
+
+		t.Setenv("GOGREEMENT_OUTPUT_FORMAT", "CONFIG-TEST-FORMAT")
+		assert.Equal(t, "config-test-format", FromEnv().OutputFormat)
+	})
+}
+
+func TestSeverityOf(t *testing.T) {
+	cfg := Empty().WithSeverities(map[string]string{
+		"IMM":    "warning",
+		"IMM02":  "info",
+		"CTOR01": "fatal",
+	})
+
+	assert.Equal(t, SeverityWarning, cfg.SeverityOf("IMM01"), "category applies to its codes")
+	assert.Equal(t, SeverityInfo, cfg.SeverityOf("IMM02"), "code wins over its category")
+	assert.Equal(t, SeverityError, cfg.SeverityOf("CTOR01"), "unknown severities are errors")
+	assert.Equal(t, SeverityError, cfg.SeverityOf("TONL01"), "unconfigured codes are errors")
+	assert.Equal(t, SeverityInfo, cfg.WithSeverities(map[string]string{"ALL": "info"}).SeverityOf("TONL01"))
+
+	assert.Equal(t, SeverityWarning, cfg.SeverityOf("EXP01"), "experimental findings are warnings by default")
+	assert.Equal(t, SeverityError, cfg.WithSeverities(map[string]string{"EXP": "error"}).SeverityOf("EXP01"))
+}
+
+func TestInCheckScope(t *testing.T) {
+	cfg := Empty().WithCheckScopes(map[string][]string{
+		"immutable": {"pkg/domain/**"},
+		"TONL":      {"cmd/**"},
+		"IMM04":     {"pkg/domain/orders/*.go"},
+	})
+
+	tests := []struct {
+		code     string
+		filename string
+		expected bool
+	}{
+		{"IMM01", "/src/app/pkg/domain/order.go", true},
+		{"IMM01", "/src/app/pkg/domain/orders/line.go", true},
+		{"IMM01", "/src/app/internal/handlers/handler.go", false},
+		{"IMM04", "/src/app/pkg/domain/orders/line.go", true},
+		{"IMM04", "/src/app/pkg/domain/order.go", false}, // code entry narrows the checker scope
+		{"TONL01", "/src/app/cmd/tool/main.go", true},
+		{"TONL01", "/src/app/pkg/domain/order.go", false},
+		{"CTOR01", "/src/app/internal/handlers/handler.go", true}, // unscoped checks apply everywhere
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.code+" "+tt.filename, func(t *testing.T) {
+			assert.Equal(t, tt.expected, cfg.InCheckScope("", tt.code, tt.filename))
+		})
+	}
+
+	assert.True(t, Empty().InCheckScope("", "IMM01", "/anywhere/file.go"))
+}
+
+func TestMatchPathGlob(t *testing.T) {
+	assert.True(t, MatchPathGlob("**", "/a/b.go"))
+	assert.True(t, MatchPathGlob("pkg/**", "/src/pkg/b.go"))
+	assert.True(t, MatchPathGlob("*_gen.go", "/src/pkg/model_gen.go"))
+	assert.False(t, MatchPathGlob("pkg/*", "/src/pkg/sub/b.go"))
+	assert.False(t, MatchPathGlob("pkg/**", "/src/pkgx/b.go"))
+	assert.False(t, MatchPathGlob("", "/src/pkg/b.go"))
+}
+
+func TestGeneratedConstructorPatterns(t *testing.T) {
+	assert.Empty(t, FromEnv().GeneratedConstructorPatterns)
+	assert.False(t, FromEnv().IsGeneratedConstructorFile("", "internal/api/widget_gen.go"))
+
+	t.Setenv("GOGREEMENT_GENERATED_CONSTRUCTOR_PATTERNS", "**/*_gen.go, zz_generated.*.go")
+	cfg := FromEnv()
+	assert.Equal(t, []string{"**/*_gen.go", "zz_generated.*.go"}, cfg.GeneratedConstructorPatterns)
+	assert.True(t, cfg.IsGeneratedConstructorFile("", "internal/api/widget_gen.go"))
+	assert.True(t, cfg.IsGeneratedConstructorFile("", "api/zz_generated.deepcopy.go"))
+	assert.False(t, cfg.IsGeneratedConstructorFile("", "internal/api/widget.go"))
+
+	fs := CreateFlagSet()
+	require.NoError(t, fs.Set("generated-constructor-patterns", "gen/**"))
+	assert.Equal(t, []string{"gen/**"}, ParseFlagsFromFlagSet(fs).GeneratedConstructorPatterns)
+}
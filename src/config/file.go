@@ -0,0 +1,242 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileName is the name of the gogreement config file looked up in the project root
+const FileName = ".gogreement.json"
+
+// starterComment is written into the starter config file. JSON has no comment
+// syntax, so the conventional "//" key carries it.
+const starterComment = "gogreement configuration. See https://a14e.github.io/gogreement/ for all options."
+
+// ErrConfigFileExists is returned by WriteStarterFile when the target file exists
+// and overwriting was not requested
+var ErrConfigFileExists = errors.New("config file already exists")
+
+// File is the on-disk representation of the gogreement configuration
+type File struct {
+	Comment string `json:"//,omitempty"`
+
+	// ScanTests mirrors Config.ScanTests
+	ScanTests bool `json:"scanTests"`
+
+	// ExcludePaths mirrors Config.ExcludePaths
+	ExcludePaths []string `json:"excludePaths"`
+
+	// ExcludeChecks mirrors Config.ExcludeChecks
+	ExcludeChecks []string `json:"excludeChecks"`
+
+	// GlobalIgnoreCodes mirrors Config.GlobalIgnoreCodes
+	GlobalIgnoreCodes []string `json:"globalIgnoreCodes"`
+
+	// SkipPackages mirrors Config.SkipPackages
+	SkipPackages []string `json:"skipPackages"`
+
+	// LocalOnlyAnnotations mirrors Config.LocalOnlyAnnotations
+	LocalOnlyAnnotations []string `json:"localOnlyAnnotations"`
+
+	// IgnoreMessagePatterns mirrors Config.IgnoreMessagePatterns
+	IgnoreMessagePatterns []string `json:"ignoreMessagePatterns"`
+
+	// VerboseImplements mirrors Config.VerboseImplements
+	VerboseImplements bool `json:"verboseImplements"`
+
+	// CheckSince mirrors Config.CheckSince
+	CheckSince bool `json:"checkSince"`
+
+	// ValidateIgnoreCodes mirrors Config.ValidateIgnoreCodes
+	ValidateIgnoreCodes bool `json:"validateIgnoreCodes"`
+
+	// CheckPointees mirrors Config.CheckPointees
+	CheckPointees bool `json:"checkPointees"`
+
+	// WarnAnonymousImmutableAssignment mirrors Config.WarnAnonymousImmutableAssignment
+	WarnAnonymousImmutableAssignment bool `json:"warnAnonymousImmutableAssignment"`
+
+	// PublicOnly mirrors Config.PublicOnly
+	PublicOnly bool `json:"publicOnly"`
+
+	// RequireImplementsAnnotation mirrors Config.RequireImplementsAnnotation
+	RequireImplementsAnnotation bool `json:"requireImplementsAnnotation"`
+
+	// RequiredInterfaces mirrors Config.RequiredInterfaces
+	RequiredInterfaces []string `json:"requiredInterfaces"`
+
+	// MutatingFunctions mirrors Config.MutatingFunctions
+	MutatingFunctions []string `json:"mutatingFunctions"`
+
+	// InPlaceFunctions mirrors Config.InPlaceFunctions
+	InPlaceFunctions []string `json:"inPlaceFunctions"`
+
+	// GeneratedConstructorPatterns mirrors Config.GeneratedConstructorPatterns
+	GeneratedConstructorPatterns []string `json:"generatedConstructorPatterns"`
+
+	// ExperimentalTag mirrors Config.ExperimentalTag
+	ExperimentalTag string `json:"experimentalTag"`
+
+	// SuggestIgnores mirrors Config.SuggestIgnores
+	SuggestIgnores bool `json:"suggestIgnores"`
+
+	// StableMessages mirrors Config.StableMessages
+	StableMessages bool `json:"stableMessages"`
+
+	// TagTestViolations mirrors Config.TagTestViolations
+	TagTestViolations bool `json:"tagTestViolations"`
+
+	// FailFast mirrors Config.FailFast
+	FailFast bool `json:"failFast"`
+
+	// MaxFindingsPerFile mirrors Config.MaxFindingsPerFile
+	MaxFindingsPerFile int `json:"maxFindingsPerFile"`
+
+	// WarningsExitCode mirrors Config.WarningsExitCode
+	WarningsExitCode int `json:"warningsExitCode"`
+
+	// Stats mirrors Config.Stats
+	Stats bool `json:"stats"`
+
+	// StatsFormat mirrors Config.StatsFormat
+	StatsFormat string `json:"statsFormat"`
+
+	// TraceContracts mirrors Config.TraceContracts
+	TraceContracts bool `json:"traceContracts"`
+
+	// EmitInventory mirrors Config.EmitInventory
+	EmitInventory bool `json:"emitInventory"`
+
+	// OutputFormat mirrors Config.OutputFormat
+	OutputFormat string `json:"outputFormat"`
+
+	// OwnersFile mirrors Config.OwnersFile
+	OwnersFile string `json:"owners"`
+
+	// DocsBaseURL mirrors Config.DocsBaseURL
+	DocsBaseURL string `json:"docsBaseUrl"`
+
+	// PathBase mirrors Config.PathBase
+	PathBase string `json:"pathBase"`
+
+	// Severities mirrors Config.Severities
+	Severities map[string]string `json:"severities"`
+
+	// CheckScopes mirrors Config.CheckScopes
+	CheckScopes map[string][]string `json:"checkScopes"`
+}
+
+// StarterFile returns the config file contents written by `gogreement init`.
+// Values are taken from Default() so the file always matches built-in defaults.
+func StarterFile() File {
+	defaults := Default()
+	return File{
+		Comment:                          starterComment,
+		ScanTests:                        defaults.ScanTests,
+		ExcludePaths:                     defaults.ExcludePaths,
+		ExcludeChecks:                    defaults.ExcludeChecks,
+		GlobalIgnoreCodes:                defaults.GlobalIgnoreCodes,
+		SkipPackages:                     defaults.SkipPackages,
+		LocalOnlyAnnotations:             defaults.LocalOnlyAnnotations,
+		IgnoreMessagePatterns:            defaults.IgnoreMessagePatterns,
+		VerboseImplements:                defaults.VerboseImplements,
+		CheckSince:                       defaults.CheckSince,
+		ValidateIgnoreCodes:              defaults.ValidateIgnoreCodes,
+		CheckPointees:                    defaults.CheckPointees,
+		WarnAnonymousImmutableAssignment: defaults.WarnAnonymousImmutableAssignment,
+		PublicOnly:                       defaults.PublicOnly,
+		RequireImplementsAnnotation:      defaults.RequireImplementsAnnotation,
+		RequiredInterfaces:               defaults.RequiredInterfaces,
+		MutatingFunctions:                defaults.MutatingFunctions,
+		InPlaceFunctions:                 defaults.InPlaceFunctions,
+		GeneratedConstructorPatterns:     defaults.GeneratedConstructorPatterns,
+		ExperimentalTag:                  defaults.ExperimentalTag,
+		SuggestIgnores:                   defaults.SuggestIgnores,
+		StableMessages:                   defaults.StableMessages,
+		TagTestViolations:                defaults.TagTestViolations,
+		FailFast:                         defaults.FailFast,
+		MaxFindingsPerFile:               defaults.MaxFindingsPerFile,
+		WarningsExitCode:                 defaults.WarningsExitCode,
+		Stats:                            defaults.Stats,
+		StatsFormat:                      defaults.StatsFormat,
+		TraceContracts:                   defaults.TraceContracts,
+		EmitInventory:                    defaults.EmitInventory,
+		OutputFormat:                     defaults.OutputFormat,
+		OwnersFile:                       defaults.OwnersFile,
+		DocsBaseURL:                      defaults.DocsBaseURL,
+		PathBase:                         defaults.PathBase,
+		Severities:                       map[string]string{},
+		CheckScopes:                      map[string][]string{},
+	}
+}
+
+// ReadFile reads a config file. Keys missing from the file keep their
+// built-in default values; unknown keys are an error, as they are most
+// likely misspelled. Check codes are normalized to uppercase.
+func ReadFile(path string) (File, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return File{}, err
+	}
+
+	file := StarterFile()
+	decoder := json.NewDecoder(bytes.NewReader(content))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&file); err != nil {
+		return File{}, fmt.Errorf("parse %s: %w", path, err)
+	}
+
+	file.ExcludeChecks = normalizeCodes(file.ExcludeChecks)
+	file.GlobalIgnoreCodes = normalizeCodes(file.GlobalIgnoreCodes)
+
+	return file, nil
+}
+
+// loadFileDefaults returns the values of the config file at path, or the
+// built-in defaults when the file is missing. A file that cannot be read or
+// parsed also falls back to the built-in defaults; its error is returned so
+// Validate can report it.
+func loadFileDefaults(path string) (File, error) {
+	file, err := ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return StarterFile(), nil
+	}
+	if err != nil {
+		return StarterFile(), err
+	}
+	return file, nil
+}
+
+// normalizeCodes trims and uppercases codes, dropping empty entries
+func normalizeCodes(input []string) []string {
+	return parseStringList(strings.Join(input, ","), true)
+}
+
+// WriteStarterFile writes the starter config file into dir and returns its path.
+// An existing file is only overwritten when force is true.
+func WriteStarterFile(dir string, force bool) (string, error) {
+	path := filepath.Join(dir, FileName)
+
+	if !force {
+		if _, err := os.Stat(path); err == nil {
+			return path, fmt.Errorf("%w: %s (use --force to overwrite)", ErrConfigFileExists, path)
+		}
+	}
+
+	content, err := json.MarshalIndent(StarterFile(), "", "  ")
+	if err != nil {
+		return path, err
+	}
+	content = append(content, '\n')
+
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		return path, err
+	}
+
+	return path, nil
+}
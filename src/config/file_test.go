@@ -0,0 +1,132 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteStarterFile(t *testing.T) {
+	dir := t.TempDir()
+
+	path, err := WriteStarterFile(dir, false)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, FileName), path)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var file File
+	require.NoError(t, json.Unmarshal(content, &file), "starter file must be valid JSON")
+
+	defaults := Default()
+	assert.Equal(t, defaults.ScanTests, file.ScanTests)
+	assert.Equal(t, defaults.ExcludePaths, file.ExcludePaths)
+	assert.Equal(t, defaults.ExcludeChecks, file.ExcludeChecks)
+	assert.Empty(t, file.Severities)
+	assert.NotEmpty(t, file.Comment)
+}
+
+func TestWriteStarterFileRefusesOverwrite(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, FileName)
+	require.NoError(t, os.WriteFile(path, []byte("custom"), 0o644))
+
+	_, err := WriteStarterFile(dir, false)
+	require.ErrorIs(t, err, ErrConfigFileExists)
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "custom", string(content), "existing file must be left untouched")
+
+	_, err = WriteStarterFile(dir, true)
+	require.NoError(t, err)
+
+	content, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.True(t, json.Valid(content), "--force should replace the file with the starter config")
+}
+
+func TestReadFile(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, FileName)
+
+	t.Run("missing keys keep defaults", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(path, []byte(`{"globalIgnoreCodes": ["imm", " ctor01 "]}`), 0o644))
+
+		file, err := ReadFile(path)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"IMM", "CTOR01"}, file.GlobalIgnoreCodes, "codes should be trimmed and uppercased")
+		assert.Equal(t, Default().ExcludePaths, file.ExcludePaths)
+		assert.False(t, file.ScanTests)
+	})
+
+	t.Run("invalid JSON is an error", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(path, []byte(`{not json`), 0o644))
+
+		_, err := ReadFile(path)
+		assert.Error(t, err)
+	})
+}
+
+func TestFromEnvReadsConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	content := `{"scanTests": true, "excludePaths": ["vendor"], "globalIgnoreCodes": ["IMM"]}`
+	require.NoError(t, os.WriteFile(FileName, []byte(content), 0o644))
+
+	cfg := FromEnv()
+	assert.True(t, cfg.ScanTests)
+	assert.Equal(t, []string{"vendor"}, cfg.ExcludePaths)
+	assert.Equal(t, []string{"IMM"}, cfg.GlobalIgnoreCodes)
+
+	// Environment takes priority over the file
+	t.Setenv("GOGREEMENT_GLOBAL_IGNORE_CODES", "ctor")
+	cfg = FromEnv()
+	assert.Equal(t, []string{"CTOR"}, cfg.GlobalIgnoreCodes)
+}
+
+func TestSeveritiesFromConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	t.Chdir(dir)
+
+	content := `{"outputFormat": "checkstyle", "severities": {"IMM": "warning"}}`
+	require.NoError(t, os.WriteFile(FileName, []byte(content), 0o644))
+
+	cfg := ParseFlagsFromFlagSet(CreateFlagSet())
+	assert.Equal(t, OutputFormatCheckstyle, cfg.OutputFormat)
+	assert.Equal(t, SeverityWarning, cfg.SeverityOf("IMM01"))
+}
+
+func TestMalformedConfigFileFailsValidation(t *testing.T) {
+	cases := []struct {
+		name    string
+		content string
+		problem string
+	}{
+		{"truncated file", `{"excludeChecks": ["IMM"`, "parse .gogreement.json: unexpected EOF"},
+		{"misspelled key", `{"excludeCheck": ["IMM"]}`, `parse .gogreement.json: json: unknown field "excludeCheck"`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Chdir(t.TempDir())
+			require.NoError(t, os.WriteFile(FileName, []byte(tc.content), 0o644))
+
+			for _, cfg := range []*Config{FromEnv(), ParseFlagsFromFlagSet(CreateFlagSet())} {
+				var validationErr *ValidationError
+				require.ErrorAs(t, cfg.Validate(), &validationErr)
+				assert.Equal(t, []string{tc.problem}, validationErr.Problems)
+			}
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		t.Chdir(t.TempDir())
+		assert.NoError(t, FromEnv().Validate(), "no config file is not a problem")
+	})
+}
@@ -0,0 +1,137 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"net/url"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/a14e/gogreement/src/codes"
+)
+
+// ValidationError lists every problem Validate found in a configuration.
+// Each problem starts with the config file key of the setting it is about,
+// or is the error of reading or parsing the config file itself.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid configuration:\n  " + strings.Join(e.Problems, "\n  ")
+}
+
+// Validate checks the settings that would otherwise be ignored or fall back
+// to a default without notice: the config file itself, severity names and the codes they are given
+// for, output, stats and path base names, check codes, package patterns,
+// globs, message patterns and the documentation base URL. It returns a
+// *ValidationError listing all problems, or nil for a usable configuration.
+func (c *Config) Validate() error {
+	var problems []string
+	report := func(format string, args ...any) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	if c.fileErr != nil {
+		report("%v", c.fileErr)
+	}
+
+	for _, key := range slices.Sorted(maps.Keys(c.Severities)) {
+		if !codes.IsKnown(key) {
+			report("severities: unknown code %q", key)
+		}
+		switch strings.ToLower(strings.TrimSpace(c.Severities[key])) {
+		case SeverityError, SeverityWarning, SeverityInfo:
+		default:
+			report("severities: %q has unknown severity %q (want %s, %s or %s)",
+				key, c.Severities[key], SeverityError, SeverityWarning, SeverityInfo)
+		}
+	}
+
+	if !isOutputFormat(c.OutputFormat) {
+		report("outputFormat: unknown format %q (want %s, %s, %s or %s)",
+			c.OutputFormat, OutputFormatText, OutputFormatCheckstyle, OutputFormatJSONV2, OutputFormatCSV)
+	}
+	if c.StatsFormat != StatsFormatText && c.StatsFormat != StatsFormatJSON {
+		report("statsFormat: unknown format %q (want %s or %s)", c.StatsFormat, StatsFormatText, StatsFormatJSON)
+	}
+	switch c.PathBase {
+	case PathBaseModule, PathBaseCwd, PathBaseAbsolute:
+	default:
+		report("pathBase: unknown base %q (want %s, %s or %s)", c.PathBase, PathBaseModule, PathBaseCwd, PathBaseAbsolute)
+	}
+	if c.DocsBaseURL != "" {
+		if base, err := url.Parse(c.DocsBaseURL); err != nil || base.Scheme == "" || base.Host == "" {
+			report("docsBaseUrl: %q is not an absolute URL", c.DocsBaseURL)
+		}
+	}
+
+	for _, code := range c.ExcludeChecks {
+		if !codes.IsKnown(code) {
+			report("excludeChecks: unknown code %q", code)
+		}
+	}
+	for _, code := range c.GlobalIgnoreCodes {
+		if !codes.IsKnown(code) {
+			report("globalIgnoreCodes: unknown code %q", code)
+		}
+	}
+
+	for _, pattern := range c.SkipPackages {
+		pattern = strings.TrimSuffix(strings.TrimSpace(pattern), "/...")
+		if _, err := path.Match(pattern, ""); err != nil {
+			report("skipPackages: malformed pattern %q", pattern)
+		}
+	}
+	for _, glob := range c.GeneratedConstructorPatterns {
+		if !isValidGlob(glob) {
+			report("generatedConstructorPatterns: malformed glob %q", glob)
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(c.CheckScopes)) {
+		if !isCheckScopeKey(key) {
+			report("checkScopes: unknown check %q", key)
+		}
+		for _, glob := range c.CheckScopes[key] {
+			if !isValidGlob(glob) {
+				report("checkScopes: %q has malformed glob %q", key, glob)
+			}
+		}
+	}
+
+	for _, pattern := range c.IgnoreMessagePatterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			report("ignoreMessagePatterns: %v", err)
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return &ValidationError{Problems: problems}
+}
+
+// isValidGlob reports whether every segment of a MatchPathGlob glob is a
+// well-formed path.Match pattern
+func isValidGlob(glob string) bool {
+	for segment := range strings.SplitSeq(filepath.ToSlash(strings.TrimSpace(glob)), "/") {
+		if _, err := path.Match(segment, ""); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// isCheckScopeKey reports whether key names a code, a category or a checker
+// the way InCheckScope looks keys up
+func isCheckScopeKey(key string) bool {
+	key = strings.TrimSpace(key)
+	if codes.IsKnown(strings.ToUpper(key)) && !strings.EqualFold(key, codes.AllCodes) {
+		return true
+	}
+	name := strings.ToLower(strings.TrimPrefix(key, "@"))
+	return slices.Contains(slices.Collect(maps.Values(checkerNames)), name)
+}
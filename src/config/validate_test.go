@@ -0,0 +1,84 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateAcceptsValidConfig(t *testing.T) {
+	assert.NoError(t, Default().Validate())
+	assert.NoError(t, FromEnv().Validate())
+
+	cfg := Default().
+		WithExcludeChecks([]string{"IMM01", "CTOR", "ALL"}).
+		WithGlobalIgnoreCodes([]string{"TONL02"}).
+		WithSkipPackages([]string{"example.com/gen/...", "example.com/*/mocks"}).
+		WithGeneratedConstructorPatterns([]string{"**/*_gen.go"}).
+		WithIgnoreMessagePatterns([]string{`^legacy: .*`}).
+		WithOutputFormat(OutputFormatCSV).
+		WithStatsFormat(StatsFormatJSON).
+		WithPathBase(PathBaseCwd).
+		WithDocsBaseURL("https://wiki.example.com/gogreement").
+		WithSeverities(map[string]string{"IMM": "Warning", "IMM02": "info", "ALL": "error"}).
+		WithCheckScopes(map[string][]string{"immutable": {"pkg/domain/**"}, "@testonly": {"**"}, "ctor01": {"cmd/**"}})
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestValidateReportsEveryProblem(t *testing.T) {
+	cfg := Default().
+		WithSeverities(map[string]string{"IMM": "off", "imm01": "error"}).
+		WithOutputFormat("sarif").
+		WithStatsFormat("yaml").
+		WithPathBase("repo").
+		WithDocsBaseURL("wiki/gogreement").
+		WithExcludeChecks([]string{"IMM99"}).
+		WithGlobalIgnoreCodes([]string{"NOPE"}).
+		WithSkipPackages([]string{"example.com/[gen/..."}).
+		WithGeneratedConstructorPatterns([]string{"**/[_gen.go"}).
+		WithCheckScopes(map[string][]string{"mutability": {"pkg/**"}, "immutable": {"pkg/[domain/**"}}).
+		WithIgnoreMessagePatterns([]string{"legacy ("})
+
+	err := cfg.Validate()
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, []string{
+		`severities: "IMM" has unknown severity "off" (want error, warning or info)`,
+		`severities: unknown code "imm01"`,
+		`outputFormat: unknown format "sarif" (want text, checkstyle, json-v2 or csv)`,
+		`statsFormat: unknown format "yaml" (want text or json)`,
+		`pathBase: unknown base "repo" (want module, cwd or absolute)`,
+		`docsBaseUrl: "wiki/gogreement" is not an absolute URL`,
+		`excludeChecks: unknown code "IMM99"`,
+		`globalIgnoreCodes: unknown code "NOPE"`,
+		`skipPackages: malformed pattern "example.com/[gen"`,
+		`generatedConstructorPatterns: malformed glob "**/[_gen.go"`,
+		`checkScopes: "immutable" has malformed glob "pkg/[domain/**"`,
+		`checkScopes: unknown check "mutability"`,
+		"ignoreMessagePatterns: error parsing regexp: missing closing ): `legacy (`",
+	}, validationErr.Problems)
+	assert.Contains(t, err.Error(), "invalid configuration:\n  severities: ")
+}
+
+func TestValidateRegisteredOutputFormat(t *testing.T) {
+	cfg := Default().WithOutputFormat("validate-test-format")
+	require.Error(t, cfg.Validate())
+
+	require.True(t, RegisterOutputFormat("validate-test-format"))
+	assert.NoError(t, cfg.Validate(), "registered formats are accepted")
+}
+
+func TestValidateFlagValues(t *testing.T) {
+	fs := CreateFlagSet()
+	require.NoError(t, fs.Set("output-format", "SARIF"))
+	require.NoError(t, fs.Set("exclude-checks", "imm01,xyz"))
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, ParseFlagsFromFlagSet(fs).Validate(), &validationErr)
+	assert.Equal(t, []string{
+		`outputFormat: unknown format "sarif" (want text, checkstyle, json-v2 or csv)`,
+		`excludeChecks: unknown code "XYZ"`,
+	}, validationErr.Problems, "codes are uppercased before they are checked")
+}
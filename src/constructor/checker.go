@@ -10,24 +10,45 @@ import (
 	"go/ast"
 	"go/token"
 	"go/types"
+	"iter"
 
 	"golang.org/x/tools/go/analysis"
 )
 
+// CheckConstructor checks that @constructor types are only instantiated in
+// their constructors, with the constructors of imported packages read from
+// their ContractsFact
 func CheckConstructor(
 	config *config.Config,
 	pass *analysis.Pass,
 	packageAnnotations *annotations.PackageAnnotations,
+) []ConstructorViolation {
+	local := annotations.NewContractsFact(packageAnnotations)
+	contracts := indexing.BuildContracts(pass, &local)
+	return CheckConstructorWithIndex(config, pass, packageAnnotations, contracts.ConstructorEnforcement)
+}
+
+// CheckConstructorWithIndex is CheckConstructor with the constructors enforced
+// in the package already indexed, such as Contracts.ConstructorEnforcement of
+// the contracts reader
+func CheckConstructorWithIndex(
+	config *config.Config,
+	pass *analysis.Pass,
+	packageAnnotations *annotations.PackageAnnotations,
+	constructors util.TypeAssociationRegistry,
 ) []ConstructorViolation {
 	var violations []ConstructorViolation
 
-	constructors := indexing.BuildConstructorIndex[*annotations.ConstructorCheckerFact](pass, packageAnnotations)
 	if constructors.Empty() {
 		return violations
 	}
 
-	// Filter files based on configuration (skip test files by default)
-	filesToCheck := config.FilterFiles(pass)
+	// Filter files based on configuration (skip test files by default, honor check scopes)
+	filesToCheck := config.FilterFilesForCheck(pass, codes.ConstructorCategoryPrefix)
+
+	violations = append(violations, checkConstructorNames(pass, filesToCheck, packageAnnotations)...)
+
+	constructors = withGeneratedConstructors(config, pass, constructors)
 
 	for file := range filesToCheck {
 		for _, decl := range file.Decls {
@@ -48,6 +69,9 @@ func CheckConstructor(
 					if v != nil {
 						violations = append(violations, *v)
 					}
+					// Keep descending: literals nested in keyed, positional or
+					// embedded fields (including elided `{...}` elements) are
+					// checked against their own type, not the outer one.
 					return true
 
 				case *ast.CallExpr:
@@ -55,6 +79,8 @@ func CheckConstructor(
 						violations = append(violations, *v)
 					} else if v := checkConversionCall(pass, node, constructors, currentFunction); v != nil {
 						violations = append(violations, *v)
+					} else if v := checkDelegatedBuilder(pass, node, constructors, currentFunction); v != nil {
+						violations = append(violations, *v)
 					}
 					return true
 
@@ -73,6 +99,120 @@ func CheckConstructor(
 	return violations
 }
 
+// checkConstructorNames reports names listed by the package's own @constructor
+// annotations that are not package-level functions. The checks above only
+// exempt free functions, so a name that exists only as a method (or not at
+// all) would leave the type without any way to be constructed.
+func checkConstructorNames(
+	pass *analysis.Pass,
+	files iter.Seq[*ast.File],
+	packageAnnotations *annotations.PackageAnnotations,
+) []ConstructorViolation {
+	var violations []ConstructorViolation
+
+	checked := make(map[*token.File]bool)
+	for file := range files {
+		checked[pass.Fset.File(file.Pos())] = true
+	}
+
+	scope := pass.Pkg.Scope()
+	for _, annot := range packageAnnotations.ConstructorAnnotations {
+		if !checked[pass.Fset.File(annot.OnTypePos)] {
+			continue
+		}
+
+		for _, name := range annot.ConstructorNames {
+			if _, ok := scope.Lookup(name).(*types.Func); ok {
+				continue
+			}
+
+			reason := fmt.Sprintf("constructor %s of type %s is not a function declared in package %s", name, annot.OnType, pass.Pkg.Name())
+			if receiver := methodReceiverNamed(scope, name); receiver != "" {
+				reason = fmt.Sprintf("constructor %s of type %s is a method of %s, but only package-level functions can be constructors", name, annot.OnType, receiver)
+			}
+
+			violations = append(violations, ConstructorViolation{
+				TypeName: annot.OnType,
+				Code:     codes.ConstructorNotFound,
+				Pos:      annot.OnTypePos,
+				Reason:   reason,
+			})
+		}
+	}
+
+	return violations
+}
+
+// withGeneratedConstructors returns constructors extended with the
+// package-level functions of generated files (config.GeneratedConstructorPatterns)
+// as constructors of the @constructor types of this package they return, by
+// value or by pointer. Generated builders then need no annotation.
+func withGeneratedConstructors(
+	cfg *config.Config,
+	pass *analysis.Pass,
+	constructors util.TypeAssociationRegistry,
+) util.TypeAssociationRegistry {
+	if len(cfg.GeneratedConstructorPatterns) == 0 {
+		return constructors
+	}
+
+	result := util.NewTypeAssociationRegistry()
+	for pkgPath, byType := range constructors {
+		for typeName, names := range byType {
+			for _, name := range names {
+				result.Add(pkgPath, name, typeName)
+			}
+		}
+	}
+
+	pkgPath := pass.Pkg.Path()
+	for file := range cfg.FilterFiles(pass) {
+		if !cfg.IsGeneratedConstructorFile(config.ModuleRoot(pass), pass.Fset.Position(file.Pos()).Filename) {
+			continue
+		}
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv != nil {
+				continue
+			}
+			obj, ok := pass.TypesInfo.Defs[fn.Name].(*types.Func)
+			if !ok {
+				continue
+			}
+			for variable := range obj.Signature().Results().Variables() {
+				resultType := variable.Type()
+				if ptr, ok := resultType.(*types.Pointer); ok {
+					resultType = ptr.Elem()
+				}
+				named, ok := resultType.(*types.Named)
+				if !ok || named.Obj().Pkg() != pass.Pkg || !constructors.HasType(pkgPath, named.Obj().Name()) {
+					continue
+				}
+				result.Add(pkgPath, fn.Name.Name, named.Obj().Name())
+			}
+		}
+	}
+
+	return result
+}
+
+// methodReceiverNamed returns the first type of scope, in name order, that
+// declares a method called name, or "" if there is none
+func methodReceiverNamed(scope *types.Scope, name string) string {
+	for _, typeName := range scope.Names() {
+		named, ok := scope.Lookup(typeName).Type().(*types.Named)
+		if !ok {
+			continue
+		}
+		for method := range named.Methods() {
+			if method.Name() == name {
+				return typeName
+			}
+		}
+	}
+	return ""
+}
+
 func checkCompositeLiteral(
 	pass *analysis.Pass,
 	lit *ast.CompositeLit,
@@ -247,6 +387,71 @@ func checkConversionCall(
 	}
 }
 
+// checkDelegatedBuilder reports an advisory when a declared constructor calls
+// another free function that returns its type by value but is not itself a
+// declared constructor of that type: such a helper is an unofficial
+// constructor, and callers could bypass the declared ones through it.
+// Pointer results are not flagged, they do not produce a new value.
+func checkDelegatedBuilder(
+	pass *analysis.Pass,
+	call *ast.CallExpr,
+	constructors util.TypeAssociationRegistry,
+	currentFunction string,
+) *ConstructorViolation {
+	if currentFunction == "" {
+		return nil
+	}
+
+	callee := util.CalledFunction(pass.TypesInfo, call.Fun)
+	if callee == nil || callee.Pkg() == nil {
+		return nil
+	}
+
+	// The declared signature is used rather than the instantiated one, so a
+	// generic helper returning its type parameter is not a builder of T.
+	signature, ok := callee.Type().(*types.Signature)
+	if !ok || signature.Recv() != nil {
+		return nil
+	}
+
+	for result := range signature.Results().Variables() {
+		named, ok := result.Type().(*types.Named)
+		if !ok || named.Obj().Pkg() == nil {
+			continue
+		}
+
+		typeName := named.Obj().Name()
+		pkgPath := named.Obj().Pkg().Path()
+
+		// Only constructors of the type, in its own package, are checked
+		if pass.Pkg.Path() != pkgPath || !constructors.Match(pkgPath, currentFunction, typeName) {
+			continue
+		}
+
+		// Delegating to another declared constructor is fine
+		if callee.Pkg().Path() == pkgPath && constructors.Match(pkgPath, callee.Name(), typeName) {
+			continue
+		}
+
+		constructorList := constructors.GetAssociated(pkgPath, typeName)
+		reason := fmt.Sprintf(
+			"advisory: constructor %s obtains %s from %s, which is not one of its constructors (allowed: %v); "+
+				"construct the value here or declare %s as a constructor",
+			currentFunction, typeName, callee.Name(), constructorList, callee.Name(),
+		)
+
+		return &ConstructorViolation{
+			TypeName: typeName,
+			Code:     codes.ConstructorDelegatedBuilder,
+			Pos:      call.Pos(),
+			Reason:   reason,
+			Node:     call,
+		}
+	}
+
+	return nil
+}
+
 func checkVarDeclaration(
 	pass *analysis.Pass,
 	decl *ast.GenDecl,
@@ -2,6 +2,7 @@ package constructor
 
 import (
 	"github.com/a14e/gogreement/src/annotations"
+	"github.com/a14e/gogreement/src/codes"
 	"github.com/a14e/gogreement/src/config"
 	"github.com/a14e/gogreement/src/testutil/testfacts"
 	"go/ast"
@@ -108,13 +109,16 @@ func TestMultipleConstructors(t *testing.T) {
 
 	violations := CheckConstructor(cfg, pass, &packageAnnotations)
 
+	var violatingFuncs []string
 	for _, v := range violations {
 		if v.TypeName == "Config" {
 			funcName := getFunctionNameFromPosition(pass, v.Pos)
 			assert.NotEqual(t, "NewConfig", funcName, "NewConfig should be allowed")
 			assert.NotEqual(t, "NewDefaultConfig", funcName, "NewDefaultConfig should be allowed")
+			violatingFuncs = append(violatingFuncs, funcName)
 		}
 	}
+	assert.Contains(t, violatingFuncs, "OverrideFreshConfig", "(&Config{}).Host = x builds the literal outside a constructor")
 }
 
 func TestNoAnnotationAllowed(t *testing.T) {
@@ -140,7 +144,7 @@ func TestReportViolations(t *testing.T) {
 		},
 	}
 
-	ReportViolations(pass, violations, nil)
+	ReportViolations(config.Empty(), pass, violations, nil)
 	t.Log("ReportViolations executed successfully")
 }
 
@@ -330,6 +334,29 @@ func TestPackageLevelInstantiationFlagged(t *testing.T) {
 		"package-level instantiation must be flagged (enclosing-function context must not leak)")
 }
 
+func TestNestedCompositeLiteralViolations(t *testing.T) {
+
+	pass := testfacts.CreateTestPassWithFacts(t, "constructortests")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+	violations := CheckConstructor(cfg, pass, &packageAnnotations)
+
+	// Gadget literals nested inside Holder/Shelf literals must be reported
+	// regardless of depth or whether the field is keyed, positional or embedded.
+	counts := make(map[string]int)
+	for _, v := range violations {
+		if v.TypeName == "Gadget" {
+			assert.Equal(t, "CTOR01", v.Code)
+			counts[getFunctionNameFromPosition(pass, v.Pos)]++
+		}
+	}
+
+	assert.Equal(t, 4, counts["MakeHolder"], "keyed, pointer and elided nested literals should be flagged")
+	assert.Equal(t, 1, counts["MakeHolderPositional"], "positional embedded literal should be flagged")
+	assert.Equal(t, 1, counts["MakeShelf"], "literal two levels deep should be flagged")
+	assert.Zero(t, counts["NewGadgetHolder"], "Gadget built by its constructor must not be flagged")
+}
+
 func TestCrossPackageConstructorNotExempt(t *testing.T) {
 
 	// ctorconsumer defines a function named NewWidget (colliding with the name
@@ -352,6 +379,89 @@ func TestCrossPackageConstructorNotExempt(t *testing.T) {
 		"cross-package instantiation of an @constructor type must be flagged despite a same-named function in the consumer package")
 }
 
+func TestConstructorTypeInExportedField(t *testing.T) {
+
+	// Panel{Inner: ctorsource.Widget{...}} constructs the Widget through the
+	// outer literal; the violation points at the inner literal
+	pass := testfacts.CreateTestPassWithFacts(t, "ctorconsumer", "ctorsource")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+
+	var inner token.Pos
+	for _, file := range pass.Files {
+		ast.Inspect(file, func(n ast.Node) bool {
+			if kv, ok := n.(*ast.KeyValueExpr); ok {
+				if key, ok := kv.Key.(*ast.Ident); ok && key.Name == "Inner" {
+					inner = kv.Value.Pos()
+				}
+			}
+			return true
+		})
+	}
+	assert.True(t, inner.IsValid(), "testdata has the Inner field literal")
+
+	var found []token.Pos
+	for _, v := range CheckConstructor(cfg, pass, &packageAnnotations) {
+		if getFunctionNameFromPosition(pass, v.Pos) == "MakePanel" {
+			assert.Equal(t, "Widget", v.TypeName)
+			assert.Equal(t, codes.ConstructorCompositeLiteral, v.Code)
+			found = append(found, v.Pos)
+		}
+	}
+
+	assert.Equal(t, []token.Pos{inner}, found, "only the inner Widget literal is reported, at its own position")
+}
+
+func TestLocalConstructorOnlyEnforcedInDeclaringPackage(t *testing.T) {
+	cfg := config.Empty()
+
+	t.Run("external construction is allowed", func(t *testing.T) {
+		pass := testfacts.CreateTestPassWithFacts(t, "ctorconsumer", "ctorsource")
+		packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+
+		for _, v := range CheckConstructor(cfg, pass, &packageAnnotations) {
+			assert.NotEqual(t, "Gauge", v.TypeName, "@constructor-local must not apply outside ctorsource: %s", v.Reason)
+		}
+	})
+
+	t.Run("internal literals are flagged", func(t *testing.T) {
+		pass := testfacts.CreateTestPassWithFacts(t, "ctorsource")
+		packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+
+		var functions []string
+		for _, v := range CheckConstructor(cfg, pass, &packageAnnotations) {
+			if v.TypeName == "Gauge" {
+				functions = append(functions, getFunctionNameFromPosition(pass, v.Pos))
+			}
+		}
+		assert.Equal(t, []string{"defaultGauge"}, functions)
+	})
+}
+
+func TestDelegatedBuilderAdvisory(t *testing.T) {
+
+	pass := testfacts.CreateTestPassWithFacts(t, "constructortests")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+	violations := CheckConstructor(cfg, pass, &packageAnnotations)
+
+	// NewSettings builds its value through buildSettings (CTOR20), which in
+	// turn needs the literal (CTOR01). Delegating to another constructor,
+	// pointer results and generic helpers are not flagged.
+	var found []string
+	for _, v := range violations {
+		if v.TypeName == "Settings" {
+			found = append(found, v.Code+" in "+getFunctionNameFromPosition(pass, v.Pos))
+			t.Logf("Settings violation: %s (%s)", v.Reason, v.Code)
+		}
+	}
+
+	assert.ElementsMatch(t, []string{
+		codes.ConstructorDelegatedBuilder + " in NewSettings",
+		codes.ConstructorCompositeLiteral + " in buildSettings",
+	}, found)
+}
+
 func getFunctionNameFromPosition(pass *analysis.Pass, pos token.Pos) string {
 	for _, file := range pass.Files {
 		for _, decl := range file.Decls {
@@ -381,3 +491,46 @@ func containsSubstr(s, substr string) bool {
 	}
 	return false
 }
+
+func TestConstructorNameMustBePackageFunction(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "constructortests")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+	violations := CheckConstructor(cfg, pass, &packageAnnotations)
+
+	var found []string
+	for _, v := range violations {
+		switch v.TypeName {
+		case "Report", "Draft", "Memo":
+			found = append(found, v.Code+": "+v.Reason)
+		}
+	}
+
+	assert.ElementsMatch(t, []string{
+		// Builder.Build shares its name with the constructor but is a method
+		codes.ConstructorCompositeLiteral + ": type instantiation must be in constructor (allowed: [Build])",
+		codes.ConstructorNotFound + ": constructor Compose of type Draft is a method of Builder, but only package-level functions can be constructors",
+		codes.ConstructorCompositeLiteral + ": type instantiation must be in constructor (allowed: [Compose])",
+		codes.ConstructorNotFound + ": constructor NewMemo of type Memo is not a function declared in package constructortests",
+	}, found)
+}
+
+func TestGeneratedConstructorPatterns(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "ctorgenerated")
+
+	functionsWithViolations := func(cfg *config.Config) []string {
+		packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+		var functions []string
+		for _, v := range CheckConstructor(cfg, pass, &packageAnnotations) {
+			functions = append(functions, getFunctionNameFromPosition(pass, v.Pos))
+		}
+		return functions
+	}
+
+	assert.ElementsMatch(t, []string{"handmade", "buildWidget", "defaultWidget", "widgetNames"},
+		functionsWithViolations(config.Empty()), "generated builders need a pattern to be exempt")
+
+	cfg := config.Empty().WithGeneratedConstructorPatterns([]string{"**/*_gen.go"})
+	assert.ElementsMatch(t, []string{"handmade", "widgetNames"}, functionsWithViolations(cfg),
+		"functions of generated files returning Widget construct it")
+}
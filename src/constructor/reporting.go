@@ -7,6 +7,7 @@ import (
 
 	"golang.org/x/tools/go/analysis"
 
+	"github.com/a14e/gogreement/src/config"
 	"github.com/a14e/gogreement/src/reporting"
 	"github.com/a14e/gogreement/src/util"
 )
@@ -37,11 +38,13 @@ func (v ConstructorViolation) GetMessage() string {
 }
 
 // ReportViolations reports constructor violations using the new pretty formatter
-func ReportViolations(pass *analysis.Pass, violations []ConstructorViolation, ignoreSet *util.IgnoreSet) {
-	reporter := reporting.NewReporter(pass, ignoreSet)
+func ReportViolations(cfg *config.Config, pass *analysis.Pass, violations []ConstructorViolation, ignoreSet *util.IgnoreSet) {
+	reporter := reporting.NewReporter(cfg, pass, ignoreSet)
 
 	// Convert to generic violations and report
+	generic := make([]reporting.Violation, 0, len(violations))
 	for _, violation := range violations {
-		reporter.ReportViolation(violation)
+		generic = append(generic, violation)
 	}
+	reporter.ReportViolations(generic)
 }
@@ -0,0 +1,58 @@
+package deprecated
+
+import (
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/a14e/gogreement/src/annotations"
+	"github.com/a14e/gogreement/src/codes"
+	"github.com/a14e/gogreement/src/config"
+	"github.com/a14e/gogreement/src/indexing"
+)
+
+// CheckDeprecatedPackages reports every import of a package whose doc comment
+// carries a //gogreement:deprecated directive. The deprecated package itself,
+// its external test package and the packages below it are not reported, as
+// they move together with it.
+func CheckDeprecatedPackages(
+	cfg *config.Config,
+	pass *analysis.Pass,
+	packageAnnotations *annotations.PackageAnnotations,
+) []DeprecatedPackageViolation {
+	var violations []DeprecatedPackageViolation
+
+	deprecated := indexing.BuildDeprecatedPackagesIndex[*annotations.DeprecatedPackageCheckerFact](pass, packageAnnotations)
+	if len(deprecated) == 0 {
+		return violations
+	}
+
+	for file := range cfg.FilterFilesForCheck(pass, codes.DeprecatedPackageCategoryPrefix) {
+		for _, spec := range file.Imports {
+			path, err := strconv.Unquote(spec.Path.Value)
+			if err != nil {
+				continue
+			}
+			directive, ok := deprecated[path]
+			if !ok || movesWith(pass.Pkg.Path(), path) {
+				continue
+			}
+
+			violations = append(violations, DeprecatedPackageViolation{
+				PackagePath: path,
+				Replacement: directive.Replacement,
+				Code:        codes.DeprecatedPackageImported,
+				Pos:         spec.Pos(),
+			})
+		}
+	}
+
+	return violations
+}
+
+// movesWith reports whether the package at importer is the deprecated package
+// at path, its external test package or a package below it
+func movesWith(importer, path string) bool {
+	return importer == path || importer == path+"_test" || strings.HasPrefix(importer, path+"/")
+}
@@ -0,0 +1,61 @@
+package deprecated
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/a14e/gogreement/src/annotations"
+	"github.com/a14e/gogreement/src/codes"
+	"github.com/a14e/gogreement/src/config"
+	"github.com/a14e/gogreement/src/testutil/testfacts"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckDeprecatedPackages(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "deprecatedconsumer", "deprecatedsource")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+
+	violations := CheckDeprecatedPackages(cfg, pass, &packageAnnotations)
+
+	var found []string
+	for _, v := range violations {
+		assert.Equal(t, codes.DeprecatedPackageImported, v.GetCode())
+		position := pass.Fset.Position(v.GetPos())
+		found = append(found, fmt.Sprintf("%s:%d", filepath.Base(position.Filename), position.Line))
+	}
+	assert.ElementsMatch(t, []string{"aliased.go:3", "consumer.go:6", "ignored.go:4"}, found,
+		"every import is reported, ignored ones are filtered by the reporter")
+
+	require.NotEmpty(t, violations)
+	assert.Equal(t,
+		`package "github.com/a14e/gogreement/testdata/unit/deprecatedsource" is deprecated; use "github.com/a14e/gogreement/testdata/unit/deprecatedsource/v2" instead`,
+		violations[0].GetMessage())
+	assert.Equal(t, config.SeverityWarning, cfg.SeverityOf(codes.DeprecatedPackageImported), "DEPPKG findings are warnings by default")
+}
+
+func TestCheckDeprecatedPackages_DeprecatedPackage(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "deprecatedsource")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+
+	require.Len(t, packageAnnotations.DeprecatedPackages, 1)
+	assert.Equal(t, "github.com/a14e/gogreement/testdata/unit/deprecatedsource/v2", packageAnnotations.DeprecatedPackages[0].Replacement)
+	assert.Empty(t, CheckDeprecatedPackages(cfg, pass, &packageAnnotations), "the deprecated package imports nothing deprecated")
+}
+
+func TestMovesWith(t *testing.T) {
+	assert.True(t, movesWith("example.com/old", "example.com/old"))
+	assert.True(t, movesWith("example.com/old_test", "example.com/old"))
+	assert.True(t, movesWith("example.com/old/internal/legacy", "example.com/old"))
+	assert.False(t, movesWith("example.com/older", "example.com/old"))
+	assert.False(t, movesWith("example.com/app", "example.com/old"))
+}
+
+func TestDeprecatedPackageViolationMessage(t *testing.T) {
+	v := DeprecatedPackageViolation{PackagePath: "example.com/old", Code: codes.DeprecatedPackageImported}
+	assert.Equal(t, `package "example.com/old" is deprecated`, v.GetMessage(), "no replacement named")
+}
@@ -0,0 +1,60 @@
+package deprecated
+
+import (
+	"fmt"
+	"go/token"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/a14e/gogreement/src/config"
+	"github.com/a14e/gogreement/src/reporting"
+	"github.com/a14e/gogreement/src/trace"
+	"github.com/a14e/gogreement/src/util"
+)
+
+// DeprecatedPackageViolation represents an import of a package deprecated
+// with //gogreement:deprecated
+// @immutable
+// implements reporting.Violation
+// implements reporting.ContractViolation
+type DeprecatedPackageViolation struct {
+	PackagePath string // Import path of the deprecated package
+	Replacement string // Import path to use instead, empty when none is given
+	Code        string // Error code from codes package
+	Pos         token.Pos
+}
+
+// GetCode returns the error code for this violation
+func (v DeprecatedPackageViolation) GetCode() string {
+	return v.Code
+}
+
+// GetPos returns the position of the violation
+func (v DeprecatedPackageViolation) GetPos() token.Pos {
+	return v.Pos
+}
+
+// GetMessage returns the main error message without formatting
+func (v DeprecatedPackageViolation) GetMessage() string {
+	if v.Replacement == "" {
+		return fmt.Sprintf("package %q is deprecated", v.PackagePath)
+	}
+	return fmt.Sprintf("package %q is deprecated; use %q instead", v.PackagePath, v.Replacement)
+}
+
+// GetContracts names the directive of the deprecated package
+func (v DeprecatedPackageViolation) GetContracts() []trace.Key {
+	return []trace.Key{{Kind: "deprecated", Package: v.PackagePath, Argument: v.Replacement}}
+}
+
+// ReportViolations reports deprecated package violations using the new pretty formatter
+func ReportViolations(cfg *config.Config, pass *analysis.Pass, violations []DeprecatedPackageViolation, ignoreSet *util.IgnoreSet) {
+	reporter := reporting.NewReporter(cfg, pass, ignoreSet)
+
+	// Convert to generic violations and report
+	generic := make([]reporting.Violation, 0, len(violations))
+	for _, violation := range violations {
+		generic = append(generic, violation)
+	}
+	reporter.ReportViolations(generic)
+}
@@ -0,0 +1,109 @@
+package enum
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/a14e/gogreement/src/annotations"
+	"github.com/a14e/gogreement/src/codes"
+	"github.com/a14e/gogreement/src/config"
+	"github.com/a14e/gogreement/src/indexing"
+)
+
+// CheckEnum reports conversions of constants to @enum types of other packages
+// (pkg.Color(99)) whose value is not one of the constants of that type
+// declared in its package. The declaring package may create any value.
+// Conversions of non-constant values cannot be checked and are allowed.
+func CheckEnum(
+	cfg *config.Config,
+	pass *analysis.Pass,
+	packageAnnotations *annotations.PackageAnnotations,
+) []EnumViolation {
+	var violations []EnumViolation
+
+	enumTypes := indexing.BuildEnumTypesIndex[*annotations.EnumCheckerFact](pass, packageAnnotations)
+	if enumTypes.Empty() {
+		return violations
+	}
+
+	// Declared constants of each enum type met so far
+	declared := make(map[*types.TypeName][]constant.Value)
+
+	for file := range cfg.FilterFilesForCheck(pass, codes.EnumCategoryPrefix) {
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok || len(call.Args) != 1 {
+				return true
+			}
+
+			// Only conversions T(x) yield a type for the function expression
+			if tv, ok := pass.TypesInfo.Types[call.Fun]; !ok || !tv.IsType() {
+				return true
+			}
+
+			named, ok := types.Unalias(pass.TypesInfo.TypeOf(call.Fun)).(*types.Named)
+			if !ok || named.Obj().Pkg() == nil {
+				return true
+			}
+			obj := named.Obj()
+			pkgPath := obj.Pkg().Path()
+			if pkgPath == pass.Pkg.Path() || !enumTypes.Contains(pkgPath, obj.Name()) {
+				return true
+			}
+
+			value := pass.TypesInfo.Types[call].Value
+			if value == nil {
+				return true
+			}
+
+			if _, ok := declared[obj]; !ok {
+				declared[obj] = declaredConstants(named)
+			}
+			if isDeclared(value, declared[obj]) {
+				return true
+			}
+
+			violations = append(violations, EnumViolation{
+				TypeName:    obj.Name(),
+				TypePkgPath: pkgPath,
+				Value:       value.ExactString(),
+				Code:        codes.EnumValueNotDeclared,
+				Pos:         call.Pos(),
+			})
+			return true
+		})
+	}
+
+	return violations
+}
+
+// declaredConstants returns the values of the package-level constants of the
+// enum type declared in the type's own package
+func declaredConstants(named *types.Named) []constant.Value {
+	var values []constant.Value
+
+	scope := named.Obj().Pkg().Scope()
+	for _, name := range scope.Names() {
+		c, ok := scope.Lookup(name).(*types.Const)
+		if !ok || !types.Identical(c.Type(), named) {
+			continue
+		}
+		values = append(values, c.Val())
+	}
+
+	return values
+}
+
+// isDeclared reports whether value equals one of the declared values
+func isDeclared(value constant.Value, declared []constant.Value) bool {
+	for _, d := range declared {
+		if constant.Compare(value, token.EQL, d) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,44 @@
+package enum
+
+import (
+	"testing"
+
+	"github.com/a14e/gogreement/src/annotations"
+	"github.com/a14e/gogreement/src/codes"
+	"github.com/a14e/gogreement/src/config"
+	"github.com/a14e/gogreement/src/testutil/testfacts"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckEnum_ForeignConversions(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "enumconsumer", "enumsource")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+
+	violations := CheckEnum(cfg, pass, &packageAnnotations)
+
+	var messages []string
+	for _, v := range violations {
+		assert.Equal(t, codes.EnumValueNotDeclared, v.GetCode())
+		messages = append(messages, v.GetMessage())
+	}
+
+	const source = "github.com/a14e/gogreement/testdata/unit/enumsource"
+	assert.ElementsMatch(t, []string{
+		`value 99 is not a declared constant of @enum type "Color"; use one of the constants declared in ` + source,
+		`value "trace" is not a declared constant of @enum type "Level"; use one of the constants declared in ` + source,
+		`value 42 is not a declared constant of @enum type "Color"; use one of the constants declared in ` + source,
+	}, messages, "declared values, variables and non-enum types are not reported")
+}
+
+func TestCheckEnum_DeclaringPackage(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "enumsource")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+
+	assert.Len(t, packageAnnotations.EnumAnnotations, 2, "Color and Level are @enum")
+
+	violations := CheckEnum(cfg, pass, &packageAnnotations)
+	assert.Empty(t, violations, "the declaring package may create any value")
+}
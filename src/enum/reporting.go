@@ -0,0 +1,59 @@
+package enum
+
+import (
+	"fmt"
+	"go/token"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/a14e/gogreement/src/config"
+	"github.com/a14e/gogreement/src/reporting"
+	"github.com/a14e/gogreement/src/trace"
+	"github.com/a14e/gogreement/src/util"
+)
+
+// EnumViolation represents a conversion creating a value of an @enum type
+// that is not one of its declared constants
+// @immutable
+// implements reporting.Violation
+// implements reporting.ContractViolation
+type EnumViolation struct {
+	TypeName    string
+	TypePkgPath string // Package path where the @enum type is declared
+	Value       string // Converted constant value as written by go/constant
+	Code        string // Error code from codes package
+	Pos         token.Pos
+}
+
+// GetCode returns the error code for this violation
+func (v EnumViolation) GetCode() string {
+	return v.Code
+}
+
+// GetPos returns the position of the violation
+func (v EnumViolation) GetPos() token.Pos {
+	return v.Pos
+}
+
+// GetMessage returns the main error message without formatting
+func (v EnumViolation) GetMessage() string {
+	return fmt.Sprintf("value %s is not a declared constant of @enum type %q; use one of the constants declared in %s",
+		v.Value, v.TypeName, v.TypePkgPath)
+}
+
+// GetContracts names the @enum annotation of the converted-to type
+func (v EnumViolation) GetContracts() []trace.Key {
+	return []trace.Key{{Kind: "enum", Package: v.TypePkgPath, Name: v.TypeName}}
+}
+
+// ReportViolations reports enum violations using the new pretty formatter
+func ReportViolations(cfg *config.Config, pass *analysis.Pass, violations []EnumViolation, ignoreSet *util.IgnoreSet) {
+	reporter := reporting.NewReporter(cfg, pass, ignoreSet)
+
+	// Convert to generic violations and report
+	generic := make([]reporting.Violation, 0, len(violations))
+	for _, violation := range violations {
+		generic = append(generic, violation)
+	}
+	reporter.ReportViolations(generic)
+}
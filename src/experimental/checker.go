@@ -0,0 +1,157 @@
+package experimental
+
+import (
+	"go/ast"
+	"go/build/constraint"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/a14e/gogreement/src/annotations"
+	"github.com/a14e/gogreement/src/codes"
+	"github.com/a14e/gogreement/src/config"
+	"github.com/a14e/gogreement/src/indexing"
+	"github.com/a14e/gogreement/src/util"
+)
+
+// maxConstraintTags caps the number of other tags a //go:build expression may
+// name for the opt-in check; larger expressions are not treated as opting in
+const maxConstraintTags = 12
+
+// CheckExperimental reports uses of @experimental types, functions and
+// methods of other packages in files that are not built only with the opt-in
+// build tag of cfg. Uses are resolved through type information, so every
+// identifier denoting the declaration is a use: calls, conversions, type
+// expressions and method values alike.
+func CheckExperimental(
+	cfg *config.Config,
+	pass *analysis.Pass,
+	packageAnnotations *annotations.PackageAnnotations,
+) []ExperimentalViolation {
+	var violations []ExperimentalViolation
+
+	experimental := indexing.BuildExperimentalIndex[*annotations.ExperimentalCheckerFact](pass, packageAnnotations)
+	if experimental.Empty() {
+		return violations
+	}
+
+	for file := range cfg.FilterFilesForCheck(pass, codes.ExperimentalCategoryPrefix) {
+		if optsIn(file, cfg.ExperimentalTag) {
+			continue
+		}
+
+		ast.Inspect(file, func(n ast.Node) bool {
+			ident, ok := n.(*ast.Ident)
+			if !ok {
+				return true
+			}
+
+			obj := pass.TypesInfo.Uses[ident]
+			if obj == nil || obj.Pkg() == nil || obj.Pkg() == pass.Pkg {
+				return true
+			}
+
+			name, receiverType, ok := declaration(obj)
+			if !ok || !experimental.Match(obj.Pkg().Path(), name, receiverType) {
+				return true
+			}
+
+			violations = append(violations, ExperimentalViolation{
+				ObjectName:   name,
+				ReceiverType: receiverType,
+				ObjectPkg:    obj.Pkg().Path(),
+				Tag:          cfg.ExperimentalTag,
+				Code:         codes.ExperimentalUsedWithoutTag,
+				Pos:          ident.Pos(),
+			})
+			return true
+		})
+	}
+
+	return violations
+}
+
+// declaration returns the name and, for methods, the receiver type name of
+// the type, function or method obj denotes. Instantiated generics resolve to
+// their generic declaration.
+func declaration(obj types.Object) (string, string, bool) {
+	switch obj := obj.(type) {
+	case *types.TypeName:
+		return obj.Name(), "", true
+	case *types.Func:
+		fn := obj.Origin()
+		recv := fn.Signature().Recv()
+		if recv == nil {
+			return fn.Name(), "", true
+		}
+		typeInfo := util.ExtractTypeInfo(recv.Type())
+		if typeInfo == nil {
+			return "", "", false
+		}
+		return fn.Name(), typeInfo.TypeName, true
+	}
+	return "", "", false
+}
+
+// optsIn reports whether file is only built with tag: its //go:build
+// constraint is false whenever tag is unset, whatever the other tags are
+func optsIn(file *ast.File, tag string) bool {
+	for _, group := range file.Comments {
+		if group.Pos() >= file.Package {
+			break
+		}
+		for _, comment := range group.List {
+			if !constraint.IsGoBuild(comment.Text) {
+				continue
+			}
+			expr, err := constraint.Parse(comment.Text)
+			if err != nil {
+				return false
+			}
+			return requiresTag(expr, tag)
+		}
+	}
+	return false
+}
+
+// requiresTag reports whether expr is unsatisfiable with tag unset, by trying
+// every combination of the other tags it names
+func requiresTag(expr constraint.Expr, tag string) bool {
+	var others []string
+	collectTags(expr, func(name string) {
+		if name != tag && !slices.Contains(others, name) {
+			others = append(others, name)
+		}
+	})
+	if len(others) > maxConstraintTags {
+		return false
+	}
+
+	for set := 0; set < 1<<len(others); set++ {
+		satisfied := expr.Eval(func(name string) bool {
+			i := slices.Index(others, name)
+			return i >= 0 && set&(1<<i) != 0
+		})
+		if satisfied {
+			return false
+		}
+	}
+	return true
+}
+
+// collectTags calls yield for every tag named in expr
+func collectTags(expr constraint.Expr, yield func(string)) {
+	switch e := expr.(type) {
+	case *constraint.TagExpr:
+		yield(e.Tag)
+	case *constraint.NotExpr:
+		collectTags(e.X, yield)
+	case *constraint.AndExpr:
+		collectTags(e.X, yield)
+		collectTags(e.Y, yield)
+	case *constraint.OrExpr:
+		collectTags(e.X, yield)
+		collectTags(e.Y, yield)
+	}
+}
@@ -0,0 +1,78 @@
+package experimental
+
+import (
+	"fmt"
+	"go/build/constraint"
+	"path/filepath"
+	"testing"
+
+	"github.com/a14e/gogreement/src/annotations"
+	"github.com/a14e/gogreement/src/codes"
+	"github.com/a14e/gogreement/src/config"
+	"github.com/a14e/gogreement/src/testutil/testfacts"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckExperimental(t *testing.T) {
+	// Load the opt-in file as well
+	t.Setenv("GOFLAGS", "-tags=experimental")
+
+	pass := testfacts.CreateTestPassWithFacts(t, "experimentalconsumer", "experimentalsource")
+	require.Len(t, pass.Files, 2, "both files of the consumer are loaded")
+
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+
+	violations := CheckExperimental(cfg, pass, &packageAnnotations)
+
+	var found []string
+	for _, v := range violations {
+		assert.Equal(t, codes.ExperimentalUsedWithoutTag, v.GetCode())
+		position := pass.Fset.Position(v.GetPos())
+		found = append(found, fmt.Sprintf("%s:%d %s", filepath.Base(position.Filename), position.Line, v.name()))
+	}
+	assert.Equal(t, []string{
+		"consumer.go:10 Client.Subscribe",
+		"consumer.go:13 Batch",
+		"consumer.go:14 Retry",
+		"consumer.go:21 Client.Subscribe",
+	}, found, "uses in the untagged file are reported, ignored ones are filtered by the reporter; the tagged file is clean")
+
+	assert.Equal(t,
+		`"Retry" is marked @experimental and may change; use it only in files built with the "experimental" tag (//go:build experimental)`,
+		violations[2].GetMessage())
+	assert.Equal(t, config.SeverityWarning, cfg.SeverityOf(codes.ExperimentalUsedWithoutTag), "EXP findings are warnings by default")
+}
+
+func TestCheckExperimental_DeclaringPackage(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "experimentalsource")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+
+	assert.Len(t, packageAnnotations.ExperimentalAnnotations, 3, "Client.Subscribe, Batch and Retry are @experimental")
+	assert.Empty(t, CheckExperimental(cfg, pass, &packageAnnotations), "the declaring package uses its own declarations freely")
+}
+
+func TestRequiresTag(t *testing.T) {
+	tests := []struct {
+		constraint string
+		expected   bool
+	}{
+		{"//go:build experimental", true},
+		{"//go:build experimental && linux", true},
+		{"//go:build (experimental || unstable) && experimental", true},
+		{"//go:build experimental || linux", false},
+		{"//go:build !experimental", false},
+		{"//go:build linux", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.constraint, func(t *testing.T) {
+			expr, err := constraint.Parse(tt.constraint)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, requiresTag(expr, "experimental"))
+		})
+	}
+}
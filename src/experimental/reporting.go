@@ -0,0 +1,68 @@
+package experimental
+
+import (
+	"fmt"
+	"go/token"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/a14e/gogreement/src/config"
+	"github.com/a14e/gogreement/src/reporting"
+	"github.com/a14e/gogreement/src/trace"
+	"github.com/a14e/gogreement/src/util"
+)
+
+// ExperimentalViolation represents a use of an @experimental declaration in a
+// file that is not built only with the opt-in tag
+// @immutable
+// implements reporting.Violation
+// implements reporting.ContractViolation
+type ExperimentalViolation struct {
+	ObjectName   string
+	ReceiverType string // Receiver type name for methods, empty otherwise
+	ObjectPkg    string // Package path where the declaration is
+	Tag          string // Opt-in build tag
+	Code         string // Error code from codes package
+	Pos          token.Pos
+}
+
+// GetCode returns the error code for this violation
+func (v ExperimentalViolation) GetCode() string {
+	return v.Code
+}
+
+// GetPos returns the position of the violation
+func (v ExperimentalViolation) GetPos() token.Pos {
+	return v.Pos
+}
+
+// GetMessage returns the main error message without formatting
+func (v ExperimentalViolation) GetMessage() string {
+	return fmt.Sprintf("%q is marked @experimental and may change; use it only in files built with the %q tag (//go:build %s)",
+		v.name(), v.Tag, v.Tag)
+}
+
+// GetContracts names the @experimental annotation of the used declaration
+func (v ExperimentalViolation) GetContracts() []trace.Key {
+	return []trace.Key{{Kind: "experimental", Package: v.ObjectPkg, Name: v.name()}}
+}
+
+// name is the declaration as "Name" or "Type.Method"
+func (v ExperimentalViolation) name() string {
+	if v.ReceiverType != "" {
+		return v.ReceiverType + "." + v.ObjectName
+	}
+	return v.ObjectName
+}
+
+// ReportViolations reports experimental violations using the new pretty formatter
+func ReportViolations(cfg *config.Config, pass *analysis.Pass, violations []ExperimentalViolation, ignoreSet *util.IgnoreSet) {
+	reporter := reporting.NewReporter(cfg, pass, ignoreSet)
+
+	// Convert to generic violations and report
+	generic := make([]reporting.Violation, 0, len(violations))
+	for _, violation := range violations {
+		generic = append(generic, violation)
+	}
+	reporter.ReportViolations(generic)
+}
@@ -117,6 +117,11 @@ func ReadIgnoreAnnotations(cfg *config.Config, pass *analysis.Pass) *util.Ignore
 		ignoreSet.AddModuleIgnore(cfg.ExcludeChecks)
 	}
 
+	// Seed globally ignored codes so they are suppressed in every package
+	if len(cfg.GlobalIgnoreCodes) > 0 {
+		ignoreSet.AddModuleIgnore(cfg.GlobalIgnoreCodes)
+	}
+
 	// Filter files based on configuration
 	filesToScan := cfg.FilterFiles(pass)
 
@@ -124,44 +129,44 @@ func ReadIgnoreAnnotations(cfg *config.Config, pass *analysis.Pass) *util.Ignore
 		// Scan all comment groups in the file
 		for _, commentGroup := range file.Comments {
 			for _, comment := range commentGroup.List {
-				// Normalize so single-line block comments (/* @ignore CODE */)
-				// are recognized the same as line comments.
-				text := util.NormalizeCommentText(comment.Text)
+				// Normalize so block comments (/* @ignore CODE */, including
+				// multi-line ones) are recognized the same as line comments.
+				for _, text := range util.CommentLines(comment.Text) {
+					// Micro-optimization: skip comments without @ignore
+					if !ignoreMatcher.Contains([]byte(text)) {
+						continue
+					}
 
-				// Micro-optimization: skip comments without @ignore
-				if !ignoreMatcher.Contains([]byte(text)) {
-					continue
-				}
+					// Parse @ignore annotation
+					if strings.Contains(text, "@ignore") {
+						startPos := comment.Pos()
+						var endPos token.Pos
 
-				// Parse @ignore annotation
-				if strings.Contains(text, "@ignore") {
-					startPos := comment.Pos()
-					var endPos token.Pos
-
-					// File-level annotation: comment before package declaration
-					if startPos < file.Package {
-						endPos = file.End()
-					} else {
-						// Check if this is an inline comment (on the same line as code)
-						inlineStart, inlineEnd, isInline := findInlineNode(file, comment, pass.Fset)
-						if isInline {
-							// Inline comment: scope covers the entire line
-							startPos = inlineStart
-							endPos = inlineEnd
+						// File-level annotation: comment before package declaration
+						if startPos < file.Package {
+							endPos = file.End()
 						} else {
-							// Block comment: find the next node after comment
-							endPos = findNextNodeAfterComment(file, startPos)
-
-							// If no next node found, scope is just the comment itself
-							if endPos == token.NoPos {
-								endPos = comment.End()
+							// Check if this is an inline comment (on the same line as code)
+							inlineStart, inlineEnd, isInline := findInlineNode(file, comment, pass.Fset)
+							if isInline {
+								// Inline comment: scope covers the entire line
+								startPos = inlineStart
+								endPos = inlineEnd
+							} else {
+								// Block comment: find the next node after comment
+								endPos = findNextNodeAfterComment(file, startPos)
+
+								// If no next node found, scope is just the comment itself
+								if endPos == token.NoPos {
+									endPos = comment.End()
+								}
 							}
 						}
-					}
 
-					annotation := parseIgnoreAnnotation(text, startPos, endPos)
-					if annotation != nil {
-						ignoreSet.Add(annotation)
+						annotation := parseIgnoreAnnotation(text, startPos, endPos)
+						if annotation != nil {
+							ignoreSet.Add(annotation)
+						}
 					}
 				}
 			}
@@ -173,7 +178,9 @@ func ReadIgnoreAnnotations(cfg *config.Config, pass *analysis.Pass) *util.Ignore
 
 // findInlineNode checks if comment is inline (on the same line as code).
 // Returns (startPos, endPos, true) if inline, or (0, 0, false) if not inline.
-// For inline comments, startPos is the beginning of the line, endPos is comment end.
+// For inline comments, startPos is the beginning of the line, endPos is comment
+// end, or the end of a simple statement that starts on the comment's line and
+// continues past it, such as a multi-line composite literal.
 // Example: var x int // @ignore CODE1
 func findInlineNode(file *ast.File, comment *ast.Comment, fset *token.FileSet) (start token.Pos, end token.Pos, found bool) {
 	commentPos := comment.Pos()
@@ -201,6 +208,7 @@ func findInlineNode(file *ast.File, comment *ast.Comment, fset *token.FileSet) (
 	// earlier line for a multi-line statement).
 	var hasCodeOnLine bool
 	var stmtStart token.Pos
+	var stmtEnd token.Pos
 
 	ast.Inspect(decl, func(n ast.Node) bool {
 		if n == nil {
@@ -212,6 +220,13 @@ func findInlineNode(file *ast.File, comment *ast.Comment, fset *token.FileSet) (
 			return false
 		}
 
+		// The outermost simple statement opened on the comment's line and
+		// still open after it is covered to its end
+		if stmtEnd == token.NoPos && isSimpleStatement(n) &&
+			n.End() > commentPos && fset.Position(n.Pos()).Line == commentLine {
+			stmtEnd = n.End()
+		}
+
 		nodeEndLine := fset.Position(n.End()).Line
 
 		// Check if this node ends on the same line as the comment
@@ -242,7 +257,24 @@ func findInlineNode(file *ast.File, comment *ast.Comment, fset *token.FileSet) (
 	if stmtStart != token.NoPos && stmtStart < start {
 		start = stmtStart
 	}
-	return start, comment.End(), true
+	end = comment.End()
+	if stmtEnd > end {
+		end = stmtEnd
+	}
+	return start, end, true
+}
+
+// isSimpleStatement reports whether n is a statement or variable declaration
+// without a body of its own. Compound statements (if, for, switch, ...) are
+// excluded, so an inline @ignore after their opening brace does not cover the
+// whole body.
+func isSimpleStatement(n ast.Node) bool {
+	switch n.(type) {
+	case *ast.AssignStmt, *ast.ExprStmt, *ast.DeclStmt, *ast.ValueSpec, *ast.ReturnStmt,
+		*ast.IncDecStmt, *ast.SendStmt, *ast.GoStmt, *ast.DeferStmt:
+		return true
+	}
+	return false
 }
 
 // findNextNodeAfterComment finds the end position of the scope affected by @ignore comment.
@@ -1,10 +1,12 @@
 package ignore
 
 import (
+	"fmt"
 	"go/ast"
 	"go/parser"
 	"go/token"
 	"go/types"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -508,6 +510,27 @@ func TestFunction() {
 		"CODE2 should NOT cover z := 3")
 }
 
+func TestReadIgnoreAnnotations_UnicodeSpaces(t *testing.T) {
+	// copy-pasted comments with a no-break space and a zero-width space
+	testCode := "package testpkg\n\nfunc TestFunction() {\n\tx := 1 //\u00a0@ignore CODE1\n\ty := 2 // \u200b@ignore CODE2\n\t_, _ = x, y\n}\n"
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", testCode, parser.ParseComments)
+	require.NoError(t, err)
+
+	pass := &analysis.Pass{
+		Fset:  fset,
+		Files: []*ast.File{file},
+		Pkg:   types.NewPackage("testpkg", "testpkg"),
+	}
+
+	ignoreSet := ReadIgnoreAnnotations(config.Empty(), pass)
+
+	stmts := file.Decls[0].(*ast.FuncDecl).Body.List
+	assert.True(t, ignoreSet.Contains("CODE1", stmts[0].Pos()))
+	assert.True(t, ignoreSet.Contains("CODE2", stmts[1].Pos()))
+}
+
 func TestReadIgnoreAnnotations_InlineVsBlock(t *testing.T) {
 	testCode := `package testpkg
 
@@ -661,6 +684,40 @@ func F() {
 		"@ignore inside a /* */ block comment should be parsed and applied")
 }
 
+func TestReadIgnoreAnnotations_MultiLineBlockComment(t *testing.T) {
+	testCode := `package testpkg
+
+func F() {
+	/*
+	 * Generated code writes the field on purpose.
+	 * @ignore CODE1, CODE2
+	 */
+	x := 1
+	_ = x
+}
+`
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", testCode, parser.ParseComments)
+	require.NoError(t, err)
+
+	pass := &analysis.Pass{
+		Fset:  fset,
+		Files: []*ast.File{file},
+		Pkg:   types.NewPackage("testpkg", "testpkg"),
+	}
+
+	ignoreSet := ReadIgnoreAnnotations(config.Empty(), pass)
+
+	funcDecl := file.Decls[0].(*ast.FuncDecl)
+	assign := funcDecl.Body.List[0].(*ast.AssignStmt)
+
+	assert.True(t, ignoreSet.Contains("CODE1", assign.Pos()),
+		"@ignore on a line of a multi-line block comment should apply to the next statement")
+	assert.True(t, ignoreSet.Contains("CODE2", assign.Pos()))
+	assert.False(t, ignoreSet.Contains("CODE3", assign.Pos()))
+}
+
 func TestReadIgnoreAnnotations_InlineOnAssignment(t *testing.T) {
 	testCode := `package testpkg
 
@@ -810,3 +867,151 @@ type TestHelper struct {
 	assert.False(t, ignoreSet.Contains("CODE1", h2Spec.Pos()),
 		"CODE1 should NOT cover h2 declaration")
 }
+
+func TestReadIgnoreAnnotations_GlobalIgnoreCodes(t *testing.T) {
+	testCode := `package testpkg
+
+type User struct {
+	Name string
+}
+
+func Mutate(u *User) {
+	u.Name = "changed"
+}
+`
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", testCode, parser.ParseComments)
+	require.NoError(t, err)
+
+	pass := &analysis.Pass{
+		Fset:  fset,
+		Files: []*ast.File{file},
+		Pkg:   types.NewPackage("testpkg", "testpkg"),
+	}
+
+	t.Run("category covers every code in every file", func(t *testing.T) {
+		cfg := config.Empty().WithGlobalIgnoreCodes([]string{"IMM"})
+		ignoreSet := ReadIgnoreAnnotations(cfg, pass)
+
+		for _, decl := range file.Decls {
+			assert.True(t, ignoreSet.Contains("IMM01", decl.Pos()))
+			assert.True(t, ignoreSet.Contains("IMM03", decl.End()))
+			assert.False(t, ignoreSet.Contains("CTOR01", decl.Pos()), "other categories must still be reported")
+		}
+	})
+
+	t.Run("specific code covers only itself", func(t *testing.T) {
+		cfg := config.Empty().WithGlobalIgnoreCodes([]string{"IMM01"})
+		ignoreSet := ReadIgnoreAnnotations(cfg, pass)
+
+		assert.True(t, ignoreSet.Contains("IMM01", file.Pos()))
+		assert.False(t, ignoreSet.Contains("IMM02", file.Pos()))
+	})
+
+	t.Run("ALL covers every code", func(t *testing.T) {
+		cfg := config.Empty().WithGlobalIgnoreCodes([]string{"ALL"})
+		ignoreSet := ReadIgnoreAnnotations(cfg, pass)
+
+		assert.True(t, ignoreSet.Contains("IMM01", file.Pos()))
+		assert.True(t, ignoreSet.Contains("PKGO02", file.End()))
+	})
+}
+
+func FuzzParseIgnore(f *testing.F) {
+	for _, seed := range []string{
+		"// @ignore CODE1",
+		"// @ignore CODE1, CODE2",
+		"// @ignore CODE1 , CODE2 , CODE3",
+		"// @ignore imm01, ctor01,",
+		"// @ignore IMM01 reason for ignoring",
+		"// @ignore",
+		"// @ignore @ignore IMM01",
+		"// @ignore ИММ01",
+		"/* @ignore IMM01 */",
+	} {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, comment string) {
+		result := parseIgnoreAnnotation(comment, token.Pos(1), token.Pos(10))
+		assert.Equal(t, result, parseIgnoreAnnotation(comment, token.Pos(1), token.Pos(10)))
+		if result == nil {
+			return
+		}
+		assert.Contains(t, comment, "@ignore")
+		require.NotEmpty(t, result.Codes)
+		for _, code := range result.Codes {
+			assert.NotEmpty(t, code)
+			assert.Equal(t, strings.ToUpper(code), code)
+			assert.Equal(t, -1, strings.IndexFunc(code, func(r rune) bool {
+				return (r < 'A' || r > 'Z') && (r < '0' || r > '9')
+			}), "code %q", code)
+		}
+	})
+}
+
+func TestReadIgnoreAnnotations_InlineCoversMultiLineStatement(t *testing.T) {
+	testCode := `package testpkg
+
+func TestFunction(u *User) {
+	u.Tags = append(u.Tags, // @ignore CODE1
+		"first",
+		"second",
+	)
+	u.Name = "after"
+
+	if u.Name != "" { // @ignore CODE2
+		u.Name = "inside"
+	}
+}
+
+type User struct {
+	Name string
+	Tags []string
+}
+`
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "test.go", testCode, parser.ParseComments)
+	require.NoError(t, err)
+
+	pass := &analysis.Pass{
+		Fset:  fset,
+		Files: []*ast.File{file},
+		Pkg:   types.NewPackage("testpkg", "testpkg"),
+	}
+
+	ignoreSet := ReadIgnoreAnnotations(config.Empty(), pass)
+	require.Equal(t, 2, ignoreSet.Len())
+
+	stmts := file.Decls[0].(*ast.FuncDecl).Body.List
+	call := stmts[0].(*ast.AssignStmt).Rhs[0].(*ast.CallExpr)
+	assert.True(t, ignoreSet.Contains("CODE1", stmts[0].Pos()))
+	assert.True(t, ignoreSet.Contains("CODE1", call.Args[2].Pos()),
+		"an inline @ignore on the first line covers the rest of the statement")
+	assert.False(t, ignoreSet.Contains("CODE1", stmts[1].Pos()), "the next statement is not covered")
+
+	ifStmt := stmts[2].(*ast.IfStmt)
+	assert.True(t, ignoreSet.Contains("CODE2", ifStmt.Cond.Pos()))
+	assert.False(t, ignoreSet.Contains("CODE2", ifStmt.Body.List[0].Pos()),
+		"an inline @ignore after an opening brace covers its line only")
+}
+
+func TestCheckIgnoreCodes(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "ignorecodes")
+
+	assert.Empty(t, CheckIgnoreCodes(config.Empty(), pass), "validation is opt-in")
+
+	violations := CheckIgnoreCodes(config.Empty().WithValidateIgnoreCodes(true), pass)
+
+	// IMM01, the IMM prefix, ALL and CTOR01 are valid; only the bogus codes are reported
+	var found []string
+	for _, v := range violations {
+		found = append(found, fmt.Sprintf("%d %s: %s", pass.Fset.Position(v.GetPos()).Line, v.GetCode(), v.GetMessage()))
+	}
+	assert.Equal(t, []string{
+		`13 IGN01: @ignore names unknown code "IMM99" and suppresses nothing`,
+		`20 IGN01: @ignore names unknown code "BOGUS1" and suppresses nothing`,
+	}, found)
+}
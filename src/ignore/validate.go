@@ -0,0 +1,77 @@
+package ignore
+
+import (
+	"fmt"
+	"go/token"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/a14e/gogreement/src/codes"
+	"github.com/a14e/gogreement/src/config"
+	"github.com/a14e/gogreement/src/util"
+)
+
+// UnknownCodeViolation represents an @ignore code that is neither a
+// registered code, a category prefix nor ALL
+// @immutable
+// implements reporting.Violation
+type UnknownCodeViolation struct {
+	IgnoreCode string // The code written in the @ignore marker
+	Code       string // Error code from codes package
+	Pos        token.Pos
+}
+
+// GetCode returns the error code for this violation
+func (v UnknownCodeViolation) GetCode() string {
+	return v.Code
+}
+
+// GetPos returns the position of the violation
+func (v UnknownCodeViolation) GetPos() token.Pos {
+	return v.Pos
+}
+
+// GetMessage returns the main error message without formatting
+func (v UnknownCodeViolation) GetMessage() string {
+	return fmt.Sprintf("@ignore names unknown code %q and suppresses nothing", v.IgnoreCode)
+}
+
+// CheckIgnoreCodes reports the codes of the package's @ignore markers that
+// suppress nothing because no such code exists, such as a mistyped IMM10.
+// Registered codes, category prefixes (IMM) and ALL are valid. The check runs
+// only with cfg.ValidateIgnoreCodes; each unknown code is reported at its
+// comment.
+func CheckIgnoreCodes(cfg *config.Config, pass *analysis.Pass) []UnknownCodeViolation {
+	if !cfg.ValidateIgnoreCodes {
+		return nil
+	}
+
+	var violations []UnknownCodeViolation
+	for file := range cfg.FilterFiles(pass) {
+		for _, commentGroup := range file.Comments {
+			for _, comment := range commentGroup.List {
+				for _, text := range util.CommentLines(comment.Text) {
+					if !ignoreMatcher.Contains([]byte(text)) {
+						continue
+					}
+					annotation := parseIgnoreAnnotation(text, comment.Pos(), comment.End())
+					if annotation == nil {
+						continue
+					}
+					for _, code := range annotation.Codes {
+						if codes.IsKnown(code) {
+							continue
+						}
+						violations = append(violations, UnknownCodeViolation{
+							IgnoreCode: code,
+							Code:       codes.IgnoreUnknownCode,
+							Pos:        comment.Pos(),
+						})
+					}
+				}
+			}
+		}
+	}
+
+	return violations
+}
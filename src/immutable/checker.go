@@ -6,6 +6,8 @@ import (
 	"go/ast"
 	"go/token"
 	"go/types"
+	"iter"
+	"slices"
 
 	"golang.org/x/tools/go/analysis"
 
@@ -20,43 +22,141 @@ func CheckImmutable(
 	pass *analysis.Pass,
 	packageAnnotations *annotations.PackageAnnotations,
 ) []ImmutableViolation {
+	violations, _ := checkImmutable(cfg, pass, packageAnnotations)
+	return violations
+}
+
+// checkImmutable runs the check and also returns the checker context it used,
+// or nil when the package sees no immutable types
+func checkImmutable(
+	cfg *config.Config,
+	pass *analysis.Pass,
+	packageAnnotations *annotations.PackageAnnotations,
+) ([]ImmutableViolation, *checkerContext) {
 	var violations []ImmutableViolation
 
 	// Build indices for efficient lookup during AST traversal
 	immutableTypes := indexing.BuildImmutableTypesIndex[*annotations.ImmutableCheckerFact](pass, packageAnnotations)
 	if immutableTypes.Empty() {
-		return violations // No immutable types to check
+		return violations, nil // No immutable types to check
 	}
 
 	constructors := indexing.BuildConstructorIndex[*annotations.ImmutableCheckerFact](pass, packageAnnotations)
 	mutableFields := indexing.BuildMutableFieldsIndex[*annotations.ImmutableCheckerFact](pass, packageAnnotations)
 
-	// Filter files based on configuration (skip test files by default)
-	filesToCheck := cfg.FilterFiles(pass)
+	// Filter files based on configuration (skip test files by default, honor check scopes)
+	filesToCheck := cfg.FilterFilesForCheck(pass, codes.ImmutableCategoryPrefix)
+
+	violations = append(violations, checkAllFieldsMutable(pass, filesToCheck, packageAnnotations)...)
 
 	ctx := &checkerContext{
-		pass:           pass,
-		immutableTypes: immutableTypes,
-		constructors:   constructors,
-		mutableFields:  mutableFields,
-	}
+		pass:            pass,
+		immutableTypes:  immutableTypes,
+		constructors:    constructors,
+		mutableFields:   mutableFields,
+		storedFuncLits:  make(map[*ast.FuncLit]bool),
+		addrAliases:     make(map[types.Object]aliasTarget),
+		mutators:        make(map[*types.Func]ImmutableViolation),
+		checkPointees:   cfg.CheckPointees,
+		warnAnonymous:   cfg.WarnAnonymousImmutableAssignment,
+		mutatingFuncs:   cfg.MutatingFunctions,
+		inPlaceFuncs:    cfg.InPlaceFunctions,
+		identityMethods: findIdentityMethods(pass),
+	}
+
+	// Deferred and goroutine calls, and method values stored in collections,
+	// are resolved after the traversal, once every mutator method of the
+	// package is known.
+	var deferredCalls []deferredCall
+	var storedMethods []storedMethodValue
 
 	// inspectNode handles assignment / inc-dec nodes. It reads the enclosing
 	// function from ctx, which is set per top-level declaration below.
 	// Compound assignments (+=, -=, ...) are processed separately from plain
 	// assignments so the same node is never reported twice.
-	inspectNode := func(n ast.Node) bool {
+	var inspectNode func(n ast.Node) bool
+	inspectNode = func(n ast.Node) bool {
 		switch node := n.(type) {
 		case *ast.AssignStmt:
+			markStoredFuncLits(ctx, node)
+			recordAddrAliases(ctx, node.Lhs, node.Rhs)
+			storedMethods = append(storedMethods, methodValuesStoredByAssign(ctx, node)...)
+			if node.Tok == token.ASSIGN && len(node.Lhs) == len(node.Rhs) {
+				for i, lhs := range node.Lhs {
+					if violation := checkAnonymousAssignment(ctx, lhs, node.Rhs[i]); violation != nil {
+						violations = append(violations, *violation)
+					}
+				}
+			}
 			if node.Tok != token.ASSIGN {
-				violations = append(violations, checkCompoundAssignment(ctx, node)...)
+				violations = append(violations, ctx.recordMutations(checkCompoundAssignment(ctx, node))...)
 				return true
 			}
-			violations = append(violations, checkAssignment(ctx, node)...)
+			violations = append(violations, ctx.recordMutations(checkAssignment(ctx, node))...)
+			return true
+
+		case *ast.GoStmt:
+			// A goroutine may run after the enclosing function returns, so a
+			// launched func literal is checked like a stored closure.
+			if lit, ok := ast.Unparen(node.Call.Fun).(*ast.FuncLit); ok {
+				ctx.storedFuncLits[lit] = true
+			}
+			deferredCalls = append(deferredCalls, newDeferredCall(ctx, node.Call, node, "goroutine"))
+			return true
+
+		case *ast.DeferStmt:
+			deferredCalls = append(deferredCalls, newDeferredCall(ctx, node.Call, node, "deferred"))
+			return true
+
+		case *ast.FuncLit:
+			// A closure stored in a field (w.onChange = func() { w.count++ })
+			// runs after the enclosing function returns, so it is checked
+			// without the enclosing constructor's exemption.
+			if !ctx.storedFuncLits[node] {
+				return true
+			}
+			enclosing := ctx.currentFunction
+			ctx.currentFunction = ""
+			ast.Inspect(node.Body, inspectNode)
+			ctx.currentFunction = enclosing
+			return false
+
+		case *ast.ValueSpec:
+			lhs := make([]ast.Expr, len(node.Names))
+			for i, name := range node.Names {
+				lhs[i] = name
+			}
+			recordAddrAliases(ctx, lhs, node.Values)
+			if node.Type != nil && len(lhs) == len(node.Values) {
+				for i, name := range lhs {
+					if violation := checkAnonymousAssignment(ctx, name, node.Values[i]); violation != nil {
+						violations = append(violations, *violation)
+					}
+				}
+			}
 			return true
 
 		case *ast.IncDecStmt:
-			violations = append(violations, checkIncDec(ctx, node)...)
+			violations = append(violations, ctx.recordMutations(checkIncDec(ctx, node))...)
+			return true
+
+		case *ast.CallExpr:
+			if violation := checkClearCall(ctx, node); violation != nil {
+				violations = append(violations, ctx.recordMutations([]ImmutableViolation{*violation})...)
+			}
+			if violation := checkInPlaceCall(ctx, node); violation != nil {
+				violations = append(violations, ctx.recordMutations([]ImmutableViolation{*violation})...)
+			}
+			if violation := checkPoolPut(ctx, node); violation != nil {
+				violations = append(violations, *violation)
+			}
+			violations = append(violations, checkMutatingCall(ctx, node)...)
+			storedMethods = append(storedMethods, methodValuesStoredByAppend(ctx, node)...)
+			return true
+
+		case *ast.CompositeLit:
+			violations = append(violations, checkPoolNew(ctx, node)...)
+			storedMethods = append(storedMethods, methodValuesStoredByLiteral(ctx, node)...)
 			return true
 		}
 		return true
@@ -69,28 +169,475 @@ func CheckImmutable(
 			// inside a package-level func literal must not deref a nil function).
 			// Mutations inside a named function (including its nested func
 			// literals) are evaluated against that function; package-level
-			// declarations are not inside any constructor.
+			// declarations are not inside any constructor. Only a package-level
+			// function can be a constructor, so a method sharing a
+			// constructor's name is never exempted.
 			if funcDecl, ok := decl.(*ast.FuncDecl); ok {
-				ctx.currentFunction = funcDecl.Name.Name
+				ctx.currentFunction = ""
+				if funcDecl.Recv == nil {
+					ctx.currentFunction = funcDecl.Name.Name
+				}
 				ctx.currentReceiver = extractReceiverInfo(ctx.pass, funcDecl)
+				ctx.currentMethod, _ = ctx.pass.TypesInfo.Defs[funcDecl.Name].(*types.Func)
 			} else {
 				ctx.currentFunction = ""
 				ctx.currentReceiver = nil
+				ctx.currentMethod = nil
 			}
+			// Aliases are tracked per function body and never outlive it.
+			clear(ctx.addrAliases)
 			ast.Inspect(decl, inspectNode)
+			if funcDecl, ok := decl.(*ast.FuncDecl); ok {
+				if violation := checkFieldAddressReturned(ctx, funcDecl); violation != nil {
+					violations = append(violations, *violation)
+				}
+			}
+		}
+	}
+
+	for _, call := range deferredCalls {
+		if violation := checkDeferredCall(ctx, call); violation != nil {
+			violations = append(violations, *violation)
+		}
+	}
+
+	for _, stored := range storedMethods {
+		if violation := checkStoredMethodValue(ctx, stored); violation != nil {
+			violations = append(violations, *violation)
+		}
+	}
+
+	return violations, ctx
+}
+
+// checkAllFieldsMutable reports the package's @immutable struct types whose
+// every field is marked @mutable. Nothing is left to protect, so the
+// annotation only misleads readers. Blank fields cannot be written and are
+// not counted; embedded fields cannot be @mutable.
+func checkAllFieldsMutable(
+	pass *analysis.Pass,
+	files iter.Seq[*ast.File],
+	packageAnnotations *annotations.PackageAnnotations,
+) []ImmutableViolation {
+	var violations []ImmutableViolation
+
+	checked := make(map[*token.File]bool)
+	for file := range files {
+		checked[pass.Fset.File(file.Pos())] = true
+	}
+
+	mutable := make(map[string]map[string]bool) // type -> @mutable field names
+	for _, annot := range packageAnnotations.MutableAnnotations {
+		if mutable[annot.OnType] == nil {
+			mutable[annot.OnType] = make(map[string]bool)
+		}
+		mutable[annot.OnType][annot.FieldName] = true
+	}
+
+	reported := make(map[string]bool)
+	for _, annot := range packageAnnotations.ImmutableAnnotations {
+		if reported[annot.OnType] || !checked[pass.Fset.File(annot.OnTypePos)] {
+			continue
+		}
+		typeName, ok := pass.Pkg.Scope().Lookup(annot.OnType).(*types.TypeName)
+		if !ok {
+			continue
+		}
+		structType, ok := typeName.Type().Underlying().(*types.Struct)
+		if !ok {
+			continue
+		}
+
+		fields := 0
+		allMutable := true
+		for field := range structType.Fields() {
+			if field.Name() == "_" {
+				continue
+			}
+			fields++
+			if field.Embedded() || !mutable[annot.OnType][field.Name()] {
+				allMutable = false
+				break
+			}
+		}
+		if fields == 0 || !allMutable {
+			continue
+		}
+
+		reported[annot.OnType] = true
+		reason := "advisory: every field is marked @mutable, so @immutable protects nothing; remove the @immutable annotation"
+		if fields == 1 {
+			reason = "advisory: the only field is marked @mutable, so @immutable protects nothing; remove the @immutable annotation"
 		}
+		violations = append(violations, ImmutableViolation{
+			TypeName:    annot.OnType,
+			TypePackage: pass.Pkg.Path(),
+			Code:        codes.MutableAllFields,
+			Pos:         annot.OnTypePos,
+			Reason:      reason,
+		})
 	}
 
 	return violations
 }
 
 type checkerContext struct {
-	pass            *analysis.Pass
-	immutableTypes  util.TypesMap
-	constructors    util.TypeAssociationRegistry
+	pass           *analysis.Pass
+	immutableTypes util.TypesMap
+	constructors   util.TypeAssociationRegistry
+	// mutableFields holds the @mutable fields of immutable types. A type is
+	// immutable only through its own @immutable annotation; a @mutable field
+	// is exempt from every field check whatever the annotations of its type.
 	mutableFields   util.TypeAssociationRegistry
 	currentFunction string
 	currentReceiver *receiverInfo
+	// storedFuncLits holds func literals assigned to a struct field or run
+	// as a goroutine; they escape the enclosing function and are checked as
+	// if outside it.
+	storedFuncLits map[*ast.FuncLit]bool
+	// addrAliases maps a local variable to the immutable value whose address
+	// it holds (p := &cfg), so writes through p are attributed to that value.
+	addrAliases map[types.Object]aliasTarget
+	// currentMethod is the function object of the enclosing declaration
+	currentMethod *types.Func
+	// mutators maps a method of an immutable type to the first mutation of
+	// its own receiver type found in its body
+	mutators map[*types.Func]ImmutableViolation
+	// checkPointees enables the IMM130 advisory for writes through pointer
+	// fields of immutable types
+	checkPointees bool
+	// warnAnonymous enables the IMM190 advisory for anonymous struct values
+	// assigned to variables of immutable types
+	warnAnonymous bool
+	// mutatingFuncs holds the full names of the functions that write through
+	// their pointer arguments (config.MutatingFunctions)
+	mutatingFuncs []string
+	// inPlaceFuncs holds the full names of the functions that reorder or
+	// overwrite their slice argument in place (config.InPlaceFunctions)
+	inPlaceFuncs []string
+	// identityMethods holds the methods of the package whose entire body is
+	// `return <receiver>`
+	identityMethods map[*types.Func]bool
+	// typeOfCalls counts the type lookups made through typeOf
+	typeOfCalls int
+}
+
+// isExternal reports whether an immutable type declared in pkgPath belongs to
+// another package than the one being checked
+func (ctx *checkerContext) isExternal(pkgPath string) bool {
+	return pkgPath != ctx.pass.Pkg.Path()
+}
+
+// typePos returns the declaration of the immutable type pkgPath.typeName, or
+// token.NoPos when its package is not reachable from the checked package
+func (ctx *checkerContext) typePos(pkgPath, typeName string) token.Pos {
+	visited := make(map[*types.Package]bool)
+	queue := []*types.Package{ctx.pass.Pkg}
+	for len(queue) > 0 {
+		pkg := queue[0]
+		queue = queue[1:]
+		if visited[pkg] {
+			continue
+		}
+		visited[pkg] = true
+		if pkg.Path() == pkgPath {
+			if obj, ok := pkg.Scope().Lookup(typeName).(*types.TypeName); ok {
+				return obj.Pos()
+			}
+			return token.NoPos
+		}
+		queue = append(queue, pkg.Imports()...)
+	}
+	return token.NoPos
+}
+
+// typeOf returns the type of expr, counting the lookup so tests can bound
+// the work done per node
+func (ctx *checkerContext) typeOf(expr ast.Expr) types.Type {
+	ctx.typeOfCalls++
+	return ctx.pass.TypesInfo.TypeOf(expr)
+}
+
+// recordMutations marks the enclosing method as a mutator when one of found
+// mutates the method's own receiver type, and returns found unchanged
+func (ctx *checkerContext) recordMutations(found []ImmutableViolation) []ImmutableViolation {
+	if ctx.currentMethod == nil || ctx.currentReceiver == nil {
+		return found
+	}
+	if _, ok := ctx.mutators[ctx.currentMethod]; ok {
+		return found
+	}
+	for _, violation := range found {
+		if violation.TypeName == ctx.currentReceiver.typeName {
+			ctx.mutators[ctx.currentMethod] = violation
+			break
+		}
+	}
+	return found
+}
+
+// deferredCall is a method call started by a go or defer statement, together
+// with the function it appears in
+// @immutable
+type deferredCall struct {
+	call     *ast.CallExpr
+	stmt     ast.Stmt
+	verb     string // "deferred" or "goroutine"
+	function string
+}
+
+func newDeferredCall(ctx *checkerContext, call *ast.CallExpr, stmt ast.Stmt, verb string) deferredCall {
+	return deferredCall{call: call, stmt: stmt, verb: verb, function: ctx.currentFunction}
+}
+
+// checkDeferredCall reports a go/defer call to a method whose body mutates its
+// immutable receiver, e.g. defer p.reset(). The mutation runs when the call
+// does, so it is reported at the go/defer site in addition to the method body.
+// Method expressions with an explicit receiver argument, defer
+// (*T).reset(p), resolve to the same method and are reported alike.
+// A deferred call inside a constructor of the type still runs during
+// construction and is allowed; a goroutine may outlive it and is not.
+func checkDeferredCall(ctx *checkerContext, deferred deferredCall) *ImmutableViolation {
+	selector, ok := ast.Unparen(deferred.call.Fun).(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+	method, ok := ctx.pass.TypesInfo.Uses[selector.Sel].(*types.Func)
+	if !ok {
+		return nil
+	}
+	mutation, ok := ctx.mutators[method]
+	if !ok {
+		return nil
+	}
+
+	pkgPath := method.Pkg().Path()
+	if deferred.verb == "deferred" && ctx.constructors.Match(pkgPath, deferred.function, mutation.TypeName) {
+		return nil
+	}
+
+	return &ImmutableViolation{
+		TypeName:    mutation.TypeName,
+		TypePackage: mutation.TypePackage,
+		TypePos:     mutation.TypePos,
+		External:    mutation.External,
+		Code:        mutation.Code,
+		Pos:         deferred.stmt.Pos(),
+		Reason:      fmt.Sprintf("%s call to method %q mutates the immutable receiver", deferred.verb, method.Name()),
+		Node:        deferred.stmt,
+	}
+}
+
+// storedMethodValue is a method value (p.reset) stored as an element of a
+// map, slice or array
+// @immutable
+type storedMethodValue struct {
+	selector   *ast.SelectorExpr
+	method     *types.Func
+	collection string // "map", "slice" or "array"
+}
+
+// checkStoredMethodValue reports the IMM160 advisory for a stored method value
+// whose method mutates its immutable receiver. Calling it later through the
+// collection (handlers["x"]()) mutates the receiver at a site no call-site
+// check can resolve, so the store itself is reported.
+func checkStoredMethodValue(ctx *checkerContext, stored storedMethodValue) *ImmutableViolation {
+	mutation, ok := ctx.mutators[stored.method]
+	if !ok {
+		return nil
+	}
+
+	return &ImmutableViolation{
+		TypeName:    mutation.TypeName,
+		TypePackage: mutation.TypePackage,
+		TypePos:     mutation.TypePos,
+		External:    mutation.External,
+		Code:        codes.ImmutableMutatorStored,
+		Pos:         stored.selector.Pos(),
+		Reason: fmt.Sprintf("advisory: method value %q stored in a %s mutates the immutable receiver when called",
+			stored.method.Name(), stored.collection),
+		Node: stored.selector,
+	}
+}
+
+// methodValuesStoredByAssign returns the method values assigned to a
+// collection element: handlers["x"] = p.reset
+func methodValuesStoredByAssign(ctx *checkerContext, stmt *ast.AssignStmt) []storedMethodValue {
+	if len(stmt.Lhs) != len(stmt.Rhs) {
+		return nil
+	}
+	var stored []storedMethodValue
+	for i, lhs := range stmt.Lhs {
+		index, ok := ast.Unparen(lhs).(*ast.IndexExpr)
+		if !ok {
+			continue
+		}
+		collection, ok := collectionKind(ctx.typeOf(index.X))
+		if !ok {
+			continue
+		}
+		if value, ok := methodValueOf(ctx, stmt.Rhs[i], collection); ok {
+			stored = append(stored, value)
+		}
+	}
+	return stored
+}
+
+// methodValuesStoredByLiteral returns the method values among the elements of
+// a map, slice or array literal: map[string]func(){"x": p.reset}
+func methodValuesStoredByLiteral(ctx *checkerContext, lit *ast.CompositeLit) []storedMethodValue {
+	collection, ok := collectionKind(ctx.typeOf(lit))
+	if !ok {
+		return nil
+	}
+	var stored []storedMethodValue
+	for _, elt := range lit.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			elt = kv.Value
+		}
+		if value, ok := methodValueOf(ctx, elt, collection); ok {
+			stored = append(stored, value)
+		}
+	}
+	return stored
+}
+
+// methodValuesStoredByAppend returns the method values appended to a slice:
+// append(hooks, p.reset)
+func methodValuesStoredByAppend(ctx *checkerContext, call *ast.CallExpr) []storedMethodValue {
+	ident, ok := ast.Unparen(call.Fun).(*ast.Ident)
+	if !ok || len(call.Args) < 2 {
+		return nil
+	}
+	if builtin, ok := ctx.pass.TypesInfo.Uses[ident].(*types.Builtin); !ok || builtin.Name() != "append" {
+		return nil
+	}
+	var stored []storedMethodValue
+	for _, arg := range call.Args[1:] {
+		if value, ok := methodValueOf(ctx, arg, "slice"); ok {
+			stored = append(stored, value)
+		}
+	}
+	return stored
+}
+
+// methodValueOf resolves expr as a method value bound to a receiver (p.reset).
+// Method expressions ((*T).reset) bind no receiver and are not matched.
+func methodValueOf(ctx *checkerContext, expr ast.Expr, collection string) (storedMethodValue, bool) {
+	selector, ok := ast.Unparen(expr).(*ast.SelectorExpr)
+	if !ok {
+		return storedMethodValue{}, false
+	}
+	selection, ok := ctx.pass.TypesInfo.Selections[selector]
+	if !ok || selection.Kind() != types.MethodVal {
+		return storedMethodValue{}, false
+	}
+	method, ok := selection.Obj().(*types.Func)
+	if !ok {
+		return storedMethodValue{}, false
+	}
+	return storedMethodValue{selector: selector, method: method, collection: collection}, true
+}
+
+// collectionKind names the kind of collection t is, if any
+func collectionKind(t types.Type) (string, bool) {
+	if t == nil {
+		return "", false
+	}
+	switch t.Underlying().(type) {
+	case *types.Map:
+		return "map", true
+	case *types.Slice:
+		return "slice", true
+	case *types.Array:
+		return "array", true
+	}
+	return "", false
+}
+
+// aliasTarget is the immutable type whose storage a local pointer aliases
+// @immutable
+type aliasTarget struct {
+	typeName string
+	pkgPath  string
+}
+
+// recordAddrAliases records locals assigned the address of an immutable value
+// (p := &cfg) or of an embedded field of one (p := &o.Inner). Assigning any
+// other value to a tracked local drops the alias.
+func recordAddrAliases(ctx *checkerContext, lhs []ast.Expr, rhs []ast.Expr) {
+	if len(lhs) != len(rhs) {
+		return
+	}
+	for i, left := range lhs {
+		ident, ok := ast.Unparen(left).(*ast.Ident)
+		if !ok || ident.Name == "_" {
+			continue
+		}
+		obj := ctx.pass.TypesInfo.ObjectOf(ident)
+		if obj == nil {
+			continue
+		}
+		if target, ok := addressedImmutable(ctx, rhs[i]); ok {
+			ctx.addrAliases[obj] = target
+		} else {
+			delete(ctx.addrAliases, obj)
+		}
+	}
+}
+
+// addressedImmutable reports the immutable type whose storage is exposed by
+// an address-of expression (&cfg, &o.Inner)
+func addressedImmutable(ctx *checkerContext, expr ast.Expr) (aliasTarget, bool) {
+	unary, ok := ast.Unparen(expr).(*ast.UnaryExpr)
+	if !ok || unary.Op != token.AND {
+		return aliasTarget{}, false
+	}
+	operand := ast.Unparen(unary.X)
+
+	if named, ok := types.Unalias(ctx.typeOf(operand)).(*types.Named); ok && named.Obj().Pkg() != nil {
+		typeName := named.Obj().Name()
+		pkgPath := named.Obj().Pkg().Path()
+		if ctx.immutableTypes.Contains(pkgPath, typeName) {
+			return aliasTarget{typeName: typeName, pkgPath: pkgPath}, true
+		}
+	}
+
+	if typeName, pkgPath, ok := immutableViaEmbedded(ctx, operand); ok {
+		return aliasTarget{typeName: typeName, pkgPath: pkgPath}, true
+	}
+	return aliasTarget{}, false
+}
+
+// aliasOf returns the immutable value aliased by expr when it is a tracked local
+func aliasOf(ctx *checkerContext, expr ast.Expr) (aliasTarget, bool) {
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+	if !ok {
+		return aliasTarget{}, false
+	}
+	obj := ctx.pass.TypesInfo.ObjectOf(ident)
+	if obj == nil {
+		return aliasTarget{}, false
+	}
+	target, ok := ctx.addrAliases[obj]
+	return target, ok
+}
+
+// markStoredFuncLits records func literals on the right-hand side of an
+// assignment whose matching left-hand side is a field selector.
+func markStoredFuncLits(ctx *checkerContext, stmt *ast.AssignStmt) {
+	if len(stmt.Lhs) != len(stmt.Rhs) {
+		return
+	}
+	for i, rhs := range stmt.Rhs {
+		lit, ok := ast.Unparen(rhs).(*ast.FuncLit)
+		if !ok {
+			continue
+		}
+		if _, ok := ast.Unparen(stmt.Lhs[i]).(*ast.SelectorExpr); ok {
+			ctx.storedFuncLits[lit] = true
+		}
+	}
 }
 
 // receiverInfo contains information about a method's receiver
@@ -160,7 +707,15 @@ func checkLHS(
 		return checkIndexAssignment(ctx, stmt, e)
 	case *ast.StarExpr:
 		// Check for receiver reassignment: *receiver = value
-		return checkReceiverReassignment(ctx, stmt, e)
+		if violation := checkReceiverReassignment(ctx, stmt, e); violation != nil {
+			return violation
+		}
+		// Check for overwriting an aliased immutable value: p := &cfg; *p = value
+		if violation := checkAliasReassignment(ctx, stmt, e); violation != nil {
+			return violation
+		}
+		// Check for writing the pointee of a pointer field: *cfg.counter = value
+		return checkPointeeMutation(ctx, stmt, e, "assign to")
 	}
 
 	return nil
@@ -176,6 +731,10 @@ func checkFieldAssignment(
 		return nil
 	}
 
+	if isValueReceiverCopy(ctx, selector.X) || isGenericHelperCopy(ctx, selector.X) {
+		return nil
+	}
+
 	if ctx.constructors.Match(pkgPath, ctx.currentFunction, typeName) {
 		return nil
 	}
@@ -185,39 +744,252 @@ func checkFieldAssignment(
 		return nil
 	}
 
+	code, reason := throughIdentityMethod(ctx, selector, codes.ImmutableFieldAssignment,
+		fmt.Sprintf("cannot assign to field %q of immutable type", selector.Sel.Name))
 	return &ImmutableViolation{
-		TypeName: typeName,
-		Code:     codes.ImmutableFieldAssignment,
-		Pos:      selector.Pos(),
-		Reason:   fmt.Sprintf("cannot assign to field %q of immutable type", selector.Sel.Name),
-		Node:     stmt,
+		TypeName:    typeName,
+		TypePackage: pkgPath,
+		TypePos:     ctx.typePos(pkgPath, typeName),
+		External:    ctx.isExternal(pkgPath),
+		Code:        code,
+		Pos:         selector.Pos(),
+		Reason:      reason,
+		Node:        stmt,
 	}
 }
 
+// throughIdentityMethod returns the code and reason of a write to the field
+// selector: the IMM140 advisory when the field is reached through the result
+// of a method that returns its receiver, such as p.self().Name = value, and
+// code and reason unchanged otherwise
+func throughIdentityMethod(ctx *checkerContext, selector *ast.SelectorExpr, code, reason string) (string, string) {
+	call, ok := ast.Unparen(selector.X).(*ast.CallExpr)
+	if !ok {
+		return code, reason
+	}
+	method := util.CalledFunction(ctx.pass.TypesInfo, call.Fun)
+	if method == nil || !ctx.identityMethods[method.Origin()] {
+		return code, reason
+	}
+
+	return codes.ImmutableIdentityMethodWrite, fmt.Sprintf("advisory: %s; %s() returns its receiver", reason, method.Name())
+}
+
+// findIdentityMethods returns the methods declared in the files of pass whose
+// entire body is `return <receiver>`. Only the package's own methods are
+// found, as the bodies of imported ones are not available.
+func findIdentityMethods(pass *analysis.Pass) map[*types.Func]bool {
+	methods := make(map[*types.Func]bool)
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			fn, ok := decl.(*ast.FuncDecl)
+			if !ok || fn.Recv == nil || len(fn.Recv.List) != 1 || len(fn.Recv.List[0].Names) != 1 {
+				continue
+			}
+			if fn.Body == nil || len(fn.Body.List) != 1 {
+				continue
+			}
+			ret, ok := fn.Body.List[0].(*ast.ReturnStmt)
+			if !ok || len(ret.Results) != 1 {
+				continue
+			}
+			ident, ok := ast.Unparen(ret.Results[0]).(*ast.Ident)
+			if !ok {
+				continue
+			}
+			recv := pass.TypesInfo.Defs[fn.Recv.List[0].Names[0]]
+			if recv == nil || pass.TypesInfo.Uses[ident] != recv {
+				continue
+			}
+			if method, ok := pass.TypesInfo.Defs[fn.Name].(*types.Func); ok {
+				methods[method] = true
+			}
+		}
+	}
+	return methods
+}
+
+// isValueReceiverCopy reports whether expr is the method's value receiver, or a
+// field path inside it (p.Inner) with no pointer along the way. Such an
+// expression names the method's own copy of the value, so writing its fields
+// cannot affect the caller's value.
+func isValueReceiverCopy(ctx *checkerContext, expr ast.Expr) bool {
+	if ctx.currentReceiver == nil || ctx.currentReceiver.obj == nil {
+		return false
+	}
+
+	for {
+		expr = ast.Unparen(expr)
+		if _, isPtr := ctx.typeOf(expr).(*types.Pointer); isPtr {
+			return false
+		}
+
+		switch e := expr.(type) {
+		case *ast.Ident:
+			return ctx.pass.TypesInfo.Uses[e] == ctx.currentReceiver.obj
+		case *ast.SelectorExpr:
+			expr = e.X
+		default:
+			return false
+		}
+	}
+}
+
+// isGenericHelperCopy reports whether expr is the result of a call to a
+// generic helper returning a pointer to a copy of its argument, such as
+// func Ptr[T any](v T) *T, or a field path inside that result with no
+// further pointer along the way. The helper's result points at its own
+// parameter, a copy like a value receiver, whenever it is declared to return
+// *T for a type parameter T that it only receives by value: no parameter
+// gives it a pointer to, or other reference to, a T of the caller.
+func isGenericHelperCopy(ctx *checkerContext, expr ast.Expr) bool {
+	for {
+		expr = ast.Unparen(expr)
+		switch e := expr.(type) {
+		case *ast.CallExpr:
+			return returnsPointerToCopy(util.CalledFunction(ctx.pass.TypesInfo, e.Fun))
+		case *ast.SelectorExpr:
+			if _, isPtr := ctx.typeOf(e).(*types.Pointer); isPtr {
+				return false
+			}
+			expr = e.X
+		default:
+			return false
+		}
+	}
+}
+
+// returnsPointerToCopy reports whether fn is declared as func[T](..., v T, ...) *T
+// with T received by value only
+func returnsPointerToCopy(fn *types.Func) bool {
+	if fn == nil {
+		return false
+	}
+	// The declared signature keeps T as a type parameter; an instance has it
+	// replaced by the type argument
+	signature, ok := fn.Origin().Type().(*types.Signature)
+	if !ok || signature.TypeParams().Len() == 0 || signature.Results().Len() != 1 {
+		return false
+	}
+	pointer, ok := signature.Results().At(0).Type().(*types.Pointer)
+	if !ok {
+		return false
+	}
+	typeParam, ok := pointer.Elem().(*types.TypeParam)
+	if !ok {
+		return false
+	}
+
+	byValue := false
+	for param := range signature.Params().Variables() {
+		switch {
+		case param.Type() == typeParam:
+			byValue = true
+		case mentionsType(param.Type(), typeParam):
+			return false
+		}
+	}
+	return byValue
+}
+
+// mentionsType reports whether t is target or is built from it, such as
+// *target, []target or a generic type instantiated with target
+func mentionsType(t, target types.Type) bool {
+	switch t := t.(type) {
+	case *types.TypeParam:
+		return t == target
+	case *types.Pointer:
+		return mentionsType(t.Elem(), target)
+	case *types.Slice:
+		return mentionsType(t.Elem(), target)
+	case *types.Array:
+		return mentionsType(t.Elem(), target)
+	case *types.Map:
+		return mentionsType(t.Key(), target) || mentionsType(t.Elem(), target)
+	case *types.Chan:
+		return mentionsType(t.Elem(), target)
+	case *types.Named:
+		for arg := range t.TypeArgs().Types() {
+			if mentionsType(arg, target) {
+				return true
+			}
+		}
+	case *types.Signature:
+		for v := range t.Params().Variables() {
+			if mentionsType(v.Type(), target) {
+				return true
+			}
+		}
+		for v := range t.Results().Variables() {
+			if mentionsType(v.Type(), target) {
+				return true
+			}
+		}
+	case *types.Struct:
+		for field := range t.Fields() {
+			if mentionsType(field.Type(), target) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // immutableReceiverOfField resolves the immutable type whose field is written by
-// selector. It first checks the immediately-selected receiver (t.field), then,
-// if that type is not immutable, walks an explicit embedded-field access path
+// selector. It first checks the immediately-selected receiver (t.field) and
+// pointer conversions ((*Base)(p).field) with immutableFieldOwner, then, if
+// that type is not immutable, walks an explicit embedded-field access path
 // (o.Inner.field) so a write through the embedded path is treated the same as
 // the promoted form (o.field) that field promotion would expose.
 func immutableReceiverOfField(ctx *checkerContext, selector *ast.SelectorExpr) (string, string, bool) {
-	receiverType := ctx.pass.TypesInfo.TypeOf(selector.X)
-	if receiverType == nil {
-		return "", "", false
+	if typeName, pkgPath, ok := immutableFieldOwner(ctx, selector); ok {
+		return typeName, pkgPath, true
 	}
 
-	if ptr, ok := receiverType.(*types.Pointer); ok {
-		receiverType = ptr.Elem()
+	if typeName, pkgPath, ok := immutableViaEmbedded(ctx, selector.X); ok {
+		return typeName, pkgPath, true
 	}
 
-	if named, ok := receiverType.(*types.Named); ok && named.Obj().Pkg() != nil {
-		typeName := named.Obj().Name()
-		pkgPath := named.Obj().Pkg().Path()
-		if ctx.immutableTypes.Contains(pkgPath, typeName) {
-			return typeName, pkgPath, true
-		}
+	// A local holding the address of an embedded field (inner := &o.Inner)
+	// exposes the immutable value's storage just like o.Inner does.
+	if target, ok := aliasOf(ctx, selector.X); ok {
+		return target.typeName, target.pkgPath, true
+	}
+
+	return "", "", false
+}
+
+// immutableFieldOwner resolves the immutable type whose field selector
+// selects directly: the type of selector.X or the type it points to, or the
+// operand of a pointer conversion ((*Base)(p).field). Embedded access paths and
+// address aliases are left to immutableReceiverOfField.
+func immutableFieldOwner(ctx *checkerContext, selector *ast.SelectorExpr) (string, string, bool) {
+	if typeName, pkgPath, ok := immutableTypeOf(ctx, types.Unalias(ctx.typeOf(selector.X))); ok {
+		return typeName, pkgPath, true
 	}
+	return immutableViaConversion(ctx, selector.X)
+}
 
-	return immutableViaEmbedded(ctx, selector.X)
+// immutableViaConversion reports the immutable type behind a pointer
+// conversion such as (*Base)(p) or (*Base)(unsafe.Pointer(p)): the result
+// points at the same storage as p, so writes through it mutate the immutable
+// value under another type's name. Only genuine conversions, whose callee is a
+// type, are followed; a conversion of a value (Base(v)) is a copy.
+func immutableViaConversion(ctx *checkerContext, expr ast.Expr) (string, string, bool) {
+	for {
+		call, ok := ast.Unparen(expr).(*ast.CallExpr)
+		if !ok || len(call.Args) != 1 || !ctx.pass.TypesInfo.Types[call.Fun].IsType() {
+			return "", "", false
+		}
+		expr = call.Args[0]
+
+		operandType := types.Unalias(ctx.typeOf(expr))
+		if _, ok := operandType.(*types.Pointer); !ok {
+			// unsafe.Pointer(p) and uintptr hops keep the walk going
+			continue
+		}
+		return immutableTypeOf(ctx, operandType)
+	}
 }
 
 // immutableViaEmbedded reports the immutable type reachable from expr through one
@@ -239,12 +1011,12 @@ func immutableViaEmbedded(ctx *checkerContext, expr ast.Expr) (string, string, b
 		return "", "", false
 	}
 
-	baseType := ctx.pass.TypesInfo.TypeOf(sel.X)
+	baseType := ctx.typeOf(sel.X)
 	if baseType != nil {
-		if ptr, ok := baseType.(*types.Pointer); ok {
+		if ptr, ok := types.Unalias(baseType).(*types.Pointer); ok {
 			baseType = ptr.Elem()
 		}
-		if named, ok := baseType.(*types.Named); ok && named.Obj().Pkg() != nil {
+		if named, ok := types.Unalias(baseType).(*types.Named); ok && named.Obj().Pkg() != nil {
 			typeName := named.Obj().Name()
 			pkgPath := named.Obj().Pkg().Path()
 			if ctx.immutableTypes.Contains(pkgPath, typeName) {
@@ -274,34 +1046,72 @@ func checkImmutableIndex(
 	index *ast.IndexExpr,
 	node ast.Node,
 ) *ImmutableViolation {
-	selector, ok := index.X.(*ast.SelectorExpr)
+	// Indexing a pointer-to-array field (x.arr[0]) dereferences it implicitly;
+	// (*x.arr)[0] spells the dereference out and writes to the same array
+	base := ast.Unparen(index.X)
+	if star, ok := base.(*ast.StarExpr); ok {
+		base = ast.Unparen(star.X)
+	}
+
+	selector, ok := base.(*ast.SelectorExpr)
 	if !ok {
 		return nil
 	}
 
-	receiverType := ctx.pass.TypesInfo.TypeOf(selector.X)
-	if receiverType == nil {
+	typeName, pkgPath, ok := immutableFieldOwner(ctx, selector)
+	if !ok {
 		return nil
 	}
 
-	if ptr, ok := receiverType.(*types.Pointer); ok {
-		receiverType = ptr.Elem()
+	if ctx.constructors.Match(pkgPath, ctx.currentFunction, typeName) {
+		return nil
 	}
 
-	named, ok := receiverType.(*types.Named)
-	if !ok {
+	// Check if the field is marked as @mutable
+	if ctx.mutableFields.Match(pkgPath, selector.Sel.Name, typeName) {
 		return nil
 	}
 
-	typeName := named.Obj().Name()
-	pkg := named.Obj().Pkg()
-	if pkg == nil {
+	reason := fmt.Sprintf("cannot modify element of field %q of immutable type", selector.Sel.Name)
+	if incDec, ok := node.(*ast.IncDecStmt); ok {
+		reason = fmt.Sprintf("cannot use %s on element of field %q of immutable type", incDec.Tok, selector.Sel.Name)
+	}
+
+	return &ImmutableViolation{
+		TypeName:    typeName,
+		TypePackage: pkgPath,
+		TypePos:     ctx.typePos(pkgPath, typeName),
+		External:    ctx.isExternal(pkgPath),
+		Code:        codes.ImmutableIndexAssignment,
+		Pos:         index.Pos(),
+		Reason:      reason,
+		Node:        node,
+	}
+}
+
+// checkClearCall reports IMM04 when the clear builtin empties a slice or map
+// field of an immutable type, e.g. clear(x.items). Like an index write it
+// changes storage shared by every copy of the value, so a value receiver is
+// not exempt.
+func checkClearCall(ctx *checkerContext, call *ast.CallExpr) *ImmutableViolation {
+	ident, ok := ast.Unparen(call.Fun).(*ast.Ident)
+	if !ok || len(call.Args) != 1 {
+		return nil
+	}
+	if builtin, ok := ctx.pass.TypesInfo.Uses[ident].(*types.Builtin); !ok || builtin.Name() != "clear" {
 		return nil
 	}
 
-	pkgPath := pkg.Path()
+	selector, ok := ast.Unparen(call.Args[0]).(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+	if selection := ctx.pass.TypesInfo.Selections[selector]; selection == nil || selection.Kind() != types.FieldVal {
+		return nil
+	}
 
-	if !ctx.immutableTypes.Contains(pkgPath, typeName) {
+	typeName, pkgPath, ok := immutableReceiverOfField(ctx, selector)
+	if !ok {
 		return nil
 	}
 
@@ -314,12 +1124,76 @@ func checkImmutableIndex(
 		return nil
 	}
 
+	kind := "slice"
+	if _, isMap := ctx.typeOf(selector).Underlying().(*types.Map); isMap {
+		kind = "map"
+	}
+
+	return &ImmutableViolation{
+		TypeName:    typeName,
+		TypePackage: pkgPath,
+		TypePos:     ctx.typePos(pkgPath, typeName),
+		External:    ctx.isExternal(pkgPath),
+		Code:        codes.ImmutableIndexAssignment,
+		Pos:         call.Pos(),
+		Reason:      fmt.Sprintf("cannot clear %s field %q of immutable type", kind, selector.Sel.Name),
+		Node:        call,
+	}
+}
+
+// checkInPlaceCall reports IMM04 when a slice field of an immutable type is
+// passed to a function that sorts, reverses or otherwise rewrites it in place,
+// e.g. slices.Sort(x.items). The functions are matched by full name against
+// config.InPlaceFunctions. Like clear, the call writes to the backing array
+// shared by every copy of the value, so a value receiver is not exempt.
+func checkInPlaceCall(ctx *checkerContext, call *ast.CallExpr) *ImmutableViolation {
+	var ident *ast.Ident
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		ident = fun
+	case *ast.SelectorExpr:
+		ident = fun.Sel
+	default:
+		return nil
+	}
+	if len(call.Args) == 0 {
+		return nil
+	}
+	fn, ok := ctx.pass.TypesInfo.Uses[ident].(*types.Func)
+	if !ok || !slices.Contains(ctx.inPlaceFuncs, fn.Origin().FullName()) {
+		return nil
+	}
+
+	selector, ok := ast.Unparen(call.Args[0]).(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+	if selection := ctx.pass.TypesInfo.Selections[selector]; selection == nil || selection.Kind() != types.FieldVal {
+		return nil
+	}
+	if _, isSlice := ctx.typeOf(selector).Underlying().(*types.Slice); !isSlice {
+		return nil
+	}
+
+	typeName, pkgPath, ok := immutableReceiverOfField(ctx, selector)
+	if !ok {
+		return nil
+	}
+	if ctx.constructors.Match(pkgPath, ctx.currentFunction, typeName) ||
+		ctx.mutableFields.Match(pkgPath, selector.Sel.Name, typeName) {
+		return nil
+	}
+
 	return &ImmutableViolation{
-		TypeName: typeName,
-		Code:     codes.ImmutableIndexAssignment,
-		Pos:      index.Pos(),
-		Reason:   fmt.Sprintf("cannot modify element of field %q of immutable type", selector.Sel.Name),
-		Node:     node,
+		TypeName:    typeName,
+		TypePackage: pkgPath,
+		TypePos:     ctx.typePos(pkgPath, typeName),
+		External:    ctx.isExternal(pkgPath),
+		Code:        codes.ImmutableIndexAssignment,
+		Pos:         call.Pos(),
+		Reason: fmt.Sprintf("cannot reorder/mutate slice field %q of immutable type with %s.%s",
+			selector.Sel.Name, fn.Pkg().Name(), fn.Name()),
+		Node: call,
 	}
 }
 
@@ -353,6 +1227,9 @@ func checkIncDec(
 	// Check for receiver increment/decrement: *receiver++
 	if star, ok := target.(*ast.StarExpr); ok {
 		violation := checkReceiverIncDec(ctx, node, star)
+		if violation == nil {
+			violation = checkPointeeMutation(ctx, node, star, "use "+node.Tok.String()+" on")
+		}
 		if violation != nil {
 			violations = append(violations, *violation)
 		}
@@ -367,29 +1244,12 @@ func checkFieldIncDec(
 	node *ast.IncDecStmt,
 	selector *ast.SelectorExpr,
 ) *ImmutableViolation {
-	receiverType := ctx.pass.TypesInfo.TypeOf(selector.X)
-	if receiverType == nil {
-		return nil
-	}
-
-	if ptr, ok := receiverType.(*types.Pointer); ok {
-		receiverType = ptr.Elem()
-	}
-
-	named, ok := receiverType.(*types.Named)
+	typeName, pkgPath, ok := immutableFieldOwner(ctx, selector)
 	if !ok {
 		return nil
 	}
 
-	typeName := named.Obj().Name()
-	pkg := named.Obj().Pkg()
-	if pkg == nil {
-		return nil
-	}
-
-	pkgPath := pkg.Path()
-
-	if !ctx.immutableTypes.Contains(pkgPath, typeName) {
+	if isValueReceiverCopy(ctx, selector.X) || isGenericHelperCopy(ctx, selector.X) {
 		return nil
 	}
 
@@ -407,12 +1267,17 @@ func checkFieldIncDec(
 		op = "--"
 	}
 
+	code, reason := throughIdentityMethod(ctx, selector, codes.ImmutableFieldIncDec,
+		fmt.Sprintf("cannot use %s on field %q of immutable type (outside constructor)", op, selector.Sel.Name))
 	return &ImmutableViolation{
-		TypeName: typeName,
-		Code:     codes.ImmutableFieldIncDec,
-		Pos:      node.Pos(),
-		Reason:   fmt.Sprintf("cannot use %s on field %q of immutable type (outside constructor)", op, selector.Sel.Name),
-		Node:     node,
+		TypeName:    typeName,
+		TypePackage: pkgPath,
+		TypePos:     ctx.typePos(pkgPath, typeName),
+		External:    ctx.isExternal(pkgPath),
+		Code:        code,
+		Pos:         node.Pos(),
+		Reason:      reason,
+		Node:        node,
 	}
 }
 
@@ -454,11 +1319,14 @@ func checkReceiverIncDec(
 	}
 
 	return &ImmutableViolation{
-		TypeName: ctx.currentReceiver.typeName,
-		Code:     codes.ImmutableFieldIncDec,
-		Pos:      star.Pos(),
-		Reason:   fmt.Sprintf("cannot use %s on immutable receiver (outside constructor)", op),
-		Node:     node,
+		TypeName:    ctx.currentReceiver.typeName,
+		TypePackage: ctx.currentReceiver.pkgPath,
+		TypePos:     ctx.typePos(ctx.currentReceiver.pkgPath, ctx.currentReceiver.typeName),
+		External:    ctx.isExternal(ctx.currentReceiver.pkgPath),
+		Code:        codes.ImmutableFieldIncDec,
+		Pos:         star.Pos(),
+		Reason:      fmt.Sprintf("cannot use %s on immutable receiver (outside constructor)", op),
+		Node:        node,
 	}
 }
 
@@ -492,34 +1360,18 @@ func checkCompoundLHS(
 		return checkImmutableIndex(ctx, index, stmt)
 	}
 
-	selector, ok := expr.(*ast.SelectorExpr)
-	if !ok {
-		return nil
+	// Compound assignment to the pointee of a pointer field: *x.counter += v
+	if star, ok := expr.(*ast.StarExpr); ok {
+		return checkPointeeMutation(ctx, stmt, star, "use "+tok.String()+" on")
 	}
 
-	receiverType := ctx.pass.TypesInfo.TypeOf(selector.X)
-	if receiverType == nil {
-		return nil
-	}
-
-	if ptr, ok := receiverType.(*types.Pointer); ok {
-		receiverType = ptr.Elem()
-	}
-
-	named, ok := receiverType.(*types.Named)
+	selector, ok := expr.(*ast.SelectorExpr)
 	if !ok {
 		return nil
 	}
 
-	typeName := named.Obj().Name()
-	pkg := named.Obj().Pkg()
-	if pkg == nil {
-		return nil
-	}
-
-	pkgPath := pkg.Path()
-
-	if !ctx.immutableTypes.Contains(pkgPath, typeName) {
+	typeName, pkgPath, ok := immutableFieldOwner(ctx, selector)
+	if !ok {
 		return nil
 	}
 
@@ -532,13 +1384,17 @@ func checkCompoundLHS(
 		return nil
 	}
 
-	op := tok.String()
+	code, reason := throughIdentityMethod(ctx, selector, codes.ImmutableFieldCompoundAssign,
+		fmt.Sprintf("cannot use %s on field %q of immutable type (outside constructor)", tok, selector.Sel.Name))
 	return &ImmutableViolation{
-		TypeName: typeName,
-		Code:     codes.ImmutableFieldCompoundAssign,
-		Pos:      selector.Pos(),
-		Reason:   fmt.Sprintf("cannot use %s on field %q of immutable type (outside constructor)", op, selector.Sel.Name),
-		Node:     stmt,
+		TypeName:    typeName,
+		TypePackage: pkgPath,
+		TypePos:     ctx.typePos(pkgPath, typeName),
+		External:    ctx.isExternal(pkgPath),
+		Code:        code,
+		Pos:         selector.Pos(),
+		Reason:      reason,
+		Node:        stmt,
 	}
 }
 
@@ -576,11 +1432,416 @@ func checkReceiverReassignment(
 		return nil
 	}
 
+	// Rebuilding the receiver from a literal (*p = Config{Name: p.Name}) reads
+	// like creating a new value, but overwrites the caller's in place
+	reason := "cannot reassign immutable receiver (outside constructor)"
+	if _, ok := ast.Unparen(assignedValue(stmt, star)).(*ast.CompositeLit); ok {
+		reason = "cannot reassign immutable receiver by rebuilding it from a composite literal " +
+			"(outside constructor); return the new value instead"
+	}
+
+	return &ImmutableViolation{
+		TypeName:    ctx.currentReceiver.typeName,
+		TypePackage: ctx.currentReceiver.pkgPath,
+		TypePos:     ctx.typePos(ctx.currentReceiver.pkgPath, ctx.currentReceiver.typeName),
+		External:    ctx.isExternal(ctx.currentReceiver.pkgPath),
+		Code:        codes.ImmutableFieldAssignment,
+		Pos:         star.Pos(),
+		Reason:      reason,
+		Node:        stmt,
+	}
+}
+
+// assignedValue returns the right-hand side assigned to lhs by stmt, or nil
+// when the values come from a single multi-value expression
+func assignedValue(stmt *ast.AssignStmt, lhs ast.Expr) ast.Expr {
+	if len(stmt.Lhs) != len(stmt.Rhs) {
+		return nil
+	}
+	for i, left := range stmt.Lhs {
+		if left == lhs {
+			return stmt.Rhs[i]
+		}
+	}
+	return nil
+}
+
+// checkAliasReassignment checks if a local pointer holding the address of an
+// immutable value is used to overwrite it (p := &cfg; *p = value)
+func checkAliasReassignment(
+	ctx *checkerContext,
+	stmt *ast.AssignStmt,
+	star *ast.StarExpr,
+) *ImmutableViolation {
+	target, ok := aliasOf(ctx, star.X)
+	if !ok {
+		return nil
+	}
+
+	if ctx.constructors.Match(target.pkgPath, ctx.currentFunction, target.typeName) {
+		return nil
+	}
+
+	return &ImmutableViolation{
+		TypeName:    target.typeName,
+		TypePackage: target.pkgPath,
+		TypePos:     ctx.typePos(target.pkgPath, target.typeName),
+		External:    ctx.isExternal(target.pkgPath),
+		Code:        codes.ImmutableFieldAssignment,
+		Pos:         star.Pos(),
+		Reason:      "cannot overwrite immutable value through a pointer to it (outside constructor)",
+		Node:        stmt,
+	}
+}
+
+// checkPointeeMutation reports the opt-in IMM130 advisory when star writes
+// the pointee of a pointer field of an immutable type (*cfg.counter += 1).
+// Immutability is shallow: reassigning the field itself is IMM01, but the
+// pointee is shared by every copy of the value and stays writable. Constructors
+// and @mutable fields are exempt as for the field itself.
+func checkPointeeMutation(
+	ctx *checkerContext,
+	node ast.Node,
+	star *ast.StarExpr,
+	verb string,
+) *ImmutableViolation {
+	if !ctx.checkPointees {
+		return nil
+	}
+
+	selector, ok := ast.Unparen(star.X).(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+
+	typeName, pkgPath, ok := immutableReceiverOfField(ctx, selector)
+	if !ok {
+		return nil
+	}
+
+	if ctx.constructors.Match(pkgPath, ctx.currentFunction, typeName) {
+		return nil
+	}
+
+	// Check if the field is marked as @mutable
+	if ctx.mutableFields.Match(pkgPath, selector.Sel.Name, typeName) {
+		return nil
+	}
+
+	return &ImmutableViolation{
+		TypeName:    typeName,
+		TypePackage: pkgPath,
+		TypePos:     ctx.typePos(pkgPath, typeName),
+		External:    ctx.isExternal(pkgPath),
+		Code:        codes.ImmutablePointeeMutation,
+		Pos:         star.Pos(),
+		Reason: fmt.Sprintf("advisory: cannot %s the value pointed to by field %q of immutable type; "+
+			"the pointee is shared by every copy", verb, selector.Sel.Name),
+		Node: node,
+	}
+}
+
+// checkPoolPut reports the IMM150 advisory for pool.Put(v) on a sync.Pool when
+// v is an immutable value or a pointer to one. Pooled values are reset and
+// reused, which contradicts immutability.
+func checkPoolPut(ctx *checkerContext, call *ast.CallExpr) *ImmutableViolation {
+	selector, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+	if !ok || len(call.Args) != 1 {
+		return nil
+	}
+	method, ok := ctx.pass.TypesInfo.Uses[selector.Sel].(*types.Func)
+	if !ok || method.FullName() != "(*sync.Pool).Put" {
+		return nil
+	}
+
+	typeName, pkgPath, ok := immutableTypeOf(ctx, ctx.typeOf(call.Args[0]))
+	if !ok {
+		return nil
+	}
+
 	return &ImmutableViolation{
-		TypeName: ctx.currentReceiver.typeName,
-		Code:     codes.ImmutableFieldAssignment,
-		Pos:      star.Pos(),
-		Reason:   "cannot reassign immutable receiver (outside constructor)",
-		Node:     stmt,
+		TypeName:    typeName,
+		TypePackage: pkgPath,
+		TypePos:     ctx.typePos(pkgPath, typeName),
+		External:    ctx.isExternal(pkgPath),
+		Code:        codes.ImmutablePooled,
+		Pos:         call.Pos(),
+		Reason:      "advisory: putting immutable values into a sync.Pool implies resetting and reusing them",
+		Node:        call,
+	}
+}
+
+// checkAnonymousAssignment reports the opt-in IMM190 advisory when rhs, whose
+// type is an anonymous struct or a pointer to one, is assigned to lhs of an
+// immutable type. The struct converts implicitly to the immutable type, or
+// satisfies the immutable interface through promoted methods, but writes to
+// the anonymous value itself are not attributed to any immutable type.
+func checkAnonymousAssignment(ctx *checkerContext, lhs ast.Expr, rhs ast.Expr) *ImmutableViolation {
+	if !ctx.warnAnonymous {
+		return nil
+	}
+
+	valueType := types.Unalias(ctx.typeOf(rhs))
+	if ptr, ok := valueType.(*types.Pointer); ok {
+		valueType = types.Unalias(ptr.Elem())
+	}
+	if _, ok := valueType.(*types.Struct); !ok {
+		return nil
+	}
+
+	typeName, pkgPath, ok := immutableTypeOf(ctx, types.Unalias(ctx.typeOf(lhs)))
+	if !ok {
+		return nil
+	}
+
+	fix := "use " + typeName + " instead"
+	if types.IsInterface(ctx.typeOf(lhs)) {
+		fix = "declare a named type implementing " + typeName
+	}
+	return &ImmutableViolation{
+		TypeName:    typeName,
+		TypePackage: pkgPath,
+		TypePos:     ctx.typePos(pkgPath, typeName),
+		External:    ctx.isExternal(pkgPath),
+		Code:        codes.ImmutableAnonymousAssignment,
+		Pos:         rhs.Pos(),
+		Reason:      fmt.Sprintf("advisory: anonymous struct value assigned to %s of immutable type; its fields are not protected, %s", types.ExprString(lhs), fix),
+		Node:        rhs,
+	}
+}
+
+// checkMutatingCall reports the IMM170 advisory for &v.field arguments of a
+// function known to write through its pointer arguments, such as
+// json.Unmarshal(data, &cfg.Limits). The call overwrites the field just like
+// an assignment, so constructors and @mutable fields are exempt alike.
+func checkMutatingCall(ctx *checkerContext, call *ast.CallExpr) []ImmutableViolation {
+	var ident *ast.Ident
+	switch fun := ast.Unparen(call.Fun).(type) {
+	case *ast.Ident:
+		ident = fun
+	case *ast.SelectorExpr:
+		ident = fun.Sel
+	default:
+		return nil
+	}
+	fn, ok := ctx.pass.TypesInfo.Uses[ident].(*types.Func)
+	if !ok || !slices.Contains(ctx.mutatingFuncs, fn.Origin().FullName()) {
+		return nil
+	}
+
+	var violations []ImmutableViolation
+	for _, arg := range call.Args {
+		unary, ok := ast.Unparen(arg).(*ast.UnaryExpr)
+		if !ok || unary.Op != token.AND {
+			continue
+		}
+		selector, ok := ast.Unparen(unary.X).(*ast.SelectorExpr)
+		if !ok {
+			continue
+		}
+		typeName, pkgPath, ok := immutableReceiverOfField(ctx, selector)
+		if !ok || isValueReceiverCopy(ctx, selector.X) || isGenericHelperCopy(ctx, selector.X) {
+			continue
+		}
+		if ctx.constructors.Match(pkgPath, ctx.currentFunction, typeName) ||
+			ctx.mutableFields.Match(pkgPath, selector.Sel.Name, typeName) {
+			continue
+		}
+
+		violations = append(violations, ImmutableViolation{
+			TypeName:    typeName,
+			TypePackage: pkgPath,
+			TypePos:     ctx.typePos(pkgPath, typeName),
+			External:    ctx.isExternal(pkgPath),
+			Code:        codes.ImmutableFieldAddressPassed,
+			Pos:         arg.Pos(),
+			Reason: fmt.Sprintf("advisory: %s writes through the address of field %q of immutable type",
+				fn.Name(), selector.Sel.Name),
+			Node: call,
+		})
+	}
+	return violations
+}
+
+// checkFieldAddressReturned reports the IMM180 advisory for a method of an
+// immutable type returning the address of one of its receiver's fields, either
+// directly (return &p.limits) or through a named result or local assigned
+// &p.limits. Callers can write through the returned pointer, which no
+// call-site check attributes to the immutable value, so the method itself is
+// reported. Value receivers return the address of their copy and are not
+// reported; neither are @mutable fields.
+func checkFieldAddressReturned(ctx *checkerContext, funcDecl *ast.FuncDecl) *ImmutableViolation {
+	recv := ctx.currentReceiver
+	if recv == nil || recv.obj == nil || funcDecl.Body == nil || !ctx.immutableTypes.Contains(recv.pkgPath, recv.typeName) {
+		return nil
+	}
+
+	// fieldAddress returns the field whose address expr takes, "" otherwise
+	fieldAddress := func(expr ast.Expr) string {
+		unary, ok := ast.Unparen(expr).(*ast.UnaryExpr)
+		if !ok || unary.Op != token.AND {
+			return ""
+		}
+		selector, ok := ast.Unparen(unary.X).(*ast.SelectorExpr)
+		if !ok {
+			return ""
+		}
+		ident, ok := ast.Unparen(selector.X).(*ast.Ident)
+		if !ok || ctx.pass.TypesInfo.Uses[ident] != recv.obj || isValueReceiverCopy(ctx, ident) {
+			return ""
+		}
+		if ctx.mutableFields.Match(recv.pkgPath, selector.Sel.Name, recv.typeName) {
+			return ""
+		}
+		return selector.Sel.Name
+	}
+
+	var namedResults []types.Object
+	if funcDecl.Type.Results != nil {
+		for _, field := range funcDecl.Type.Results.List {
+			for _, name := range field.Names {
+				namedResults = append(namedResults, ctx.pass.TypesInfo.Defs[name])
+			}
+		}
+	}
+
+	// held maps the named results and locals to the field whose address
+	// they were last assigned
+	held := make(map[types.Object]string)
+	var leaked string
+	ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+		if leaked != "" {
+			return false
+		}
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			// Returns of a closure are not returns of the method
+			return false
+
+		case *ast.AssignStmt:
+			if len(node.Lhs) != len(node.Rhs) {
+				return true
+			}
+			for i, lhs := range node.Lhs {
+				ident, ok := ast.Unparen(lhs).(*ast.Ident)
+				if !ok {
+					continue
+				}
+				obj := ctx.pass.TypesInfo.ObjectOf(ident)
+				if field := fieldAddress(node.Rhs[i]); field != "" {
+					held[obj] = field
+				} else {
+					delete(held, obj)
+				}
+			}
+
+		case *ast.ReturnStmt:
+			if len(node.Results) == 0 {
+				for _, obj := range namedResults {
+					if field, ok := held[obj]; ok {
+						leaked = field
+						return false
+					}
+				}
+			}
+			for _, result := range node.Results {
+				if field := fieldAddress(result); field != "" {
+					leaked = field
+					return false
+				}
+				if ident, ok := ast.Unparen(result).(*ast.Ident); ok {
+					if field, ok := held[ctx.pass.TypesInfo.Uses[ident]]; ok {
+						leaked = field
+						return false
+					}
+				}
+			}
+		}
+		return true
+	})
+	if leaked == "" {
+		return nil
+	}
+
+	return &ImmutableViolation{
+		TypeName:    recv.typeName,
+		TypePackage: recv.pkgPath,
+		TypePos:     ctx.typePos(recv.pkgPath, recv.typeName),
+		Code:        codes.ImmutableFieldAddressReturned,
+		Pos:         funcDecl.Name.Pos(),
+		Reason: fmt.Sprintf("advisory: method %q returns the address of field %q, so callers can mutate the immutable value; return a copy instead",
+			funcDecl.Name.Name, leaked),
+		Node: funcDecl.Name,
+	}
+}
+
+// checkPoolNew reports the IMM150 advisory for sync.Pool{New: func() any {...}}
+// literals whose New function returns immutable values
+func checkPoolNew(ctx *checkerContext, lit *ast.CompositeLit) []ImmutableViolation {
+	named, ok := types.Unalias(ctx.typeOf(lit)).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != "sync" || named.Obj().Name() != "Pool" {
+		return nil
+	}
+
+	var violations []ImmutableViolation
+	for _, elt := range lit.Elts {
+		kv, ok := elt.(*ast.KeyValueExpr)
+		if !ok {
+			continue
+		}
+		if key, ok := kv.Key.(*ast.Ident); !ok || key.Name != "New" {
+			continue
+		}
+		newFunc, ok := ast.Unparen(kv.Value).(*ast.FuncLit)
+		if !ok {
+			continue
+		}
+
+		ast.Inspect(newFunc.Body, func(n ast.Node) bool {
+			switch node := n.(type) {
+			case *ast.FuncLit:
+				return false
+			case *ast.ReturnStmt:
+				for _, result := range node.Results {
+					typeName, pkgPath, ok := immutableTypeOf(ctx, ctx.typeOf(result))
+					if !ok {
+						continue
+					}
+					violations = append(violations, ImmutableViolation{
+						TypeName:    typeName,
+						TypePackage: pkgPath,
+						TypePos:     ctx.typePos(pkgPath, typeName),
+						External:    ctx.isExternal(pkgPath),
+						Code:        codes.ImmutablePooled,
+						Pos:         result.Pos(),
+						Reason:      "advisory: a sync.Pool creating immutable values implies resetting and reusing them",
+						Node:        node,
+					})
+				}
+			}
+			return true
+		})
+	}
+
+	return violations
+}
+
+// immutableTypeOf returns the immutable type t is, or points to
+func immutableTypeOf(ctx *checkerContext, t types.Type) (string, string, bool) {
+	if t == nil {
+		return "", "", false
+	}
+	if ptr, ok := t.(*types.Pointer); ok {
+		t = ptr.Elem()
+	}
+	named, ok := types.Unalias(t).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return "", "", false
+	}
+	typeName := named.Obj().Name()
+	pkgPath := named.Obj().Pkg().Path()
+	if !ctx.immutableTypes.Contains(pkgPath, typeName) {
+		return "", "", false
 	}
+	return typeName, pkgPath, true
 }
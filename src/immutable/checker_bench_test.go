@@ -0,0 +1,98 @@
+package immutable
+
+import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+
+	"github.com/a14e/gogreement/src/annotations"
+	"github.com/a14e/gogreement/src/codes"
+	"github.com/a14e/gogreement/src/config"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis"
+)
+
+// mutationsPerType is the number of mutations syntheticImmutablePass writes
+// against each of its types
+const mutationsPerType = 5
+
+// syntheticImmutablePass type-checks a package of n immutable types, each
+// with a constructor, a reader and a method mutating it mutationsPerType
+// times
+func syntheticImmutablePass(tb testing.TB, n int) *analysis.Pass {
+	var src strings.Builder
+	src.WriteString("package synthetic\n\n")
+	for i := range n {
+		fmt.Fprintf(&src, "// @immutable\n// @constructor NewRecord%d\ntype Record%d struct {\n\tname  string\n\tcount int\n\ttags  []string\n}\n\n", i, i)
+		fmt.Fprintf(&src, "func NewRecord%d(name string) *Record%d {\n\tr := &Record%d{}\n\tr.name = name\n\tr.tags = make([]string, 1)\n\treturn r\n}\n\n", i, i, i)
+		fmt.Fprintf(&src, "func (r *Record%d) Name() string { return r.name }\n\n", i)
+		fmt.Fprintf(&src, "func (r *Record%d) Touch(other *Record%d) {\n\tr.name = other.name\n\tr.count++\n\tr.count += other.count\n\tr.tags[0] = other.name\n\t(*Record%d)(other).count = 0\n}\n\n", i, i, i)
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "synthetic.go", src.String(), parser.ParseComments)
+	require.NoError(tb, err)
+
+	info := &types.Info{
+		Types:      make(map[ast.Expr]types.TypeAndValue),
+		Defs:       make(map[*ast.Ident]types.Object),
+		Uses:       make(map[*ast.Ident]types.Object),
+		Selections: make(map[*ast.SelectorExpr]*types.Selection),
+	}
+	conf := types.Config{Importer: importer.Default()}
+	pkg, err := conf.Check("synthetic", fset, []*ast.File{file}, info)
+	require.NoError(tb, err)
+
+	return &analysis.Pass{Pkg: pkg, Fset: fset, Files: []*ast.File{file}, TypesInfo: info}
+}
+
+// TestCheckImmutableTypeLookupsGrowLinearly guards the hot path against
+// accidental quadratic work: the number of type lookups per synthetic type
+// must not grow with the size of the package
+func TestCheckImmutableTypeLookupsGrowLinearly(t *testing.T) {
+	cfg := config.Empty()
+	lookupsPerType := make(map[int]float64)
+	for _, n := range []int{50, 400} {
+		pass := syntheticImmutablePass(t, n)
+		packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+
+		violations, ctx := checkImmutable(cfg, pass, &packageAnnotations)
+		require.NotNil(t, ctx)
+		require.Len(t, violations, n*mutationsPerType, "every mutation outside the constructors is reported")
+		for _, v := range violations {
+			assert.Contains(t, []string{
+				codes.ImmutableFieldAssignment,
+				codes.ImmutableFieldIncDec,
+				codes.ImmutableFieldCompoundAssign,
+				codes.ImmutableIndexAssignment,
+			}, v.Code)
+		}
+
+		lookupsPerType[n] = float64(ctx.typeOfCalls) / float64(n)
+		t.Logf("%d types: %d type lookups", n, ctx.typeOfCalls)
+	}
+
+	assert.LessOrEqual(t, lookupsPerType[50], 20.0, "type lookups per synthetic type")
+	assert.InDelta(t, lookupsPerType[50], lookupsPerType[400], 1.0,
+		"type lookups per type stay constant as the package grows")
+}
+
+// BenchmarkCheckImmutable runs the checker on a large package with many
+// immutable types and mutations of them
+func BenchmarkCheckImmutable(b *testing.B) {
+	cfg := config.Empty()
+	pass := syntheticImmutablePass(b, 2000)
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+
+	b.ReportAllocs()
+	for b.Loop() {
+		CheckImmutable(cfg, pass, &packageAnnotations)
+	}
+}
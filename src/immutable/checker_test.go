@@ -1,10 +1,14 @@
 package immutable
 
 import (
+	"fmt"
 	"go/token"
+	"os"
+	"strings"
 	"testing"
 
 	"github.com/a14e/gogreement/src/annotations"
+	"github.com/a14e/gogreement/src/codes"
 	"github.com/a14e/gogreement/src/config"
 	"github.com/a14e/gogreement/src/testutil/testfacts"
 
@@ -216,7 +220,7 @@ func TestReportViolations(t *testing.T) {
 	}
 
 	// Should not panic
-	ReportViolations(pass, violations, nil)
+	ReportViolations(config.Empty(), pass, violations, nil)
 
 	t.Log("ReportViolations executed successfully")
 }
@@ -333,6 +337,27 @@ func TestReceiverReassignment(t *testing.T) {
 	assert.True(t, hasCounterResetViolation, "should detect Counter receiver reassignment")
 }
 
+func TestValueReceiverFieldWrites(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "immutabletests")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+	violations := CheckImmutable(cfg, pass, &packageAnnotations)
+
+	var badgeViolations []ImmutableViolation
+	for _, v := range violations {
+		if v.TypeName == "Badge" {
+			badgeViolations = append(badgeViolations, v)
+		}
+	}
+
+	// Touch (value receiver) writes Label, Uses and Meta.Color of its copy;
+	// only the two writes in Retouch (pointer receiver) are violations
+	if assert.Len(t, badgeViolations, 2, "only the pointer receiver method should be flagged") {
+		assert.Equal(t, codes.ImmutableFieldAssignment, badgeViolations[0].Code)
+		assert.Equal(t, codes.ImmutableFieldIncDec, badgeViolations[1].Code)
+	}
+}
+
 func TestPrimitiveTypeAliasReassignment(t *testing.T) {
 
 	pass := testfacts.CreateTestPassWithFacts(t, "immutabletests")
@@ -469,6 +494,33 @@ func TestPackageLevelFuncLiteralChecked(t *testing.T) {
 		"package-level func literal mutation of an immutable field should be checked and flagged")
 }
 
+func TestClosureStoredInFieldViolation(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "immutabletests")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+	violations := CheckImmutable(cfg, pass, &packageAnnotations)
+
+	incDec := 0
+	fieldAssign := 0
+	for _, v := range violations {
+		if v.TypeName != "Watcher" {
+			continue
+		}
+		t.Logf("Watcher %s: %s", v.Code, v.Reason)
+		switch {
+		case v.Code == "IMM03" && contains(v.Reason, "count"):
+			incDec++
+		case v.Code == "IMM01" && contains(v.Reason, "onChange"):
+			fieldAssign++
+		}
+	}
+
+	// w.count++ inside both stored closures (NewWatcher and Rewire)
+	assert.Equal(t, 2, incDec, "mutation inside a closure stored in a field should be flagged, even in a constructor")
+	// Only Rewire's assignment to onChange; the constructor's is allowed
+	assert.Equal(t, 1, fieldAssign, "storing a closure in an immutable field outside a constructor should be flagged")
+}
+
 func TestImmutableTransitiveImport(t *testing.T) {
 	// transitivetop imports only transitivemid; the @immutable Thing lives in
 	// transitivesrc (a transitive dependency). The violation is only detected
@@ -617,3 +669,708 @@ func containsSubstr(s, substr string) bool {
 	}
 	return false
 }
+
+func TestMutationThroughAddressAlias(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "immutabletests")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+	violations := CheckImmutable(cfg, pass, &packageAnnotations)
+
+	var reasons []string
+	for _, v := range violations {
+		if v.TypeName == "Settings" {
+			assert.Equal(t, "IMM01", v.Code)
+			reasons = append(reasons, v.Reason)
+		}
+	}
+
+	// ptr.Level, *ptr and inner.Field outside the constructor; NewSettings
+	// and the rebound alias in ReuseSettingsAlias are allowed.
+	assert.ElementsMatch(t, []string{
+		`cannot assign to field "Level" of immutable type`,
+		"cannot overwrite immutable value through a pointer to it (outside constructor)",
+		`cannot assign to field "Field" of immutable type`,
+	}, reasons)
+}
+
+func TestGoAndDeferViolations(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "immutabletests")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+	violations := CheckImmutable(cfg, pass, &packageAnnotations)
+
+	var found []string
+	for _, v := range violations {
+		if v.TypeName == "Session" {
+			found = append(found, v.Code+": "+v.Reason)
+		}
+	}
+
+	assert.ElementsMatch(t, []string{
+		// reset's own body
+		`IMM01: cannot assign to field "hits" of immutable type`,
+		// goroutine launched from NewSession is not covered by the constructor
+		`IMM01: cannot assign to field "closed" of immutable type`,
+		// UseSession
+		`IMM01: deferred call to method "reset" mutates the immutable receiver`,
+		`IMM01: goroutine call to method "reset" mutates the immutable receiver`,
+		`IMM03: cannot use ++ on field "hits" of immutable type (outside constructor)`,
+		`IMM01: cannot assign to field "closed" of immutable type`,
+	}, found)
+}
+
+func TestMethodExpressionMutatorCalls(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "immutabletests")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+	violations := CheckImmutable(cfg, pass, &packageAnnotations)
+
+	var found []string
+	for _, v := range violations {
+		if v.TypeName == "Tally" {
+			found = append(found, v.Code+": "+v.Reason)
+		}
+	}
+
+	assert.ElementsMatch(t, []string{
+		// clear's own body
+		`IMM01: cannot assign to field "count" of immutable type`,
+		// UseTally: (*Tally).clear(t) passes the receiver explicitly
+		`IMM01: deferred call to method "clear" mutates the immutable receiver`,
+		`IMM01: goroutine call to method "clear" mutates the immutable receiver`,
+		`IMM01: deferred call to method "clear" mutates the immutable receiver`,
+	}, found, "the constructor's deferred call and read-only methods are not reported")
+}
+
+func TestMutationThroughTypeAssertion(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "immutabletests")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+	violations := CheckImmutable(cfg, pass, &packageAnnotations)
+
+	var found []string
+	for _, v := range violations {
+		if v.TypeName == "Endpoint" {
+			found = append(found, v.Code+": "+v.Reason)
+		}
+	}
+
+	// v.(*Endpoint) resolves to the immutable named type, so later writes
+	// through the asserted value are reported; NewEndpoint is the constructor
+	assert.ElementsMatch(t, []string{
+		`IMM01: cannot assign to field "URL" of immutable type`,
+		`IMM03: cannot use ++ on field "Retries" of immutable type (outside constructor)`,
+		`IMM01: cannot assign to field "Retries" of immutable type`,
+		`IMM01: cannot assign to field "URL" of immutable type`,
+	}, found)
+}
+
+func TestRecursiveImmutableType(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "immutabletests")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+	violations := CheckImmutable(cfg, pass, &packageAnnotations)
+
+	var found []string
+	for _, v := range violations {
+		if v.TypeName == "Tree" || v.TypeName == "Chain" {
+			found = append(found, v.TypeName+" "+v.Code+": "+v.Reason)
+		}
+	}
+
+	assert.ElementsMatch(t, []string{
+		`Tree IMM01: cannot assign to field "children" of immutable type`,
+		`Tree IMM04: cannot modify element of field "children" of immutable type`,
+		`Tree IMM01: cannot assign to field "value" of immutable type`,
+		`Tree IMM03: cannot use ++ on field "value" of immutable type (outside constructor)`,
+		`Chain IMM01: cannot assign to field "id" of immutable type`,
+	}, found)
+}
+
+func TestPointeeMutationAdvisory(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "immutabletests")
+
+	meterFindings := func(cfg *config.Config) []string {
+		packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+		var found []string
+		for _, v := range CheckImmutable(cfg, pass, &packageAnnotations) {
+			if v.TypeName == "Meter" {
+				found = append(found, v.Code+": "+v.Reason)
+			}
+		}
+		return found
+	}
+
+	fieldReassignment := `IMM01: cannot assign to field "counterPtr" of immutable type`
+
+	t.Run("off by default", func(t *testing.T) {
+		assert.Equal(t, []string{fieldReassignment}, meterFindings(config.Empty()))
+	})
+
+	t.Run("opt-in", func(t *testing.T) {
+		pointee := func(verb string) string {
+			return codes.ImmutablePointeeMutation + ": advisory: cannot " + verb +
+				` the value pointed to by field "counterPtr" of immutable type; the pointee is shared by every copy`
+		}
+		// Value receivers are not exempt: Rewind's copy shares the pointee
+		assert.ElementsMatch(t, []string{
+			fieldReassignment,
+			pointee("use += on"),
+			pointee("assign to"),
+			pointee("use ++ on"),
+			pointee("assign to"),
+		}, meterFindings(config.Empty().WithCheckPointees(true)))
+	})
+}
+
+func TestImmutableFuncTypeReceiver(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "immutabletests")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+
+	var found []string
+	for _, v := range CheckImmutable(cfg, pass, &packageAnnotations) {
+		if v.TypeName == "Handler" {
+			found = append(found, v.Code+": "+v.Reason)
+		}
+	}
+
+	reassignment := codes.ImmutableFieldAssignment + ": cannot reassign immutable receiver (outside constructor)"
+	// Replace and the closure returned by Later; calling the func value in
+	// Serve and ServePtr is not a mutation
+	assert.Equal(t, []string{reassignment, reassignment}, found)
+}
+
+func TestMethodNamedLikeConstructorNotExempt(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "immutabletests")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+
+	var found []string
+	for _, v := range CheckImmutable(cfg, pass, &packageAnnotations) {
+		if v.TypeName == "Ledger" {
+			found = append(found, v.Code+": "+v.Reason)
+		}
+	}
+
+	// Only the method (*Ledger).Open; the constructor function Open is exempt
+	assert.Equal(t, []string{codes.ImmutableFieldAssignment + `: cannot assign to field "entries" of immutable type`}, found)
+}
+
+func TestMutationOfAddressedLiteral(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "immutabletests")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+
+	var found []string
+	for _, v := range CheckImmutable(cfg, pass, &packageAnnotations) {
+		if v.TypeName == "Config" {
+			found = append(found, v.Code+": "+v.Reason)
+		}
+	}
+
+	// (&Config{}).field resolves to the literal's type like any *Config
+	assert.Equal(t, []string{
+		codes.ImmutableFieldAssignment + `: cannot assign to field "host" of immutable type`,
+		codes.ImmutableFieldIncDec + `: cannot use ++ on field "port" of immutable type (outside constructor)`,
+	}, found)
+}
+
+func TestAllFieldsMutableAdvisory(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "immutabletests")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+
+	found := make(map[string]string)
+	for _, v := range CheckImmutable(cfg, pass, &packageAnnotations) {
+		if v.Code == codes.MutableAllFields {
+			found[v.TypeName] = v.Reason
+		}
+	}
+
+	// Meter and Session keep fields without @mutable, so they are not reported
+	assert.Equal(t, map[string]string{
+		"Scratchpad": "advisory: the only field is marked @mutable, so @immutable protects nothing; remove the @immutable annotation",
+		"Buffers":    "advisory: every field is marked @mutable, so @immutable protects nothing; remove the @immutable annotation",
+	}, found)
+}
+
+func TestMutationInSwitchAndSelect(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "immutabletests")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+
+	var found []string
+	for _, v := range CheckImmutable(cfg, pass, &packageAnnotations) {
+		if v.TypeName == "Mailbox" {
+			found = append(found, v.Code+": "+v.Reason)
+		}
+	}
+
+	// Comm clause assignments (case m.last = <-ch:) are AssignStmts like any other
+	assert.Equal(t, []string{
+		codes.ImmutableFieldAssignment + `: cannot assign to field "last" of immutable type`,
+		codes.ImmutableFieldAssignment + `: cannot assign to field "count" of immutable type`,
+		codes.ImmutableFieldAssignment + `: cannot assign to field "count" of immutable type`,
+		codes.ImmutableFieldCompoundAssign + `: cannot use += on field "count" of immutable type (outside constructor)`,
+	}, found, "the select in NewMailbox is inside the constructor")
+}
+
+func TestMutationThroughAddressedElement(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "immutabletests")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+
+	var found []string
+	for _, v := range CheckImmutable(cfg, pass, &packageAnnotations) {
+		if v.TypeName == "Setting" {
+			found = append(found, v.Code+": "+v.Reason)
+		}
+	}
+
+	// p := &settings[i] holds a *Setting like any other pointer to it
+	assert.Equal(t, []string{
+		codes.ImmutableFieldIncDec + `: cannot use ++ on field "value" of immutable type (outside constructor)`,
+		codes.ImmutableFieldAssignment + `: cannot assign to field "key" of immutable type`,
+		codes.ImmutableFieldAssignment + `: cannot overwrite immutable value through a pointer to it (outside constructor)`,
+		codes.ImmutableFieldAssignment + `: cannot assign to field "value" of immutable type`,
+		codes.ImmutableFieldAssignment + `: cannot assign to field "value" of immutable type`,
+	}, found, "ReadAll only reads through the address")
+}
+
+func TestMutationInLabeledStatements(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "immutabletests")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+
+	var found []string
+	for _, v := range CheckImmutable(cfg, pass, &packageAnnotations) {
+		if v.TypeName != "Cursor" {
+			continue
+		}
+		position := pass.Fset.Position(v.Pos)
+		source, err := os.ReadFile(position.Filename)
+		require.NoError(t, err)
+		line := strings.Split(string(source), "\n")[position.Line-1]
+		found = append(found, strings.TrimSpace(strings.Split(line, "//")[0]))
+	}
+
+	// Labels and goto do not leave the enclosing function, so NewCursor stays
+	// a constructor inside its labeled loop
+	assert.Equal(t, []string{"c.offset = i", "c.line = 0"}, found)
+}
+
+func TestClearBuiltin(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "immutabletests")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+
+	var found []string
+	for _, v := range CheckImmutable(cfg, pass, &packageAnnotations) {
+		if v.TypeName == "Registry" {
+			found = append(found, v.Code+": "+v.Reason)
+		}
+	}
+
+	assert.Equal(t, []string{
+		codes.ImmutableIndexAssignment + `: cannot clear map field "entries" of immutable type`,
+		codes.ImmutableIndexAssignment + `: cannot clear slice field "order" of immutable type`,
+	}, found, "the constructor and @mutable fields are exempt")
+}
+
+func TestMutationThroughReturnedReceiver(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "immutabletests")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+
+	var found []string
+	for _, v := range CheckImmutable(cfg, pass, &packageAnnotations) {
+		if v.TypeName == "Handle" {
+			found = append(found, v.Code+": "+v.Reason)
+		}
+	}
+
+	// The written field is resolved from the type of the call result, so every
+	// write is reported; one through a method whose body is only `return h`
+	// is the IMM140 advisory instead
+	assert.Equal(t, []string{
+		codes.ImmutableIdentityMethodWrite + `: advisory: cannot assign to field "Name" of immutable type; self() returns its receiver`,
+		codes.ImmutableIdentityMethodWrite + `: advisory: cannot use ++ on field "Version" of immutable type (outside constructor); self() returns its receiver`,
+		codes.ImmutableIdentityMethodWrite + `: advisory: cannot use += on field "Name" of immutable type (outside constructor); self() returns its receiver`,
+		codes.ImmutableFieldAssignment + `: cannot assign to field "Name" of immutable type`,
+		codes.ImmutableFieldCompoundAssign + `: cannot use += on field "Name" of immutable type (outside constructor)`,
+	}, found)
+}
+
+func TestMutationThroughGenericHelperCopy(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "immutabletests")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+
+	var found []string
+	for _, v := range CheckImmutable(cfg, pass, &packageAnnotations) {
+		if v.TypeName == "Knob" {
+			found = append(found, fmt.Sprintf("%d: %s: %s", pass.Fset.Position(v.Pos).Line, v.Code, v.Reason))
+		}
+	}
+
+	// Ptr(k) points at Ptr's own copy of k, like a value receiver, so only
+	// the write to k itself is reported in TurnKnob. Keep receives *T and
+	// its result may be the caller's value.
+	assert.Equal(t, []string{
+		"879: " + codes.ImmutableFieldAssignment + `: cannot assign to field "Level" of immutable type`,
+		"1187: " + codes.ImmutableFieldAssignment + `: cannot assign to field "Level" of immutable type`,
+	}, found)
+}
+
+func TestImmutableInSyncPool(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "immutabletests")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+
+	var found []string
+	for _, v := range CheckImmutable(cfg, pass, &packageAnnotations) {
+		if v.TypeName == "Frame" {
+			found = append(found, v.Code+": "+v.Reason)
+		}
+	}
+
+	assert.Equal(t, []string{
+		codes.ImmutablePooled + ": advisory: a sync.Pool creating immutable values implies resetting and reusing them",
+		codes.ImmutablePooled + ": advisory: putting immutable values into a sync.Pool implies resetting and reusing them",
+	}, found)
+}
+
+func TestMutationThroughPointerToArrayField(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "immutabletests")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+
+	var found []string
+	for _, v := range CheckImmutable(cfg, pass, &packageAnnotations) {
+		if v.TypeName == "Window" {
+			found = append(found, v.Code+": "+v.Reason)
+		}
+	}
+
+	// Both the implicit and the explicit dereference write to the array
+	// shared by every copy of the Window
+	assert.Equal(t, []string{
+		codes.ImmutableIndexAssignment + `: cannot modify element of field "samples" of immutable type`,
+		codes.ImmutableIndexAssignment + `: cannot modify element of field "samples" of immutable type`,
+	}, found)
+}
+
+func TestMutatorMethodValueStoredInCollection(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "immutabletests")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+
+	var found []string
+	for _, v := range CheckImmutable(cfg, pass, &packageAnnotations) {
+		if v.TypeName == "Gauge" {
+			found = append(found, v.Code+": "+v.Reason)
+		}
+	}
+
+	// The read-only Value and the method value kept in a local are not reported
+	assert.Equal(t, []string{
+		codes.ImmutableFieldAssignment + `: cannot assign to field "value" of immutable type`,
+		codes.ImmutableMutatorStored + `: advisory: method value "zero" stored in a map mutates the immutable receiver when called`,
+		codes.ImmutableMutatorStored + `: advisory: method value "zero" stored in a slice mutates the immutable receiver when called`,
+		codes.ImmutableMutatorStored + `: advisory: method value "zero" stored in a slice mutates the immutable receiver when called`,
+	}, found)
+}
+
+func TestMutationThroughPointerFieldOfMutableType(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "immutabletests")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+
+	var found []string
+	for _, v := range CheckImmutable(cfg, pass, &packageAnnotations) {
+		if v.TypeName == "Engine" || v.TypeName == "Car" {
+			found = append(found, v.TypeName+" "+v.Code+": "+v.Reason)
+		}
+	}
+
+	// The immediate base of the selector decides, not the outermost variable:
+	// c.engine is an *Engine, whatever c is
+	assert.Equal(t, []string{
+		`Engine IMM01: cannot assign to field "Power" of immutable type`,
+		`Engine IMM01: cannot assign to field "Power" of immutable type`,
+		`Engine IMM02: cannot use += on field "Power" of immutable type (outside constructor)`,
+		`Engine IMM01: cannot assign to field "Power" of immutable type`,
+	}, found)
+}
+
+func TestFieldAddressPassedToMutatingFunction(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "immutabletests")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+
+	var found []string
+	for _, v := range CheckImmutable(cfg, pass, &packageAnnotations) {
+		if v.TypeName == "Profile" {
+			found = append(found, v.Code+": "+v.Reason)
+		}
+	}
+
+	assert.Equal(t, []string{
+		`IMM170: advisory: Unmarshal writes through the address of field "Limits" of immutable type`,
+		`IMM170: advisory: Sscan writes through the address of field "Retries" of immutable type`,
+		`IMM170: advisory: Decode writes through the address of field "Limits" of immutable type`,
+	}, found)
+
+	t.Run("the list is configurable", func(t *testing.T) {
+		cfg := config.Empty().WithMutatingFunctions([]string{"fmt.Sscan"})
+		var codes []string
+		for _, v := range CheckImmutable(cfg, pass, &packageAnnotations) {
+			if v.TypeName == "Profile" {
+				codes = append(codes, v.Reason)
+			}
+		}
+		assert.Equal(t, []string{`advisory: Sscan writes through the address of field "Retries" of immutable type`}, codes)
+	})
+}
+
+func TestSliceFieldMutatedInPlace(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "immutabletests")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+
+	var found []string
+	for _, v := range CheckImmutable(cfg, pass, &packageAnnotations) {
+		if v.TypeName == "Leaderboard" {
+			found = append(found, v.Code+": "+v.Reason)
+		}
+	}
+
+	// the constructor and the sorted copy are allowed
+	assert.Equal(t, []string{
+		`IMM04: cannot reorder/mutate slice field "Scores" of immutable type with slices.Sort`,
+		`IMM04: cannot reorder/mutate slice field "Scores" of immutable type with slices.Reverse`,
+		`IMM04: cannot reorder/mutate slice field "Names" of immutable type with sort.Strings`,
+		`IMM04: cannot reorder/mutate slice field "Names" of immutable type with slices.SortFunc`,
+	}, found)
+
+	t.Run("the list is configurable", func(t *testing.T) {
+		cfg := config.Empty().WithInPlaceFunctions([]string{"sort.Strings"})
+		var reasons []string
+		for _, v := range CheckImmutable(cfg, pass, &packageAnnotations) {
+			if v.TypeName == "Leaderboard" {
+				reasons = append(reasons, v.Reason)
+			}
+		}
+		assert.Equal(t, []string{`cannot reorder/mutate slice field "Names" of immutable type with sort.Strings`}, reasons)
+	})
+}
+
+func TestReceiverRebuiltFromCompositeLiteral(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "immutabletests")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+
+	var found []string
+	for _, v := range CheckImmutable(cfg, pass, &packageAnnotations) {
+		if v.TypeName == "Ticket" {
+			found = append(found, v.Code+": "+v.Reason)
+		}
+	}
+
+	assert.Equal(t, []string{
+		"IMM01: cannot reassign immutable receiver by rebuilding it from a composite literal " +
+			"(outside constructor); return the new value instead",
+		"IMM01: cannot reassign immutable receiver (outside constructor)",
+	}, found)
+}
+
+func TestIncDecOfFieldElement(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "immutabletests")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+
+	var found []string
+	for _, v := range CheckImmutable(cfg, pass, &packageAnnotations) {
+		if v.TypeName == "WordCount" {
+			found = append(found, v.Code+": "+v.Reason)
+		}
+	}
+
+	assert.Equal(t, []string{
+		codes.ImmutableIndexAssignment + `: cannot use ++ on element of field "counts" of immutable type`,
+		codes.ImmutableIndexAssignment + `: cannot use -- on element of field "items" of immutable type`,
+	}, found, "constructor increments are allowed")
+}
+
+func TestFieldAddressReturned(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "immutabletests")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+
+	var found []string
+	for _, v := range CheckImmutable(cfg, pass, &packageAnnotations) {
+		if v.TypeName == "Quota" {
+			found = append(found, v.Code+": "+v.Reason)
+		}
+	}
+
+	assert.Equal(t, []string{
+		codes.ImmutableFieldAddressReturned + `: advisory: method "Limits" returns the address of field "limits", ` +
+			"so callers can mutate the immutable value; return a copy instead",
+		codes.ImmutableFieldAddressReturned + `: advisory: method "Burst" returns the address of field "burst", ` +
+			"so callers can mutate the immutable value; return a copy instead",
+		codes.ImmutableFieldAddressReturned + `: advisory: method "BurstOrNil" returns the address of field "burst", ` +
+			"so callers can mutate the immutable value; return a copy instead",
+	}, found, "copies, @mutable fields and value receivers are not reported")
+}
+
+func TestPackageWithCgoFile(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "cgotests")
+	cfg := config.Empty()
+
+	for file := range cfg.FilterFiles(pass) {
+		assert.True(t, strings.HasSuffix(pass.Fset.Position(file.Pos()).Filename, ".go"),
+			"files generated by cgo are skipped")
+	}
+
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+	require.Len(t, packageAnnotations.ImmutableAnnotations, 1)
+	assert.Equal(t, "Counter", packageAnnotations.ImmutableAnnotations[0].OnType)
+
+	var found []string
+	for _, v := range CheckImmutable(cfg, pass, &packageAnnotations) {
+		found = append(found, v.TypeName+" "+v.Code+": "+v.Reason)
+	}
+	assert.Equal(t, []string{`Counter IMM01: cannot assign to field "value" of immutable type`}, found)
+}
+
+func TestMutationThroughMapOfPointers(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "immutabletests")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+
+	var found []string
+	for _, v := range CheckImmutable(cfg, pass, &packageAnnotations) {
+		if v.TypeName == "Upstream" {
+			found = append(found, v.Code+": "+v.Reason)
+		}
+	}
+
+	// m[k] has type *Upstream, so the written field resolves to Upstream even
+	// though the map itself is an ordinary mutable container
+	assert.Equal(t, []string{
+		codes.ImmutableFieldAssignment + `: cannot assign to field "Address" of immutable type`,
+		codes.ImmutableFieldCompoundAssign + `: cannot use += on field "Weight" of immutable type (outside constructor)`,
+	}, found)
+}
+
+func TestMutationThroughConvertedReceiver(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "immutabletests")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+
+	var found []string
+	for _, v := range CheckImmutable(cfg, pass, &packageAnnotations) {
+		if v.TypeName == "Viewport" {
+			found = append(found, v.Code+": "+v.Reason)
+		}
+	}
+
+	// (*viewportLayout)(w) points at the immutable Viewport, so the write resolves
+	// to Viewport; a value conversion and a call returning the other type do not
+	assert.Equal(t, []string{
+		codes.ImmutableFieldAssignment + `: cannot assign to field "Width" of immutable type`,
+		codes.ImmutableFieldCompoundAssign + `: cannot use += on field "Height" of immutable type (outside constructor)`,
+		codes.ImmutableFieldAssignment + `: cannot assign to field "Height" of immutable type`,
+		codes.ImmutableFieldIncDec + `: cannot use ++ on field "Width" of immutable type (outside constructor)`,
+	}, found)
+}
+
+func TestAnonymousStructAssignedToImmutable(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "anonimmutable")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+
+	assert.Empty(t, CheckImmutable(cfg, pass, &packageAnnotations), "the advisory is opt-in")
+
+	var found []string
+	for _, v := range CheckImmutable(cfg.WithWarnAnonymousImmutableAssignment(true), pass, &packageAnnotations) {
+		require.Equal(t, codes.ImmutableAnonymousAssignment, v.Code)
+		found = append(found, fmt.Sprintf("%d %s", pass.Fset.Position(v.Pos).Line, v.Reason))
+	}
+
+	assert.Equal(t, []string{
+		`24 advisory: anonymous struct value assigned to p of immutable type; its fields are not protected, use Point instead`,
+		`25 advisory: anonymous struct value assigned to q of immutable type; its fields are not protected, use Point instead`,
+		`26 advisory: anonymous struct value assigned to s of immutable type; its fields are not protected, declare a named type implementing Shape`,
+	}, found)
+}
+
+func TestCommaOkAssignmentToField(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "immutabletests")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+
+	var found []string
+	for _, v := range CheckImmutable(cfg, pass, &packageAnnotations) {
+		if v.TypeName != "Lookup" {
+			continue
+		}
+		position := pass.Fset.Position(v.Pos)
+		source, err := os.ReadFile(position.Filename)
+		require.NoError(t, err)
+		line := strings.Split(string(source), "\n")[position.Line-1]
+		found = append(found, v.Code+" "+line[position.Column-1:strings.Index(line, ",")])
+	}
+
+	// The multi-value form reports the field and leaves the ok local alone
+	assert.Equal(t, []string{codes.ImmutableFieldAssignment + " p.cache"}, found)
+}
+
+func TestPublicOnlySkipsUnexportedImmutableTypes(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "publiconlytests")
+
+	typeNames := func(cfg *config.Config) []string {
+		packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+		var result []string
+		for _, v := range CheckImmutable(cfg, pass, &packageAnnotations) {
+			result = append(result, v.TypeName)
+		}
+		return result
+	}
+
+	assert.ElementsMatch(t, []string{"Config", "settings", "settings"}, typeNames(config.Empty()))
+	assert.Equal(t, []string{"Config"}, typeNames(config.Empty().WithPublicOnly(true)),
+		"unexported immutable types are not enforced")
+}
+
+func TestMutableFieldPrecedence(t *testing.T) {
+	// A @mutable field is exempt wherever it is written from, while the other
+	// fields of its @immutable type stay protected. On a type without
+	// @immutable the annotation changes nothing.
+	for _, tc := range []struct {
+		pkg  string
+		want []string
+	}{
+		{"mutablesource", []string{
+			"30: " + codes.ImmutableFieldAssignment + `: cannot assign to field "Balance" of immutable type`,
+		}},
+		{"mutableconsumer", []string{
+			"9: " + codes.ImmutableFieldAssignment + `: cannot assign to field "Owner" of immutable type`,
+			"10: " + codes.ImmutableFieldIncDec + `: cannot use ++ on field "Balance" of immutable type (outside constructor)`,
+		}},
+	} {
+		t.Run(tc.pkg, func(t *testing.T) {
+			pass := testfacts.CreateTestPassWithFacts(t, tc.pkg)
+			cfg := config.Empty()
+			packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+
+			var found []string
+			for _, v := range CheckImmutable(cfg, pass, &packageAnnotations) {
+				found = append(found, fmt.Sprintf("%d: %s: %s", pass.Fset.Position(v.Pos).Line, v.Code, v.Reason))
+			}
+			assert.Equal(t, tc.want, found)
+		})
+	}
+}
@@ -7,15 +7,25 @@ import (
 
 	"golang.org/x/tools/go/analysis"
 
+	"github.com/a14e/gogreement/src/config"
 	"github.com/a14e/gogreement/src/reporting"
+	"github.com/a14e/gogreement/src/trace"
 	"github.com/a14e/gogreement/src/util"
 )
 
 // ImmutableViolation represents a mutation of an immutable type
 // @immutable
 // implements reporting.Violation
+// implements reporting.RelatedViolation
+// implements reporting.ContractViolation
 type ImmutableViolation struct {
 	TypeName string
+	// TypePackage is the import path of the package declaring the immutable type
+	TypePackage string
+	// TypePos is the declaration of the immutable type, token.NoPos when unknown
+	TypePos token.Pos
+	// External is set when the mutating code is not in TypePackage
+	External bool
 	Reason   string
 	Code     string // Error code from codes package
 	Pos      token.Pos
@@ -34,15 +44,37 @@ func (v ImmutableViolation) GetPos() token.Pos {
 
 // GetMessage returns the main error message without formatting
 func (v ImmutableViolation) GetMessage() string {
+	if v.External {
+		return fmt.Sprintf("immutability violation: external package mutates immutable type %q: %s",
+			v.TypePackage+"."+v.TypeName, v.Reason)
+	}
 	return fmt.Sprintf("immutability violation in type %q: %s", v.TypeName, v.Reason)
 }
 
+// GetRelated points at the declaration of the immutable type
+func (v ImmutableViolation) GetRelated() []reporting.RelatedLocation {
+	if !v.TypePos.IsValid() || v.TypePos == v.Pos {
+		return nil
+	}
+	return []reporting.RelatedLocation{{
+		Pos:     v.TypePos,
+		Message: fmt.Sprintf("@immutable type %q is declared here", v.TypeName),
+	}}
+}
+
+// GetContracts names the @immutable annotation of the mutated type
+func (v ImmutableViolation) GetContracts() []trace.Key {
+	return []trace.Key{{Kind: "immutable", Package: v.TypePackage, Name: v.TypeName}}
+}
+
 // ReportViolations reports immutable violations using the new pretty formatter
-func ReportViolations(pass *analysis.Pass, violations []ImmutableViolation, ignoreSet *util.IgnoreSet) {
-	reporter := reporting.NewReporter(pass, ignoreSet)
+func ReportViolations(cfg *config.Config, pass *analysis.Pass, violations []ImmutableViolation, ignoreSet *util.IgnoreSet) {
+	reporter := reporting.NewReporter(cfg, pass, ignoreSet)
 
 	// Convert to generic violations and report
+	generic := make([]reporting.Violation, 0, len(violations))
 	for _, violation := range violations {
-		reporter.ReportViolation(violation)
+		generic = append(generic, violation)
 	}
+	reporter.ReportViolations(generic)
 }
@@ -1,13 +1,17 @@
 package implements
 
 import (
+	"os"
 	"testing"
 
 	"github.com/a14e/gogreement/src/annotations"
+	"github.com/a14e/gogreement/src/codes"
 	"github.com/a14e/gogreement/src/config"
 	"github.com/a14e/gogreement/src/testutil"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis"
 )
 
 func TestImplementsEdgeCases(t *testing.T) {
@@ -15,7 +19,8 @@ func TestImplementsEdgeCases(t *testing.T) {
 	cfg := config.Empty()
 	ann := annotations.ReadAllAnnotations(cfg, pass)
 
-	interfaces := LoadInterfaces(pass, ann.ToInterfaceQuery())
+	interfaces, err := LoadInterfaces(pass, ann.ToInterfaceQuery())
+	require.NoError(t, err)
 	typeModels := LoadTypes(pass, ann.ToTypeQuery())
 	missing := FindMissingMethods(ann.ImplementsAnnotations, interfaces, typeModels)
 
@@ -39,6 +44,163 @@ func TestImplementsEdgeCases(t *testing.T) {
 		assert.True(t, missingByType["SinglePtrImpl"],
 			"SinglePtrImpl takes *int, not **int, so it must NOT implement DoublePtr")
 	})
+
+	t.Run("via clause overrides the receiver form", func(t *testing.T) {
+		assert.False(t, missingByType["ViaPointerImpl"],
+			"via *ViaPointerImpl checks the pointer method set, which has Foo")
+		assert.True(t, missingByType["ViaValueImpl"],
+			"via ViaValueImpl checks the value method set, which lacks the pointer-receiver Foo")
+	})
+
+	t.Run("via clause naming another type is reported, not dropped", func(t *testing.T) {
+		assert.True(t, missingByType["ViaRenamed"],
+			"the contract of an annotation with a mismatched via clause is still checked")
+
+		mismatched := FindMismatchedReceivers(ann.ImplementsAnnotations)
+		require.Len(t, mismatched, 1)
+		assert.Equal(t, "ViaRenamed", mismatched[0].TypeName)
+		assert.Equal(t, "*Old", mismatched[0].Receiver)
+		assert.Equal(t, codes.ImplementsReceiverMismatch, mismatched[0].GetCode())
+		for _, a := range ann.ImplementsAnnotations {
+			if a.OnType == "ViaRenamed" {
+				assert.Equal(t, pass.Fset.Position(a.OnTypePos).Line-1, pass.Fset.Position(mismatched[0].Pos).Line,
+					"reported at the annotation")
+			}
+		}
+		assert.Equal(t, `@implements on type "ViaRenamed" names receiver "*Old" in its via clause; `+
+			`the clause must name the annotated type ("ViaRenamed" or "*ViaRenamed") and is ignored`,
+			mismatched[0].GetMessage())
+	})
+
+	t.Run("interface parameter qualified by the interface's own package", func(t *testing.T) {
+		assert.False(t, missingByType["ReaderFromImpl"],
+			"ReaderFromImpl takes io.Reader, matching the unqualified Reader in io.ReaderFrom")
+
+		var readFrom *InterfaceMethod
+		for _, iface := range interfaces {
+			if iface.Package == "io" && iface.Name == "ReaderFrom" {
+				readFrom = &iface.Methods[0]
+			}
+		}
+		if assert.NotNil(t, readFrom, "io.ReaderFrom should be loaded") {
+			assert.Equal(t, "Reader", readFrom.Inputs[0].TypeName)
+			assert.Equal(t, "io", readFrom.Inputs[0].TypePackage,
+				"unqualified Reader must resolve to the interface's defining package")
+		}
+	})
+}
+
+func TestImplementsGenericAliasInstantiation(t *testing.T) {
+	pass := testutil.CreateTestPass(t, "implementsedgecases")
+	cfg := config.Empty()
+	ann := annotations.ReadAllAnnotations(cfg, pass)
+
+	interfaces, err := LoadInterfaces(pass, ann.ToInterfaceQuery())
+	require.NoError(t, err)
+	typeModels := LoadTypes(pass, ann.ToTypeQuery())
+
+	missingInterfaces := make(map[string]bool)
+	for _, m := range FindMissingInterfaces(ann.ImplementsAnnotations, interfaces) {
+		missingInterfaces[m.TypeName] = true
+	}
+	missingMethods := make(map[string]bool)
+	for _, m := range FindMissingMethods(ann.ImplementsAnnotations, interfaces, typeModels) {
+		missingMethods[m.TypeName] = true
+	}
+
+	t.Run("alias of a generic alias instantiation", func(t *testing.T) {
+		assert.False(t, missingInterfaces["StringWriter"], "StringSink should resolve through SinkOf[string]")
+		assert.False(t, missingMethods["StringWriter"], "StringWriter implements Sink[string]")
+	})
+
+	t.Run("alias of a generic interface instantiation", func(t *testing.T) {
+		assert.False(t, missingInterfaces["IntWriterMismatch"], "IntSink should resolve to Sink[int]")
+		assert.True(t, missingMethods["IntWriterMismatch"], "Write(string) does not satisfy Sink[int]")
+	})
+
+	t.Run("uninstantiated generic alias is not a target", func(t *testing.T) {
+		assert.True(t, missingInterfaces["GenericAliasTarget"], "SinkOf without type arguments cannot be resolved")
+	})
+}
+
+func TestImplementsBlankIdentifierParameters(t *testing.T) {
+	pass := testutil.CreateTestPass(t, "implementsedgecases")
+	cfg := config.Empty()
+	ann := annotations.ReadAllAnnotations(cfg, pass)
+
+	interfaces, err := LoadInterfaces(pass, ann.ToInterfaceQuery())
+	require.NoError(t, err)
+	typeModels := LoadTypes(pass, ann.ToTypeQuery())
+
+	missingMethods := make(map[string]bool)
+	for _, m := range FindMissingMethods(ann.ImplementsAnnotations, interfaces, typeModels) {
+		missingMethods[m.TypeName] = true
+	}
+
+	var doer *InterfaceModel
+	for _, iface := range interfaces {
+		if iface.Name == "BlankDoer" {
+			doer = iface
+		}
+	}
+	if assert.NotNil(t, doer, "BlankDoer should be loaded") {
+		inputs := make(map[string]int)
+		for _, m := range doer.Methods {
+			inputs[m.Name] = len(m.Inputs)
+		}
+		assert.Equal(t, 2, inputs["Do"], "a blank parameter is still a parameter")
+		assert.Equal(t, 3, inputs["Merge"], "grouped blanks count once per identifier")
+	}
+
+	assert.False(t, missingMethods["NamedDoer"], "parameter names, including _, are irrelevant to matching")
+	assert.True(t, missingMethods["ShortMergeDoer"], "dropping a grouped blank parameter changes the signature")
+}
+
+func TestFindUnsatisfiedOneOf(t *testing.T) {
+	pass := testutil.CreateTestPass(t, "implementsedgecases")
+	cfg := config.Empty()
+	ann := annotations.ReadAllAnnotations(cfg, pass)
+
+	interfaces, err := LoadInterfaces(pass, ann.ToInterfaceQuery())
+	require.NoError(t, err)
+	typeModels := LoadTypes(pass, ann.ToTypeQuery())
+	unsatisfied := FindUnsatisfiedOneOf(ann.ImplementsOneOfAnnotations, interfaces, typeModels)
+
+	flagged := make(map[string]bool)
+	for _, report := range unsatisfied {
+		flagged[report.TypeName] = true
+		t.Logf("unsatisfied: %s", report.GetMessage())
+	}
+
+	assert.False(t, flagged["PluginReader"], "implementing exactly one listed interface satisfies the group")
+	assert.True(t, flagged["PluginNone"], "implementing none of the listed interfaces is a violation")
+	assert.Len(t, unsatisfied, 1)
+}
+
+func TestFindEmbeddedImplementations(t *testing.T) {
+	pass := testutil.CreateTestPass(t, "implementsedgecases")
+	cfg := config.Empty()
+	ann := annotations.ReadAllAnnotations(cfg, pass)
+
+	interfaces, err := LoadInterfaces(pass, ann.ToInterfaceQuery())
+	require.NoError(t, err)
+	typeModels := LoadTypes(pass, ann.ToTypeQuery())
+	reports := FindEmbeddedImplementations(ann.ImplementsAnnotations, interfaces, typeModels)
+
+	var typeNames []string
+	for _, r := range reports {
+		typeNames = append(typeNames, r.TypeName)
+		assert.Equal(t, "IMPL50", r.GetCode())
+		t.Log(r.GetMessage())
+	}
+
+	// Only ReaderWrapper gets every io.Reader method from the embedded field;
+	// CountingReader overrides Read and ReadCloserImpl declares Close itself.
+	assert.Equal(t, []string{"ReaderWrapper"}, typeNames)
+	if len(reports) == 1 {
+		assert.Contains(t, reports[0].GetMessage(), `only through an embedded interface field`)
+		assert.Contains(t, reports[0].GetMessage(), `"io.Reader"`)
+	}
 }
 
 func TestImplementsUnexportedMethodCrossPackage(t *testing.T) {
@@ -46,7 +208,8 @@ func TestImplementsUnexportedMethodCrossPackage(t *testing.T) {
 	cfg := config.Empty()
 	ann := annotations.ReadAllAnnotations(cfg, pass)
 
-	interfaces := LoadInterfaces(pass, ann.ToInterfaceQuery())
+	interfaces, err := LoadInterfaces(pass, ann.ToInterfaceQuery())
+	require.NoError(t, err)
 	typeModels := LoadTypes(pass, ann.ToTypeQuery())
 	missing := FindMissingMethods(ann.ImplementsAnnotations, interfaces, typeModels)
 
@@ -60,3 +223,482 @@ func TestImplementsUnexportedMethodCrossPackage(t *testing.T) {
 	assert.True(t, found,
 		"a type with its own read() does not satisfy an interface whose unexported read() belongs to another package")
 }
+
+func TestVerboseMissingMethodsListsBothMethodSets(t *testing.T) {
+	pass := testutil.CreateTestPass(t, "implementsedgecases")
+	cfg := config.Empty()
+	ann := annotations.ReadAllAnnotations(cfg, pass)
+
+	interfaces, err := LoadInterfaces(pass, ann.ToInterfaceQuery())
+	require.NoError(t, err)
+	typeModels := LoadTypes(pass, ann.ToTypeQuery())
+
+	findReport := func(reports []MissingMethodsReport, code string) *MissingMethodsReport {
+		for i := range reports {
+			if reports[i].TypeName == "PartialStore" && reports[i].GetCode() == code {
+				return &reports[i]
+			}
+		}
+		return nil
+	}
+
+	t.Run("default output only lists missing methods", func(t *testing.T) {
+		reports := FindMissingMethods(ann.ImplementsAnnotations, interfaces, typeModels)
+		report := findReport(reports, codes.ImplementsSignatureMismatch)
+		if !assert.NotNil(t, report, "PartialStore has Put with the wrong signature") {
+			return
+		}
+
+		message := report.GetMessage()
+		assert.Contains(t, message, "want Put(string, []byte) error\n  have Put(string, string) error")
+		assert.NotContains(t, message, "methods:\n  Get", "method sets must only be printed in verbose mode")
+	})
+
+	t.Run("verbose output lists every method of both sides", func(t *testing.T) {
+		report := findReport(FindMissingMethodsVerbose(ann.ImplementsAnnotations, interfaces, typeModels), codes.ImplementsMissingMethods)
+		if !assert.NotNil(t, report, "PartialStore should not implement Store") {
+			return
+		}
+
+		message := report.GetMessage()
+		t.Log(message)
+
+		assert.Contains(t, message, "missing methods:\n  Close() error\n")
+		assert.Contains(t, message,
+			"interface \"Store\" methods:\n"+
+				"  Close() error\n"+
+				"  Get(string) ([]byte, error)\n"+
+				"  Put(string, []byte) error")
+		assert.Contains(t, message,
+			"type \"PartialStore\" methods:\n"+
+				"  Close() error (pointer receiver)\n"+
+				"  Get(string) ([]byte, error)\n"+
+				"  Put(string, string) error")
+	})
+}
+
+func TestImplementsEmbeddedGenericInstantiation(t *testing.T) {
+	pass := testutil.CreateTestPass(t, "implementsedgecases")
+	cfg := config.Empty()
+	ann := annotations.ReadAllAnnotations(cfg, pass)
+
+	interfaces, err := LoadInterfaces(pass, ann.ToInterfaceQuery())
+	require.NoError(t, err)
+	typeModels := LoadTypes(pass, ann.ToTypeQuery())
+
+	var bag *InterfaceModel
+	for _, iface := range interfaces {
+		if iface.Name == "IntBag" {
+			bag = iface
+		}
+	}
+	if assert.NotNil(t, bag, "IntBag should be loaded") {
+		signatures := make(map[string]string)
+		for _, m := range bag.Methods {
+			signatures[m.Name] = formatMethodSignature(m)
+		}
+		assert.Equal(t, map[string]string{
+			"Get":   "Get(int) int",
+			"Put":   "Put(int)",
+			"Len":   "Len() int",
+			"Reset": "Reset()",
+		}, signatures, "embedded Container[int] methods are flattened with T = int")
+	}
+
+	mismatched := make(map[string][]string)
+	for _, m := range FindMissingMethods(ann.ImplementsAnnotations, interfaces, typeModels) {
+		for _, mismatch := range m.Mismatches {
+			mismatched[m.TypeName] = append(mismatched[m.TypeName], mismatch.Want.Name)
+		}
+		if len(m.Mismatches) == 0 {
+			mismatched[m.TypeName] = append(mismatched[m.TypeName], "missing")
+		}
+	}
+
+	assert.NotContains(t, mismatched, "SliceBag", "SliceBag implements Container[int] and Reset")
+	assert.ElementsMatch(t, []string{"Get", "Put"}, mismatched["StringBag"],
+		"Get and Put are checked against the int instantiation")
+}
+
+func TestImplementsOwnAndPromotedMethods(t *testing.T) {
+	pass := testutil.CreateTestPass(t, "implementsedgecases")
+	cfg := config.Empty()
+	ann := annotations.ReadAllAnnotations(cfg, pass)
+
+	interfaces, err := LoadInterfaces(pass, ann.ToInterfaceQuery())
+	require.NoError(t, err)
+	typeModels := LoadTypes(pass, ann.ToTypeQuery())
+
+	methods := make(map[string]string)
+	for _, model := range typeModels {
+		if model.Name != "FileHandle" {
+			continue
+		}
+		for _, m := range model.Methods {
+			methods[m.Name] = formatTypeMethodSignature(m)
+		}
+	}
+	assert.Equal(t, map[string]string{
+		"Read":  "Read([]byte) (int, error)",
+		"Close": "Close() error",
+	}, methods, "own Read shadows the promoted one, Close is promoted")
+
+	mismatched := make(map[string][]string)
+	for _, m := range FindMissingMethods(ann.ImplementsAnnotations, interfaces, typeModels) {
+		for _, mismatch := range m.Mismatches {
+			mismatched[m.TypeName] = append(mismatched[m.TypeName], mismatch.Want.Name)
+		}
+	}
+
+	assert.NotContains(t, mismatched, "FileHandle", "FileHandle implements io.ReadCloser with own Read and promoted Close")
+	assert.Equal(t, []string{"Close"}, mismatched["ShadowedHandle"], "the own Close shadows the matching promoted one")
+}
+
+func TestImplementsChannelDirection(t *testing.T) {
+	pass := testutil.CreateTestPass(t, "implementsedgecases")
+	cfg := config.Empty()
+	ann := annotations.ReadAllAnnotations(cfg, pass)
+
+	interfaces, err := LoadInterfaces(pass, ann.ToInterfaceQuery())
+	require.NoError(t, err)
+	typeModels := LoadTypes(pass, ann.ToTypeQuery())
+
+	mismatched := make(map[string][]string)
+	for _, m := range FindMissingMethods(ann.ImplementsAnnotations, interfaces, typeModels) {
+		for _, mismatch := range m.Mismatches {
+			mismatched[m.TypeName] = append(mismatched[m.TypeName], formatTypeMethodSignature(mismatch.Have))
+		}
+	}
+
+	assert.NotContains(t, mismatched, "DoneContext", "Done() <-chan struct{} matches context.Context")
+	assert.Equal(t, []string{"Done() chan struct{}"}, mismatched["BidirectionalContext"],
+		"a bidirectional channel does not match <-chan struct{}")
+	assert.Equal(t, []string{"Done() chan<- struct{}"}, mismatched["SendOnlyContext"],
+		"a send-only channel does not match <-chan struct{}")
+}
+
+func TestImplementsSelfReferentialInterface(t *testing.T) {
+	pass := testutil.CreateTestPass(t, "implementsedgecases")
+	cfg := config.Empty()
+	ann := annotations.ReadAllAnnotations(cfg, pass)
+
+	interfaces, err := LoadInterfaces(pass, ann.ToInterfaceQuery())
+	require.NoError(t, err)
+	typeModels := LoadTypes(pass, ann.ToTypeQuery())
+
+	var builder *InterfaceModel
+	for _, iface := range interfaces {
+		if iface.Name == "Builder" {
+			builder = iface
+		}
+	}
+	require.NotNil(t, builder)
+	for _, method := range builder.Methods {
+		if method.Name == "With" {
+			require.Len(t, method.Outputs, 1)
+			assert.Equal(t, "Builder", method.Outputs[0].TypeName, "the result is the interface itself")
+			assert.Equal(t, pass.Pkg.Path(), method.Outputs[0].TypePackage)
+		}
+	}
+
+	reports := make(map[string]MissingMethodsReport)
+	for _, m := range FindMissingMethods(ann.ImplementsAnnotations, interfaces, typeModels) {
+		reports[m.TypeName] = m
+	}
+
+	assert.NotContains(t, reports, "FluentBuilder", "returning Builder satisfies the fluent interface")
+
+	report, ok := reports["ConcreteBuilder"]
+	require.True(t, ok, "returning the concrete type does not satisfy the interface")
+	assert.Equal(t, codes.ImplementsSignatureMismatch, report.GetCode())
+	require.Len(t, report.Mismatches, 1)
+	assert.True(t, report.Mismatches[0].ReturnsConcreteType)
+	assert.Contains(t, report.GetMessage(),
+		"With returns the concrete type where the interface returns itself; "+
+			"Go requires identical result types, so declare the result as Builder")
+}
+
+func TestImplementsPointerParameterHint(t *testing.T) {
+	pass := testutil.CreateTestPass(t, "implementsedgecases")
+	cfg := config.Empty()
+	ann := annotations.ReadAllAnnotations(cfg, pass)
+
+	interfaces, err := LoadInterfaces(pass, ann.ToInterfaceQuery())
+	require.NoError(t, err)
+	typeModels := LoadTypes(pass, ann.ToTypeQuery())
+
+	reports := make(map[string]MissingMethodsReport)
+	for _, m := range FindMissingMethods(ann.ImplementsAnnotations, interfaces, typeModels) {
+		reports[m.TypeName] = m
+	}
+
+	assert.NotContains(t, reports, "PointerRunner")
+
+	report, ok := reports["ValueRunner"]
+	require.True(t, ok, "a value parameter does not match a pointer parameter")
+	assert.Equal(t, codes.ImplementsSignatureMismatch, report.GetCode())
+	require.Len(t, report.Mismatches, 1)
+	assert.Equal(t, []int{0}, report.Mismatches[0].PointerParams)
+	assert.Contains(t, report.GetMessage(), "parameter 1 of Run should be *implementsedgecases.Job, got implementsedgecases.Job")
+
+	t.Run("no hint when other parts differ too", func(t *testing.T) {
+		for _, mismatch := range reports["ConcreteBuilder"].Mismatches {
+			assert.Empty(t, mismatch.PointerParams)
+		}
+	})
+}
+
+func TestImplementsInterfaceEmbeddingError(t *testing.T) {
+	pass := testutil.CreateTestPass(t, "implementsedgecases")
+	cfg := config.Empty()
+	ann := annotations.ReadAllAnnotations(cfg, pass)
+
+	interfaces, err := LoadInterfaces(pass, ann.ToInterfaceQuery())
+	require.NoError(t, err)
+	typeModels := LoadTypes(pass, ann.ToTypeQuery())
+
+	for _, iface := range interfaces {
+		if iface.Name == "CodedError" {
+			var names []string
+			for _, method := range iface.Methods {
+				names = append(names, method.Name)
+			}
+			assert.ElementsMatch(t, []string{"Code", "Error"}, names, "methods of the embedded error are flattened in")
+		}
+	}
+
+	reports := make(map[string]MissingMethodsReport)
+	for _, m := range FindMissingMethods(ann.ImplementsAnnotations, interfaces, typeModels) {
+		reports[m.TypeName] = m
+	}
+
+	assert.NotContains(t, reports, "NotFound")
+
+	report, ok := reports["CodeOnly"]
+	require.True(t, ok, "Error() of the embedded error is required")
+	assert.Equal(t, codes.ImplementsMissingMethods, report.GetCode())
+	require.Len(t, report.Methods, 1)
+	assert.Equal(t, "Error", report.Methods[0].Name)
+	assert.Contains(t, report.GetMessage(), "Error() string")
+}
+
+func TestImplementsAnyParameterHint(t *testing.T) {
+	pass := testutil.CreateTestPass(t, "implementsedgecases")
+	cfg := config.Empty()
+	ann := annotations.ReadAllAnnotations(cfg, pass)
+
+	interfaces, err := LoadInterfaces(pass, ann.ToInterfaceQuery())
+	require.NoError(t, err)
+	typeModels := LoadTypes(pass, ann.ToTypeQuery())
+
+	reports := make(map[string]MissingMethodsReport)
+	for _, m := range FindMissingMethods(ann.ImplementsAnnotations, interfaces, typeModels) {
+		reports[m.TypeName] = m
+	}
+
+	assert.NotContains(t, reports, "EmptyInterfaceConsumer", "interface{} is the same type as any")
+
+	report, ok := reports["StringerConsumer"]
+	require.True(t, ok, "a type any accepts does not match an any parameter")
+	assert.Equal(t, codes.ImplementsSignatureMismatch, report.GetCode())
+	require.Len(t, report.Mismatches, 1)
+	assert.Equal(t, []int{0}, report.Mismatches[0].AnyParams)
+	assert.Empty(t, report.Mismatches[0].PointerParams)
+	assert.Contains(t, report.GetMessage(),
+		"parameter 1 of Accept must be any: Go requires identical parameter types, even though any accepts every fmt.Stringer")
+}
+
+func TestImplementedBy(t *testing.T) {
+	pass := testutil.CreateTestPass(t, "implementsedgecases")
+	cfg := config.Empty()
+	ann := annotations.ReadAllAnnotations(cfg, pass)
+
+	interfaces, err := LoadInterfaces(pass, ann.ToInterfaceQuery())
+	require.NoError(t, err)
+
+	var found []string
+	for _, report := range FindUnimplementedBy(pass, ann.ImplementedByAnnotations, interfaces) {
+		assert.Equal(t, codes.ImplementsImplementedBy, report.GetCode())
+		found = append(found, report.InterfaceName+": "+report.Implementer)
+	}
+
+	assert.Equal(t, []string{
+		"Repository: ReadOnlyRepository",
+		"Repository: Ghost",
+		"TextSink: bytes.Buffer",
+	}, found, "MemRepository, *FileRepository, *strings.Builder and *bytes.Buffer implement their interfaces")
+
+	t.Run("messages", func(t *testing.T) {
+		reports := FindUnimplementedBy(pass, ann.ImplementedByAnnotations, interfaces)
+		require.Len(t, reports, 3)
+		assert.Equal(t,
+			"type \"ReadOnlyRepository\" listed by @implementedby does not implement interface \"Repository\"\n"+
+				"missing methods:\n"+
+				"  Put(string, string)",
+			reports[0].GetMessage())
+		assert.Equal(t, `type "Ghost" listed by @implementedby on interface "Repository" is not found`, reports[1].GetMessage())
+		assert.Contains(t, reports[2].GetMessage(), "WriteString")
+	})
+}
+
+func TestTypeSetInterface(t *testing.T) {
+	pass := testutil.CreateTestPass(t, "implementsedgecases")
+	cfg := config.Empty()
+	ann := annotations.ReadAllAnnotations(cfg, pass)
+
+	interfaces, err := LoadInterfaces(pass, ann.ToInterfaceQuery())
+	require.NoError(t, err)
+	typeModels := LoadTypes(pass, ann.ToTypeQuery())
+
+	var messages []string
+	for _, report := range FindTypeSetInterfaces(ann.AllImplementsAnnotations(), interfaces) {
+		assert.Equal(t, codes.ImplementsTypeSet, report.GetCode())
+		if report.TypeName == "Celsius" || report.TypeName == "Rank" {
+			messages = append(messages, report.GetMessage())
+		}
+	}
+	assert.Equal(t, []string{
+		`interface "Numeric" named by @implements on type "Celsius" is a type set (~int | ~float64), not a method set; it can only constrain type parameters and no type implements it`,
+		`interface "Ordered" named by @implements on type "Rank" is a type set (~int | ~string), not a method set; it can only constrain type parameters and no type implements it`,
+	}, messages, "the alias and the defined constraint interface are both reported")
+
+	for _, report := range FindMissingMethods(ann.ImplementsAnnotations, interfaces, typeModels) {
+		assert.NotContains(t, []string{"Celsius", "Rank"}, report.TypeName, "type sets are not checked for methods")
+	}
+}
+
+func TestAddPointerSuggestedFix(t *testing.T) {
+	pass := testutil.CreateTestPass(t, "implementsedgecases")
+	cfg := config.Empty()
+	ann := annotations.ReadAllAnnotations(cfg, pass)
+
+	interfaces, err := LoadInterfaces(pass, ann.ToInterfaceQuery())
+	require.NoError(t, err)
+	typeModels := LoadTypes(pass, ann.ToTypeQuery())
+
+	fixes := make(map[string][]analysis.SuggestedFix)
+	for _, report := range FindMissingMethods(ann.ImplementsAnnotations, interfaces, typeModels) {
+		fixes[report.TypeName] = report.GetSuggestedFixes()
+	}
+
+	assert.Empty(t, fixes["Slider"], "& does not add the missing Foo")
+	assert.Empty(t, fixes["ViaValueImpl"], "the via clause names the receiver form explicitly")
+	assert.Empty(t, fixes["PartialStore"], "& does not fix the Put signature")
+
+	require.Len(t, fixes["Cursor"], 1)
+	fix := fixes["Cursor"][0]
+	assert.Equal(t, "Change the annotation to @implements &Reader", fix.Message)
+	require.Len(t, fix.TextEdits, 1)
+
+	edit := fix.TextEdits[0]
+	filename := pass.Fset.Position(edit.Pos).Filename
+	src, err := os.ReadFile(filename)
+	require.NoError(t, err)
+	tokFile := pass.Fset.File(edit.Pos)
+	fixed := string(src[:tokFile.Offset(edit.Pos)]) + string(edit.NewText) + string(src[tokFile.Offset(edit.End):])
+	assert.Contains(t, fixed, "// @implements &Reader\ntype Cursor struct{}")
+}
+
+func TestMissingMethodsRelatedAnnotation(t *testing.T) {
+	pass := testutil.CreateTestPass(t, "implementsedgecases")
+	cfg := config.Empty()
+	ann := annotations.ReadAllAnnotations(cfg, pass)
+
+	interfaces, err := LoadInterfaces(pass, ann.ToInterfaceQuery())
+	require.NoError(t, err)
+	typeModels := LoadTypes(pass, ann.ToTypeQuery())
+
+	var reports []MissingMethodsReport
+	for _, report := range FindMissingMethods(ann.ImplementsAnnotations, interfaces, typeModels) {
+		if report.TypeName == "PartialStore" {
+			reports = append(reports, report)
+		}
+	}
+
+	// Missing Close and the mismatched Put are two reports of one annotation
+	require.Len(t, reports, 2)
+	for _, report := range reports {
+		related := report.GetRelated()
+		require.Len(t, related, 1, report.GetCode())
+		assert.Equal(t, "@implements Store is declared here", related[0].Message)
+
+		annotationLine := pass.Fset.Position(related[0].Pos).Line
+		typeLine := pass.Fset.Position(report.GetPos()).Line
+		assert.Equal(t, typeLine-1, annotationLine, "points at the annotation above the type")
+	}
+
+	assert.Empty(t, MissingMethodsReport{TypeName: "T"}.GetRelated(), "no related location without the annotation position")
+}
+
+func TestTypeParameterWithCoreType(t *testing.T) {
+	pass := testutil.CreateTestPass(t, "implementsedgecases")
+	cfg := config.Empty()
+	ann := annotations.ReadAllAnnotations(cfg, pass)
+
+	interfaces, err := LoadInterfaces(pass, ann.ToInterfaceQuery())
+	require.NoError(t, err)
+	typeModels := LoadTypes(pass, ann.ToTypeQuery())
+
+	reported := make(map[string]string)
+	for _, report := range FindMissingMethods(ann.ImplementsAnnotations, interfaces, typeModels) {
+		reported[report.TypeName] = report.GetCode()
+	}
+
+	assert.NotContains(t, reported, "ExactBuffer", "E can only be []byte, so Write(p E) matches Write(p []byte)")
+	assert.Equal(t, codes.ImplementsSignatureMismatch, reported["TildeBuffer"],
+		"E may be a named byte slice type, which is not identical to []byte")
+}
+
+func TestImplementsThroughUnexportedEmbeddedInterface(t *testing.T) {
+	pass := testutil.CreateTestPass(t, "implementsedgecases")
+	cfg := config.Empty()
+	ann := annotations.ReadAllAnnotations(cfg, pass)
+
+	interfaces, err := LoadInterfaces(pass, ann.ToInterfaceQuery())
+	require.NoError(t, err)
+	typeModels := LoadTypes(pass, ann.ToTypeQuery())
+
+	promoted := make(map[string]bool)
+	for _, model := range typeModels {
+		if model.Name == "CachedLookup" {
+			for _, method := range model.Methods {
+				promoted[method.Name] = method.FromEmbeddedInterface
+			}
+		}
+	}
+	assert.Equal(t, map[string]bool{"Get": true, "Len": false}, promoted,
+		"Get is promoted from the unexported embedded interface")
+
+	for _, report := range FindMissingMethods(ann.ImplementsAnnotations, interfaces, typeModels) {
+		assert.NotEqual(t, "CachedLookup", report.TypeName, report.GetMessage())
+	}
+	for _, report := range FindEmbeddedImplementations(ann.ImplementsAnnotations, interfaces, typeModels) {
+		assert.NotEqual(t, "CachedLookup", report.TypeName, "Len is declared on the type itself")
+	}
+}
+
+func TestImplementsWithMethodsInAnotherFile(t *testing.T) {
+	pass := testutil.CreateTestPass(t, "implementsedgecases")
+	cfg := config.Empty()
+	ann := annotations.ReadAllAnnotations(cfg, pass)
+
+	interfaces, err := LoadInterfaces(pass, ann.ToInterfaceQuery())
+	require.NoError(t, err)
+	typeModels := LoadTypes(pass, ann.ToTypeQuery())
+
+	methods := make(map[string][]string)
+	for _, model := range typeModels {
+		if model.Name == "Ledger" || model.Name == "PointerLedger" {
+			for _, method := range model.Methods {
+				methods[model.Name] = append(methods[model.Name], method.Name)
+			}
+		}
+	}
+	assert.ElementsMatch(t, []string{"Append", "Entries"}, methods["Ledger"],
+		"methods declared in another file than the type are loaded")
+	assert.ElementsMatch(t, []string{"Append", "Entries"}, methods["PointerLedger"])
+
+	for _, report := range FindMissingMethods(ann.ImplementsAnnotations, interfaces, typeModels) {
+		assert.NotContains(t, []string{"Ledger", "PointerLedger"}, report.TypeName, report.GetMessage())
+	}
+}
@@ -0,0 +1,107 @@
+package implements
+
+import (
+	"fmt"
+	"go/token"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/a14e/gogreement/src/annotations"
+	"github.com/a14e/gogreement/src/codes"
+)
+
+// ImplementedByReport is reported for a type listed by @implementedby on an
+// interface that does not implement it
+// @immutable
+// implements reporting.Violation
+type ImplementedByReport struct {
+	InterfaceName string
+	// Implementer is the type as written in the annotation, e.g. "*MemStore"
+	Implementer string
+	// NotFound is set when the listed type is not declared in its package
+	NotFound bool
+	// Problems are the missing methods and mismatched signatures of the type
+	Problems []MissingMethodsReport
+	Pos      token.Pos
+}
+
+// GetCode returns the error code for this violation
+func (v ImplementedByReport) GetCode() string {
+	return codes.ImplementsImplementedBy
+}
+
+// GetPos returns the position of the violation
+func (v ImplementedByReport) GetPos() token.Pos {
+	return v.Pos
+}
+
+// GetMessage returns the main error message without formatting
+func (v ImplementedByReport) GetMessage() string {
+	if v.NotFound {
+		return fmt.Sprintf(
+			"type \"%s\" listed by @implementedby on interface \"%s\" is not found",
+			v.Implementer,
+			v.InterfaceName,
+		)
+	}
+
+	// The first line of each problem names the type and interface again
+	lines := []string{fmt.Sprintf(
+		"type \"%s\" listed by @implementedby does not implement interface \"%s\"",
+		v.Implementer,
+		v.InterfaceName,
+	)}
+	for _, problem := range v.Problems {
+		_, details, _ := strings.Cut(problem.GetMessage(), "\n")
+		lines = append(lines, details)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// FindUnimplementedBy checks every type listed by the @implementedby
+// annotations against its interface with FindMissingMethods. Listed types are
+// looked up in the current package or, when qualified, in the imported
+// package; a type that cannot be found is reported as well.
+func FindUnimplementedBy(
+	pass *analysis.Pass,
+	groups []annotations.ImplementedByAnnotation,
+	interfaces []*InterfaceModel,
+) []ImplementedByReport {
+	var result []ImplementedByReport
+
+	for _, group := range groups {
+		for _, implementer := range group.Implementers {
+			var types []*TypeModel
+			if pkg := importedPackage(pass.Pkg, implementer.PackageFullPath); pkg != nil && !implementer.PackageNotFound {
+				if model := lookupType(pkg, implementer.TypeName); model != nil {
+					types = append(types, model)
+				}
+			}
+
+			if len(types) == 0 {
+				result = append(result, ImplementedByReport{
+					InterfaceName: group.OnInterface,
+					Implementer:   implementer.Spelling,
+					NotFound:      true,
+					Pos:           group.OnInterfacePos,
+				})
+				continue
+			}
+
+			problems := FindMissingMethods([]annotations.ImplementsAnnotation{implementer.Check}, interfaces, types)
+			if len(problems) == 0 {
+				continue
+			}
+
+			result = append(result, ImplementedByReport{
+				InterfaceName: group.OnInterface,
+				Implementer:   implementer.Spelling,
+				Problems:      problems,
+				Pos:           group.OnInterfacePos,
+			})
+		}
+	}
+
+	return result
+}
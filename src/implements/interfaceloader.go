@@ -1,7 +1,10 @@
 package implements
 
 import (
+	"fmt"
 	"go/types"
+	"slices"
+	"strings"
 
 	"github.com/a14e/gogreement/src/annotations"
 
@@ -14,6 +17,12 @@ type InterfaceModel struct {
 	Name    string
 	Package string
 	Methods []InterfaceMethod
+
+	// TypeSet is set for interfaces with type terms (~int | ~float64) or
+	// comparable, which only constrain type parameters. Terms holds the type
+	// terms as written, e.g. "~int | ~float64".
+	TypeSet bool
+	Terms   string
 }
 
 // InterfaceMethod
@@ -40,87 +49,117 @@ type InterfaceType struct {
 	Canonical string
 }
 
-// LoadInterfaces loads specified interfaces from the analysis pass
-func LoadInterfaces(pass *analysis.Pass, queries []annotations.InterfaceQuery) []*InterfaceModel {
-	var result []*InterfaceModel
+// MaxEmbeddingDepth caps how many levels of embedded interfaces LoadInterfaces
+// follows before it gives up on an interface
+var MaxEmbeddingDepth = 64
+
+// LoadInterfaces loads specified interfaces from the analysis pass. It returns
+// an error for an interface whose embedded interfaces form a cycle or nest
+// deeper than MaxEmbeddingDepth; the type checker rejects both, so this only
+// guards against malformed type information.
+// Queries are resolved by a fresh Loader, see Loader.Interfaces.
+func LoadInterfaces(pass *analysis.Pass, queries []annotations.InterfaceQuery) ([]*InterfaceModel, error) {
+	return NewLoader(pass).Interfaces(queries)
+}
 
-	// Group queries by package for efficient lookup
-	pkgToInterface := make(map[string]map[string]bool) // pkg -> interface names
-	for _, q := range queries {
-		pkg := q.PackageName
-		if pkg == "" {
-			pkg = pass.Pkg.Path()
-		}
-		if pkgToInterface[pkg] == nil {
-			pkgToInterface[pkg] = make(map[string]bool)
-		}
-		pkgToInterface[pkg][q.InterfaceName] = true
+// interfaceModel builds the model of the interface typeName declares in pkg,
+// or returns nil when it does not declare an interface
+func interfaceModel(pkg *types.Package, typeName *types.TypeName) (*InterfaceModel, error) {
+	// An alias has no type arguments to supply in @implements, so a generic
+	// alias (type SinkOf[T any] = Sink[T]) itself cannot be a target; an
+	// alias of its instantiation (type StringSink = SinkOf[string]) can.
+	if alias, ok := typeName.Type().(*types.Alias); ok && alias.TypeParams().Len() > 0 {
+		return nil, nil
 	}
 
-	// Collect all packages to scan (current + imports)
-	packagesToScan := make([]*types.Package, 0)
-
-	if pkgToInterface[pass.Pkg.Path()] != nil {
-		packagesToScan = append(packagesToScan, pass.Pkg)
+	// Check if it's an interface. Unalias follows alias chains, including
+	// generic alias instantiations, to the instantiated interface type.
+	iface, ok := types.Unalias(typeName.Type()).Underlying().(*types.Interface)
+	if !ok {
+		return nil, nil
+	}
+	if err := checkEmbedding(iface, embeddingRoot(typeName), 0); err != nil {
+		return nil, fmt.Errorf("interface %s.%s: %w", pkg.Path(), typeName.Name(), err)
 	}
 
-	for _, imp := range pass.Pkg.Imports() {
-		if pkgToInterface[imp.Path()] != nil {
-			packagesToScan = append(packagesToScan, imp)
+	// Complete flattens embedded interfaces into the method set; an
+	// embedded instantiation (Container[int]) contributes its methods with
+	// the type arguments already substituted.
+	iface = iface.Complete()
+
+	return &InterfaceModel{
+		Name:    typeName.Name(),
+		Package: pkg.Path(), // Full import path
+		Methods: extractMethodsFromInterface(iface),
+		TypeSet: !iface.IsMethodSet(),
+		Terms:   typeTerms(iface),
+	}, nil
+}
+
+// typeTerms returns the type terms embedded directly in iface, such as
+// "~int | ~float64", or "" for an interface of methods and embedded
+// interfaces only
+func typeTerms(iface *types.Interface) string {
+	var terms []string
+	for i := 0; i < iface.NumEmbeddeds(); i++ {
+		embedded := iface.EmbeddedType(i)
+		if _, ok := embedded.Underlying().(*types.Interface); ok {
+			continue
 		}
+		terms = append(terms, embedded.String())
 	}
+	return strings.Join(terms, "; ")
+}
 
-	// Scan all packages uniformly using types.Package
-	for _, pkg := range packagesToScan {
-		interfaces := findInterfacesInPackage(pkg, pkgToInterface[pkg.Path()])
-		result = append(result, interfaces...)
+// embeddingRoot starts the embedding path of an interface at its defining
+// type name, following aliases, so a cycle back to an aliased interface is seen
+func embeddingRoot(typeName *types.TypeName) []*types.TypeName {
+	if named, ok := types.Unalias(typeName.Type()).(*types.Named); ok {
+		return []*types.TypeName{named.Obj()}
 	}
-
-	return result
+	return []*types.TypeName{typeName}
 }
 
-// findInterfacesInPackage extracts interfaces from package using types.Package
-func findInterfacesInPackage(
-	pkg *types.Package,
-	targetInterfaces map[string]bool,
-) []*InterfaceModel {
-	var result []*InterfaceModel
-
-	scope := pkg.Scope()
-	for _, name := range scope.Names() {
-		if !targetInterfaces[name] {
-			continue
+// checkEmbedding walks the interfaces embedded in iface. path holds the
+// interfaces embedding it, outermost first, so an interface reached again
+// through its own embeddings is reported as a cycle; the same interface
+// embedded twice through different branches is not.
+func checkEmbedding(iface *types.Interface, path []*types.TypeName, depth int) error {
+	for i := 0; i < iface.NumEmbeddeds(); i++ {
+		if depth >= MaxEmbeddingDepth {
+			return fmt.Errorf("embedded interfaces nest deeper than %d levels", MaxEmbeddingDepth)
 		}
 
-		obj := scope.Lookup(name)
-		if obj == nil {
-			continue
-		}
+		embedded := types.Unalias(iface.EmbeddedType(i))
 
-		// Check if it's a type name
-		typeName, ok := obj.(*types.TypeName)
-		if !ok {
-			continue
+		next := path
+		if named, ok := embedded.(*types.Named); ok {
+			next = append(path[:len(path):len(path)], named.Obj())
+			if slices.Contains(path, named.Obj()) {
+				return fmt.Errorf("embedded interfaces form a cycle: %s", formatEmbeddingPath(next))
+			}
 		}
 
-		// Check if it's an interface
-		iface, ok := typeName.Type().Underlying().(*types.Interface)
+		// Type terms of constraint interfaces (~int | string) embed no methods
+		inner, ok := embedded.Underlying().(*types.Interface)
 		if !ok {
 			continue
 		}
-		//  we need to complete the interface
-		iface = iface.Complete()
-
-		model := &InterfaceModel{
-			Name:    name,
-			Package: pkg.Path(), // Full import path
-			Methods: extractMethodsFromInterface(iface),
+		if err := checkEmbedding(inner, next, depth+1); err != nil {
+			return err
 		}
-
-		result = append(result, model)
 	}
 
-	return result
+	return nil
+}
+
+// formatEmbeddingPath joins interface names as "A -> B -> A"
+func formatEmbeddingPath(path []*types.TypeName) string {
+	names := make([]string, len(path))
+	for i, obj := range path {
+		names[i] = obj.Name()
+	}
+	return strings.Join(names, " -> ")
 }
 
 // extractMethodsFromInterface extracts methods from types.Interface
@@ -172,6 +211,13 @@ func extractTypesFromTuple(tuple *types.Tuple, isVariadic bool) []InterfaceType
 
 // convertTypesToInterfaceType converts types.Type to InterfaceType
 func convertTypesToInterfaceType(t types.Type) InterfaceType {
+	t = unaliasType(t)
+
+	// any and interface{} are the same type
+	if iface, ok := t.(*types.Interface); ok && iface.Empty() {
+		return InterfaceType{TypeName: "any", Canonical: "any"}
+	}
+
 	// Handle pointer
 	if ptr, ok := t.(*types.Pointer); ok {
 		inner := convertTypesToInterfaceType(ptr.Elem())
@@ -180,7 +226,9 @@ func convertTypesToInterfaceType(t types.Type) InterfaceType {
 		return inner
 	}
 
-	// Handle named types
+	// Handle named types. The package is taken from the type's own object, so
+	// an unqualified name in the interface source (Reader inside io.ReaderFrom)
+	// resolves to its defining package ("io"), never to an empty path.
 	if named, ok := t.(*types.Named); ok {
 		obj := named.Obj()
 		pkg := obj.Pkg()
@@ -1,6 +1,8 @@
 package implements
 
 import (
+	"go/token"
+	"go/types"
 	"testing"
 
 	"github.com/a14e/gogreement/src/annotations"
@@ -8,6 +10,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis"
 )
 
 func TestLoadInterfaces(t *testing.T) {
@@ -86,7 +89,8 @@ func TestLoadInterfaces(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := LoadInterfaces(pass, tt.queries)
+			result, err := LoadInterfaces(pass, tt.queries)
+			require.NoError(t, err)
 
 			assert.Len(t, result, tt.expectedCount)
 
@@ -120,7 +124,8 @@ func TestLoadInterfacesMethodSignatures(t *testing.T) {
 		{InterfaceName: "Writer", PackageName: ""},
 	}
 
-	result := LoadInterfaces(pass, queries)
+	result, err := LoadInterfaces(pass, queries)
+	require.NoError(t, err)
 	require.NotEmpty(t, result, "expected to find interfaces")
 
 	// Helper to find interface by name
@@ -226,7 +231,8 @@ func TestLoadInterfacesMethodSignatures(t *testing.T) {
 func TestLoadInterfacesEmptyQueries(t *testing.T) {
 	pass := testutil.CreateTestPass(t, "interfacesforloading")
 
-	result := LoadInterfaces(pass, []annotations.InterfaceQuery{})
+	result, err := LoadInterfaces(pass, []annotations.InterfaceQuery{})
+	require.NoError(t, err)
 
 	assert.Empty(t, result, "expected no interfaces when queries are empty")
 }
@@ -239,7 +245,8 @@ func TestLoadInterfacesDuplicateQueries(t *testing.T) {
 		{InterfaceName: "Reader", PackageName: ""}, // duplicate
 	}
 
-	result := LoadInterfaces(pass, queries)
+	result, err := LoadInterfaces(pass, queries)
+	require.NoError(t, err)
 
 	// Should return only one instance despite duplicate query
 	assert.Len(t, result, 1)
@@ -318,7 +325,8 @@ func TestLoadInterfacesFromStdlib(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := LoadInterfaces(pass, tt.queries)
+			result, err := LoadInterfaces(pass, tt.queries)
+			require.NoError(t, err)
 
 			assert.Len(t, result, tt.expectedCount)
 
@@ -352,7 +360,8 @@ func TestLoadInterfacesStdlibMethodSignatures(t *testing.T) {
 		{InterfaceName: "Context", PackageName: "context"},
 	}
 
-	result := LoadInterfaces(pass, queries)
+	result, err := LoadInterfaces(pass, queries)
+	require.NoError(t, err)
 	require.Len(t, result, 3, "expected to find 3 interfaces")
 
 	// Helper to find interface by name and package
@@ -431,7 +440,8 @@ func TestLoadInterfacesNonExistentStdlib(t *testing.T) {
 		{InterfaceName: "NonExistentInterface", PackageName: "io"},
 	}
 
-	result := LoadInterfaces(pass, queries)
+	result, err := LoadInterfaces(pass, queries)
+	require.NoError(t, err)
 
 	assert.Empty(t, result, "should not find non-existent interface")
 }
@@ -444,8 +454,78 @@ func TestLoadInterfacesUnimportedPackage(t *testing.T) {
 		{InterfaceName: "ResponseWriter", PackageName: "net/http"},
 	}
 
-	result := LoadInterfaces(pass, queries)
+	result, err := LoadInterfaces(pass, queries)
+	require.NoError(t, err)
 
 	// Should be empty because net/http is not imported in withimports package
 	assert.Empty(t, result, "should not find interface from unimported package")
 }
+
+// embeddingPackage builds a package whose interfaces embed each other as given
+// (name -> embedded names). The type checker rejects embedding cycles, so they
+// can only be constructed directly with go/types.
+func embeddingPackage(embeds map[string][]string) *types.Package {
+	pkg := types.NewPackage("example.com/embedding", "embedding")
+	named := make(map[string]*types.Named)
+	for name := range embeds {
+		obj := types.NewTypeName(token.NoPos, pkg, name, nil)
+		named[name] = types.NewNamed(obj, nil, nil)
+		pkg.Scope().Insert(obj)
+	}
+	for name, embedded := range embeds {
+		var embeddeds []types.Type
+		for _, e := range embedded {
+			embeddeds = append(embeddeds, named[e])
+		}
+		named[name].SetUnderlying(types.NewInterfaceType(nil, embeddeds))
+	}
+	return pkg
+}
+
+func TestLoadInterfacesEmbeddingCycle(t *testing.T) {
+	pass := &analysis.Pass{Pkg: embeddingPackage(map[string][]string{
+		"A": {"B"},
+		"B": {"C"},
+		"C": {"A"},
+	})}
+
+	result, err := LoadInterfaces(pass, []annotations.InterfaceQuery{{InterfaceName: "A"}})
+
+	require.Error(t, err)
+	assert.Nil(t, result)
+	assert.Equal(t, "interface example.com/embedding.A: embedded interfaces form a cycle: A -> B -> C -> A", err.Error())
+}
+
+func TestLoadInterfacesEmbeddingDiamond(t *testing.T) {
+	pass := &analysis.Pass{Pkg: embeddingPackage(map[string][]string{
+		"Top":   {"Left", "Right"},
+		"Left":  {"Base"},
+		"Right": {"Base"},
+		"Base":  nil,
+	})}
+
+	result, err := LoadInterfaces(pass, []annotations.InterfaceQuery{{InterfaceName: "Top"}})
+
+	require.NoError(t, err, "an interface embedded through two branches is not a cycle")
+	require.Len(t, result, 1)
+	assert.Equal(t, "Top", result[0].Name)
+}
+
+func TestLoadInterfacesEmbeddingDepth(t *testing.T) {
+	defer func(depth int) { MaxEmbeddingDepth = depth }(MaxEmbeddingDepth)
+	MaxEmbeddingDepth = 2
+
+	pass := &analysis.Pass{Pkg: embeddingPackage(map[string][]string{
+		"L0": {"L1"},
+		"L1": {"L2"},
+		"L2": {"L3"},
+		"L3": nil,
+	})}
+
+	_, err := LoadInterfaces(pass, []annotations.InterfaceQuery{{InterfaceName: "L1"}})
+	require.NoError(t, err, "L1 embeds two levels")
+
+	_, err = LoadInterfaces(pass, []annotations.InterfaceQuery{{InterfaceName: "L0"}})
+	require.Error(t, err)
+	assert.Equal(t, "interface example.com/embedding.L0: embedded interfaces nest deeper than 2 levels", err.Error())
+}
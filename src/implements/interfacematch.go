@@ -1,6 +1,9 @@
 package implements
 
 import (
+	"go/token"
+	"strings"
+
 	"github.com/a14e/gogreement/src/annotations"
 )
 
@@ -21,6 +24,31 @@ func FindMissingPackages(annotations []annotations.ImplementsAnnotation) []Missi
 	return result
 }
 
+// FindMismatchedReceivers identifies annotations whose "via" clause names a
+// type other than the annotated one. They are reported at the annotation
+// when its position is known, so a renamed type does not turn its contract
+// off without notice.
+func FindMismatchedReceivers(annotations []annotations.ImplementsAnnotation) []ReceiverMismatchReport {
+	var result []ReceiverMismatchReport
+
+	for _, ann := range annotations {
+		if ann.MismatchedReceiver == "" {
+			continue
+		}
+		pos := ann.TargetPos
+		if !pos.IsValid() {
+			pos = ann.OnTypePos
+		}
+		result = append(result, ReceiverMismatchReport{
+			Receiver: ann.MismatchedReceiver,
+			TypeName: ann.OnType,
+			Pos:      pos,
+		})
+	}
+
+	return result
+}
+
 // FindMissingInterfaces identifies annotations where the interface was not found
 func FindMissingInterfaces(
 	annotations []annotations.ImplementsAnnotation,
@@ -56,11 +84,68 @@ func FindMissingInterfaces(
 	return result
 }
 
+// FindTypeSetInterfaces identifies annotations naming an interface with a type
+// set, such as `type Numeric = interface{ ~int | ~float64 }`. Such interfaces
+// only constrain type parameters; no type implements them as a method set.
+func FindTypeSetInterfaces(
+	annotations []annotations.ImplementsAnnotation,
+	interfaces []*InterfaceModel,
+) []TypeSetReport {
+	var result []TypeSetReport
+
+	typeSets := make(map[string]*InterfaceModel)
+	for _, iface := range interfaces {
+		if iface.TypeSet {
+			typeSets[iface.Package+"."+iface.Name] = iface
+		}
+	}
+
+	for _, ann := range annotations {
+		if ann.PackageNotFound {
+			continue
+		}
+
+		iface, ok := typeSets[ann.PackageFullPath+"."+ann.InterfaceName]
+		if !ok {
+			continue
+		}
+
+		result = append(result, TypeSetReport{
+			InterfaceName: ann.InterfaceName,
+			PackageName:   ann.PackageName,
+			TypeName:      ann.OnType,
+			Terms:         iface.Terms,
+			Pos:           ann.OnTypePos,
+		})
+	}
+
+	return result
+}
+
 // FindMissingMethods identifies types that don't implement required interfaces
 func FindMissingMethods(
 	annotations []annotations.ImplementsAnnotation,
 	interfaces []*InterfaceModel,
 	types []*TypeModel,
+) []MissingMethodsReport {
+	return findMissingMethods(annotations, interfaces, types, false)
+}
+
+// FindMissingMethodsVerbose is FindMissingMethods with the full method sets of
+// the interface and the type attached to each report, for --verbose-implements
+func FindMissingMethodsVerbose(
+	annotations []annotations.ImplementsAnnotation,
+	interfaces []*InterfaceModel,
+	types []*TypeModel,
+) []MissingMethodsReport {
+	return findMissingMethods(annotations, interfaces, types, true)
+}
+
+func findMissingMethods(
+	annotations []annotations.ImplementsAnnotation,
+	interfaces []*InterfaceModel,
+	types []*TypeModel,
+	verbose bool,
 ) []MissingMethodsReport {
 	var result []MissingMethodsReport
 
@@ -88,6 +173,9 @@ func FindMissingMethods(
 		if !ifaceExists {
 			continue // Already reported in FindMissingInterfaces
 		}
+		if iface.TypeSet {
+			continue // Reported in FindTypeSetInterfaces
+		}
 
 		typeModel, typeExists := typeIndex[ann.OnType]
 		if !typeExists {
@@ -96,14 +184,46 @@ func FindMissingMethods(
 		}
 
 		// Check if type implements interface
-		missing := checkImplementation(typeModel, iface, ann.IsPointer)
+		missing, mismatches := checkImplementation(typeModel, iface, ann.RequiresPointerMethodSet())
+		if len(missing) == 0 && len(mismatches) == 0 {
+			continue
+		}
+
+		// The full method sets are only carried in verbose mode
+		var interfaceMethods []InterfaceMethod
+		var typeMethods []TypeMethod
+		if verbose {
+			interfaceMethods = iface.Methods
+			typeMethods = typeModel.Methods
+		}
+
+		// Absent methods (IMPL03) and signature mismatches (IMPL04) are
+		// reported separately so each can be ignored on its own
 		if len(missing) > 0 {
 			result = append(result, MissingMethodsReport{
-				InterfaceName: ann.InterfaceName,
-				PackageName:   ann.PackageName,
-				TypeName:      ann.OnType,
-				Methods:       missing,
-				Pos:           ann.OnTypePos,
+				InterfaceName:    ann.InterfaceName,
+				PackageName:      ann.PackageName,
+				TypeName:         ann.OnType,
+				Methods:          missing,
+				Pos:              ann.OnTypePos,
+				Verbose:          verbose,
+				InterfaceMethods: interfaceMethods,
+				TypeMethods:      typeMethods,
+				AddPointerPos:    addPointerPos(ann, typeModel, iface, mismatches),
+				AnnotationPos:    ann.TargetPos,
+			})
+		}
+		if len(mismatches) > 0 {
+			result = append(result, MissingMethodsReport{
+				InterfaceName:    ann.InterfaceName,
+				PackageName:      ann.PackageName,
+				TypeName:         ann.OnType,
+				Mismatches:       mismatches,
+				Pos:              ann.OnTypePos,
+				Verbose:          verbose,
+				InterfaceMethods: interfaceMethods,
+				TypeMethods:      typeMethods,
+				AnnotationPos:    ann.TargetPos,
 			})
 		}
 	}
@@ -111,14 +231,152 @@ func FindMissingMethods(
 	return result
 }
 
+// addPointerPos returns where to add "&" to a value-mode annotation whose
+// interface the pointer method set satisfies, or token.NoPos when the
+// annotation already names a receiver form or "&" would not fix it
+func addPointerPos(
+	ann annotations.ImplementsAnnotation,
+	typeModel *TypeModel,
+	iface *InterfaceModel,
+	mismatches []SignatureMismatch,
+) token.Pos {
+	if ann.RequiresPointerMethodSet() || ann.HasReceiverOverride || len(mismatches) > 0 {
+		return token.NoPos
+	}
+	missing, pointerMismatches := checkImplementation(typeModel, iface, true)
+	if len(missing) > 0 || len(pointerMismatches) > 0 {
+		return token.NoPos
+	}
+	return ann.TargetPos
+}
+
+// FindUnsatisfiedOneOf identifies @implements-oneof groups whose type
+// implements none of the listed interfaces. Each alternative is checked with
+// FindMissingMethods; an alternative whose package or interface cannot be
+// resolved never satisfies the group (it is reported as IMPL01/IMPL02).
+func FindUnsatisfiedOneOf(
+	groups []annotations.ImplementsOneOfAnnotation,
+	interfaces []*InterfaceModel,
+	types []*TypeModel,
+) []NoneOfReport {
+	var result []NoneOfReport
+
+	// Interfaces with a type set cannot be implemented, so they never
+	// satisfy a group either
+	foundInterfaces := make(map[string]bool)
+	for _, iface := range interfaces {
+		foundInterfaces[iface.Package+"."+iface.Name] = !iface.TypeSet
+	}
+
+	foundTypes := make(map[string]bool)
+	for _, t := range types {
+		foundTypes[t.Name] = true
+	}
+
+	for _, group := range groups {
+		satisfied := false
+		for _, alt := range group.Alternatives {
+			if alt.PackageNotFound || !foundInterfaces[alt.PackageFullPath+"."+alt.InterfaceName] || !foundTypes[alt.OnType] {
+				continue
+			}
+			if len(FindMissingMethods([]annotations.ImplementsAnnotation{alt}, interfaces, types)) == 0 {
+				satisfied = true
+				break
+			}
+		}
+		if satisfied {
+			continue
+		}
+
+		result = append(result, NoneOfReport{
+			Alternatives: group.Alternatives,
+			TypeName:     group.OnType,
+			Pos:          group.OnTypePos,
+		})
+	}
+
+	return result
+}
+
+// FindEmbeddedImplementations identifies satisfied annotations whose every
+// interface method is promoted from an embedded interface field. Such a type
+// satisfies the interface trivially, and calls panic if the field is nil.
+func FindEmbeddedImplementations(
+	annotations []annotations.ImplementsAnnotation,
+	interfaces []*InterfaceModel,
+	types []*TypeModel,
+) []EmbeddedInterfaceReport {
+	var result []EmbeddedInterfaceReport
+
+	interfaceIndex := make(map[string]*InterfaceModel)
+	for _, iface := range interfaces {
+		interfaceIndex[iface.Package+"."+iface.Name] = iface
+	}
+
+	typeIndex := make(map[string]*TypeModel)
+	for _, t := range types {
+		typeIndex[t.Name] = t
+	}
+
+	for _, ann := range annotations {
+		if ann.PackageNotFound {
+			continue
+		}
+
+		iface, ifaceExists := interfaceIndex[ann.PackageFullPath+"."+ann.InterfaceName]
+		typeModel, typeExists := typeIndex[ann.OnType]
+		if !ifaceExists || !typeExists || len(iface.Methods) == 0 || iface.TypeSet {
+			continue
+		}
+
+		// Missing methods are reported as IMPL03/IMPL04 instead
+		if missing, mismatches := checkImplementation(typeModel, iface, ann.RequiresPointerMethodSet()); len(missing) > 0 || len(mismatches) > 0 {
+			continue
+		}
+
+		if !allFromEmbeddedInterface(typeModel, iface) {
+			continue
+		}
+
+		result = append(result, EmbeddedInterfaceReport{
+			InterfaceName: ann.InterfaceName,
+			PackageName:   ann.PackageName,
+			TypeName:      ann.OnType,
+			Pos:           ann.OnTypePos,
+		})
+	}
+
+	return result
+}
+
+// allFromEmbeddedInterface reports whether every method of iface is provided
+// by a method the type promotes from an embedded interface field
+func allFromEmbeddedInterface(typeModel *TypeModel, iface *InterfaceModel) bool {
+	promoted := make(map[string]bool)
+	for _, method := range typeModel.Methods {
+		if method.FromEmbeddedInterface {
+			promoted[methodKey(method.Id, method.Name)] = true
+		}
+	}
+
+	for _, ifaceMethod := range iface.Methods {
+		if !promoted[methodKey(ifaceMethod.Id, ifaceMethod.Name)] {
+			return false
+		}
+	}
+	return true
+}
+
 // checkImplementation checks if type implements interface
-// Returns list of missing methods with full signatures
+// Returns the interface methods absent from the type's method set, and the
+// methods present under the same name but with a different signature
 func checkImplementation(
 	typeModel *TypeModel,
 	iface *InterfaceModel,
 	requirePointer bool,
-) []InterfaceMethod {
+) ([]InterfaceMethod, []SignatureMismatch) {
 	var missing []InterfaceMethod
+	var mismatches []SignatureMismatch
 
 	// Create index of type's methods
 	typeMethods := make(map[string]TypeMethod)
@@ -141,11 +399,17 @@ func checkImplementation(
 
 		// Check signature match
 		if !signaturesMatch(typeMethod, ifaceMethod) {
-			missing = append(missing, ifaceMethod)
+			mismatches = append(mismatches, SignatureMismatch{
+				Want:                ifaceMethod,
+				Have:                typeMethod,
+				ReturnsConcreteType: returnsConcreteType(typeModel, iface, typeMethod, ifaceMethod),
+				PointerParams:       paramsDifferingOnly(typeMethod, ifaceMethod, differsOnlyByPointer),
+				AnyParams:           paramsDifferingOnly(typeMethod, ifaceMethod, differsOnlyByAny),
+			})
 		}
 	}
 
-	return missing
+	return missing, mismatches
 }
 
 // signaturesMatch checks if type method matches interface method signature
@@ -177,6 +441,86 @@ func signaturesMatch(typeMethod TypeMethod, ifaceMethod InterfaceMethod) bool {
 	return true
 }
 
+// returnsConcreteType reports whether a mismatched method differs from the
+// interface method only in results where the interface returns itself and the
+// type returns its own type (T or *T)
+func returnsConcreteType(typeModel *TypeModel, iface *InterfaceModel, typeMethod TypeMethod, ifaceMethod InterfaceMethod) bool {
+	if len(typeMethod.Inputs) != len(ifaceMethod.Inputs) || len(typeMethod.Outputs) != len(ifaceMethod.Outputs) {
+		return false
+	}
+	for i := range typeMethod.Inputs {
+		if !typesMatch(&typeMethod.Inputs[i], &ifaceMethod.Inputs[i]) {
+			return false
+		}
+	}
+
+	concrete := false
+	for i := range typeMethod.Outputs {
+		have, want := &typeMethod.Outputs[i], &ifaceMethod.Outputs[i]
+		if typesMatch(have, want) {
+			continue
+		}
+		returnsSelf := !want.IsPointer && want.TypeName == iface.Name && want.TypePackage == iface.Package
+		returnsOwnType := have.TypeName == typeModel.Name && have.TypePackage == typeModel.Package
+		if !returnsSelf || !returnsOwnType {
+			return false
+		}
+		concrete = true
+	}
+	return concrete
+}
+
+// paramsDifferingOnly returns the indexes of the parameters of a mismatched
+// method that differ from the interface method in the way differs reports,
+// e.g. only by pointer indirection (*T against T). It returns nil when
+// anything else differs as well, so a hint is only given when fixing those
+// parameters fixes the signature.
+func paramsDifferingOnly(typeMethod TypeMethod, ifaceMethod InterfaceMethod, differs func(*MethodType, *InterfaceType) bool) []int {
+	if len(typeMethod.Inputs) != len(ifaceMethod.Inputs) || len(typeMethod.Outputs) != len(ifaceMethod.Outputs) {
+		return nil
+	}
+	for i := range typeMethod.Outputs {
+		if !typesMatch(&typeMethod.Outputs[i], &ifaceMethod.Outputs[i]) {
+			return nil
+		}
+	}
+
+	var params []int
+	for i := range typeMethod.Inputs {
+		have, want := &typeMethod.Inputs[i], &ifaceMethod.Inputs[i]
+		if typesMatch(have, want) {
+			continue
+		}
+		if !differs(have, want) {
+			return nil
+		}
+		params = append(params, i)
+	}
+	return params
+}
+
+// differsOnlyByPointer reports whether t1 and t2 name the same type and only
+// one of them is a pointer to it
+func differsOnlyByPointer(t1 *MethodType, t2 *InterfaceType) bool {
+	if t1.IsPointer == t2.IsPointer ||
+		t1.TypeName != t2.TypeName ||
+		t1.TypePackage != t2.TypePackage ||
+		t1.IsVariadic != t2.IsVariadic {
+		return false
+	}
+	pointer, value := t1.Canonical, t2.Canonical
+	if t2.IsPointer {
+		pointer, value = value, pointer
+	}
+	return strings.Replace(pointer, "*", "", 1) == value
+}
+
+// differsOnlyByAny reports whether exactly one of t1 and t2 is any. Go
+// requires identical parameter types, so a type any accepts does not match it.
+func differsOnlyByAny(t1 *MethodType, t2 *InterfaceType) bool {
+	return (t1.Canonical == "any") != (t2.Canonical == "any") && t1.IsVariadic == t2.IsVariadic
+}
+
 // methodKey returns the qualified method id when available (so unexported
 // methods are matched per-package), falling back to the bare name for
 // hand-built models that do not populate Id.
@@ -190,8 +534,12 @@ func methodKey(id, name string) string {
 // typesMatch checks if two types are the same. The canonical go/types string
 // is the precise comparison (it captures generic type arguments and pointer
 // depth); the coarse fields are kept for hand-built models that leave the
-// canonical string empty.
+// canonical string empty. A type parameter constrained to exactly one type
+// matches that type, since every instantiation is identical to it.
 func typesMatch(t1 *MethodType, t2 *InterfaceType) bool {
+	if t1.Core != "" && t1.Core == t2.Canonical {
+		return t1.IsPointer == t2.IsPointer && t1.IsVariadic == t2.IsVariadic
+	}
 	return t1.Canonical == t2.Canonical &&
 		t1.TypeName == t2.TypeName &&
 		t1.TypePackage == t2.TypePackage &&
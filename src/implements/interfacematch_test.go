@@ -426,15 +426,27 @@ func TestFindMissingMethods(t *testing.T) {
 					InterfaceName: "Reader",
 					PackageName:   "io",
 					TypeName:      "MyReader",
-					Methods: []InterfaceMethod{
+					Mismatches: []SignatureMismatch{
 						{
-							Name: "Read",
-							Inputs: []InterfaceType{
-								{TypeName: "[]byte"},
+							Want: InterfaceMethod{
+								Name: "Read",
+								Inputs: []InterfaceType{
+									{TypeName: "[]byte"},
+								},
+								Outputs: []InterfaceType{
+									{TypeName: "int"},
+									{TypeName: "error"},
+								},
 							},
-							Outputs: []InterfaceType{
-								{TypeName: "int"},
-								{TypeName: "error"},
+							Have: TypeMethod{
+								Name: "Read",
+								Inputs: []MethodType{
+									{TypeName: "string"},
+								},
+								Outputs: []MethodType{
+									{TypeName: "int"},
+									{TypeName: "error"},
+								},
 							},
 						},
 					},
@@ -0,0 +1,142 @@
+package implements
+
+import (
+	"cmp"
+	"go/types"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/a14e/gogreement/src/annotations"
+)
+
+// Loader resolves the interfaces and types named by annotations one query at
+// a time. Each model is built the first time it is asked for and cached, so
+// repeated queries share it. A name is looked up in its package scope rather
+// than found by scanning every name of the package; only queried names get a
+// model either way. A Loader is meant to serve a single
+// package; its models are released with it once the package is checked.
+// @constructor NewLoader
+type Loader struct {
+	pass       *analysis.Pass
+	interfaces map[interfaceKey]interfaceResult
+	types      map[string]*TypeModel
+}
+
+// interfaceKey identifies an interface by its package path and name
+type interfaceKey struct {
+	pkgPath string
+	name    string
+}
+
+// interfaceResult is a resolved interface query: a nil model means the name
+// does not denote an interface
+type interfaceResult struct {
+	model *InterfaceModel
+	err   error
+}
+
+// NewLoader creates a Loader for the package of pass with an empty cache
+func NewLoader(pass *analysis.Pass) *Loader {
+	return &Loader{
+		pass:       pass,
+		interfaces: make(map[interfaceKey]interfaceResult),
+		types:      make(map[string]*TypeModel),
+	}
+}
+
+// Interface returns the model of the interface name declared in the package
+// pkgPath, which must be the current package or one it imports. It returns
+// nil when there is no such interface.
+func (l *Loader) Interface(pkgPath string, name string) (*InterfaceModel, error) {
+	key := interfaceKey{pkgPath: pkgPath, name: name}
+	if cached, ok := l.interfaces[key]; ok {
+		return cached.model, cached.err
+	}
+
+	var result interfaceResult
+	if pkg := importedPackage(l.pass.Pkg, pkgPath); pkg != nil {
+		if typeName, ok := pkg.Scope().Lookup(name).(*types.TypeName); ok {
+			result.model, result.err = interfaceModel(pkg, typeName)
+		}
+	}
+	l.interfaces[key] = result
+	return result.model, result.err
+}
+
+// Type returns the model of the named type name declared in the current
+// package, or nil when there is no such type
+func (l *Loader) Type(name string) *TypeModel {
+	if cached, ok := l.types[name]; ok {
+		return cached
+	}
+
+	model := lookupType(l.pass.Pkg, name)
+	l.types[name] = model
+	return model
+}
+
+// Interfaces resolves queries with Interface. A query without a package
+// names an interface of the current package. The models are ordered by
+// package, the current one first and then its imports, and by name within a
+// package; names that do not denote an interface are left out.
+func (l *Loader) Interfaces(queries []annotations.InterfaceQuery) ([]*InterfaceModel, error) {
+	var result []*InterfaceModel
+
+	// Group queries by package
+	pkgToInterface := make(map[string][]string) // pkg -> interface names
+	for _, q := range queries {
+		pkg := cmp.Or(q.PackageName, l.pass.Pkg.Path())
+		if !slices.Contains(pkgToInterface[pkg], q.InterfaceName) {
+			pkgToInterface[pkg] = append(pkgToInterface[pkg], q.InterfaceName)
+		}
+	}
+
+	packages := append([]*types.Package{l.pass.Pkg}, l.pass.Pkg.Imports()...)
+	for _, pkg := range packages {
+		names := pkgToInterface[pkg.Path()]
+		slices.Sort(names)
+
+		for _, name := range names {
+			model, err := l.Interface(pkg.Path(), name)
+			if err != nil {
+				return nil, err
+			}
+			if model != nil {
+				result = append(result, model)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// Types resolves queries with Type. The models are ordered by name; names
+// that do not denote a named type are left out.
+func (l *Loader) Types(queries []annotations.TypeQuery) []*TypeModel {
+	var result []*TypeModel
+
+	names := make([]string, 0, len(queries))
+	for _, q := range queries {
+		names = append(names, q.TypeName)
+	}
+	slices.Sort(names)
+
+	for _, name := range slices.Compact(names) {
+		if model := l.Type(name); model != nil {
+			result = append(result, model)
+		}
+	}
+
+	return result
+}
+
+// lookupType builds the model of the named type name declared in pkg, or
+// returns nil when there is no such type
+func lookupType(pkg *types.Package, name string) *TypeModel {
+	typeName, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+	if !ok {
+		return nil
+	}
+	return typeModel(pkg, typeName)
+}
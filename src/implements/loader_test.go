@@ -0,0 +1,225 @@
+package implements
+
+import (
+	"fmt"
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+
+	"github.com/a14e/gogreement/src/annotations"
+	"github.com/a14e/gogreement/src/config"
+	"github.com/a14e/gogreement/src/testutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis"
+)
+
+func TestLoaderCachesModels(t *testing.T) {
+	pass := testutil.CreateTestPass(t, "interfacesforloading")
+	loader := NewLoader(pass)
+
+	reader, err := loader.Interface(pass.Pkg.Path(), "Reader")
+	require.NoError(t, err)
+	require.NotNil(t, reader)
+
+	again, err := loader.Interface(pass.Pkg.Path(), "Reader")
+	require.NoError(t, err)
+	assert.Same(t, reader, again, "a repeated query returns the cached model")
+
+	missing, err := loader.Interface(pass.Pkg.Path(), "NoSuchInterface")
+	require.NoError(t, err)
+	assert.Nil(t, missing)
+
+	unknownPkg, err := loader.Interface("example.com/not/imported", "Reader")
+	require.NoError(t, err)
+	assert.Nil(t, unknownPkg, "only the current package and its imports are searched")
+
+	models, err := loader.Interfaces([]annotations.InterfaceQuery{
+		{InterfaceName: "Writer"},
+		{InterfaceName: "Reader"},
+		{InterfaceName: "Reader", PackageName: pass.Pkg.Path()},
+	})
+	require.NoError(t, err)
+	require.Len(t, models, 2, "duplicate queries resolve to one model")
+	assert.Equal(t, "Reader", models[0].Name, "models are sorted by name")
+	assert.Same(t, reader, models[0])
+	assert.Equal(t, "Writer", models[1].Name)
+}
+
+// TestLoaderFindingsMatchEagerLoad checks that resolving only the queried
+// declarations finds the same problems as models of every declaration
+func TestLoaderFindingsMatchEagerLoad(t *testing.T) {
+	for _, pkgName := range []string{"implementsedgecases", "interfacesforloading", "withimports"} {
+		t.Run(pkgName, func(t *testing.T) {
+			pass := testutil.CreateTestPass(t, pkgName)
+			ann := annotations.ReadAllAnnotations(config.Empty(), pass)
+			allImplements := ann.AllImplementsAnnotations()
+
+			eagerInterfaces, eagerTypes := loadEverything(t, pass)
+			interfaces, err := LoadInterfaces(pass, ann.ToInterfaceQuery())
+			require.NoError(t, err)
+			typeModels := LoadTypes(pass, ann.ToTypeQuery())
+
+			assert.Equal(t,
+				FindMissingInterfaces(allImplements, eagerInterfaces),
+				FindMissingInterfaces(allImplements, interfaces))
+			assert.Equal(t,
+				FindMissingMethodsVerbose(ann.ImplementsAnnotations, eagerInterfaces, eagerTypes),
+				FindMissingMethodsVerbose(ann.ImplementsAnnotations, interfaces, typeModels))
+			assert.Equal(t,
+				FindEmbeddedImplementations(ann.ImplementsAnnotations, eagerInterfaces, eagerTypes),
+				FindEmbeddedImplementations(ann.ImplementsAnnotations, interfaces, typeModels))
+			assert.Equal(t,
+				FindUnsatisfiedOneOf(ann.ImplementsOneOfAnnotations, eagerInterfaces, eagerTypes),
+				FindUnsatisfiedOneOf(ann.ImplementsOneOfAnnotations, interfaces, typeModels))
+			assert.Equal(t,
+				FindUnimplementedBy(pass, ann.ImplementedByAnnotations, eagerInterfaces),
+				FindUnimplementedBy(pass, ann.ImplementedByAnnotations, interfaces))
+			assert.Equal(t,
+				FindTypeSetInterfaces(allImplements, eagerInterfaces),
+				FindTypeSetInterfaces(allImplements, interfaces))
+		})
+	}
+}
+
+// loadEverything builds the model of every interface of the package of pass
+// and its imports, and of every named type of the package
+func loadEverything(tb testing.TB, pass *analysis.Pass) ([]*InterfaceModel, []*TypeModel) {
+	var interfaces []*InterfaceModel
+	var typeModels []*TypeModel
+
+	for _, pkg := range append([]*types.Package{pass.Pkg}, pass.Pkg.Imports()...) {
+		scope := pkg.Scope()
+		for _, name := range scope.Names() {
+			typeName, ok := scope.Lookup(name).(*types.TypeName)
+			if !ok {
+				continue
+			}
+
+			model, err := interfaceModel(pkg, typeName)
+			require.NoError(tb, err)
+			if model != nil {
+				interfaces = append(interfaces, model)
+			}
+			if model := typeModel(pkg, typeName); model != nil && pkg == pass.Pkg {
+				typeModels = append(typeModels, model)
+			}
+		}
+	}
+
+	return interfaces, typeModels
+}
+
+// syntheticPass type-checks a package of n interfaces and n types
+// implementing them
+func syntheticPass(tb testing.TB, n int) *analysis.Pass {
+	var src strings.Builder
+	src.WriteString("package synthetic\n\nimport \"io\"\n\n")
+	for i := range n {
+		fmt.Fprintf(&src, "type Service%d interface {\n\tio.Closer\n\tGet(key string) ([]byte, error)\n\tPut(key string, value []byte) error\n}\n\n", i)
+		fmt.Fprintf(&src, "type Impl%d struct{ data map[string][]byte }\n\n", i)
+		fmt.Fprintf(&src, "func (s *Impl%d) Close() error { return nil }\n", i)
+		fmt.Fprintf(&src, "func (s *Impl%d) Get(key string) ([]byte, error) { return s.data[key], nil }\n", i)
+		fmt.Fprintf(&src, "func (s *Impl%d) Put(key string, value []byte) error { s.data[key] = value; return nil }\n\n", i)
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "synthetic.go", src.String(), 0)
+	require.NoError(tb, err)
+
+	conf := types.Config{Importer: importer.Default()}
+	pkg, err := conf.Check("synthetic", fset, []*ast.File{file}, nil)
+	require.NoError(tb, err)
+
+	return &analysis.Pass{Pkg: pkg, Fset: fset, Files: []*ast.File{file}}
+}
+
+// loadByScanning resolves the queries the way LoadInterfaces and LoadTypes
+// did before the Loader: every name in the scope of each queried package is
+// checked against the queried ones, and models are built for those only
+func loadByScanning(
+	tb testing.TB,
+	pass *analysis.Pass,
+	interfaceQueries []annotations.InterfaceQuery,
+	typeQueries []annotations.TypeQuery,
+) ([]*InterfaceModel, []*TypeModel) {
+	pkgToInterface := make(map[string]map[string]bool)
+	for _, q := range interfaceQueries {
+		pkg := q.PackageName
+		if pkg == "" {
+			pkg = pass.Pkg.Path()
+		}
+		if pkgToInterface[pkg] == nil {
+			pkgToInterface[pkg] = make(map[string]bool)
+		}
+		pkgToInterface[pkg][q.InterfaceName] = true
+	}
+
+	var interfaces []*InterfaceModel
+	for _, pkg := range append([]*types.Package{pass.Pkg}, pass.Pkg.Imports()...) {
+		targets := pkgToInterface[pkg.Path()]
+		if targets == nil {
+			continue
+		}
+		scope := pkg.Scope()
+		for _, name := range scope.Names() {
+			if !targets[name] {
+				continue
+			}
+			model, err := interfaceModel(pkg, scope.Lookup(name).(*types.TypeName))
+			require.NoError(tb, err)
+			if model != nil {
+				interfaces = append(interfaces, model)
+			}
+		}
+	}
+
+	targetTypes := make(map[string]bool)
+	for _, q := range typeQueries {
+		targetTypes[q.TypeName] = true
+	}
+	var typeModels []*TypeModel
+	scope := pass.Pkg.Scope()
+	for _, name := range scope.Names() {
+		if !targetTypes[name] {
+			continue
+		}
+		if model := typeModel(pass.Pkg, scope.Lookup(name).(*types.TypeName)); model != nil {
+			typeModels = append(typeModels, model)
+		}
+	}
+
+	return interfaces, typeModels
+}
+
+// BenchmarkLoadInterfaces compares resolving the few names an annotation
+// names by scanning every name of a large package, as the loaders did before
+// the Loader, with looking them up in the package scope. Both build models
+// for the queried names only, so the difference is the scan alone.
+func BenchmarkLoadInterfaces(b *testing.B) {
+	pass := syntheticPass(b, 2000)
+	interfaceQueries := []annotations.InterfaceQuery{{InterfaceName: "Service7"}, {InterfaceName: "Service1999"}}
+	typeQueries := []annotations.TypeQuery{{TypeName: "Impl7"}, {TypeName: "Impl1999"}}
+
+	b.Run("scan", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			loadByScanning(b, pass, interfaceQueries, typeQueries)
+		}
+	})
+
+	b.Run("lookup", func(b *testing.B) {
+		b.ReportAllocs()
+		for b.Loop() {
+			loader := NewLoader(pass)
+			_, err := loader.Interfaces(interfaceQueries)
+			require.NoError(b, err)
+			loader.Types(typeQueries)
+		}
+	})
+}
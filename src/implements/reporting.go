@@ -5,8 +5,11 @@ import (
 	"go/token"
 	"strings"
 
+	"github.com/a14e/gogreement/src/annotations"
 	"github.com/a14e/gogreement/src/codes"
+	"github.com/a14e/gogreement/src/config"
 	"github.com/a14e/gogreement/src/reporting"
+	"github.com/a14e/gogreement/src/trace"
 	"github.com/a14e/gogreement/src/util"
 
 	"golang.org/x/tools/go/analysis"
@@ -76,16 +79,59 @@ func (v MissingInterfaceReport) GetMessage() string {
 
 // @immutable
 // implements reporting.Violation
+// implements reporting.RelatedViolation
+// implements reporting.ContractViolation
 type MissingMethodsReport struct {
 	InterfaceName string
 	PackageName   string
 	TypeName      string
 	Methods       []InterfaceMethod // Full method signatures
 	Pos           token.Pos
+
+	// Mismatches lists methods the type has with a different signature. A
+	// report carries either Methods (IMPL03) or Mismatches (IMPL04).
+	Mismatches []SignatureMismatch
+
+	// Verbose adds the full method sets of both sides to the message.
+	// InterfaceMethods and TypeMethods are only filled when it is set.
+	Verbose          bool
+	InterfaceMethods []InterfaceMethod
+	TypeMethods      []TypeMethod
+
+	// AddPointerPos is set when every missing method has a pointer receiver,
+	// so writing @implements &Interface fixes the report. It is where the "&"
+	// goes in the annotation comment.
+	AddPointerPos token.Pos
+
+	// AnnotationPos is the interface in the @implements comment, token.NoPos
+	// when unknown. The report is placed on the type, so a type failing
+	// several annotations links each report back to its own annotation.
+	AnnotationPos token.Pos
+}
+
+// SignatureMismatch pairs an interface method with the type's method of the
+// same name whose signature differs
+// @immutable
+type SignatureMismatch struct {
+	Want InterfaceMethod
+	Have TypeMethod
+	// ReturnsConcreteType is set when the signatures differ only in results
+	// where the interface returns itself and the type returns its own type
+	// (the fluent-interface mistake: Go has no covariant results)
+	ReturnsConcreteType bool
+	// PointerParams lists the parameters that differ only by pointer
+	// indirection (*T against T), when nothing else differs
+	PointerParams []int
+	// AnyParams lists the parameters where exactly one side is any, when
+	// nothing else differs
+	AnyParams []int
 }
 
 // GetCode returns the error code for this violation
 func (v MissingMethodsReport) GetCode() string {
+	if len(v.Mismatches) > 0 {
+		return codes.ImplementsSignatureMismatch
+	}
 	return codes.ImplementsMissingMethods
 }
 
@@ -103,48 +149,366 @@ func (v MissingMethodsReport) GetMessage() string {
 
 	// Format each method signature on a new line
 	var methodLines []string
+	title := "missing methods:"
 	for _, method := range v.Methods {
 		methodLines = append(methodLines, "  "+formatMethodSignature(method))
 	}
+	if len(v.Mismatches) > 0 {
+		title = "methods with mismatched signatures:"
+		for _, mismatch := range v.Mismatches {
+			methodLines = append(methodLines,
+				"  want "+formatMethodSignature(mismatch.Want),
+				"  have "+formatTypeMethodSignature(mismatch.Have),
+			)
+			if mismatch.ReturnsConcreteType {
+				methodLines = append(methodLines, fmt.Sprintf(
+					"  %s returns the concrete type where the interface returns itself; "+
+						"Go requires identical result types, so declare the result as %s%s",
+					mismatch.Want.Name, pkgPrefix, v.InterfaceName,
+				))
+			}
+			for _, i := range mismatch.PointerParams {
+				want, have := mismatch.Want.Inputs[i], mismatch.Have.Inputs[i]
+				methodLines = append(methodLines, fmt.Sprintf(
+					"  parameter %d of %s should be %s, got %s",
+					i+1, mismatch.Want.Name,
+					formatType(want),
+					formatTypeParts(have.IsVariadic, have.IsPointer, have.TypePackage, have.TypeName),
+				))
+			}
+			for _, i := range mismatch.AnyParams {
+				want, have := mismatch.Want.Inputs[i], mismatch.Have.Inputs[i]
+				line := fmt.Sprintf("  parameter %d of %s must be %s, not any: Go requires identical parameter types",
+					i+1, mismatch.Want.Name, formatType(want))
+				if want.Canonical == "any" {
+					line = fmt.Sprintf("  parameter %d of %s must be any: Go requires identical parameter types, "+
+						"even though any accepts every %s",
+						i+1, mismatch.Want.Name,
+						formatTypeParts(have.IsVariadic, have.IsPointer, have.TypePackage, have.TypeName))
+				}
+				methodLines = append(methodLines, line)
+			}
+		}
+	}
 
-	return fmt.Sprintf(
-		"type \"%s\" does not implement interface \"%s%s\"\nmissing methods:\n%s",
+	message := fmt.Sprintf(
+		"type \"%s\" does not implement interface \"%s%s\"\n%s\n%s",
 		v.TypeName,
 		pkgPrefix,
 		v.InterfaceName,
+		title,
 		strings.Join(methodLines, "\n"),
 	)
+
+	if !v.Verbose {
+		return message
+	}
+
+	return message + "\n" + v.formatMethodSets(pkgPrefix)
+}
+
+// GetSuggestedFixes offers to check the pointer method set by adding "&" to
+// the annotation, when that satisfies the interface
+func (v MissingMethodsReport) GetSuggestedFixes() []analysis.SuggestedFix {
+	if !v.AddPointerPos.IsValid() {
+		return nil
+	}
+
+	pkgPrefix := ""
+	if v.PackageName != "" {
+		pkgPrefix = v.PackageName + "."
+	}
+	return []analysis.SuggestedFix{{
+		Message: fmt.Sprintf("Change the annotation to @implements &%s%s", pkgPrefix, v.InterfaceName),
+		TextEdits: []analysis.TextEdit{
+			{Pos: v.AddPointerPos, End: v.AddPointerPos, NewText: []byte("&")},
+		},
+	}}
+}
+
+// GetRelated points at the @implements annotation the report belongs to
+func (v MissingMethodsReport) GetRelated() []reporting.RelatedLocation {
+	if !v.AnnotationPos.IsValid() {
+		return nil
+	}
+
+	pkgPrefix := ""
+	if v.PackageName != "" {
+		pkgPrefix = v.PackageName + "."
+	}
+	return []reporting.RelatedLocation{{
+		Pos:     v.AnnotationPos,
+		Message: fmt.Sprintf("@implements %s%s is declared here", pkgPrefix, v.InterfaceName),
+	}}
+}
+
+// GetContracts names the @implements annotation of the type, which belongs to
+// the package being analyzed
+func (v MissingMethodsReport) GetContracts() []trace.Key {
+	pkgPrefix := ""
+	if v.PackageName != "" {
+		pkgPrefix = v.PackageName + "."
+	}
+	return []trace.Key{{Kind: "implements", Name: v.TypeName, Argument: pkgPrefix + v.InterfaceName}}
+}
+
+// formatMethodSets renders every method of the interface and of the type so a
+// mismatch can be compared side by side
+func (v MissingMethodsReport) formatMethodSets(pkgPrefix string) string {
+	var lines []string
+
+	lines = append(lines, fmt.Sprintf("interface \"%s%s\" methods:", pkgPrefix, v.InterfaceName))
+	for _, method := range v.InterfaceMethods {
+		lines = append(lines, "  "+formatMethodSignature(method))
+	}
+
+	lines = append(lines, fmt.Sprintf("type \"%s\" methods:", v.TypeName))
+	if len(v.TypeMethods) == 0 {
+		lines = append(lines, "  (none)")
+	}
+	for _, method := range v.TypeMethods {
+		line := "  " + formatTypeMethodSignature(method)
+		if !method.InValueSet {
+			line += " (pointer receiver)"
+		}
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// EmbeddedInterfaceReport is an advisory for an @implements annotation that is
+// satisfied only by methods promoted from an embedded interface field
+// @immutable
+// implements reporting.Violation
+type EmbeddedInterfaceReport struct {
+	InterfaceName string
+	PackageName   string
+	TypeName      string
+	Pos           token.Pos
+}
+
+// GetCode returns the error code for this violation
+func (v EmbeddedInterfaceReport) GetCode() string {
+	return codes.ImplementsEmbeddedInterface
+}
+
+// GetPos returns the position of the violation
+func (v EmbeddedInterfaceReport) GetPos() token.Pos {
+	return v.Pos
+}
+
+// GetMessage returns the main error message without formatting
+func (v EmbeddedInterfaceReport) GetMessage() string {
+	pkgPrefix := ""
+	if v.PackageName != "" {
+		pkgPrefix = v.PackageName + "."
+	}
+	return fmt.Sprintf(
+		"advisory: type \"%s\" satisfies interface \"%s%s\" only through an embedded interface field, "+
+			"which may be nil at runtime; the @implements annotation is redundant",
+		v.TypeName,
+		pkgPrefix,
+		v.InterfaceName,
+	)
+}
+
+// TypeSetReport is an @implements annotation naming an interface with a type
+// set, which no type can implement
+// @immutable
+// implements reporting.Violation
+type TypeSetReport struct {
+	InterfaceName string
+	PackageName   string
+	TypeName      string
+	Terms         string // Type terms of the interface, e.g. "~int | ~float64"
+	Pos           token.Pos
+}
+
+// GetCode returns the error code for this violation
+func (v TypeSetReport) GetCode() string {
+	return codes.ImplementsTypeSet
+}
+
+// GetPos returns the position of the violation
+func (v TypeSetReport) GetPos() token.Pos {
+	return v.Pos
+}
+
+// GetMessage returns the main error message without formatting
+func (v TypeSetReport) GetMessage() string {
+	pkgPrefix := ""
+	if v.PackageName != "" {
+		pkgPrefix = v.PackageName + "."
+	}
+	terms := "comparable"
+	if v.Terms != "" {
+		terms = v.Terms
+	}
+	return fmt.Sprintf(
+		"interface \"%s%s\" named by @implements on type \"%s\" is a type set (%s), not a method set; "+
+			"it can only constrain type parameters and no type implements it",
+		pkgPrefix,
+		v.InterfaceName,
+		v.TypeName,
+		terms,
+	)
+}
+
+// @immutable
+// implements reporting.Violation
+type ReceiverMismatchReport struct {
+	Receiver string // As written in the via clause, e.g. "*Old"
+	TypeName string
+	Pos      token.Pos
+}
+
+// GetCode returns the error code for this violation
+func (v ReceiverMismatchReport) GetCode() string {
+	return codes.ImplementsReceiverMismatch
+}
+
+// GetPos returns the position of the violation
+func (v ReceiverMismatchReport) GetPos() token.Pos {
+	return v.Pos
+}
+
+// GetMessage returns the main error message without formatting
+func (v ReceiverMismatchReport) GetMessage() string {
+	return fmt.Sprintf(
+		"@implements on type \"%s\" names receiver \"%s\" in its via clause; "+
+			"the clause must name the annotated type (\"%s\" or \"*%s\") and is ignored",
+		v.TypeName,
+		v.Receiver,
+		v.TypeName,
+		v.TypeName,
+	)
+}
+
+// @immutable
+// implements reporting.Violation
+type NoneOfReport struct {
+	Alternatives []annotations.ImplementsAnnotation
+	TypeName     string
+	Pos          token.Pos
+}
+
+// GetCode returns the error code for this violation
+func (v NoneOfReport) GetCode() string {
+	return codes.ImplementsNoneOf
+}
+
+// GetPos returns the position of the violation
+func (v NoneOfReport) GetPos() token.Pos {
+	return v.Pos
+}
+
+// GetMessage returns the main error message without formatting
+func (v NoneOfReport) GetMessage() string {
+	names := make([]string, 0, len(v.Alternatives))
+	for _, alt := range v.Alternatives {
+		name := alt.InterfaceName
+		if alt.PackageName != "" {
+			name = alt.PackageName + "." + name
+		}
+		if alt.RequiresPointerMethodSet() {
+			name = "&" + name
+		}
+		names = append(names, fmt.Sprintf("%q", name))
+	}
+	return fmt.Sprintf(
+		"type \"%s\" implements none of the @implements-oneof interfaces: %s",
+		v.TypeName,
+		strings.Join(names, ", "),
+	)
+}
+
+// annotationKey identifies the @implements annotation a report is about: the
+// type it is written on and the package it names as written
+type annotationKey struct {
+	pos         token.Pos
+	typeName    string
+	packageName string
 }
 
 // ReportProblems reports all implements violations using the new pretty formatter.
 // Supports @ignore directives for suppressing violations when needed.
+// Interface and method reports of an annotation whose package is not imported
+// are dropped in favour of the missing package report.
 func ReportProblems(
+	cfg *config.Config,
 	pass *analysis.Pass,
 	missingPackages []MissingPackageReport,
+	mismatchedReceivers []ReceiverMismatchReport,
 	missingInterfaces []MissingInterfaceReport,
 	missingMethods []MissingMethodsReport,
+	embeddedInterfaces []EmbeddedInterfaceReport,
+	unsatisfiedOneOf []NoneOfReport,
+	unimplementedBy []ImplementedByReport,
+	typeSets []TypeSetReport,
+	missingAnnotations []MissingAnnotationReport,
 	ignoreSet *util.IgnoreSet,
 ) {
-	reporter := reporting.NewReporter(pass, ignoreSet)
+	reporter := reporting.NewReporter(cfg, pass, ignoreSet)
 
 	// Convert all violations to generic Violation interface and report
 	var violations []reporting.Violation
 
-	// Add missing packages
+	// Add missing packages. An annotation whose package is not imported
+	// cannot have its interface or methods checked, so only this root cause
+	// is reported for it.
+	unresolved := make(map[annotationKey]bool)
 	for _, mp := range missingPackages {
+		unresolved[annotationKey{pos: mp.Pos, typeName: mp.TypeName, packageName: mp.PackageName}] = true
 		violations = append(violations, mp)
 	}
 
+	// Add via clauses naming another type
+	for _, mr := range mismatchedReceivers {
+		violations = append(violations, mr)
+	}
+
 	// Add missing interfaces
 	for _, mi := range missingInterfaces {
+		if unresolved[annotationKey{pos: mi.Pos, typeName: mi.TypeName, packageName: mi.PackageName}] {
+			continue
+		}
 		violations = append(violations, mi)
 	}
 
 	// Add missing methods
 	for _, mm := range missingMethods {
+		if unresolved[annotationKey{pos: mm.Pos, typeName: mm.TypeName, packageName: mm.PackageName}] {
+			continue
+		}
 		violations = append(violations, mm)
 	}
 
+	// Add embedded-interface advisories
+	for _, ei := range embeddedInterfaces {
+		violations = append(violations, ei)
+	}
+
+	// Add @implements-oneof groups with no implemented interface
+	for _, no := range unsatisfiedOneOf {
+		violations = append(violations, no)
+	}
+
+	// Add @implementedby types that do not implement their interface
+	for _, ub := range unimplementedBy {
+		violations = append(violations, ub)
+	}
+
+	// Add interfaces that only constrain type parameters
+	for _, ts := range typeSets {
+		violations = append(violations, ts)
+	}
+
+	// Add required interfaces implemented without an annotation
+	for _, ma := range missingAnnotations {
+		violations = append(violations, ma)
+	}
+
 	// Report all violations using the new pretty formatter
 	reporter.ReportViolations(violations)
 }
@@ -152,23 +516,36 @@ func ReportProblems(
 // formatMethodSignature formats a method signature for display
 // Example: Read(p []byte) (n int, err error)
 func formatMethodSignature(method InterfaceMethod) string {
-	var result strings.Builder
+	return formatSignature(method.Name, formatTypeList(method.Inputs), formatTypeList(method.Outputs), len(method.Outputs))
+}
+
+// formatTypeMethodSignature formats a type's method signature the same way as
+// formatMethodSignature so both sides of a mismatch line up
+func formatTypeMethodSignature(method TypeMethod) string {
+	var inputs, outputs []string
+	for _, t := range method.Inputs {
+		inputs = append(inputs, formatTypeParts(t.IsVariadic, t.IsPointer, t.TypePackage, t.TypeName))
+	}
+	for _, t := range method.Outputs {
+		outputs = append(outputs, formatTypeParts(t.IsVariadic, t.IsPointer, t.TypePackage, t.TypeName))
+	}
 
-	// Format inputs
-	inputs := formatTypeList(method.Inputs)
+	return formatSignature(method.Name, strings.Join(inputs, ", "), strings.Join(outputs, ", "), len(method.Outputs))
+}
 
-	// Format outputs
-	outputs := formatTypeList(method.Outputs)
+// formatSignature joins already formatted inputs and outputs into a signature
+func formatSignature(name string, inputs string, outputs string, outputCount int) string {
+	var result strings.Builder
 
 	// Build signature
-	result.WriteString(method.Name)
+	result.WriteString(name)
 	result.WriteString("(")
 	result.WriteString(inputs)
 	result.WriteString(")")
 
 	if outputs != "" {
 		// Wrap in parens only if multiple outputs
-		if len(method.Outputs) > 1 {
+		if outputCount > 1 {
 			result.WriteString(" (")
 			result.WriteString(outputs)
 			result.WriteString(")")
@@ -200,22 +577,27 @@ func formatTypeList(types []InterfaceType) string {
 // formatType formats a single type for display
 // Examples: int, *string, []byte, io.Reader, ...string
 func formatType(t InterfaceType) string {
+	return formatTypeParts(t.IsVariadic, t.IsPointer, t.TypePackage, t.TypeName)
+}
+
+// formatTypeParts formats the coarse type fields shared by InterfaceType and MethodType
+func formatTypeParts(isVariadic bool, isPointer bool, typePackage string, typeName string) string {
 	var result strings.Builder
 
 	// Add variadic prefix
-	if t.IsVariadic {
+	if isVariadic {
 		result.WriteString("...")
 	}
 
 	// Add pointer prefix
-	if t.IsPointer {
+	if isPointer {
 		result.WriteString("*")
 	}
 
 	// Add package prefix
-	if t.TypePackage != "" {
+	if typePackage != "" {
 		// Extract short package name from full path
-		if parts := strings.Split(t.TypePackage, "/"); len(parts) > 0 {
+		if parts := strings.Split(typePackage, "/"); len(parts) > 0 {
 			shortPkg := parts[len(parts)-1]
 
 			result.WriteString(shortPkg)
@@ -224,7 +606,7 @@ func formatType(t InterfaceType) string {
 	}
 
 	// Add type name
-	result.WriteString(t.TypeName)
+	result.WriteString(typeName)
 
 	return result.String()
 }
@@ -1,9 +1,19 @@
 package implements
 
 import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
 	"testing"
 
+	"github.com/a14e/gogreement/src/annotations"
+	"github.com/a14e/gogreement/src/config"
+
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis"
 )
 
 func TestFormatMethodSignature(t *testing.T) {
@@ -211,3 +221,81 @@ func TestFormatTypeList(t *testing.T) {
 		})
 	}
 }
+
+// TestReportProblemsReportsRootCauseOnly checks that an annotation naming a
+// package that is not imported gets the missing package report only, even
+// when reports for its interface and methods are passed in too
+func TestReportProblemsReportsRootCauseOnly(t *testing.T) {
+	src := `package rootcause
+
+import "io"
+
+// @implements missing.Reader
+type Detached struct{}
+
+// @implements io.Reader
+type Unreadable struct{}
+
+var _ io.Writer
+`
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "rootcause.go", src, parser.ParseComments)
+	require.NoError(t, err)
+	info := &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+		Defs:  make(map[*ast.Ident]types.Object),
+		Uses:  make(map[*ast.Ident]types.Object),
+	}
+	pkg, err := (&types.Config{Importer: importer.Default()}).Check("rootcause", fset, []*ast.File{file}, info)
+	require.NoError(t, err)
+
+	var diagnostics []analysis.Diagnostic
+	pass := &analysis.Pass{
+		Fset:      fset,
+		Files:     []*ast.File{file},
+		Pkg:       pkg,
+		TypesInfo: info,
+		ReadFile:  func(string) ([]byte, error) { return []byte(src), nil },
+		Report:    func(d analysis.Diagnostic) { diagnostics = append(diagnostics, d) },
+	}
+
+	cfg := config.Empty().WithStableMessages(true)
+	ann := annotations.ReadAllAnnotations(cfg, pass)
+	interfaces, err := LoadInterfaces(pass, ann.ToInterfaceQuery())
+	require.NoError(t, err)
+	typeModels := LoadTypes(pass, ann.ToTypeQuery())
+
+	missingPackages := FindMissingPackages(ann.ImplementsAnnotations)
+	require.Len(t, missingPackages, 1)
+	detached := missingPackages[0]
+
+	// Reports a caller derived for the same annotation regardless of the
+	// missing package
+	missingInterfaces := append(FindMissingInterfaces(ann.ImplementsAnnotations, interfaces), MissingInterfaceReport{
+		InterfaceName: "Reader",
+		PackageName:   detached.PackageName,
+		TypeName:      detached.TypeName,
+		Pos:           detached.Pos,
+	})
+	missingMethods := append(FindMissingMethods(ann.ImplementsAnnotations, interfaces, typeModels), MissingMethodsReport{
+		InterfaceName: "Reader",
+		PackageName:   detached.PackageName,
+		TypeName:      detached.TypeName,
+		Methods:       []InterfaceMethod{{Name: "Read"}},
+		Pos:           detached.Pos,
+	})
+
+	ReportProblems(cfg, pass, missingPackages, nil, missingInterfaces, missingMethods,
+		nil, nil, nil, nil, nil, nil)
+
+	reported := make(map[string][]string)
+	for _, d := range diagnostics {
+		typeName := "Unreadable"
+		if fset.Position(d.Pos).Line == 6 {
+			typeName = "Detached"
+		}
+		reported[typeName] = append(reported[typeName], d.Category)
+	}
+	assert.Equal(t, []string{"IMPL01"}, reported["Detached"], "only the missing package is reported")
+	assert.Equal(t, []string{"IMPL03"}, reported["Unreadable"], "other annotations are reported as usual")
+}
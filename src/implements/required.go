@@ -0,0 +1,178 @@
+package implements
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"iter"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/a14e/gogreement/src/annotations"
+	"github.com/a14e/gogreement/src/codes"
+)
+
+// MissingAnnotationReport is reported for an exported type that satisfies one
+// of the required interfaces without declaring it with @implements
+// @immutable
+// implements reporting.Violation
+type MissingAnnotationReport struct {
+	TypeName string
+	// Interface is the interface as written in the configuration, e.g. "io.Reader"
+	Interface string
+	// Annotation is the annotation that would document the contract
+	Annotation string
+	Pos        token.Pos
+}
+
+// GetCode returns the error code for this violation
+func (v MissingAnnotationReport) GetCode() string {
+	return codes.ImplementsAnnotationRequired
+}
+
+// GetPos returns the position of the violation
+func (v MissingAnnotationReport) GetPos() token.Pos {
+	return v.Pos
+}
+
+// GetMessage returns the main error message without formatting
+func (v MissingAnnotationReport) GetMessage() string {
+	return fmt.Sprintf(
+		"type \"%s\" implements \"%s\" but does not declare it; add \"// %s\" to its doc comment",
+		v.TypeName,
+		v.Interface,
+		v.Annotation,
+	)
+}
+
+// FindMissingAnnotations reports the exported, non-generic types declared in
+// files that satisfy one of the required interfaces (given as
+// "importpath.Name", e.g. "io.Reader") through their value or pointer method
+// set, but carry no @implements or @implements-oneof annotation naming it.
+// Only interfaces of the package itself or of its imports can be annotated, so
+// only those are considered.
+func FindMissingAnnotations(
+	pass *analysis.Pass,
+	files iter.Seq[*ast.File],
+	required []string,
+	annotated []annotations.ImplementsAnnotation,
+) []MissingAnnotationReport {
+	var result []MissingAnnotationReport
+
+	type requiredInterface struct {
+		spelling string
+		pkg      *types.Package
+		name     string
+		iface    *types.Interface
+	}
+
+	var interfaces []requiredInterface
+	for _, spelling := range required {
+		spelling = strings.TrimSpace(spelling)
+		dot := strings.LastIndex(spelling, ".")
+		if dot <= 0 {
+			continue
+		}
+		pkg := importedPackage(pass.Pkg, spelling[:dot])
+		if pkg == nil {
+			continue
+		}
+		obj, ok := pkg.Scope().Lookup(spelling[dot+1:]).(*types.TypeName)
+		if !ok {
+			continue
+		}
+		iface, ok := obj.Type().Underlying().(*types.Interface)
+		if !ok {
+			continue
+		}
+		interfaces = append(interfaces, requiredInterface{spelling: spelling, pkg: pkg, name: obj.Name(), iface: iface})
+	}
+	if len(interfaces) == 0 {
+		return result
+	}
+
+	declared := make(map[string]bool) // "Type|pkgpath.Interface"
+	for _, ann := range annotated {
+		declared[ann.OnType+"|"+ann.PackageFullPath+"."+ann.InterfaceName] = true
+	}
+
+	for _, obj := range declaredTypes(pass, files) {
+		name := obj.Name()
+		named, ok := obj.Type().(*types.Named)
+		if !ok || named.TypeParams().Len() > 0 || types.IsInterface(named) {
+			continue
+		}
+
+		for _, required := range interfaces {
+			if declared[name+"|"+required.pkg.Path()+"."+required.name] {
+				continue
+			}
+			if !types.Implements(named, required.iface) && !types.Implements(types.NewPointer(named), required.iface) {
+				continue
+			}
+
+			annotation := "@implements " + ifaceReference(pass.Pkg, required.pkg, required.name)
+			if !types.Implements(named, required.iface) {
+				annotation = "@implements &" + ifaceReference(pass.Pkg, required.pkg, required.name)
+			}
+
+			result = append(result, MissingAnnotationReport{
+				TypeName:   name,
+				Interface:  required.spelling,
+				Annotation: annotation,
+				Pos:        obj.Pos(),
+			})
+		}
+	}
+
+	return result
+}
+
+// declaredTypes returns the exported, non-alias type names declared at the top
+// level of files, in source order
+func declaredTypes(pass *analysis.Pass, files iter.Seq[*ast.File]) []*types.TypeName {
+	var result []*types.TypeName
+	for file := range files {
+		for _, decl := range file.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				typeSpec, ok := spec.(*ast.TypeSpec)
+				if !ok || typeSpec.Assign.IsValid() || !typeSpec.Name.IsExported() {
+					continue
+				}
+				if obj, ok := pass.TypesInfo.Defs[typeSpec.Name].(*types.TypeName); ok {
+					result = append(result, obj)
+				}
+			}
+		}
+	}
+	return result
+}
+
+// importedPackage returns pkg itself or the import of pkg with the given path
+func importedPackage(pkg *types.Package, path string) *types.Package {
+	if pkg.Path() == path {
+		return pkg
+	}
+	for _, imp := range pkg.Imports() {
+		if imp.Path() == path {
+			return imp
+		}
+	}
+	return nil
+}
+
+// ifaceReference spells an interface the way an @implements annotation in
+// pkg refers to it: bare for the package itself, qualified by the package
+// name otherwise
+func ifaceReference(pkg *types.Package, ifacePkg *types.Package, name string) string {
+	if ifacePkg == pkg {
+		return name
+	}
+	return ifacePkg.Name() + "." + name
+}
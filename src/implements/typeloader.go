@@ -27,6 +27,9 @@ type TypeMethod struct {
 	Inputs     []MethodType
 	Outputs    []MethodType
 	InValueSet bool // true if the method is in the value method set of T (not only *T)
+	// FromEmbeddedInterface is true if the method is promoted from an embedded
+	// interface field, so calling it dispatches to that (possibly nil) value.
+	FromEmbeddedInterface bool
 }
 
 // MethodType represents a type in method signature
@@ -41,71 +44,41 @@ type MethodType struct {
 	// type arguments (List[int] vs List[string]) and pointer depth (*T vs **T)
 	// that the coarse fields above lose.
 	Canonical string
+	// Core is set for a type parameter whose constraint permits exactly one
+	// type ([T []byte]), or a pointer to one. It is the canonical string of
+	// that type, which every instantiation is identical to.
+	Core string
 }
 
-// LoadTypes loads specified named types from the current package
+// LoadTypes loads specified named types from the current package.
+// Queries are resolved by a fresh Loader, see Loader.Types. Methods come from
+// the type's method sets, so those declared in other files of the package
+// than the type itself are included.
 func LoadTypes(pass *analysis.Pass, queries []annotations.TypeQuery) []*TypeModel {
-	var result []*TypeModel
-
-	// Create a set of type names we're looking for
-	targetTypes := make(map[string]bool)
-	for _, q := range queries {
-		targetTypes[q.TypeName] = true
-	}
-
-	// Scan only current package
-	result = findTypesInPackage(pass.Pkg, targetTypes)
-
-	return result
+	return NewLoader(pass).Types(queries)
 }
 
-// findTypesInPackage extracts named types and their methods from package
-func findTypesInPackage(
-	pkg *types.Package,
-	targetTypes map[string]bool,
-) []*TypeModel {
-	var result []*TypeModel
-
-	scope := pkg.Scope()
-	for _, name := range scope.Names() {
-		if !targetTypes[name] {
-			continue
-		}
-
-		obj := scope.Lookup(name)
-		if obj == nil {
-			continue
-		}
-
-		// Check if it's a type name
-		typeName, ok := obj.(*types.TypeName)
-		if !ok {
-			continue
-		}
-
-		// We want named types (struct, int, string, etc.)
-		namedType, ok := typeName.Type().(*types.Named)
-		if !ok {
-			continue
-		}
-
-		// Determine underlying type for debugging/reporting.go
-		underlyingType := getUnderlyingTypeName(namedType.Underlying())
+// typeModel builds the model of the named type typeName declares in pkg, or
+// returns nil when it declares an alias
+func typeModel(pkg *types.Package, typeName *types.TypeName) *TypeModel {
+	// We want named types (struct, int, string, etc.)
+	namedType, ok := typeName.Type().(*types.Named)
+	if !ok {
+		return nil
+	}
 
-		// Extract methods for this type
-		methods := extractMethodsFromNamedType(namedType)
+	// Determine underlying type for debugging/reporting.go
+	underlyingType := getUnderlyingTypeName(namedType.Underlying())
 
-		model := &TypeModel{
-			Name:           name,
-			Package:        pkg.Path(),
-			UnderlyingType: underlyingType,
-			Methods:        methods,
-		}
+	// Extract methods for this type
+	methods := extractMethodsFromNamedType(namedType)
 
-		result = append(result, model)
+	return &TypeModel{
+		Name:           typeName.Name(),
+		Package:        pkg.Path(),
+		UnderlyingType: underlyingType,
+		Methods:        methods,
 	}
-
-	return result
 }
 
 // getUnderlyingTypeName returns a string representation of the underlying type
@@ -151,7 +124,9 @@ func extractMethodsFromNamedType(named *types.Named) []TypeMethod {
 		valueSet[vSet.At(i).Obj().(*types.Func).Id()] = true
 	}
 
-	// Method set for *T includes both T and *T receivers.
+	// Method set for *T includes both T and *T receivers. It contains the
+	// declared methods and the promoted ones; a declared method shadows a
+	// promoted method of the same name, as in the language.
 	ptrType := types.NewPointer(named)
 	methodSet := types.NewMethodSet(ptrType)
 
@@ -160,18 +135,45 @@ func extractMethodsFromNamedType(named *types.Named) []TypeMethod {
 		method := selection.Obj().(*types.Func)
 		sig := method.Type().(*types.Signature)
 
+		// A promoted method keeps the receiver it was declared on, which is
+		// the interface type for methods of an embedded interface.
+		fromEmbeddedInterface := len(selection.Index()) > 1 &&
+			sig.Recv() != nil && types.IsInterface(sig.Recv().Type())
+
 		methods = append(methods, TypeMethod{
-			Name:       method.Name(),
-			Id:         method.Id(),
-			Inputs:     extractMethodTypesFromTuple(sig.Params(), sig.Variadic()),
-			Outputs:    extractMethodTypesFromTuple(sig.Results(), false),
-			InValueSet: valueSet[method.Id()],
+			Name:                  method.Name(),
+			Id:                    method.Id(),
+			Inputs:                extractMethodTypesFromTuple(sig.Params(), sig.Variadic()),
+			Outputs:               extractMethodTypesFromTuple(sig.Results(), false),
+			InValueSet:            valueSet[method.Id()],
+			FromEmbeddedInterface: fromEmbeddedInterface,
 		})
 	}
 
 	return methods
 }
 
+// unaliasType resolves aliases in t and in the element types of pointers,
+// slices, arrays, maps and channels, so types spelled through an alias
+// (any, type ID = int) compare equal to their spelled-out form. Aliases in
+// type arguments and function types are kept.
+func unaliasType(t types.Type) types.Type {
+	switch t := types.Unalias(t).(type) {
+	case *types.Pointer:
+		return types.NewPointer(unaliasType(t.Elem()))
+	case *types.Slice:
+		return types.NewSlice(unaliasType(t.Elem()))
+	case *types.Array:
+		return types.NewArray(unaliasType(t.Elem()), t.Len())
+	case *types.Map:
+		return types.NewMap(unaliasType(t.Key()), unaliasType(t.Elem()))
+	case *types.Chan:
+		return types.NewChan(t.Dir(), unaliasType(t.Elem()))
+	default:
+		return t
+	}
+}
+
 // extractMethodTypesFromTuple converts types.Tuple to MethodType slice
 func extractMethodTypesFromTuple(tuple *types.Tuple, isVariadic bool) []MethodType {
 	if tuple == nil {
@@ -199,16 +201,58 @@ func extractMethodTypesFromTuple(tuple *types.Tuple, isVariadic bool) []MethodTy
 	return result
 }
 
+// exactCoreType returns the canonical string of the only type the constraint
+// of param permits, or "" when it permits more. A single exact term ([]byte)
+// qualifies; a tilde term (~[]byte) does not, since named types with that
+// underlying type are not identical to it.
+func exactCoreType(param *types.TypeParam) string {
+	constraint, ok := param.Constraint().Underlying().(*types.Interface)
+	if !ok || constraint.NumEmbeddeds() != 1 {
+		return ""
+	}
+
+	term := constraint.EmbeddedType(0)
+	if union, ok := term.(*types.Union); ok {
+		if union.Len() != 1 || union.Term(0).Tilde() {
+			return ""
+		}
+		term = union.Term(0).Type()
+	}
+	if types.IsInterface(term) {
+		return ""
+	}
+	return unaliasType(term).String()
+}
+
 // convertTypesToMethodType converts types.Type to MethodType
 func convertTypesToMethodType(t types.Type) MethodType {
+	t = unaliasType(t)
+
+	// any and interface{} are the same type
+	if iface, ok := t.(*types.Interface); ok && iface.Empty() {
+		return MethodType{TypeName: "any", Canonical: "any"}
+	}
+
 	// Handle pointer
 	if ptr, ok := t.(*types.Pointer); ok {
 		inner := convertTypesToMethodType(ptr.Elem())
 		inner.IsPointer = true
 		inner.Canonical = t.String() // full *T / **T string
+		if inner.Core != "" {
+			inner.Core = "*" + inner.Core
+		}
 		return inner
 	}
 
+	// Handle type parameters
+	if param, ok := t.(*types.TypeParam); ok {
+		return MethodType{
+			TypeName:  t.String(),
+			Canonical: t.String(),
+			Core:      exactCoreType(param),
+		}
+	}
+
 	// Handle named types
 	if named, ok := t.(*types.Named); ok {
 		obj := named.Obj()
@@ -0,0 +1,97 @@
+package indexing
+
+import (
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/a14e/gogreement/src/annotations"
+	"github.com/a14e/gogreement/src/util"
+)
+
+// Contracts holds the indices of the current package and its transitive
+// imports, built from their ContractsFact. Each index matches the one the
+// corresponding Build...Index function derives from the per-checker facts.
+// @immutable
+type Contracts struct {
+	ImmutableTypes         util.TypesMap
+	MutableFields          util.TypeAssociationRegistry
+	Constructors           util.TypeAssociationRegistry
+	ConstructorEnforcement util.TypeAssociationRegistry
+	TestOnlyTypes          util.TypesMap
+	TestOnlyFuncs          util.TypeAssociationRegistry
+	TestOnlyMethods        util.TypeAssociationRegistry
+	PackageOnly            *util.AttachmentsMap
+}
+
+// BuildContracts creates the indices from the ContractsFact of the current
+// package, local, and the facts of the imported packages
+func BuildContracts(pass *analysis.Pass, local *annotations.ContractsFact) Contracts {
+	immutableTypes := util.NewTypesMap()
+	mutableFields := util.NewTypeAssociationRegistry()
+	constructors := util.NewTypeAssociationRegistry()
+	enforcement := util.NewTypeAssociationRegistry()
+	testOnlyTypes := util.NewTypesMap()
+	testOnlyFuncs := util.NewTypeAssociationRegistry()
+	testOnlyMethods := util.NewTypeAssociationRegistry()
+	packageOnly := &util.AttachmentsMap{}
+
+	add := func(pkgPath string, fact *annotations.ContractsFact) {
+		for _, typeName := range fact.ImmutableTypes {
+			immutableTypes.Add(pkgPath, typeName)
+		}
+		for _, field := range fact.MutableFields {
+			mutableFields.Add(pkgPath, field.Name, field.Type)
+		}
+		for _, contract := range fact.Constructors {
+			for _, constructorName := range contract.Constructors {
+				constructors.Add(pkgPath, constructorName, contract.Type)
+				if !contract.LocalOnly || pkgPath == pass.Pkg.Path() {
+					enforcement.Add(pkgPath, constructorName, contract.Type)
+				}
+			}
+		}
+		for _, typeName := range fact.TestOnlyTypes {
+			testOnlyTypes.Add(pkgPath, typeName)
+		}
+		for _, funcName := range fact.TestOnlyFuncs {
+			testOnlyFuncs.Add(pkgPath, funcName, funcName)
+		}
+		for _, method := range fact.TestOnlyMethods {
+			testOnlyMethods.Add(pkgPath, method.Name, method.Type)
+		}
+		for _, contract := range fact.PackageOnly {
+			for _, allowedPkg := range contract.AllowedPackages {
+				switch contract.Kind {
+				case annotations.TestOnlyOnType:
+					packageOnly.AddPkgTypeAttachment(pkgPath, contract.Object, allowedPkg)
+				case annotations.TestOnlyOnFunc:
+					packageOnly.AddPkgFunctionAttachment(pkgPath, contract.Object, allowedPkg)
+				case annotations.TestOnlyOnMethod:
+					packageOnly.AddPkgTypeMethodAttachment(pkgPath, contract.Receiver, contract.Object, allowedPkg)
+				}
+			}
+		}
+	}
+
+	if pass.Pkg != nil {
+		add(pass.Pkg.Path(), local)
+		if pass.ImportPackageFact != nil {
+			for imp := range importClosure(pass) {
+				var fact annotations.ContractsFact
+				if pass.ImportPackageFact(imp, &fact) {
+					add(imp.Path(), &fact)
+				}
+			}
+		}
+	}
+
+	return Contracts{
+		ImmutableTypes:         immutableTypes,
+		MutableFields:          mutableFields,
+		Constructors:           constructors,
+		ConstructorEnforcement: enforcement,
+		TestOnlyTypes:          testOnlyTypes,
+		TestOnlyFuncs:          testOnlyFuncs,
+		TestOnlyMethods:        testOnlyMethods,
+		PackageOnly:            packageOnly,
+	}
+}
@@ -45,6 +45,26 @@ func BuildConstructorIndex[T annotations.AnnotationWrapper](pass *analysis.Pass,
 	return result
 }
 
+// BuildConstructorEnforcementIndex is BuildConstructorIndex restricted to the
+// annotations enforced in the current package: @constructor-local annotations
+// of imported packages are left out, so their types may be constructed freely here
+func BuildConstructorEnforcementIndex[T annotations.AnnotationWrapper](pass *analysis.Pass, packageAnnotations *annotations.PackageAnnotations) util.TypeAssociationRegistry {
+	result := util.NewTypeAssociationRegistry()
+
+	for pkg, ann := range iterOverPackages[T](pass, packageAnnotations) {
+		for _, annot := range ann.ConstructorAnnotations {
+			if annot.LocalOnly && pkg.Path() != pass.Pkg.Path() {
+				continue
+			}
+			for _, constructorName := range annot.ConstructorNames {
+				result.Add(pkg.Path(), constructorName, annot.OnType)
+			}
+		}
+	}
+
+	return result
+}
+
 // BuildTestOnlyTypesIndex creates an index of @testonly types from current and imported packages
 func BuildTestOnlyTypesIndex[T annotations.AnnotationWrapper](pass *analysis.Pass, packageAnnotations *annotations.PackageAnnotations) util.TypesMap {
 	result := util.NewTypesMap()
@@ -139,6 +159,94 @@ func BuildPackageOnlyIndex[T annotations.AnnotationWrapper](pass *analysis.Pass,
 	return result
 }
 
+// BuildEnumTypesIndex creates an index of @enum types from current and imported packages
+func BuildEnumTypesIndex[T annotations.AnnotationWrapper](pass *analysis.Pass, packageAnnotations *annotations.PackageAnnotations) util.TypesMap {
+	result := util.NewTypesMap()
+
+	for pkg, ann := range iterOverPackages[T](pass, packageAnnotations) {
+		for _, annot := range ann.EnumAnnotations {
+			result.Add(pkg.Path(), annot.OnType)
+		}
+	}
+
+	return result
+}
+
+// BuildRequiredFieldsIndex creates an index of @required fields from current and imported packages
+// Returns a map: packageName -> typeName -> []fieldNames
+func BuildRequiredFieldsIndex[T annotations.AnnotationWrapper](pass *analysis.Pass, packageAnnotations *annotations.PackageAnnotations) util.TypeAssociationRegistry {
+	result := util.NewTypeAssociationRegistry()
+
+	for pkg, ann := range iterOverPackages[T](pass, packageAnnotations) {
+		for _, annot := range ann.RequiredAnnotations {
+			result.Add(pkg.Path(), annot.FieldName, annot.OnType)
+		}
+	}
+
+	return result
+}
+
+// BuildSingletonTypesIndex creates an index of @singleton types from current and imported packages
+func BuildSingletonTypesIndex[T annotations.AnnotationWrapper](pass *analysis.Pass, packageAnnotations *annotations.PackageAnnotations) util.TypesMap {
+	result := util.NewTypesMap()
+
+	for pkg, ann := range iterOverPackages[T](pass, packageAnnotations) {
+		for _, annot := range ann.SingletonAnnotations {
+			result.Add(pkg.Path(), annot.OnType)
+		}
+	}
+
+	return result
+}
+
+// BuildMustReturnIndex creates an index of @mustreturn functions and methods
+// from current and imported packages. Methods are associated with their
+// receiver type, functions with the empty type name.
+func BuildMustReturnIndex[T annotations.AnnotationWrapper](pass *analysis.Pass, packageAnnotations *annotations.PackageAnnotations) util.TypeAssociationRegistry {
+	result := util.NewTypeAssociationRegistry()
+
+	for pkg, ann := range iterOverPackages[T](pass, packageAnnotations) {
+		for _, annot := range ann.MustReturnAnnotations {
+			result.Add(pkg.Path(), annot.ObjectName, annot.ReceiverType)
+		}
+	}
+
+	return result
+}
+
+// BuildExperimentalIndex creates an index of @experimental types, functions
+// and methods from current and imported packages. Methods are associated with
+// their receiver type, types and functions with the empty type name.
+func BuildExperimentalIndex[T annotations.AnnotationWrapper](pass *analysis.Pass, packageAnnotations *annotations.PackageAnnotations) util.TypeAssociationRegistry {
+	result := util.NewTypeAssociationRegistry()
+
+	for pkg, ann := range iterOverPackages[T](pass, packageAnnotations) {
+		for _, annot := range ann.ExperimentalAnnotations {
+			result.Add(pkg.Path(), annot.ObjectName, annot.ReceiverType)
+		}
+	}
+
+	return result
+}
+
+// BuildDeprecatedPackagesIndex maps the paths of imported packages deprecated
+// with //gogreement:deprecated to their directive. The current package is
+// left out, as it may always use itself.
+func BuildDeprecatedPackagesIndex[T annotations.AnnotationWrapper](pass *analysis.Pass, packageAnnotations *annotations.PackageAnnotations) map[string]annotations.DeprecatedPackageAnnotation {
+	result := make(map[string]annotations.DeprecatedPackageAnnotation)
+
+	for pkg, ann := range iterOverPackages[T](pass, packageAnnotations) {
+		if pkg == pass.Pkg {
+			continue
+		}
+		for _, annot := range ann.DeprecatedPackages {
+			result[pkg.Path()] = annot
+		}
+	}
+
+	return result
+}
+
 // iterOverPackages just iter over packageAnnotations + facts over imported packages
 func iterOverPackages[T annotations.AnnotationWrapper](
 	pass *analysis.Pass,
@@ -160,12 +268,25 @@ func iterOverPackages[T annotations.AnnotationWrapper](
 
 		var zero T
 
-		// Walk the full transitive import closure, not just direct imports.
-		// types.Package.Imports() returns only direct imports, but a type
-		// annotated in package C can reach the current package through a direct
-		// import B (e.g. a B function returning a C type), so C's facts must be
-		// loaded too. The analysis framework makes facts available for every
-		// package in the closure.
+		for imp := range importClosure(pass) {
+			fact := zero.CreateEmpty()
+			if pass.ImportPackageFact(imp, fact) {
+				if !yield(imp, fact.GetAnnotations()) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// importClosure iterates over the full transitive import closure of the
+// current package, not just its direct imports. types.Package.Imports()
+// returns only direct imports, but a type annotated in package C can reach
+// the current package through a direct import B (e.g. a B function returning
+// a C type), so C's facts must be loaded too. The analysis framework makes
+// facts available for every package in the closure.
+func importClosure(pass *analysis.Pass) iter.Seq[*types.Package] {
+	return func(yield func(*types.Package) bool) {
 		seen := make(map[*types.Package]bool)
 		stack := append([]*types.Package(nil), pass.Pkg.Imports()...)
 		for len(stack) > 0 {
@@ -176,11 +297,8 @@ func iterOverPackages[T annotations.AnnotationWrapper](
 			}
 			seen[imp] = true
 
-			fact := zero.CreateEmpty()
-			if pass.ImportPackageFact(imp, fact) {
-				if !yield(imp, fact.GetAnnotations()) {
-					return
-				}
+			if !yield(imp) {
+				return
 			}
 
 			stack = append(stack, imp.Imports()...)
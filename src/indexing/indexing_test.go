@@ -1,7 +1,11 @@
 package indexing
 
 import (
+	"go/ast"
+	"go/parser"
+	"go/token"
 	"go/types"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -10,6 +14,7 @@ import (
 
 	"github.com/a14e/gogreement/src/annotations"
 	"github.com/a14e/gogreement/src/config"
+	"github.com/a14e/gogreement/src/testutil"
 	"github.com/a14e/gogreement/src/testutil/testfacts"
 )
 
@@ -113,7 +118,7 @@ func TestBuildConstructorIndex(t *testing.T) {
 	pass := testfacts.CreateTestPassWithFacts(t, "immutabletests")
 	packageAnnotations := annotations.ReadAllAnnotations(config.Empty(), pass)
 
-	index := BuildConstructorIndex[*annotations.ConstructorCheckerFact](pass, &packageAnnotations)
+	index := BuildConstructorIndex[*annotations.ImmutableCheckerFact](pass, &packageAnnotations)
 
 	pkgPath := pass.Pkg.Path()
 
@@ -191,7 +196,7 @@ func TestBuildConstructorIndexEmpty(t *testing.T) {
 		ConstructorAnnotations: []annotations.ConstructorAnnotation{},
 	}
 
-	index := BuildConstructorIndex[*annotations.ConstructorCheckerFact](pass, &emptyAnnotations)
+	index := BuildConstructorIndex[*annotations.ImmutableCheckerFact](pass, &emptyAnnotations)
 
 	assert.Equal(t, 0, index.Len(), "should be empty when no constructor annotations")
 }
@@ -201,7 +206,7 @@ func TestBuildConstructorIndexWithImports(t *testing.T) {
 	pass := testfacts.CreateTestPassWithFacts(t, "immutabletests")
 	packageAnnotations := annotations.ReadAllAnnotations(config.Empty(), pass)
 
-	index := BuildConstructorIndex[*annotations.ConstructorCheckerFact](pass, &packageAnnotations)
+	index := BuildConstructorIndex[*annotations.ImmutableCheckerFact](pass, &packageAnnotations)
 
 	// Check local constructors
 	localPkgPath := pass.Pkg.Path()
@@ -413,3 +418,113 @@ func TestBuildPackageOnlyIndex_EmptyAnnotations(t *testing.T) {
 	assert.False(t, index.HasAnyFunctionAttachments(pkgPath, "AnyFunction"))
 	assert.False(t, index.HasAnyMethodAttachments(pkgPath, "AnyType", "AnyMethod"))
 }
+
+func TestBuildIndexesWithBuildTaggedDuplicates(t *testing.T) {
+
+	t.Run("only the active declaration is indexed", func(t *testing.T) {
+		pass := testfacts.CreateTestPassWithFacts(t, "buildtagdups")
+		packageAnnotations := annotations.ReadAllAnnotations(config.Empty(), pass)
+		pkgPath := pass.Pkg.Path()
+
+		assert.Len(t, packageAnnotations.ImmutableAnnotations, 1, "packages loads one Handle file per build")
+		assert.Len(t, packageAnnotations.ImplementsAnnotations, 1)
+
+		constructors := BuildConstructorIndex[*annotations.ImmutableCheckerFact](pass, &packageAnnotations)
+		assert.Equal(t, []string{"NewHandle"}, constructors.GetAssociated(pkgPath, "Handle"))
+	})
+
+	t.Run("duplicates across tagged files are indexed once", func(t *testing.T) {
+		// Parse every file regardless of build constraints, as a tool that
+		// ignores tags would, so both Handle declarations are seen.
+		dir := filepath.Join(testutil.GetRootTestdataPath(), "unit", "buildtagdups")
+		fset := token.NewFileSet()
+		var files []*ast.File
+		for _, name := range []string{"buildtagdups.go", "handle_linux.go", "handle_other.go"} {
+			file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+			require.NoError(t, err)
+			files = append(files, file)
+		}
+
+		pkgPath := "buildtagdups"
+		pass := &analysis.Pass{
+			Fset:  fset,
+			Files: files,
+			Pkg:   types.NewPackage(pkgPath, "buildtagdups"),
+		}
+
+		packageAnnotations := annotations.ReadAllAnnotations(config.Empty(), pass)
+		require.Len(t, packageAnnotations.ImmutableAnnotations, 2, "both tagged declarations carry @immutable")
+
+		require.NotPanics(t, func() {
+			immutableTypes := BuildImmutableTypesIndex[*annotations.ImmutableCheckerFact](pass, &packageAnnotations)
+			assert.True(t, immutableTypes.Contains(pkgPath, "Handle"))
+			assert.Equal(t, 1, immutableTypes.Len())
+
+			constructors := BuildConstructorIndex[*annotations.ImmutableCheckerFact](pass, &packageAnnotations)
+			assert.Equal(t, []string{"NewHandle"}, constructors.GetAssociated(pkgPath, "Handle"))
+			assert.Equal(t, 1, constructors.Len())
+
+			mutableFields := BuildMutableFieldsIndex[*annotations.ImmutableCheckerFact](pass, &packageAnnotations)
+			assert.Equal(t, []string{"closed"}, mutableFields.GetAssociated(pkgPath, "Handle"))
+		})
+	})
+}
+
+func TestBuildContractsMatchesIndices(t *testing.T) {
+	for _, pkgName := range []string{"immutabletests", "withimports", "testonlyexample", "packageonlysource", "buildtagdups"} {
+		t.Run(pkgName, func(t *testing.T) {
+			pass := testfacts.CreateTestPassWithFacts(t, pkgName)
+			packageAnnotations := annotations.ReadAllAnnotations(config.Empty(), pass)
+
+			local := annotations.NewContractsFact(&packageAnnotations)
+			contracts := BuildContracts(pass, &local)
+
+			assert.Equal(t, BuildImmutableTypesIndex[*annotations.ImmutableCheckerFact](pass, &packageAnnotations), contracts.ImmutableTypes)
+			assert.Equal(t, BuildMutableFieldsIndex[*annotations.ImmutableCheckerFact](pass, &packageAnnotations), contracts.MutableFields)
+			assert.Equal(t, BuildConstructorIndex[*annotations.ImmutableCheckerFact](pass, &packageAnnotations), contracts.Constructors)
+			assert.Equal(t, BuildConstructorEnforcementIndex[*annotations.ImmutableCheckerFact](pass, &packageAnnotations), contracts.ConstructorEnforcement)
+			assert.Equal(t, BuildTestOnlyTypesIndex[*annotations.TestOnlyCheckerFact](pass, &packageAnnotations), contracts.TestOnlyTypes)
+			assert.Equal(t, BuildTestOnlyFuncsIndex[*annotations.TestOnlyCheckerFact](pass, &packageAnnotations), contracts.TestOnlyFuncs)
+			assert.Equal(t, BuildTestOnlyMethodsIndex[*annotations.TestOnlyCheckerFact](pass, &packageAnnotations), contracts.TestOnlyMethods)
+			assert.Equal(t, BuildPackageOnlyIndex[*annotations.PackageOnlyCheckerFact](pass, &packageAnnotations), contracts.PackageOnly)
+		})
+	}
+}
+
+func TestImmutableQuery(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "immutabletests")
+
+	localPkgPath := pass.Pkg.Path()
+	importedPkgPath := "github.com/a14e/gogreement/testdata/unit/interfacesforloading"
+
+	assert.True(t, Immutable(pass, localPkgPath, "Person"))
+	assert.True(t, Immutable(pass, localPkgPath, "Leaderboard"))
+	assert.False(t, Immutable(pass, localPkgPath, "MutableType"))
+	assert.False(t, Immutable(pass, localPkgPath, "NotDeclared"))
+
+	assert.True(t, Immutable(pass, importedPkgPath, "FileReader"), "contracts of imported packages come from their facts")
+	assert.False(t, Immutable(pass, importedPkgPath, "MutableType"))
+	assert.False(t, Immutable(pass, "example.com/unknown", "FileReader"))
+}
+
+func TestConstructorsOfQuery(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "immutabletests")
+	pkgPath := pass.Pkg.Path()
+
+	assert.Equal(t, []string{"NewPerson"}, ConstructorsOf(pass, pkgPath, "Person"))
+	assert.ElementsMatch(t, []string{"NewConfig", "NewDefaultConfig"}, ConstructorsOf(pass, pkgPath, "Config"))
+	assert.Nil(t, ConstructorsOf(pass, pkgPath, "MutableType"))
+
+	// the result is a copy; changing it does not change later answers
+	constructors := ConstructorsOf(pass, pkgPath, "Person")
+	constructors[0] = "Other"
+	assert.Equal(t, []string{"NewPerson"}, ConstructorsOf(pass, pkgPath, "Person"))
+
+	t.Run("imported type", func(t *testing.T) {
+		pass := testfacts.CreateTestPassWithFacts(t, "ctorconsumer", "ctorsource")
+		sourcePkgPath := "github.com/a14e/gogreement/testdata/unit/ctorsource"
+
+		assert.Equal(t, []string{"NewWidget"}, ConstructorsOf(pass, sourcePkgPath, "Widget"))
+		assert.False(t, Immutable(pass, sourcePkgPath, "Widget"))
+	})
+}
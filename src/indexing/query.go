@@ -0,0 +1,56 @@
+package indexing
+
+import (
+	"runtime"
+	"slices"
+	"sync"
+	"weak"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/a14e/gogreement/src/annotations"
+	"github.com/a14e/gogreement/src/config"
+)
+
+// Point queries for tools that are not checkers, such as editor integrations.
+// They answer from the annotations of the package of pass and the
+// ContractsFact of its transitive imports, the same contracts the checkers
+// enforce. The indices are built on the first query of a pass and reused by
+// the following ones until the pass is garbage collected.
+
+// queried holds the Contracts of the passes queried so far
+var queried sync.Map // weak.Pointer[analysis.Pass] -> Contracts
+
+// Immutable reports whether the type typeName of the package pkgPath is
+// annotated immutable, as seen from the package of pass
+func Immutable(pass *analysis.Pass, pkgPath string, typeName string) bool {
+	return contractsOf(pass).ImmutableTypes.Contains(pkgPath, typeName)
+}
+
+// ConstructorsOf returns the constructors the type typeName of the package
+// pkgPath is annotated with, as seen from the package of pass, or nil for a
+// type without a constructor annotation
+func ConstructorsOf(pass *analysis.Pass, pkgPath string, typeName string) []string {
+	return slices.Clone(contractsOf(pass).Constructors.GetAssociated(pkgPath, typeName))
+}
+
+// contractsOf returns the Contracts of pass, building them on first use. The
+// annotations of the package itself are read with the default file filter,
+// so test files are left out.
+func contractsOf(pass *analysis.Pass) Contracts {
+	key := weak.Make(pass)
+	if contracts, ok := queried.Load(key); ok {
+		return contracts.(Contracts)
+	}
+
+	var local annotations.ContractsFact
+	if pass.Pkg != nil {
+		packageAnnotations := annotations.ReadAllAnnotations(config.Empty(), pass)
+		local = annotations.NewContractsFact(&packageAnnotations)
+	}
+	contracts, loaded := queried.LoadOrStore(key, BuildContracts(pass, &local))
+	if !loaded {
+		runtime.AddCleanup(pass, func(key weak.Pointer[analysis.Pass]) { queried.Delete(key) }, key)
+	}
+	return contracts.(Contracts)
+}
@@ -0,0 +1,276 @@
+// Package inventory lists the annotations of the analyzed packages for the
+// --emit-inventory flag.
+//
+// The annotation reader collects the annotations of every package it reads
+// with Collect. Like the trace package, every entry is kept in a process-wide
+// list and, when SinkEnv is set, appended to that file as one JSON line; the
+// gogreement command aggregates the file with ReadSink once its child process
+// exits.
+package inventory
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"io"
+	"os"
+	"slices"
+	"sync"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/a14e/gogreement/src/annotations"
+)
+
+// SinkEnv names the environment variable holding the file that Add appends
+// entries to. It is set by the gogreement command for its child process.
+const SinkEnv = "GOGREEMENT_INVENTORY_FILE"
+
+// Kinds of declarations an annotation is written on
+const (
+	TargetType      = "type"
+	TargetInterface = "interface"
+	TargetFunction  = "function"
+	TargetMethod    = "method"
+	TargetField     = "field"
+	TargetPackage   = "package"
+)
+
+// Position is a position in a source file
+type Position struct {
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+}
+
+// Entry is one annotation: its kind ("implements"), the declaration it is
+// written on ("Config", "Config.Name" for fields and methods, the package
+// path for annotations on the package itself) and what it names as written
+// ("&io.Reader" for @implements, the constructors of @constructor)
+type Entry struct {
+	Kind       string   `json:"kind"`
+	Target     string   `json:"target"`
+	TargetKind string   `json:"targetKind"`
+	Package    string   `json:"package"`
+	Position   Position `json:"position"`
+	Arguments  []string `json:"arguments,omitempty"`
+}
+
+var (
+	mu      sync.Mutex
+	entries []Entry
+)
+
+// Collect lists the annotations of the package of pass
+func Collect(pass *analysis.Pass, ann *annotations.PackageAnnotations) []Entry {
+	var result []Entry
+	add := func(kind, target, targetKind string, pos token.Pos, arguments ...string) {
+		position := pass.Fset.Position(pos)
+		result = append(result, Entry{
+			Kind:       kind,
+			Target:     target,
+			TargetKind: targetKind,
+			Package:    pass.Pkg.Path(),
+			Position:   Position{File: position.Filename, Line: position.Line, Column: position.Column},
+			Arguments:  arguments,
+		})
+	}
+	member := func(kind annotations.TestOnlyKind, receiver, name string) (string, string) {
+		switch {
+		case kind == annotations.TestOnlyOnType:
+			return name, TargetType
+		case receiver == "":
+			return name, TargetFunction
+		default:
+			return receiver + "." + name, TargetMethod
+		}
+	}
+	interfaceName := func(annot annotations.ImplementsAnnotation) string {
+		name := annot.InterfaceName
+		if annot.PackageName != "" {
+			name = annot.PackageName + "." + name
+		}
+		if annot.IsPointer {
+			name = "&" + name
+		}
+		return name
+	}
+
+	for _, annot := range ann.ImplementsAnnotations {
+		add("implements", annot.OnType, TargetType, annot.OnTypePos, interfaceName(annot))
+	}
+	for _, annot := range ann.ImplementsOneOfAnnotations {
+		var alternatives []string
+		for _, alternative := range annot.Alternatives {
+			alternatives = append(alternatives, interfaceName(alternative))
+		}
+		add("implements-oneof", annot.OnType, TargetType, annot.OnTypePos, alternatives...)
+	}
+	for _, annot := range ann.ImplementedByAnnotations {
+		var implementers []string
+		for _, implementer := range annot.Implementers {
+			implementers = append(implementers, implementer.Spelling)
+		}
+		add("implementedby", annot.OnInterface, TargetInterface, annot.OnInterfacePos, implementers...)
+	}
+	for _, annot := range ann.NotImplementsAnnotations {
+		name := annot.InterfaceName
+		if annot.PackageName != "" {
+			name = annot.PackageName + "." + name
+		}
+		if annot.IsPointer {
+			name = "&" + name
+		}
+		add("notimplements", annot.OnType, TargetType, annot.OnTypePos, name)
+	}
+	for _, annot := range ann.ConstructorAnnotations {
+		add("constructor", annot.OnType, TargetType, annot.OnTypePos, annot.ConstructorNames...)
+	}
+	for _, annot := range ann.ImmutableAnnotations {
+		add("immutable", annot.OnType, TargetType, annot.OnTypePos)
+	}
+	for _, annot := range ann.MutableAnnotations {
+		add("mutable", annot.OnType+"."+annot.FieldName, TargetField, annot.Pos)
+	}
+	for _, annot := range ann.TestonlyAnnotations {
+		target, targetKind := member(annot.Kind, annot.ReceiverType, annot.ObjectName)
+		add("testonly", target, targetKind, annot.Pos)
+	}
+	for _, annot := range ann.PackageOnlyAnnotations {
+		target, targetKind := member(annot.Kind, annot.ReceiverType, annot.ObjectName)
+		add("packageonly", target, targetKind, annot.Pos, annot.AllowedPackages...)
+	}
+	for _, annot := range ann.SinceAnnotations {
+		target, targetKind := member(annot.Kind, annot.ReceiverType, annot.ObjectName)
+		add("since", target, targetKind, annot.Pos, annot.Version)
+	}
+	for _, annot := range ann.EnumAnnotations {
+		add("enum", annot.OnType, TargetType, annot.OnTypePos)
+	}
+	for _, annot := range ann.RequiredAnnotations {
+		add("required", annot.OnType+"."+annot.FieldName, TargetField, annot.Pos)
+	}
+	for _, annot := range ann.SingletonAnnotations {
+		add("singleton", annot.OnType, TargetType, annot.OnTypePos)
+	}
+	for _, annot := range ann.MustReturnAnnotations {
+		target, targetKind := member(annot.Kind, annot.ReceiverType, annot.ObjectName)
+		add("mustreturn", target, targetKind, annot.Pos)
+	}
+	for _, annot := range ann.ExperimentalAnnotations {
+		target, targetKind := member(annot.Kind, annot.ReceiverType, annot.ObjectName)
+		add("experimental", target, targetKind, annot.Pos)
+	}
+	for _, annot := range ann.DeprecatedPackages {
+		var arguments []string
+		if annot.Replacement != "" {
+			arguments = append(arguments, annot.Replacement)
+		}
+		add("deprecated", pass.Pkg.Path(), TargetPackage, annot.Pos, arguments...)
+	}
+	return result
+}
+
+// Add keeps batch in the process-wide list and, when SinkEnv is set, appends
+// it to the sink file
+func Add(batch ...Entry) {
+	if len(batch) == 0 {
+		return
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	entries = append(entries, batch...)
+
+	path := os.Getenv(SinkEnv)
+	if path == "" {
+		return
+	}
+	var lines []byte
+	for _, entry := range batch {
+		line, err := json.Marshal(entry)
+		if err != nil {
+			return
+		}
+		lines = append(append(lines, line...), '\n')
+	}
+	sink, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return
+	}
+	defer sink.Close()
+	_, _ = sink.Write(lines)
+}
+
+// Total returns the entries recorded in this process
+func Total() []Entry {
+	mu.Lock()
+	defer mu.Unlock()
+
+	return Aggregate(entries)
+}
+
+// Reset clears the entries of this process
+func Reset() {
+	mu.Lock()
+	defer mu.Unlock()
+
+	entries = nil
+}
+
+// ReadSink aggregates the entries appended to the sink file at path
+func ReadSink(path string) ([]Entry, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var result []Entry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var entry Entry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return nil, fmt.Errorf("parse %s: %w", path, err)
+		}
+		result = append(result, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return Aggregate(result), nil
+}
+
+// Aggregate sorts entries by position and kind. Packages analyzed twice (as
+// test variants) record the same annotations twice; duplicates are dropped.
+func Aggregate(entries []Entry) []Entry {
+	result := slices.Clone(entries)
+	slices.SortStableFunc(result, compareEntries)
+	return slices.CompactFunc(result, func(a, b Entry) bool { return compareEntries(a, b) == 0 })
+}
+
+func compareEntries(a, b Entry) int {
+	return cmp.Or(
+		cmp.Compare(a.Position.File, b.Position.File),
+		cmp.Compare(a.Position.Line, b.Position.Line),
+		cmp.Compare(a.Position.Column, b.Position.Column),
+		cmp.Compare(a.Kind, b.Kind),
+		cmp.Compare(a.Target, b.Target),
+		slices.Compare(a.Arguments, b.Arguments),
+	)
+}
+
+// WriteJSON writes entries as an indented JSON array
+func WriteJSON(w io.Writer, entries []Entry) error {
+	if entries == nil {
+		entries = []Entry{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false) // "&io.Reader" stays readable
+	return encoder.Encode(entries)
+}
@@ -0,0 +1,46 @@
+package inventory
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/a14e/gogreement/src/annotations"
+	"github.com/a14e/gogreement/src/config"
+	"github.com/a14e/gogreement/src/testutil"
+)
+
+func TestCollect(t *testing.T) {
+	pass := testutil.CreateTestPass(t, "inventorytests")
+	ann := annotations.ReadAllAnnotations(config.Empty(), pass)
+
+	entries := Aggregate(Collect(pass, &ann))
+	for i := range entries {
+		assert.Equal(t, "inventorytests.go", filepath.Base(entries[i].Position.File))
+		entries[i].Position.File = ""
+	}
+
+	pkg := pass.Pkg.Path()
+	assert.Equal(t, []Entry{
+		{Kind: "constructor", Target: "Document", TargetKind: TargetType, Package: pkg,
+			Position: Position{Line: 9, Column: 6}, Arguments: []string{"NewDocument", "ParseDocument"}},
+		{Kind: "immutable", Target: "Document", TargetKind: TargetType, Package: pkg,
+			Position: Position{Line: 9, Column: 6}},
+		{Kind: "implements", Target: "Document", TargetKind: TargetType, Package: pkg,
+			Position: Position{Line: 9, Column: 6}, Arguments: []string{"&io.Reader"}},
+		{Kind: "mutable", Target: "Document.views", TargetKind: TargetField, Package: pkg,
+			Position: Position{Line: 13, Column: 2}},
+		{Kind: "testonly", Target: "Document.Reset", TargetKind: TargetMethod, Package: pkg,
+			Position: Position{Line: 32, Column: 1}},
+	}, entries)
+}
+
+func TestAggregateDropsDuplicates(t *testing.T) {
+	first := Entry{Kind: "immutable", Target: "A", Position: Position{File: "a.go", Line: 3}}
+	second := Entry{Kind: "constructor", Target: "A", Position: Position{File: "a.go", Line: 3}, Arguments: []string{"NewA"}}
+	earlier := Entry{Kind: "immutable", Target: "B", Position: Position{File: "a.go", Line: 1}}
+
+	assert.Equal(t, []Entry{earlier, second, first}, Aggregate([]Entry{first, second, earlier, first, second}),
+		"test variants of a package record their annotations twice")
+}
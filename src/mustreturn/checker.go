@@ -0,0 +1,116 @@
+package mustreturn
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/a14e/gogreement/src/annotations"
+	"github.com/a14e/gogreement/src/codes"
+	"github.com/a14e/gogreement/src/config"
+	"github.com/a14e/gogreement/src/indexing"
+	"github.com/a14e/gogreement/src/util"
+)
+
+// CheckMustReturn reports calls of @mustreturn functions and methods whose
+// result is discarded: calls used as statements or started by go and defer,
+// and calls whose results are all assigned or declared to the blank
+// identifier. The callee is resolved through type
+// information, so shadowing locals and method values of other types are not
+// mistaken for it.
+func CheckMustReturn(
+	cfg *config.Config,
+	pass *analysis.Pass,
+	packageAnnotations *annotations.PackageAnnotations,
+) []MustReturnViolation {
+	var violations []MustReturnViolation
+
+	mustReturn := indexing.BuildMustReturnIndex[*annotations.MustReturnCheckerFact](pass, packageAnnotations)
+	if mustReturn.Empty() {
+		return violations
+	}
+
+	for file := range cfg.FilterFilesForCheck(pass, codes.MustReturnCategoryPrefix) {
+		ast.Inspect(file, func(n ast.Node) bool {
+			var call *ast.CallExpr
+			switch stmt := n.(type) {
+			case *ast.ExprStmt:
+				call, _ = ast.Unparen(stmt.X).(*ast.CallExpr)
+			case *ast.AssignStmt:
+				if len(stmt.Rhs) == 1 && allBlank(stmt.Lhs) {
+					call, _ = ast.Unparen(stmt.Rhs[0]).(*ast.CallExpr)
+				}
+			case *ast.ValueSpec:
+				if len(stmt.Values) == 1 && allBlankNames(stmt.Names) {
+					call, _ = ast.Unparen(stmt.Values[0]).(*ast.CallExpr)
+				}
+			case *ast.GoStmt:
+				call = stmt.Call
+			case *ast.DeferStmt:
+				call = stmt.Call
+			}
+			if call == nil {
+				return true
+			}
+
+			fn := util.CalledFunction(pass.TypesInfo, call.Fun)
+			if fn == nil || fn.Pkg() == nil {
+				return true
+			}
+			fn = fn.Origin()
+
+			signature, ok := fn.Type().(*types.Signature)
+			if !ok || signature.Results().Len() == 0 {
+				return true
+			}
+
+			receiverType := ""
+			if recv := signature.Recv(); recv != nil {
+				typeInfo := util.ExtractTypeInfo(recv.Type())
+				if typeInfo == nil {
+					return true
+				}
+				receiverType = typeInfo.TypeName
+			}
+
+			pkgPath := fn.Pkg().Path()
+			if !mustReturn.Match(pkgPath, fn.Name(), receiverType) {
+				return true
+			}
+
+			violations = append(violations, MustReturnViolation{
+				FuncName:     fn.Name(),
+				ReceiverType: receiverType,
+				FuncPkgPath:  pkgPath,
+				Code:         codes.MustReturnResultDiscarded,
+				Pos:          call.Pos(),
+			})
+			return true
+		})
+	}
+
+	return violations
+}
+
+// allBlank reports whether every expression of lhs is the blank identifier
+func allBlank(lhs []ast.Expr) bool {
+	for _, expr := range lhs {
+		ident, ok := expr.(*ast.Ident)
+		if !ok || ident.Name != "_" {
+			return false
+		}
+	}
+	return true
+}
+
+// allBlankNames reports whether every name of a var declaration is the blank
+// identifier
+func allBlankNames(names []*ast.Ident) bool {
+	for _, name := range names {
+		if name.Name != "_" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,41 @@
+package mustreturn
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/a14e/gogreement/src/annotations"
+	"github.com/a14e/gogreement/src/codes"
+	"github.com/a14e/gogreement/src/config"
+	"github.com/a14e/gogreement/src/testutil/testfacts"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckMustReturn(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "mustreturntests")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+
+	assert.Len(t, packageAnnotations.MustReturnAnnotations, 3, "Normalize, Pair and Set.Add are @mustreturn")
+
+	violations := CheckMustReturn(cfg, pass, &packageAnnotations)
+
+	var found []string
+	for _, v := range violations {
+		assert.Equal(t, codes.MustReturnResultDiscarded, v.GetCode())
+		found = append(found, fmt.Sprintf("%d: %s", pass.Fset.Position(v.GetPos()).Line, v.GetMessage()))
+	}
+	assert.Equal(t, []string{
+		"34: result of function Normalize is discarded; it is marked @mustreturn and must be used",
+		"35: result of function Normalize is discarded; it is marked @mustreturn and must be used",
+		"36: result of function Pair is discarded; it is marked @mustreturn and must be used",
+		"37: result of method Add on Set is discarded; it is marked @mustreturn and must be used",
+		"38: result of method Add on Set is discarded; it is marked @mustreturn and must be used",
+		"55: result of function Normalize is discarded; it is marked @mustreturn and must be used",
+		"58: result of function Normalize is discarded; it is marked @mustreturn and must be used",
+		"59: result of method Add on Set is discarded; it is marked @mustreturn and must be used",
+		"60: result of function Normalize is discarded; it is marked @mustreturn and must be used",
+		"61: result of function Pair is discarded; it is marked @mustreturn and must be used",
+	}, found, "discarded, blank-assigned, blank-declared, go and defer results are reported; used results, other methods and shadowing locals are not")
+}
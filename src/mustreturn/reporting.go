@@ -0,0 +1,66 @@
+package mustreturn
+
+import (
+	"fmt"
+	"go/token"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/a14e/gogreement/src/config"
+	"github.com/a14e/gogreement/src/reporting"
+	"github.com/a14e/gogreement/src/trace"
+	"github.com/a14e/gogreement/src/util"
+)
+
+// MustReturnViolation represents a call of a @mustreturn function or method
+// whose result is discarded
+// @immutable
+// implements reporting.Violation
+// implements reporting.ContractViolation
+type MustReturnViolation struct {
+	FuncName     string
+	ReceiverType string // Receiver type name for methods, empty for functions
+	FuncPkgPath  string // Package path where the function is declared
+	Code         string // Error code from codes package
+	Pos          token.Pos
+}
+
+// GetCode returns the error code for this violation
+func (v MustReturnViolation) GetCode() string {
+	return v.Code
+}
+
+// GetPos returns the position of the violation
+func (v MustReturnViolation) GetPos() token.Pos {
+	return v.Pos
+}
+
+// GetMessage returns the main error message without formatting
+func (v MustReturnViolation) GetMessage() string {
+	if v.ReceiverType != "" {
+		return fmt.Sprintf("result of method %s on %s is discarded; it is marked @mustreturn and must be used",
+			v.FuncName, v.ReceiverType)
+	}
+	return fmt.Sprintf("result of function %s is discarded; it is marked @mustreturn and must be used", v.FuncName)
+}
+
+// GetContracts names the @mustreturn annotation of the called function
+func (v MustReturnViolation) GetContracts() []trace.Key {
+	name := v.FuncName
+	if v.ReceiverType != "" {
+		name = v.ReceiverType + "." + v.FuncName
+	}
+	return []trace.Key{{Kind: "mustreturn", Package: v.FuncPkgPath, Name: name}}
+}
+
+// ReportViolations reports mustreturn violations using the new pretty formatter
+func ReportViolations(cfg *config.Config, pass *analysis.Pass, violations []MustReturnViolation, ignoreSet *util.IgnoreSet) {
+	reporter := reporting.NewReporter(cfg, pass, ignoreSet)
+
+	// Convert to generic violations and report
+	generic := make([]reporting.Violation, 0, len(violations))
+	for _, violation := range violations {
+		generic = append(generic, violation)
+	}
+	reporter.ReportViolations(generic)
+}
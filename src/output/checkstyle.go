@@ -0,0 +1,220 @@
+// Package output converts the findings of an analysis run into report formats
+// consumed by CI tools.
+//
+// multichecker only prints text or its own JSON tree, so the gogreement command
+// runs the analysis in a child process with -json, reads the tree back with
+// ReadJSONTree and writes it in the requested format.
+package output
+
+import (
+	"cmp"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Finding is a single reported violation
+type Finding struct {
+	File     string
+	Line     int
+	Column   int
+	Code     string
+	Message  string
+	Severity string
+	// Owner is the team owning the finding, resolved from the owners file
+	Owner string
+	// Documentation is the URL documenting the rule of the finding, set by
+	// LinkDocumentation; empty for the pages of the book
+	Documentation string
+	// Related are secondary positions, such as the declaration of the
+	// annotated type
+	Related []RelatedLocation
+}
+
+// RelatedLocation is a secondary position of a finding
+type RelatedLocation struct {
+	File    string
+	Line    int
+	Column  int
+	Message string
+}
+
+// findingKey identifies a finding regardless of its related positions
+type findingKey struct {
+	File    string
+	Line    int
+	Column  int
+	Code    string
+	Message string
+}
+
+// jsonDiagnostic mirrors the diagnostic objects printed by multichecker -json
+type jsonDiagnostic struct {
+	Category string `json:"category"`
+	Posn     string `json:"posn"`
+	Message  string `json:"message"`
+	Related  []struct {
+		Posn    string `json:"posn"`
+		Message string `json:"message"`
+	} `json:"related"`
+}
+
+// ReadJSONTree parses the output of multichecker -json. It returns the
+// findings, sorted by position and without the duplicates reported for test
+// variants of a package, and the analysis errors the tree carries.
+func ReadJSONTree(r io.Reader) ([]Finding, []string, error) {
+	var tree map[string]map[string]json.RawMessage
+	if err := json.NewDecoder(r).Decode(&tree); err != nil {
+		if err == io.EOF {
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("parse analysis output: %w", err)
+	}
+
+	var findings []Finding
+	var analysisErrors []string
+	seen := make(map[findingKey]bool)
+
+	for pkgID, analyzers := range tree {
+		for analyzerName, raw := range analyzers {
+			var failure struct {
+				Error string `json:"error"`
+			}
+			if json.Unmarshal(raw, &failure) == nil {
+				if failure.Error != "" {
+					analysisErrors = append(analysisErrors, fmt.Sprintf("%s: %s: %s", pkgID, analyzerName, failure.Error))
+				}
+				continue
+			}
+
+			var diagnostics []jsonDiagnostic
+			if err := json.Unmarshal(raw, &diagnostics); err != nil {
+				return nil, nil, fmt.Errorf("parse diagnostics of %s: %w", pkgID, err)
+			}
+			for _, d := range diagnostics {
+				finding := toFinding(d)
+				key := findingKey{finding.File, finding.Line, finding.Column, finding.Code, finding.Message}
+				if !seen[key] {
+					seen[key] = true
+					findings = append(findings, finding)
+				}
+			}
+		}
+	}
+
+	sortFindings(findings)
+	slices.Sort(analysisErrors)
+
+	return findings, analysisErrors, nil
+}
+
+// sortFindings sorts findings by position, then code and message
+func sortFindings(findings []Finding) {
+	slices.SortFunc(findings, func(a, b Finding) int {
+		return cmp.Or(
+			cmp.Compare(a.File, b.File),
+			cmp.Compare(a.Line, b.Line),
+			cmp.Compare(a.Column, b.Column),
+			cmp.Compare(a.Code, b.Code),
+			cmp.Compare(a.Message, b.Message),
+		)
+	})
+}
+
+// toFinding converts a diagnostic. Positions are "file:line:col", and the
+// file itself may contain colons, so they are split from the right. The
+// message keeps only the headline of the pretty-printed error.
+func toFinding(d jsonDiagnostic) Finding {
+	finding := Finding{Code: d.Category}
+	finding.File, finding.Line, finding.Column = splitPosition(d.Posn)
+
+	for _, related := range d.Related {
+		location := RelatedLocation{Message: related.Message}
+		location.File, location.Line, location.Column = splitPosition(related.Posn)
+		finding.Related = append(finding.Related, location)
+	}
+
+	message, _, _ := strings.Cut(d.Message, "\n")
+	message = strings.TrimPrefix(message, "error: ")
+	if finding.Code != "" {
+		message = strings.TrimPrefix(message, "["+finding.Code+"] ")
+	}
+	finding.Message = strings.TrimSpace(message)
+
+	return finding
+}
+
+// splitPosition splits "file:line:col" into its parts; a missing line or
+// column is 0
+func splitPosition(posn string) (file string, line, column int) {
+	file = posn
+	if i := strings.LastIndexByte(file, ':'); i >= 0 {
+		if n, err := strconv.Atoi(file[i+1:]); err == nil {
+			column, file = n, file[:i]
+		}
+	}
+	if i := strings.LastIndexByte(file, ':'); i >= 0 {
+		if n, err := strconv.Atoi(file[i+1:]); err == nil {
+			line, file = n, file[:i]
+		}
+	}
+	return file, line, column
+}
+
+type checkstyleReport struct {
+	XMLName xml.Name         `xml:"checkstyle"`
+	Version string           `xml:"version,attr"`
+	Files   []checkstyleFile `xml:"file"`
+}
+
+type checkstyleFile struct {
+	Name   string            `xml:"name,attr"`
+	Errors []checkstyleError `xml:"error"`
+}
+
+type checkstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Column   int    `xml:"column,attr"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// WriteCheckstyle writes findings as a Checkstyle XML report, one <file>
+// element per file in order of first appearance. The check code becomes the
+// source of each <error>.
+func WriteCheckstyle(w io.Writer, findings []Finding) error {
+	report := checkstyleReport{Version: "4.3"}
+	fileIndex := make(map[string]int)
+
+	for _, finding := range findings {
+		i, ok := fileIndex[finding.File]
+		if !ok {
+			i = len(report.Files)
+			fileIndex[finding.File] = i
+			report.Files = append(report.Files, checkstyleFile{Name: finding.File})
+		}
+		report.Files[i].Errors = append(report.Files[i].Errors, checkstyleError{
+			Line:     finding.Line,
+			Column:   finding.Column,
+			Severity: cmp.Or(finding.Severity, "error"),
+			Message:  finding.Message,
+			Source:   finding.Code,
+		})
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
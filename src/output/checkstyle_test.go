@@ -0,0 +1,88 @@
+package output
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteCheckstyle(t *testing.T) {
+	findings := []Finding{
+		{File: "a/a.go", Line: 3, Column: 2, Code: "IMM01", Message: `cannot assign to field "x"`, Severity: "warning"},
+		{File: "b/b.go", Line: 7, Column: 1, Code: "CTOR01", Message: "use of <new> outside constructor"},
+		{File: "a/a.go", Line: 9, Column: 5, Code: "IMPL03", Message: "missing methods", Severity: "info"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteCheckstyle(&buf, findings))
+	assert.True(t, strings.HasPrefix(buf.String(), xml.Header))
+
+	var report struct {
+		XMLName xml.Name `xml:"checkstyle"`
+		Files   []struct {
+			Name   string `xml:"name,attr"`
+			Errors []struct {
+				Line     int    `xml:"line,attr"`
+				Column   int    `xml:"column,attr"`
+				Severity string `xml:"severity,attr"`
+				Message  string `xml:"message,attr"`
+				Source   string `xml:"source,attr"`
+			} `xml:"error"`
+		} `xml:"file"`
+	}
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &report), "report must be well-formed XML")
+
+	require.Len(t, report.Files, 2, "findings are grouped by file")
+	assert.Equal(t, "a/a.go", report.Files[0].Name)
+	assert.Equal(t, "b/b.go", report.Files[1].Name)
+
+	require.Len(t, report.Files[0].Errors, 2)
+	first := report.Files[0].Errors[0]
+	assert.Equal(t, 3, first.Line)
+	assert.Equal(t, 2, first.Column)
+	assert.Equal(t, "warning", first.Severity)
+	assert.Equal(t, `cannot assign to field "x"`, first.Message)
+	assert.Equal(t, "IMM01", first.Source)
+	assert.Equal(t, "IMPL03", report.Files[0].Errors[1].Source)
+
+	require.Len(t, report.Files[1].Errors, 1)
+	assert.Equal(t, "error", report.Files[1].Errors[0].Severity, "missing severity defaults to error")
+	assert.Equal(t, "use of <new> outside constructor", report.Files[1].Errors[0].Message)
+}
+
+func TestWriteCheckstyleEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteCheckstyle(&buf, nil))
+	assert.Contains(t, buf.String(), "<checkstyle")
+	assert.NotContains(t, buf.String(), "<file")
+}
+
+func TestReadJSONTree(t *testing.T) {
+	input := `{
+		"example.com/p": {
+			"ImmutableChecker": [
+				{"category": "IMM01", "posn": "C:/src/p/p.go:12:3", "message": "error: [IMM01] cannot assign\n  --> p.go:12:3\n"}
+			],
+			"ConstructorChecker": {"error": "boom"}
+		},
+		"example.com/p [example.com/p.test]": {
+			"ImmutableChecker": [
+				{"category": "IMM01", "posn": "C:/src/p/p.go:12:3", "message": "error: [IMM01] cannot assign\n  --> p.go:12:3\n"},
+				{"category": "", "posn": "C:/src/p/p.go:1:1", "message": "... and 3 more findings in this file"}
+			]
+		}
+	}`
+
+	findings, analysisErrors, err := ReadJSONTree(strings.NewReader(input))
+	require.NoError(t, err)
+
+	assert.Equal(t, []Finding{
+		{File: "C:/src/p/p.go", Line: 1, Column: 1, Message: "... and 3 more findings in this file"},
+		{File: "C:/src/p/p.go", Line: 12, Column: 3, Code: "IMM01", Message: "cannot assign"},
+	}, findings, "test variants must not duplicate findings")
+	assert.Equal(t, []string{"example.com/p: ConstructorChecker: boom"}, analysisErrors)
+}
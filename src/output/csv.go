@@ -0,0 +1,42 @@
+package output
+
+import (
+	"cmp"
+	"encoding/csv"
+	"io"
+	"strconv"
+
+	"github.com/a14e/gogreement/src/codes"
+)
+
+// csvHeader lists the columns written by WriteCSV
+var csvHeader = []string{"file", "line", "column", "code", "category", "severity", "message"}
+
+// WriteCSV writes findings as CSV with a header row and one row per finding,
+// for importing into spreadsheets or databases. Findings without a registered
+// code, such as per-file summary notes, have an empty category.
+func WriteCSV(w io.Writer, findings []Finding) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+
+	for _, finding := range findings {
+		_, category, _ := codes.Lookup(finding.Code)
+		row := []string{
+			finding.File,
+			strconv.Itoa(finding.Line),
+			strconv.Itoa(finding.Column),
+			finding.Code,
+			category,
+			cmp.Or(finding.Severity, "error"),
+			finding.Message,
+		}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
@@ -0,0 +1,29 @@
+package output
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteCSV(t *testing.T) {
+	findings := []Finding{
+		{File: "a/a.go", Line: 3, Column: 2, Code: "IMM01", Message: `cannot assign to field "x", use a copy`, Severity: "warning"},
+		{File: "a/a.go", Line: 40, Column: 1, Message: "... and 3 more findings in this file"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteCSV(&buf, findings))
+
+	assert.Equal(t, "file,line,column,code,category,severity,message\n"+
+		`a/a.go,3,2,IMM01,IMM,warning,"cannot assign to field ""x"", use a copy"`+"\n"+
+		"a/a.go,40,1,,,error,... and 3 more findings in this file\n", buf.String())
+}
+
+func TestWriteCSVEmpty(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteCSV(&buf, nil))
+	assert.Equal(t, "file,line,column,code,category,severity,message\n", buf.String())
+}
@@ -0,0 +1,26 @@
+package output
+
+import "github.com/a14e/gogreement/src/config"
+
+// FindingsExitCode is the exit code of a run with error findings. It matches
+// the exit code of the text output printed by the analysis driver.
+const FindingsExitCode = 3
+
+// ExitCode returns the exit code of a run that reported findings with their
+// severities set. Any error finding, or a finding without a severity, exits
+// with FindingsExitCode. Runs with only warning and info findings exit with
+// warningsExitCode, or FindingsExitCode when it is negative.
+func ExitCode(findings []Finding, warningsExitCode int) int {
+	if len(findings) == 0 {
+		return 0
+	}
+	for _, finding := range findings {
+		if finding.Severity != config.SeverityWarning && finding.Severity != config.SeverityInfo {
+			return FindingsExitCode
+		}
+	}
+	if warningsExitCode < 0 {
+		return FindingsExitCode
+	}
+	return warningsExitCode
+}
@@ -0,0 +1,24 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExitCode(t *testing.T) {
+	warning := Finding{Code: "IMM01", Severity: "warning"}
+	info := Finding{Code: "TONL01", Severity: "info"}
+	failure := Finding{Code: "CTOR01", Severity: "error"}
+	note := Finding{Message: "... and 3 more findings in this file"}
+
+	assert.Equal(t, 0, ExitCode(nil, 0), "no findings")
+	assert.Equal(t, 0, ExitCode(nil, 5), "no findings ignore the warnings exit code")
+
+	assert.Equal(t, 0, ExitCode([]Finding{warning, info}, 0), "warning-only run")
+	assert.Equal(t, 2, ExitCode([]Finding{warning}, 2), "configured warnings exit code")
+	assert.Equal(t, FindingsExitCode, ExitCode([]Finding{warning}, -1), "unset warnings exit code")
+
+	assert.Equal(t, FindingsExitCode, ExitCode([]Finding{warning, failure}, 0), "error finding")
+	assert.Equal(t, FindingsExitCode, ExitCode([]Finding{warning, note}, 0), "missing severity counts as error")
+}
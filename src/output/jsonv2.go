@@ -0,0 +1,92 @@
+package output
+
+import (
+	"cmp"
+	"encoding/json"
+	"io"
+
+	"github.com/a14e/gogreement/src/codes"
+)
+
+// JSONSchemaVersion is the version of the report written by WriteJSONV2
+const JSONSchemaVersion = 2
+
+type jsonReport struct {
+	Version  int           `json:"version"`
+	Findings []jsonFinding `json:"findings"`
+}
+
+type jsonFinding struct {
+	Code string `json:"code"`
+	// Category is the prefix the code belongs to, e.g. "IMM"
+	Category      string        `json:"category,omitempty"`
+	Severity      string        `json:"severity"`
+	Description   string        `json:"description,omitempty"`
+	Documentation string        `json:"documentation,omitempty"`
+	Message       string        `json:"message"`
+	Owner         string        `json:"owner,omitempty"`
+	Fingerprint   string        `json:"fingerprint"`
+	Location      jsonLocation  `json:"location"`
+	Related       []jsonRelated `json:"related,omitempty"`
+}
+
+type jsonLocation struct {
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+}
+
+type jsonRelated struct {
+	Location jsonLocation `json:"location"`
+	Message  string       `json:"message"`
+}
+
+// WriteJSONV2 writes findings as a versioned JSON report. Besides the message
+// and position, every finding carries the metadata of its rule from the codes
+// registry: category, description and documentation URL. Findings without a
+// registered code, such as per-file summary notes, have no rule metadata. The
+// fingerprint of each finding lets MergeFindings combine reports.
+func WriteJSONV2(w io.Writer, findings []Finding) error {
+	report := jsonReport{Version: JSONSchemaVersion, Findings: []jsonFinding{}}
+
+	for _, finding := range findings {
+		entry := jsonFinding{
+			Code:        finding.Code,
+			Severity:    cmp.Or(finding.Severity, "error"),
+			Message:     finding.Message,
+			Owner:       finding.Owner,
+			Fingerprint: Fingerprint(finding),
+			Location:    jsonLocation{File: finding.File, Line: finding.Line, Column: finding.Column},
+		}
+		if code, category, ok := codes.Lookup(finding.Code); ok {
+			entry.Category = category
+			entry.Description = code.Description
+			entry.Documentation = cmp.Or(finding.Documentation, codes.GetDocumentationURL(code.ID))
+		}
+		for _, related := range finding.Related {
+			entry.Related = append(entry.Related, jsonRelated{
+				Location: jsonLocation{File: related.File, Line: related.Line, Column: related.Column},
+				Message:  related.Message,
+			})
+		}
+		report.Findings = append(report.Findings, entry)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+// LinkDocumentation points the Documentation of every finding with a
+// registered code at "<baseURL>/<code>", for --docs-base-url. An empty
+// baseURL leaves the findings linking to the pages of the book.
+func LinkDocumentation(findings []Finding, baseURL string) {
+	if baseURL == "" {
+		return
+	}
+	for i := range findings {
+		if _, _, ok := codes.Lookup(findings[i].Code); ok {
+			findings[i].Documentation = codes.DocumentationURL(baseURL, findings[i].Code)
+		}
+	}
+}
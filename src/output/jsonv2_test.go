@@ -0,0 +1,121 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteJSONV2(t *testing.T) {
+	findings := []Finding{
+		{
+			File: "a/a.go", Line: 3, Column: 2, Code: "IMM01", Message: `cannot assign to field "x"`, Severity: "warning",
+			Related: []RelatedLocation{{File: "a/types.go", Line: 10, Column: 6, Message: `@immutable type "Config" is declared here`}},
+		},
+		{File: "a/a.go", Line: 40, Column: 1, Message: "... and 3 more findings in this file"},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteJSONV2(&buf, findings))
+
+	var report struct {
+		Version  int `json:"version"`
+		Findings []struct {
+			Code          string `json:"code"`
+			Category      string `json:"category"`
+			Severity      string `json:"severity"`
+			Description   string `json:"description"`
+			Documentation string `json:"documentation"`
+			Message       string `json:"message"`
+			Location      struct {
+				File   string `json:"file"`
+				Line   int    `json:"line"`
+				Column int    `json:"column"`
+			} `json:"location"`
+			Related []struct {
+				Location struct {
+					File string `json:"file"`
+					Line int    `json:"line"`
+				} `json:"location"`
+				Message string `json:"message"`
+			} `json:"related"`
+		} `json:"findings"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &report))
+
+	assert.Equal(t, JSONSchemaVersion, report.Version)
+	require.Len(t, report.Findings, 2)
+
+	immutable := report.Findings[0]
+	assert.Equal(t, "IMM01", immutable.Code)
+	assert.Equal(t, "IMM", immutable.Category)
+	assert.Equal(t, "Field of immutable type is being assigned", immutable.Description)
+	assert.Equal(t, "https://a14e.github.io/gogreement/02_02_immutable.html", immutable.Documentation)
+	assert.Equal(t, "warning", immutable.Severity)
+	assert.Equal(t, `cannot assign to field "x"`, immutable.Message)
+	assert.Equal(t, "a/a.go", immutable.Location.File)
+	assert.Equal(t, 3, immutable.Location.Line)
+	assert.Equal(t, 2, immutable.Location.Column)
+	require.Len(t, immutable.Related, 1)
+	assert.Equal(t, "a/types.go", immutable.Related[0].Location.File)
+	assert.Equal(t, 10, immutable.Related[0].Location.Line)
+	assert.Equal(t, `@immutable type "Config" is declared here`, immutable.Related[0].Message)
+
+	note := report.Findings[1]
+	assert.Empty(t, note.Category, "notes have no rule")
+	assert.Empty(t, note.Description)
+	assert.Equal(t, "error", note.Severity, "missing severity defaults to error")
+}
+
+func TestWriteJSONV2DocsBaseURL(t *testing.T) {
+	findings := []Finding{
+		{File: "a/a.go", Line: 3, Column: 2, Code: "IMM01", Message: `cannot assign to field "x"`},
+		{File: "a/a.go", Line: 40, Column: 1, Message: "... and 3 more findings in this file"},
+	}
+	LinkDocumentation(findings, "https://wiki.example.com/gogreement/")
+	assert.Empty(t, findings[1].Documentation, "findings without a registered code link nowhere")
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteJSONV2(&buf, findings))
+
+	var report struct {
+		Findings []struct {
+			Code          string `json:"code"`
+			Documentation string `json:"documentation"`
+		} `json:"findings"`
+	}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &report))
+	require.Len(t, report.Findings, 2)
+	assert.Equal(t, "https://wiki.example.com/gogreement/IMM01", report.Findings[0].Documentation)
+	assert.Empty(t, report.Findings[1].Documentation)
+}
+
+func TestWriteJSONV2Empty(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteJSONV2(&buf, nil))
+	assert.JSONEq(t, `{"version": 2, "findings": []}`, buf.String())
+}
+
+func TestReadJSONTreeRelated(t *testing.T) {
+	input := `{
+		"example.com/p": {
+			"ImmutableChecker": [
+				{
+					"category": "IMM01", "posn": "/src/p/p.go:12:3", "message": "error: [IMM01] cannot assign",
+					"related": [{"posn": "/src/q/q.go:4:6", "message": "@immutable type \"Config\" is declared here"}]
+				}
+			]
+		}
+	}`
+
+	findings, _, err := ReadJSONTree(strings.NewReader(input))
+	require.NoError(t, err)
+	require.Len(t, findings, 1)
+	assert.Equal(t, []RelatedLocation{
+		{File: "/src/q/q.go", Line: 4, Column: 6, Message: `@immutable type "Config" is declared here`},
+	}, findings[0].Related)
+}
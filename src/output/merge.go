@@ -0,0 +1,75 @@
+package output
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// Fingerprint identifies a finding across runs: a hash of its code, position
+// and message. Related locations, severity and owner are left out, they are
+// metadata of the finding. Paths should be rebased the same way on every run
+// (the default module path base does) so that shards agree.
+func Fingerprint(finding Finding) string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s\x00%s\x00%d\x00%d\x00%s", finding.Code, finding.File, finding.Line, finding.Column, finding.Message)
+	return hex.EncodeToString(hash.Sum(nil))[:16]
+}
+
+// ReadJSONV2 parses a report written by WriteJSONV2. The rule metadata of the
+// findings is not read back; WriteJSONV2 derives it from the codes again.
+func ReadJSONV2(r io.Reader) ([]Finding, error) {
+	var report jsonReport
+	if err := json.NewDecoder(r).Decode(&report); err != nil {
+		return nil, fmt.Errorf("parse json-v2 report: %w", err)
+	}
+	if report.Version != JSONSchemaVersion {
+		return nil, fmt.Errorf("unsupported report version %d, want %d", report.Version, JSONSchemaVersion)
+	}
+
+	findings := make([]Finding, 0, len(report.Findings))
+	for _, entry := range report.Findings {
+		finding := Finding{
+			File:     entry.Location.File,
+			Line:     entry.Location.Line,
+			Column:   entry.Location.Column,
+			Code:     entry.Code,
+			Message:  entry.Message,
+			Severity: entry.Severity,
+			Owner:    entry.Owner,
+		}
+		for _, related := range entry.Related {
+			finding.Related = append(finding.Related, RelatedLocation{
+				File:    related.Location.File,
+				Line:    related.Location.Line,
+				Column:  related.Location.Column,
+				Message: related.Message,
+			})
+		}
+		findings = append(findings, finding)
+	}
+	return findings, nil
+}
+
+// MergeFindings combines the findings of several reports, such as the shards
+// of a CI run, sorted by position. Findings with the same fingerprint are
+// reported once; the first report listing one provides its metadata.
+func MergeFindings(reports ...[]Finding) []Finding {
+	var merged []Finding
+	seen := make(map[string]bool)
+	for _, findings := range reports {
+		for _, finding := range findings {
+			fingerprint := Fingerprint(finding)
+			if seen[fingerprint] {
+				continue
+			}
+			seen[fingerprint] = true
+			merged = append(merged, finding)
+		}
+	}
+
+	sortFindings(merged)
+	return merged
+}
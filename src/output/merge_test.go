@@ -0,0 +1,55 @@
+package output
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeJSONV2Reports(t *testing.T) {
+	shared := Finding{
+		File: "a/a.go", Line: 3, Column: 2, Code: "IMM01", Message: `cannot assign to field "x"`, Severity: "warning",
+		Related: []RelatedLocation{{File: "a/types.go", Line: 10, Column: 6, Message: `@immutable type "Config" is declared here`}},
+	}
+	first := []Finding{shared, {File: "b/b.go", Line: 7, Column: 1, Code: "ENUM01", Message: "missing case"}}
+	second := []Finding{{File: "a/a.go", Line: 1, Column: 1, Code: "REQ01", Message: "missing field"}, shared}
+
+	var reports [][]Finding
+	for _, findings := range [][]Finding{first, second} {
+		var buf bytes.Buffer
+		require.NoError(t, WriteJSONV2(&buf, findings))
+		assert.Contains(t, buf.String(), `"fingerprint": "`+Fingerprint(shared)+`"`)
+
+		read, err := ReadJSONV2(&buf)
+		require.NoError(t, err)
+		reports = append(reports, read)
+	}
+
+	merged := MergeFindings(reports...)
+	require.Len(t, merged, 3, "the finding of both shards is kept once")
+	assert.Equal(t, "REQ01", merged[0].Code)
+	assert.Equal(t, shared, merged[1], "severity and related locations survive the round trip")
+	assert.Equal(t, "ENUM01", merged[2].Code)
+}
+
+func TestFingerprintIgnoresMetadata(t *testing.T) {
+	finding := Finding{File: "a/a.go", Line: 3, Column: 2, Code: "IMM01", Message: "m"}
+	annotated := finding
+	annotated.Severity = "warning"
+	annotated.Owner = "@team"
+
+	assert.Equal(t, Fingerprint(finding), Fingerprint(annotated))
+	assert.Len(t, Fingerprint(finding), 16)
+
+	moved := finding
+	moved.Line = 4
+	assert.NotEqual(t, Fingerprint(finding), Fingerprint(moved))
+}
+
+func TestReadJSONV2RejectsOtherVersions(t *testing.T) {
+	_, err := ReadJSONV2(strings.NewReader(`{"version": 1, "findings": []}`))
+	assert.ErrorContains(t, err, "unsupported report version")
+}
@@ -0,0 +1,64 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/a14e/gogreement/src/config"
+)
+
+// Owners routes findings to the teams owning them. The first rule matching a
+// finding names its owner.
+type Owners struct {
+	Rules []OwnerRule `json:"rules"`
+}
+
+// OwnerRule assigns Owner to the findings matching all of its criteria. Codes
+// holds codes ("IMM01") or categories ("IMM"), Paths holds path globs as in
+// checkScopes ("internal/billing/**"). A rule without criteria matches every
+// finding, which makes it a fallback when listed last.
+type OwnerRule struct {
+	Owner string   `json:"owner"`
+	Codes []string `json:"codes,omitempty"`
+	Paths []string `json:"paths,omitempty"`
+}
+
+// ReadOwnersFile reads an owners file:
+//
+//	{"rules": [{"owner": "payments", "paths": ["internal/billing/**"]}, {"owner": "platform", "codes": ["IMM"]}]}
+func ReadOwnersFile(path string) (Owners, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return Owners{}, err
+	}
+
+	var owners Owners
+	if err := json.Unmarshal(content, &owners); err != nil {
+		return Owners{}, fmt.Errorf("parse %s: %w", path, err)
+	}
+	return owners, nil
+}
+
+// Resolve returns the owner of a finding with the given code in file, or ""
+// when no rule matches
+func (o Owners) Resolve(code, file string) string {
+	category := strings.TrimRight(code, "0123456789")
+	for _, rule := range o.Rules {
+		if len(rule.Codes) > 0 && !slices.ContainsFunc(rule.Codes, func(c string) bool {
+			c = strings.TrimSpace(c)
+			return strings.EqualFold(c, code) || strings.EqualFold(c, category)
+		}) {
+			continue
+		}
+		if len(rule.Paths) > 0 && !slices.ContainsFunc(rule.Paths, func(glob string) bool {
+			return config.MatchPathGlob(glob, file)
+		}) {
+			continue
+		}
+		return rule.Owner
+	}
+	return ""
+}
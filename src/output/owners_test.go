@@ -0,0 +1,60 @@
+package output
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOwnersResolve(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "owners.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"rules": [
+		{"owner": "payments", "paths": ["internal/billing/**"]},
+		{"owner": "platform", "codes": ["IMM", "CTOR01"]},
+		{"owner": "api", "codes": ["IMPL"], "paths": ["api/**"]}
+	]}`), 0o644))
+
+	owners, err := ReadOwnersFile(path)
+	require.NoError(t, err)
+
+	assert.Equal(t, "payments", owners.Resolve("IMM01", "/src/internal/billing/invoice.go"), "the first matching rule wins")
+	assert.Equal(t, "platform", owners.Resolve("IMM03", "/src/internal/users/user.go"), "a category matches its codes")
+	assert.Equal(t, "platform", owners.Resolve("CTOR01", "/src/cmd/main.go"))
+	assert.Equal(t, "", owners.Resolve("CTOR02", "/src/cmd/main.go"))
+	assert.Equal(t, "api", owners.Resolve("IMPL03", "/src/api/handler.go"), "every criterion of a rule must match")
+	assert.Equal(t, "", owners.Resolve("IMPL03", "/src/cmd/handler.go"))
+
+	t.Run("json-v2 carries the owner", func(t *testing.T) {
+		findings := []Finding{
+			{File: "/src/internal/billing/invoice.go", Line: 1, Column: 1, Code: "IMM01", Message: "m"},
+			{File: "/src/cmd/main.go", Line: 1, Column: 1, Code: "TONL01", Message: "m"},
+		}
+		for i := range findings {
+			findings[i].Owner = owners.Resolve(findings[i].Code, findings[i].File)
+		}
+
+		var buf bytes.Buffer
+		require.NoError(t, WriteJSONV2(&buf, findings))
+
+		var report struct {
+			Findings []map[string]any `json:"findings"`
+		}
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &report))
+		require.Len(t, report.Findings, 2)
+		assert.Equal(t, "payments", report.Findings[0]["owner"])
+		assert.NotContains(t, report.Findings[1], "owner", "findings without an owner omit the field")
+	})
+}
+
+func TestReadOwnersFileInvalid(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "owners.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"rules": [`), 0o644))
+
+	_, err := ReadOwnersFile(path)
+	assert.ErrorContains(t, err, "parse "+path)
+}
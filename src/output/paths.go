@@ -0,0 +1,49 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FindModuleRoot returns the directory of the go.mod file in dir or the
+// nearest of its parents
+func FindModuleRoot(dir string) (string, bool) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", false
+	}
+	for {
+		if info, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil && !info.IsDir() {
+			return dir, true
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", false
+		}
+		dir = parent
+	}
+}
+
+// RebasePaths rewrites the file paths of findings and their related locations
+// relative to base, using forward slashes so reports are identical on every
+// machine. Paths outside base are left unchanged.
+func RebasePaths(findings []Finding, base string) {
+	for i := range findings {
+		findings[i].File = relativeTo(base, findings[i].File)
+		for j := range findings[i].Related {
+			findings[i].Related[j].File = relativeTo(base, findings[i].Related[j].File)
+		}
+	}
+}
+
+func relativeTo(base, file string) string {
+	if !filepath.IsAbs(file) {
+		return file
+	}
+	rel, err := filepath.Rel(base, file)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return file
+	}
+	return filepath.ToSlash(rel)
+}
@@ -0,0 +1,47 @@
+package output
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRebasePathsToModuleRoot(t *testing.T) {
+	root := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/m\n"), 0o644))
+	pkgDir := filepath.Join(root, "internal", "pkg")
+	require.NoError(t, os.MkdirAll(pkgDir, 0o755))
+
+	file := filepath.Join(pkgDir, "a.go")
+	outside := filepath.Join(t.TempDir(), "b.go")
+
+	for _, cwd := range []string{root, pkgDir} {
+		t.Run(cwd, func(t *testing.T) {
+			t.Chdir(cwd)
+
+			dir, err := os.Getwd()
+			require.NoError(t, err)
+			moduleRoot, ok := FindModuleRoot(dir)
+			require.True(t, ok)
+
+			findings := []Finding{
+				{File: file, Code: "IMM01", Related: []RelatedLocation{{File: file}, {File: outside}}},
+				{File: outside, Code: "IMM01"},
+			}
+			RebasePaths(findings, moduleRoot)
+
+			assert.Equal(t, "internal/pkg/a.go", findings[0].File, "module-relative whatever the working directory")
+			assert.Equal(t, "internal/pkg/a.go", findings[0].Related[0].File)
+			assert.Equal(t, outside, findings[0].Related[1].File, "paths outside the module stay absolute")
+			assert.Equal(t, outside, findings[1].File)
+		})
+	}
+}
+
+func TestFindModuleRootMissing(t *testing.T) {
+	_, ok := FindModuleRoot(string(filepath.Separator))
+	assert.False(t, ok)
+}
@@ -0,0 +1,40 @@
+package output
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/a14e/gogreement/src/config"
+)
+
+// ReporterFunc receives all findings of a run, after severities, owners and
+// path bases are applied, and writes them wherever it likes
+type ReporterFunc func(findings []Finding) error
+
+// reporters holds the reporters added by RegisterReporter by lowercase name
+var reporters sync.Map
+
+// RegisterReporter registers fn as the report printed for
+// --output-format=name, so tooling that builds its own gogreement binary can
+// add output sinks without patching the command. Names are case-insensitive.
+// It is meant to be called from an init function and panics when name is
+// empty, built in or already registered.
+func RegisterReporter(name string, fn ReporterFunc) {
+	if fn == nil {
+		panic("output: RegisterReporter with nil reporter")
+	}
+	if !config.RegisterOutputFormat(name) {
+		panic(fmt.Sprintf("output: output format %q is empty or already registered", name))
+	}
+	reporters.Store(strings.ToLower(strings.TrimSpace(name)), fn)
+}
+
+// LookupReporter returns the reporter registered for the output format name
+func LookupReporter(name string) (ReporterFunc, bool) {
+	fn, ok := reporters.Load(strings.ToLower(strings.TrimSpace(name)))
+	if !ok {
+		return nil, false
+	}
+	return fn.(ReporterFunc), true
+}
@@ -0,0 +1,45 @@
+package output
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/a14e/gogreement/src/config"
+)
+
+func TestRegisterReporter(t *testing.T) {
+	var received []Finding
+	RegisterReporter("Test-Collector", func(findings []Finding) error {
+		received = findings
+		return nil
+	})
+
+	fs := config.CreateFlagSet()
+	require.NoError(t, fs.Set("output-format", "test-collector"))
+	format := config.ParseFlagsFromFlagSet(fs).OutputFormat
+	assert.Equal(t, "test-collector", format, "registered formats are accepted by --output-format")
+
+	report, ok := LookupReporter(format)
+	require.True(t, ok)
+
+	findings := []Finding{
+		{File: "a/a.go", Line: 3, Column: 2, Code: "IMM01", Message: `cannot assign to field "x"`, Severity: "error"},
+		{File: "b/b.go", Line: 7, Column: 1, Code: "CTOR01", Message: "use the constructor", Owner: "platform"},
+	}
+	require.NoError(t, report(findings))
+	assert.Equal(t, findings, received)
+
+	t.Run("duplicate and built-in names panic", func(t *testing.T) {
+		noop := func([]Finding) error { return nil }
+		assert.Panics(t, func() { RegisterReporter("test-collector", noop) })
+		assert.Panics(t, func() { RegisterReporter("json-v2", noop) })
+		assert.Panics(t, func() { RegisterReporter(" ", noop) })
+	})
+
+	t.Run("unregistered formats have no reporter", func(t *testing.T) {
+		_, ok := LookupReporter("checkstyle")
+		assert.False(t, ok)
+	})
+}
@@ -31,8 +31,8 @@ func CheckPackageOnly(
 		return violations
 	}
 
-	// Check all files
-	filesToCheck := cfg.FilterFiles(pass)
+	// Check all files in scope
+	filesToCheck := cfg.FilterFilesForCheck(pass, codes.PackageOnlyCategoryPrefix)
 
 	context := packageOnlyContext{
 		pass:             pass,
@@ -57,6 +57,16 @@ func CheckPackageOnly(
 
 		ast.Inspect(file, func(n ast.Node) bool {
 			switch node := n.(type) {
+			case *ast.ValueSpec:
+				lhs := make([]ast.Expr, len(node.Names))
+				for i, name := range node.Names {
+					lhs[i] = name
+				}
+				violations = append(violations, findFunctionEscapes(&context, lhs, node.Values)...)
+
+			case *ast.AssignStmt:
+				violations = append(violations, findFunctionEscapes(&context, node.Lhs, node.Rhs)...)
+
 			case *ast.SelectorExpr:
 				selectorIdents[node.Sel] = true
 				if x, ok := node.X.(*ast.Ident); ok {
@@ -170,6 +180,114 @@ func findIdentViolation(
 	return nil
 }
 
+// findFunctionEscapes checks assignments of @packageonly function values to
+// exported package-level variables. Such a variable can be called from any
+// package, so it would silently lift the restriction. Only references that are
+// themselves allowed (same or allowed package) are checked here; forbidden
+// references are already reported as PKGO02.
+// Returns violations (already filtered by the ignore set)
+func findFunctionEscapes(
+	ctx *packageOnlyContext,
+	lhs []ast.Expr,
+	rhs []ast.Expr,
+) []PackageOnlyViolation {
+	var violations []PackageOnlyViolation
+
+	// Multi-value forms (a, b = f()) never carry a bare function value per target
+	if len(lhs) != len(rhs) {
+		return violations
+	}
+
+	for i, target := range lhs {
+		varName, ok := exportedPackageVar(ctx, target)
+		if !ok {
+			continue
+		}
+
+		fn := referencedFunction(ctx, rhs[i])
+		if fn == nil {
+			continue
+		}
+
+		pkgPath := fn.Pkg().Path()
+		funcName := fn.Name()
+		if !ctx.packageOnlyIndex.HasAnyFunctionAttachments(pkgPath, funcName) {
+			continue
+		}
+
+		isAllowed := pkgPath == ctx.currentPkgPath ||
+			ctx.packageOnlyIndex.HasPkgFunctionAttachment(pkgPath, funcName, ctx.currentPkgPath) ||
+			ctx.packageOnlyIndex.HasPkgFunctionAttachment(pkgPath, funcName, ctx.currentPkgName)
+		if !isAllowed {
+			continue
+		}
+
+		pos := rhs[i].Pos()
+		if ctx.ignoreSet.Contains(codes.PackageOnlyFunctionEscape, pos) {
+			continue
+		}
+
+		violations = append(violations, PackageOnlyViolation{
+			ItemName:        funcName,
+			ItemPkgPath:     pkgPath,
+			CurrentPkgPath:  ctx.currentPkgPath,
+			AllowedPackages: ctx.packageOnlyIndex.GetAttachmentsForFunction(pkgPath, funcName, pkgPath),
+			ExportedVar:     varName,
+			Pos:             pos,
+			Code:            codes.PackageOnlyFunctionEscape,
+		})
+	}
+
+	return violations
+}
+
+// exportedPackageVar returns the name of the exported package-level variable
+// denoted by expr (`Fn` or `pkg.Fn`)
+func exportedPackageVar(ctx *packageOnlyContext, expr ast.Expr) (string, bool) {
+	var ident *ast.Ident
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		ident = e
+	case *ast.SelectorExpr:
+		ident = e.Sel
+	default:
+		return "", false
+	}
+
+	v, ok := ctx.pass.TypesInfo.ObjectOf(ident).(*types.Var)
+	if !ok || v.Pkg() == nil || !v.Exported() || v.Parent() != v.Pkg().Scope() {
+		return "", false
+	}
+
+	return v.Name(), true
+}
+
+// referencedFunction returns the package-level function whose value expr takes
+// (`fn` or `pkg.Fn`, not a call), or nil
+func referencedFunction(ctx *packageOnlyContext, expr ast.Expr) *types.Func {
+	var ident *ast.Ident
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.Ident:
+		ident = e
+	case *ast.SelectorExpr:
+		ident = e.Sel
+	default:
+		return nil
+	}
+
+	fn, ok := ctx.pass.TypesInfo.ObjectOf(ident).(*types.Func)
+	if !ok || fn.Pkg() == nil {
+		return nil
+	}
+
+	// Method values (recv.M) are not covered by function attachments
+	if sig, ok := fn.Type().(*types.Signature); !ok || sig.Recv() != nil {
+		return nil
+	}
+
+	return fn
+}
+
 // findTypeViolation checks if a type usage violates @packageonly restrictions
 // Returns violation or nil
 func findTypeViolation(
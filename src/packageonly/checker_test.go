@@ -99,6 +99,38 @@ func TestCheckPackageOnly_AllowedListExcludesSource(t *testing.T) {
 	}
 }
 
+func TestCheckPackageOnly_FunctionValueEscape(t *testing.T) {
+	t.Run("exported variables in the defining package are flagged", func(t *testing.T) {
+		pass := testfacts.CreateTestPassWithFacts(t, "packageonlysource")
+		cfg := config.Empty()
+		packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+
+		violations := CheckPackageOnly(cfg, pass, &packageAnnotations, nil)
+
+		var escapedInto []string
+		for _, v := range violations {
+			t.Logf("Violation: %s", v.GetMessage())
+			assert.Equal(t, codes.PackageOnlyFunctionEscape, v.GetCode(), "only escapes are reported in the defining package")
+			assert.Equal(t, "PackageOnlyFunction", v.ItemName)
+			escapedInto = append(escapedInto, v.ExportedVar)
+		}
+
+		assert.ElementsMatch(t, []string{"ExportedFunctionRef", "ExportedAssignedLater"}, escapedInto)
+	})
+
+	t.Run("forbidden packages only report the reference", func(t *testing.T) {
+		pass := testfacts.CreateTestPassWithFacts(t, "packageonlyviolations", "packageonlysource")
+		cfg := config.Empty()
+		packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+
+		violations := CheckPackageOnly(cfg, pass, &packageAnnotations, nil)
+		for _, v := range violations {
+			assert.NotEqual(t, codes.PackageOnlyFunctionEscape, v.GetCode(),
+				"a forbidden reference is already PKGO02 and must not be reported twice")
+		}
+	})
+}
+
 func TestPackageOnlyViolation_GetCode(t *testing.T) {
 	tests := []struct {
 		name         string
@@ -166,6 +198,18 @@ func TestPackageOnlyViolation_GetMessage(t *testing.T) {
 			},
 			expectedSubstr: "MyStruct.MyMethod method is @packageonly",
 		},
+		{
+			name: "Function escape violation",
+			violation: PackageOnlyViolation{
+				ItemName:        "MyFunction",
+				ItemPkgPath:     "github.com/example/source",
+				CurrentPkgPath:  "github.com/example/source",
+				AllowedPackages: []string{"github.com/example/allowed"},
+				ExportedVar:     "MyFunctionRef",
+				Code:            codes.PackageOnlyFunctionEscape,
+			},
+			expectedSubstr: "cannot be stored in exported variable MyFunctionRef",
+		},
 	}
 
 	for _, tt := range tests {
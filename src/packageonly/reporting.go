@@ -7,12 +7,15 @@ import (
 	"golang.org/x/tools/go/analysis"
 
 	"github.com/a14e/gogreement/src/codes"
+	"github.com/a14e/gogreement/src/config"
 	"github.com/a14e/gogreement/src/reporting"
+	"github.com/a14e/gogreement/src/trace"
 )
 
 // PackageOnlyViolation represents a violation of @packageonly usage
 // @immutable
 // implements reporting.Violation
+// implements reporting.ContractViolation
 type PackageOnlyViolation struct {
 	Pos             token.Pos
 	ItemName        string   // Name of the @packageonly object being used
@@ -20,6 +23,7 @@ type PackageOnlyViolation struct {
 	CurrentPkgPath  string   // Current package path where the violation occurred
 	AllowedPackages []string // Allowed packages for this item
 	ReceiverType    string   // Receiver type for methods (empty for types/functions)
+	ExportedVar     string   // Exported variable the function value escapes into (PKGO04 only)
 	Code            string   // Error code for this violation
 }
 
@@ -45,18 +49,32 @@ func (v PackageOnlyViolation) GetMessage() string {
 	case codes.PackageOnlyFunctionCall:
 		return fmt.Sprintf("%s function is @packageonly and cannot be used from %s. Allowed packages: %s",
 			v.ItemName, v.CurrentPkgPath, fmt.Sprintf("%v", v.AllowedPackages))
+	case codes.PackageOnlyFunctionEscape:
+		return fmt.Sprintf("%s function is @packageonly and cannot be stored in exported variable %s, which any package can call. Allowed packages: %s",
+			v.ItemName, v.ExportedVar, fmt.Sprintf("%v", v.AllowedPackages))
 	default:
 		return fmt.Sprintf("%s is @packageonly and cannot be used from %s", v.ItemName, v.CurrentPkgPath)
 	}
 }
 
+// GetContracts names the @packageonly annotation of the used item
+func (v PackageOnlyViolation) GetContracts() []trace.Key {
+	name := v.ItemName
+	if v.ReceiverType != "" {
+		name = v.ReceiverType + "." + v.ItemName
+	}
+	return []trace.Key{{Kind: "packageonly", Package: v.ItemPkgPath, Name: name}}
+}
+
 // ReportViolations reports packageonly violations using the new pretty formatter
 // NOTE: violations should already be filtered by @ignore directives in CheckPackageOnly
-func ReportViolations(pass *analysis.Pass, violations []PackageOnlyViolation) {
-	reporter := reporting.NewReporter(pass, nil) // No ignore set needed, already filtered
+func ReportViolations(cfg *config.Config, pass *analysis.Pass, violations []PackageOnlyViolation) {
+	reporter := reporting.NewReporter(cfg, pass, nil) // No ignore set needed, already filtered
 
 	// Convert to generic violations and report
+	generic := make([]reporting.Violation, 0, len(violations))
 	for _, violation := range violations {
-		reporter.ReportViolation(violation)
+		generic = append(generic, violation)
 	}
+	reporter.ReportViolations(generic)
 }
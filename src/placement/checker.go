@@ -0,0 +1,104 @@
+package placement
+
+import (
+	"cmp"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/a14e/gogreement/src/annotations"
+	"github.com/a14e/gogreement/src/codes"
+	"github.com/a14e/gogreement/src/config"
+)
+
+// CheckPlacement reports the annotations of the current package that are
+// written on a kind of declaration they do not apply to, such as @implements
+// on a function or @mutable on a type. ReadAllAnnotations notes them while
+// reading; the checkers never see them, so without a report they would be
+// ignored silently.
+func CheckPlacement(
+	cfg *config.Config,
+	pass *analysis.Pass,
+	packageAnnotations *annotations.PackageAnnotations,
+) []PlacementViolation {
+	var violations []PlacementViolation
+
+	for _, annot := range packageAnnotations.MisplacedAnnotations {
+		violations = append(violations, PlacementViolation{
+			Annotation:  annot.Annotation,
+			Declaration: annot.Declaration,
+			BelongsOn:   annot.BelongsOn,
+			Code:        codes.AnnotationMisplaced,
+			Pos:         annot.Pos,
+		})
+	}
+
+	return violations
+}
+
+// CheckConflicts reports the types of the current package annotated with
+// both @implements and @notimplements for the same interface, once at each
+// of the two annotations. "@notimplements &I" denies that even *T implements
+// I and so contradicts every @implements I, while "@notimplements I" only
+// contradicts a claim about the value method set: "@implements &I" together
+// with "@notimplements I" says that *T implements I and T does not. The
+// alternatives of @implements-oneof claim nothing on their own and are not
+// compared. Unresolved interfaces are reported by the implements checker.
+func CheckConflicts(
+	cfg *config.Config,
+	pass *analysis.Pass,
+	packageAnnotations *annotations.PackageAnnotations,
+) []ConflictViolation {
+	var violations []ConflictViolation
+
+	for _, denied := range packageAnnotations.NotImplementsAnnotations {
+		if denied.PackageNotFound {
+			continue
+		}
+		for _, claimed := range packageAnnotations.ImplementsAnnotations {
+			if claimed.PackageNotFound || claimed.OnType != denied.OnType ||
+				claimed.PackageFullPath != denied.PackageFullPath || claimed.InterfaceName != denied.InterfaceName {
+				continue
+			}
+			if claimed.RequiresPointerMethodSet() && !denied.IsPointer {
+				continue
+			}
+
+			claimedPos := cmp.Or(claimed.TargetPos, claimed.OnTypePos)
+			claimedSpelling := spelling(claimed.IsPointer, claimed.PackageName, claimed.InterfaceName)
+			deniedSpelling := spelling(denied.IsPointer, denied.PackageName, denied.InterfaceName)
+
+			violations = append(violations,
+				ConflictViolation{
+					TypeName:   denied.OnType,
+					Annotation: "notimplements " + deniedSpelling,
+					Other:      "implements " + claimedSpelling,
+					OtherPos:   claimedPos,
+					Code:       codes.AnnotationConflict,
+					Pos:        denied.TargetPos,
+				},
+				ConflictViolation{
+					TypeName:   claimed.OnType,
+					Annotation: "implements " + claimedSpelling,
+					Other:      "notimplements " + deniedSpelling,
+					OtherPos:   denied.TargetPos,
+					Code:       codes.AnnotationConflict,
+					Pos:        claimedPos,
+				},
+			)
+		}
+	}
+
+	return violations
+}
+
+// spelling returns an interface as written in an annotation, e.g. "&io.Reader"
+func spelling(isPointer bool, packageName, interfaceName string) string {
+	name := interfaceName
+	if packageName != "" {
+		name = packageName + "." + name
+	}
+	if isPointer {
+		name = "&" + name
+	}
+	return name
+}
@@ -0,0 +1,70 @@
+package placement
+
+import (
+	"fmt"
+	"go/token"
+	"testing"
+
+	"github.com/a14e/gogreement/src/annotations"
+	"github.com/a14e/gogreement/src/codes"
+	"github.com/a14e/gogreement/src/config"
+	"github.com/a14e/gogreement/src/testutil/testfacts"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckPlacement(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "misplacedtests")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+
+	var messages []string
+	for _, v := range CheckPlacement(cfg, pass, &packageAnnotations) {
+		assert.Equal(t, codes.AnnotationMisplaced, v.GetCode())
+		messages = append(messages, v.GetMessage())
+	}
+
+	// The @ignore on Legacy is applied when reporting, so its @constructor is
+	// still noted here
+	assert.Equal(t, []string{
+		"@immutable has no effect on struct fields and is ignored; it belongs on types",
+		"@mutable has no effect on types and is ignored; it belongs on struct fields of @immutable types",
+		"@implementedby has no effect on types and is ignored; it belongs on interfaces",
+		"@immutable has no effect on functions and is ignored; it belongs on types",
+		"@implements has no effect on methods and is ignored; it belongs on types",
+		"@constructor has no effect on functions and is ignored; it belongs on types",
+	}, messages)
+
+	assert.Len(t, packageAnnotations.ImmutableAnnotations, 1, "only Settings is @immutable")
+	assert.Len(t, packageAnnotations.MutableAnnotations, 1, "the @mutable field is still read")
+	assert.Empty(t, packageAnnotations.ImplementedByAnnotations)
+}
+
+func TestCheckConflicts(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "conflicttests")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+
+	assert.Len(t, packageAnnotations.NotImplementsAnnotations, 4)
+
+	position := func(pos token.Pos) string {
+		p := pass.Fset.Position(pos)
+		return fmt.Sprintf("%d:%d", p.Line, p.Column)
+	}
+
+	var found []string
+	for _, v := range CheckConflicts(cfg, pass, &packageAnnotations) {
+		assert.Equal(t, codes.AnnotationConflict, v.GetCode())
+		related := v.GetRelated()
+		require.Len(t, related, 1)
+		found = append(found, fmt.Sprintf("%s %s (other at %s)", position(v.GetPos()), v.GetMessage(), position(related[0].Pos)))
+	}
+
+	assert.Equal(t, []string{
+		`7:19 @notimplements io.Reader on type "File" contradicts @implements io.Reader on the same type; remove one of them (other at 6:16)`,
+		`6:16 @implements io.Reader on type "File" contradicts @notimplements io.Reader on the same type; remove one of them (other at 7:19)`,
+		`21:19 @notimplements &io.Closer on type "Conn" contradicts @implements io.Closer on the same type; remove one of them (other at 20:16)`,
+		`20:16 @implements io.Closer on type "Conn" contradicts @notimplements &io.Closer on the same type; remove one of them (other at 21:19)`,
+	}, found, "both annotations of a contradicting pair are reported; a pointer-only claim with a value denial is consistent")
+}
@@ -0,0 +1,88 @@
+package placement
+
+import (
+	"fmt"
+	"go/token"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/a14e/gogreement/src/config"
+	"github.com/a14e/gogreement/src/reporting"
+	"github.com/a14e/gogreement/src/util"
+)
+
+// PlacementViolation represents an annotation on a kind of declaration it
+// does not apply to
+// @immutable
+// implements reporting.Violation
+type PlacementViolation struct {
+	Annotation  string // Annotation kind without "@", e.g. "implements"
+	Declaration string // Kind of declaration it is written on, e.g. "functions"
+	BelongsOn   string // Kinds of declarations it applies to, e.g. "types"
+	Code        string // Error code from codes package
+	Pos         token.Pos
+}
+
+// GetCode returns the error code for this violation
+func (v PlacementViolation) GetCode() string {
+	return v.Code
+}
+
+// GetPos returns the position of the violation
+func (v PlacementViolation) GetPos() token.Pos {
+	return v.Pos
+}
+
+// GetMessage returns the main error message without formatting
+func (v PlacementViolation) GetMessage() string {
+	return fmt.Sprintf("@%s has no effect on %s and is ignored; it belongs on %s",
+		v.Annotation, v.Declaration, v.BelongsOn)
+}
+
+// ConflictViolation represents one of two annotations of a type that
+// contradict each other, such as @implements io.Reader and
+// @notimplements io.Reader
+// @immutable
+// implements reporting.Violation
+// implements reporting.RelatedViolation
+type ConflictViolation struct {
+	TypeName   string
+	Annotation string // Annotation reported, without "@", e.g. "notimplements io.Reader"
+	Other      string // Annotation it contradicts, e.g. "implements io.Reader"
+	OtherPos   token.Pos
+	Code       string // Error code from codes package
+	Pos        token.Pos
+}
+
+// GetCode returns the error code for this violation
+func (v ConflictViolation) GetCode() string {
+	return v.Code
+}
+
+// GetPos returns the position of the violation
+func (v ConflictViolation) GetPos() token.Pos {
+	return v.Pos
+}
+
+// GetMessage returns the main error message without formatting
+func (v ConflictViolation) GetMessage() string {
+	return fmt.Sprintf("@%s on type %q contradicts @%s on the same type; remove one of them",
+		v.Annotation, v.TypeName, v.Other)
+}
+
+// GetRelated points at the contradicting annotation
+func (v ConflictViolation) GetRelated() []reporting.RelatedLocation {
+	if !v.OtherPos.IsValid() {
+		return nil
+	}
+	return []reporting.RelatedLocation{{
+		Pos:     v.OtherPos,
+		Message: fmt.Sprintf("@%s is written here", v.Other),
+	}}
+}
+
+// ReportViolations reports misplaced and conflicting annotations using the new pretty formatter
+func ReportViolations(cfg *config.Config, pass *analysis.Pass, violations []reporting.Violation, ignoreSet *util.IgnoreSet) {
+	reporter := reporting.NewReporter(cfg, pass, ignoreSet)
+	reporter.ReportViolations(violations)
+}
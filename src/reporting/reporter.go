@@ -2,13 +2,23 @@ package reporting
 
 import (
 	"bufio"
+	"cmp"
 	"fmt"
+	"go/ast"
 	"go/token"
+	"go/types"
+	"slices"
 	"strings"
+	"sync"
+	"sync/atomic"
 
 	"golang.org/x/tools/go/analysis"
 
+	"github.com/a14e/gogreement/src/annotations"
 	"github.com/a14e/gogreement/src/codes"
+	"github.com/a14e/gogreement/src/config"
+	"github.com/a14e/gogreement/src/stats"
+	"github.com/a14e/gogreement/src/trace"
 	"github.com/a14e/gogreement/src/util"
 )
 
@@ -30,40 +40,389 @@ type Violation interface {
 	GetMessage() string
 }
 
+// RelatedLocation is a secondary position of a violation, such as the
+// declaration of the annotated type
+type RelatedLocation struct {
+	Pos     token.Pos
+	Message string
+}
+
+// RelatedViolation is implemented by violations that point at further
+// positions. They are attached to the diagnostic as related information.
+type RelatedViolation interface {
+	Violation
+
+	// GetRelated returns the secondary positions of the violation
+	GetRelated() []RelatedLocation
+}
+
+// FixableViolation is implemented by violations that know how to fix the
+// code. Their fixes are attached to the diagnostic ahead of the @ignore fix.
+type FixableViolation interface {
+	Violation
+
+	// GetSuggestedFixes returns the fixes of the violation
+	GetSuggestedFixes() []analysis.SuggestedFix
+}
+
+// ContractViolation is implemented by violations that break known
+// annotations. --trace-contracts attributes their findings to them.
+type ContractViolation interface {
+	Violation
+
+	// GetContracts returns the keys of the broken annotations. An empty
+	// Package stands for the package being analyzed.
+	GetContracts() []trace.Key
+}
+
+// failFastReported is set once the first finding of a --fail-fast run was
+// reported. Analyzers of all packages share it, as multichecker runs them in
+// one process; ResetRun starts a new run.
+var failFastReported atomic.Bool
+
+// heldFindings holds, per package, the findings of a --max-findings-per-file
+// run until LimitFindings reports them. The checkers of a package run in no
+// fixed order, so the limit is applied once all of them are done, which
+// keeps the reported findings the same from run to run; ResetRun starts a
+// new run.
+var (
+	heldFindingsMu sync.Mutex
+	heldFindings   = make(map[*types.Package][]heldFinding)
+)
+
+// heldFinding is a finding held back for LimitFindings
+type heldFinding struct {
+	position   token.Position
+	message    string // Violation message, which orders findings at the same position
+	diagnostic analysis.Diagnostic
+}
+
+// ResetRun starts a new analysis run: the first finding of a --fail-fast run
+// and the findings held for --max-findings-per-file are forgotten, so
+// earlier runs in the same process do not affect it. The gogreement command
+// calls it before the analysis starts.
+func ResetRun() {
+	failFastReported.Store(false)
+
+	heldFindingsMu.Lock()
+	defer heldFindingsMu.Unlock()
+	clear(heldFindings)
+}
+
+// FailFastTriggered reports whether cfg asks to fail fast and a finding was
+// already reported, so the remaining checks can be skipped
+func FailFastTriggered(cfg *config.Config) bool {
+	return cfg != nil && cfg.FailFast && failFastReported.Load()
+}
+
 // Reporter handles violation reporting with pretty formatting
 type Reporter struct {
-	pass      *analysis.Pass
-	ignoreSet *util.IgnoreSet
-	lineCache map[string][]string // filename -> cached lines
+	pass           *analysis.Pass
+	ignoreSet      *util.IgnoreSet
+	suggestIgnores bool                // attach an "add @ignore" fix to each diagnostic
+	maxPerFile     int                 // findings reported per file by LimitFindings, 0 = unlimited
+	stats          bool                // count reported violations for --stats
+	trace          bool                // attribute reported violations to their annotations, for --trace-contracts
+	stable         bool                // headline-only messages in position order, for --stable-messages
+	tagTests       bool                // tag messages of findings in _test.go files, for --tag-test-violations
+	failFast       bool                // report only the first finding of the run, for --fail-fast
+	docsBaseURL    string              // base of the help links, for --docs-base-url
+	cfg            *config.Config      // consulted for check scopes, nil = unrestricted
+	lineCache      map[string][]string // filename -> cached lines
 }
 
-func NewReporter(pass *analysis.Pass, ignoreSet *util.IgnoreSet) *Reporter {
-	return &Reporter{
+// NewReporter creates a reporter for pass. cfg may be nil, in which case no
+// optional output (such as suggested fixes) is produced.
+func NewReporter(cfg *config.Config, pass *analysis.Pass, ignoreSet *util.IgnoreSet) *Reporter {
+	reporter := &Reporter{
 		pass:      pass,
 		ignoreSet: ignoreSet,
 		lineCache: make(map[string][]string),
 	}
+	if cfg != nil {
+		reporter.suggestIgnores = cfg.SuggestIgnores
+		reporter.maxPerFile = cfg.MaxFindingsPerFile
+		reporter.stats = cfg.Stats
+		reporter.trace = cfg.TraceContracts
+		reporter.stable = cfg.StableMessages
+		reporter.tagTests = cfg.TagTestViolations
+		reporter.failFast = cfg.FailFast
+		reporter.docsBaseURL = cfg.DocsBaseURL
+		reporter.cfg = cfg
+	}
+	return reporter
 }
 
+// ReportViolation reports violation unless it is ignored, like
+// ReportViolations
 func (r *Reporter) ReportViolation(violation Violation) {
+	r.ReportViolations([]Violation{violation})
+}
+
+// hidden reports whether violation is ignored, matches one of the ignored
+// message patterns, lies in an unexported declaration under --public-only or
+// is outside the check scope of its code. Checkers
+// already skip files outside the scope of their category; scopes given for
+// single codes are applied here.
+func (r *Reporter) hidden(violation Violation) bool {
 	if r.ignoreSet.Contains(violation.GetCode(), violation.GetPos()) {
+		return true
+	}
+	if r.cfg == nil {
+		return false
+	}
+	if r.cfg.IgnoresMessage(violation.GetMessage()) {
+		return true
+	}
+	if r.cfg.PublicOnly && r.inPrivateDeclaration(violation.GetPos()) {
+		return true
+	}
+	filename := r.pass.Fset.Position(violation.GetPos()).Filename
+	return !r.cfg.InCheckScope(config.ModuleRoot(r.pass), violation.GetCode(), filename)
+}
+
+// inPrivateDeclaration reports whether pos lies inside an unexported type,
+// function, method or package-level variable, or a method of an unexported
+// type, for --public-only
+func (r *Reporter) inPrivateDeclaration(pos token.Pos) bool {
+	for _, file := range r.pass.Files {
+		if pos < file.FileStart || pos > file.FileEnd {
+			continue
+		}
+		for _, decl := range file.Decls {
+			if pos < decl.Pos() || pos >= decl.End() {
+				continue
+			}
+			switch d := decl.(type) {
+			case *ast.FuncDecl:
+				receiverType := ""
+				if d.Recv != nil && len(d.Recv.List) > 0 {
+					receiverType = annotations.ExtractReceiverType(d.Recv.List[0].Type)
+				}
+				return !annotations.IsPublicDeclaration(d.Name.Name, receiverType)
+			case *ast.GenDecl:
+				for _, spec := range d.Specs {
+					if pos < spec.Pos() || pos >= spec.End() {
+						continue
+					}
+					switch s := spec.(type) {
+					case *ast.TypeSpec:
+						return !s.Name.IsExported()
+					case *ast.ValueSpec:
+						return !slices.ContainsFunc(s.Names, (*ast.Ident).IsExported)
+					}
+				}
+			}
+			return false
+		}
+		return false
+	}
+	return false
+}
+
+// recordStats counts violations per code for --stats, including findings later
+// folded into a per-file summary note
+func (r *Reporter) recordStats(violations []Violation) {
+	if !r.stats || len(violations) == 0 {
 		return
 	}
+	perCode := make(map[string]int)
+	for _, violation := range violations {
+		perCode[violation.GetCode()]++
+	}
+	stats.Record(stats.Counters{Violations: perCode})
+}
 
-	r.pass.Report(analysis.Diagnostic{
-		Pos:     violation.GetPos(),
-		Message: r.formatPrettyError(violation),
-	})
+// recordTrace attributes violations to the annotations they break for
+// --trace-contracts, including findings later folded into a per-file summary
+// note
+func (r *Reporter) recordTrace(violations []Violation) {
+	if !r.trace {
+		return
+	}
+	var records []trace.Record
+	for _, violation := range violations {
+		contract, ok := violation.(ContractViolation)
+		if !ok {
+			continue
+		}
+		position := r.pass.Fset.Position(violation.GetPos())
+		finding := trace.Finding{
+			Code:     violation.GetCode(),
+			Message:  violation.GetMessage(),
+			Position: trace.Position{File: position.Filename, Line: position.Line, Column: position.Column},
+		}
+		for _, key := range contract.GetContracts() {
+			if key.Package == "" {
+				key.Package = r.pass.Pkg.Path()
+			}
+			records = append(records, trace.Record{Key: key, Finding: &finding})
+		}
+	}
+	trace.Add(records...)
+}
+
+// report emits the diagnostic for violation without consulting the ignore set.
+// With fail-fast only the first finding of the run gets through.
+func (r *Reporter) report(violation Violation) {
+	if r.failFast && !failFastReported.CompareAndSwap(false, true) {
+		return
+	}
+	r.pass.Report(r.diagnostic(violation))
+}
+
+// diagnostic builds the diagnostic reported for violation
+func (r *Reporter) diagnostic(violation Violation) analysis.Diagnostic {
+	var fixes []analysis.SuggestedFix
+	if fixable, ok := violation.(FixableViolation); ok {
+		fixes = append(fixes, fixable.GetSuggestedFixes()...)
+	}
+	if r.suggestIgnores {
+		if fix, ok := r.suggestIgnore(violation); ok {
+			fixes = append(fixes, fix)
+		}
+	}
+
+	var related []analysis.RelatedInformation
+	if withRelated, ok := violation.(RelatedViolation); ok {
+		for _, location := range withRelated.GetRelated() {
+			related = append(related, analysis.RelatedInformation{Pos: location.Pos, Message: location.Message})
+		}
+	}
+
+	return analysis.Diagnostic{
+		Pos:            violation.GetPos(),
+		Category:       violation.GetCode(),
+		Message:        r.formatPrettyError(violation),
+		SuggestedFixes: fixes,
+		Related:        related,
+	}
 }
 
+// ReportViolations reports violations that are not ignored. With a
+// per-file limit they are held back instead, and LimitFindings reports the
+// first findings of each file once every checker of the package is done.
+// Ignored violations never count towards the limit.
 func (r *Reporter) ReportViolations(violations []Violation) {
+	var visible []Violation
 	for _, violation := range violations {
-		r.ReportViolation(violation)
+		if !r.hidden(violation) {
+			visible = append(visible, violation)
+		}
+	}
+	r.recordStats(visible)
+	r.recordTrace(visible)
+
+	if r.failFast {
+		// The first finding in source order is the one worth fixing first;
+		// there is nothing left to summarize after it
+		slices.SortStableFunc(visible, func(a, b Violation) int {
+			return cmp.Compare(a.GetPos(), b.GetPos())
+		})
+		if len(visible) > 0 {
+			r.report(visible[0])
+		}
+		return
+	}
+
+	if r.stable {
+		// Checkers collect violations in map order in places; make the output
+		// independent of it
+		slices.SortStableFunc(visible, func(a, b Violation) int {
+			return cmp.Or(
+				cmp.Compare(a.GetPos(), b.GetPos()),
+				cmp.Compare(a.GetCode(), b.GetCode()),
+				cmp.Compare(a.GetMessage(), b.GetMessage()),
+			)
+		})
+	}
+
+	if r.maxPerFile <= 0 {
+		for _, violation := range visible {
+			r.report(violation)
+		}
+		return
+	}
+
+	held := make([]heldFinding, 0, len(visible))
+	for _, violation := range visible {
+		held = append(held, heldFinding{
+			position:   r.pass.Fset.Position(violation.GetPos()),
+			message:    violation.GetMessage(),
+			diagnostic: r.diagnostic(violation),
+		})
+	}
+
+	heldFindingsMu.Lock()
+	defer heldFindingsMu.Unlock()
+	heldFindings[r.pass.Pkg] = append(heldFindings[r.pass.Pkg], held...)
+}
+
+// LimitFindings reports the findings the checkers of the package of pass held
+// back for --max-findings-per-file: the first maxPerFile findings of each file
+// by position, then code and message, and one note at the first omitted
+// finding of a file saying how many more there are. The note has no code; it
+// is not a finding and the report formats leave it out. It must run after
+// every checker of the package.
+func LimitFindings(pass *analysis.Pass, maxPerFile int) {
+	heldFindingsMu.Lock()
+	held := heldFindings[pass.Pkg]
+	delete(heldFindings, pass.Pkg)
+	heldFindingsMu.Unlock()
+
+	slices.SortFunc(held, func(a, b heldFinding) int {
+		return cmp.Or(
+			cmp.Compare(a.position.Filename, b.position.Filename),
+			cmp.Compare(a.position.Offset, b.position.Offset),
+			cmp.Compare(a.diagnostic.Category, b.diagnostic.Category),
+			cmp.Compare(a.message, b.message),
+		)
+	})
+	held = slices.CompactFunc(held, func(a, b heldFinding) bool {
+		return a.position == b.position && a.diagnostic.Category == b.diagnostic.Category && a.message == b.message
+	})
+
+	for i := 0; i < len(held); {
+		end := i
+		for end < len(held) && held[end].position.Filename == held[i].position.Filename {
+			end++
+		}
+		file := held[i:end]
+		i = end
+
+		for _, finding := range file[:min(maxPerFile, len(file))] {
+			pass.Report(finding.diagnostic)
+		}
+		if omitted := len(file) - maxPerFile; omitted > 0 {
+			findings := "findings"
+			if omitted == 1 {
+				findings = "finding"
+			}
+			pass.Report(analysis.Diagnostic{
+				Pos:     file[maxPerFile].diagnostic.Pos,
+				Message: fmt.Sprintf("... and %d more %s in this file", omitted, findings),
+			})
+		}
 	}
 }
 
-// formatPrettyError formats an error with pretty borders and help
+// DiscardFindings forgets the findings held back for pkg without reporting
+// them, for packages LimitFindings never runs on, such as the dependencies a
+// driver only analyzes for their facts
+func DiscardFindings(pkg *types.Package) {
+	heldFindingsMu.Lock()
+	defer heldFindingsMu.Unlock()
+	delete(heldFindings, pkg)
+}
+
+// formatPrettyError formats an error with pretty borders and help. With
+// stable messages only the headline is kept: line numbers and source lines
+// change with unrelated edits, which makes golden files noisy.
 func (r *Reporter) formatPrettyError(violation Violation) string {
+	if r.stable {
+		return "error: [" + violation.GetCode() + "] " + r.message(violation)
+	}
+
 	position := r.pass.Fset.Position(violation.GetPos())
 
 	lines := r.readSourceLines(position.Filename, position.Line, 2, 1) // 2 lines before, 1 line after
@@ -75,7 +434,7 @@ func (r *Reporter) formatPrettyError(violation Violation) string {
 	builder.WriteString("[")
 	builder.WriteString(violation.GetCode())
 	builder.WriteString("] ")
-	builder.WriteString(violation.GetMessage())
+	builder.WriteString(r.message(violation))
 	builder.WriteString("\n")
 
 	// Source code context
@@ -125,13 +484,22 @@ func (r *Reporter) formatPrettyError(violation Violation) string {
 		builder.WriteString(strings.Repeat(" ", lineNumWidth))
 		builder.WriteString(" |\n")
 		builder.WriteString("   = help: ")
-		builder.WriteString(codes.GetDocumentationURL(violation.GetCode()))
+		builder.WriteString(codes.DocumentationURL(r.docsBaseURL, violation.GetCode()))
 		builder.WriteString("\n")
 	}
 
 	return builder.String()
 }
 
+// message returns the message of violation, tagged with [test] when the
+// violation is in a _test.go file and --tag-test-violations is set
+func (r *Reporter) message(violation Violation) string {
+	if r.tagTests && strings.HasSuffix(r.pass.Fset.Position(violation.GetPos()).Filename, "_test.go") {
+		return "[test] " + violation.GetMessage()
+	}
+	return violation.GetMessage()
+}
+
 // sourceLines represents a set of source lines with their numbers
 type sourceLines struct {
 	content     []string
@@ -1,10 +1,14 @@
 package reporting
 
 import (
+	"go/ast"
+	"go/parser"
 	"go/token"
 	"strings"
 	"testing"
 
+	"github.com/a14e/gogreement/src/config"
+	"github.com/a14e/gogreement/src/ignore"
 	"github.com/a14e/gogreement/src/util"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -20,7 +24,7 @@ func TestGetFileLinesLongLine(t *testing.T) {
 	pass := &analysis.Pass{
 		ReadFile: func(string) ([]byte, error) { return []byte(content), nil },
 	}
-	r := NewReporter(pass, nil)
+	r := NewReporter(nil, pass, nil)
 
 	lines := r.getFileLines("fake.go")
 	require.GreaterOrEqual(t, len(lines), 3, "all lines must be read, not truncated at the long line")
@@ -51,7 +55,7 @@ func TestNewReporter(t *testing.T) {
 	pass := &analysis.Pass{}
 	ignoreSet := &util.IgnoreSet{}
 
-	reporter := NewReporter(pass, ignoreSet)
+	reporter := NewReporter(nil, pass, ignoreSet)
 
 	assert.NotNil(t, reporter)
 	assert.Equal(t, pass, reporter.pass)
@@ -172,8 +176,390 @@ func TestReportViolations(t *testing.T) {
 		ignoreSet := &util.IgnoreSet{}
 		violations := []Violation{}
 
-		reporter := NewReporter(pass, ignoreSet)
+		reporter := NewReporter(nil, pass, ignoreSet)
 		// Should not panic
 		reporter.ReportViolations(violations)
 	})
 }
+
+func TestReportViolationsMaxFindingsPerFile(t *testing.T) {
+	const src = `package p
+
+func F(a, b, c, d, e, f *int) {
+	*a = 1
+	*b = 2 // @ignore IMM01
+	*c = 3
+	*d = 4
+	*e = 5
+	*f = 6
+}
+`
+	var diagnostics []analysis.Diagnostic
+	pass := parseSuggestPass(t, src, func(d analysis.Diagnostic) { diagnostics = append(diagnostics, d) })
+	ignoreSet := ignore.ReadIgnoreAnnotations(config.Empty(), pass)
+
+	// Reported out of source order: the cap keeps the first findings by position
+	var violations []Violation
+	for _, stmt := range []string{"*f = 6", "*e = 5", "*d = 4", "*c = 3", "*b = 2", "*a = 1"} {
+		violations = append(violations, MockViolation{code: "IMM01", pos: posOf(t, pass, src, stmt), message: stmt})
+	}
+
+	ResetRun()
+	t.Cleanup(ResetRun)
+
+	cfg := config.Empty().WithMaxFindingsPerFile(2)
+	NewReporter(cfg, pass, ignoreSet).ReportViolations(violations)
+	assert.Empty(t, diagnostics, "findings are held until every checker is done")
+
+	LimitFindings(pass, 2)
+	require.Len(t, diagnostics, 3, "two findings plus the truncation note")
+	assert.Contains(t, diagnostics[0].Message, "*a = 1")
+	assert.Contains(t, diagnostics[1].Message, "*c = 3", "the ignored *b must not count towards the cap")
+	assert.Equal(t, "... and 3 more findings in this file", diagnostics[2].Message)
+	assert.Empty(t, diagnostics[2].Category, "the note is not a finding")
+	assert.Equal(t, posOf(t, pass, src, "*d = 4"), diagnostics[2].Pos, "the note points at the first omitted finding")
+
+	diagnostics = nil
+	LimitFindings(pass, 2)
+	assert.Empty(t, diagnostics, "held findings are reported once")
+
+	t.Run("counted across analyzers in any order", func(t *testing.T) {
+		// Two analyzers with three findings each in the same file
+		var ctorViolations []Violation
+		for _, stmt := range []string{"*d = 4", "*e = 5", "*f = 6"} {
+			ctorViolations = append(ctorViolations, MockViolation{code: "CTOR01", pos: posOf(t, pass, src, stmt), message: stmt})
+		}
+		cfg := config.Empty().WithMaxFindingsPerFile(2)
+
+		var runs [][]string
+		for _, order := range [][][]Violation{{violations[:3], ctorViolations}, {ctorViolations, violations[:3]}} {
+			diagnostics = nil
+			for _, reported := range order {
+				NewReporter(cfg, pass, ignoreSet).ReportViolations(reported)
+			}
+			LimitFindings(pass, 2)
+
+			var messages []string
+			for _, d := range diagnostics {
+				headline, _, _ := strings.Cut(d.Message, "\n")
+				messages = append(messages, headline)
+			}
+			runs = append(runs, messages)
+		}
+
+		assert.Equal(t, []string{
+			"error: [CTOR01] *d = 4",
+			"error: [IMM01] *d = 4",
+			"... and 4 more findings in this file",
+		}, runs[0], "one cap for the file, ordered by position and code")
+		assert.Equal(t, runs[0], runs[1], "the order analyzers run in does not matter")
+	})
+
+	t.Run("single violations are held too", func(t *testing.T) {
+		diagnostics = nil
+		reporter := NewReporter(config.Empty().WithMaxFindingsPerFile(1), pass, ignoreSet)
+		reporter.ReportViolation(violations[1])
+		reporter.ReportViolation(violations[0])
+		reporter.ReportViolation(violations[0])
+		assert.Empty(t, diagnostics)
+
+		LimitFindings(pass, 1)
+		require.Len(t, diagnostics, 2, "a finding reported twice counts once")
+		assert.Contains(t, diagnostics[0].Message, "*e = 5")
+		assert.Equal(t, "... and 1 more finding in this file", diagnostics[1].Message)
+	})
+
+	t.Run("discarded findings are never reported", func(t *testing.T) {
+		diagnostics = nil
+		NewReporter(cfg, pass, ignoreSet).ReportViolations(violations)
+		DiscardFindings(pass.Pkg)
+
+		LimitFindings(pass, 2)
+		assert.Empty(t, diagnostics)
+	})
+
+	t.Run("unlimited by default", func(t *testing.T) {
+		diagnostics = nil
+		NewReporter(config.Empty(), pass, ignoreSet).ReportViolations(violations)
+		assert.Len(t, diagnostics, 5)
+	})
+}
+
+func TestReportViolationsStableMessages(t *testing.T) {
+	const src = `package p
+
+func F(a, b *int) {
+	*a = 1
+	*b = 2
+}
+`
+	var diagnostics []analysis.Diagnostic
+	pass := parseSuggestPass(t, src, func(d analysis.Diagnostic) { diagnostics = append(diagnostics, d) })
+
+	violations := []Violation{
+		MockViolation{code: "IMM01", pos: posOf(t, pass, src, "*b = 2"), message: "second"},
+		MockViolation{code: "IMM02", pos: posOf(t, pass, src, "*a = 1"), message: "first, other code"},
+		MockViolation{code: "IMM01", pos: posOf(t, pass, src, "*a = 1"), message: "first"},
+	}
+
+	cfg := config.Empty().WithStableMessages(true)
+	NewReporter(cfg, pass, nil).ReportViolations(violations)
+
+	var messages []string
+	for _, d := range diagnostics {
+		messages = append(messages, d.Message)
+	}
+	assert.Equal(t, []string{
+		"error: [IMM01] first",
+		"error: [IMM02] first, other code",
+		"error: [IMM01] second",
+	}, messages, "headlines only, ordered by position, then code")
+
+	t.Run("pretty by default", func(t *testing.T) {
+		diagnostics = nil
+		NewReporter(config.Empty(), pass, nil).ReportViolations(violations[:1])
+		require.Len(t, diagnostics, 1)
+		assert.Contains(t, diagnostics[0].Message, "5 | \t*b = 2")
+	})
+}
+
+func TestReportViolationsDocsBaseURL(t *testing.T) {
+	const src = `package p
+
+func F(a *int) {
+	*a = 1
+}
+`
+	var diagnostics []analysis.Diagnostic
+	pass := parseSuggestPass(t, src, func(d analysis.Diagnostic) { diagnostics = append(diagnostics, d) })
+	violations := []Violation{MockViolation{code: "IMM01", pos: posOf(t, pass, src, "*a = 1"), message: "first"}}
+
+	NewReporter(config.Empty().WithDocsBaseURL("https://wiki.example.com/gogreement"), pass, nil).ReportViolations(violations)
+	require.Len(t, diagnostics, 1)
+	assert.Contains(t, diagnostics[0].Message, "= help: https://wiki.example.com/gogreement/IMM01\n")
+
+	diagnostics = nil
+	NewReporter(config.Empty(), pass, nil).ReportViolations(violations)
+	require.Len(t, diagnostics, 1)
+	assert.Contains(t, diagnostics[0].Message, "= help: https://a14e.github.io/gogreement/02_02_immutable.html\n")
+}
+
+func TestTagTestViolations(t *testing.T) {
+	const src = `package p
+
+func F(a *int) {
+	*a = 1
+}
+`
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, name := range []string{"p.go", "p_test.go"} {
+		file, err := parser.ParseFile(fset, name, src, parser.ParseComments)
+		require.NoError(t, err)
+		files = append(files, file)
+	}
+
+	var diagnostics []analysis.Diagnostic
+	pass := &analysis.Pass{
+		Fset:     fset,
+		Files:    files,
+		ReadFile: func(string) ([]byte, error) { return []byte(src), nil },
+		Report:   func(d analysis.Diagnostic) { diagnostics = append(diagnostics, d) },
+	}
+	offset := token.Pos(strings.Index(src, "*a = 1"))
+	violations := []Violation{
+		MockViolation{code: "IMM01", pos: files[0].FileStart + offset, message: "production"},
+		MockViolation{code: "IMM01", pos: files[1].FileStart + offset, message: "test"},
+	}
+
+	cfg := config.Empty().WithScanTests(true).WithStableMessages(true).WithTagTestViolations(true)
+	NewReporter(cfg, pass, nil).ReportViolations(violations)
+
+	var messages []string
+	for _, d := range diagnostics {
+		messages = append(messages, d.Message)
+	}
+	assert.Equal(t, []string{"error: [IMM01] production", "error: [IMM01] [test] test"}, messages)
+
+	t.Run("pretty messages are tagged too", func(t *testing.T) {
+		diagnostics = nil
+		NewReporter(config.Empty().WithTagTestViolations(true), pass, nil).ReportViolations(violations[1:])
+		require.Len(t, diagnostics, 1)
+		assert.True(t, strings.HasPrefix(diagnostics[0].Message, "error: [IMM01] [test] test\n"))
+	})
+}
+
+// relatedViolation points at a second position
+type relatedViolation struct {
+	MockViolation
+	related []RelatedLocation
+}
+
+func (v relatedViolation) GetRelated() []RelatedLocation {
+	return v.related
+}
+
+func TestReportViolationsRelated(t *testing.T) {
+	const src = `package p
+
+type T struct{ x int }
+
+func F(t *T) {
+	t.x = 1
+}
+`
+	var diagnostics []analysis.Diagnostic
+	pass := parseSuggestPass(t, src, func(d analysis.Diagnostic) { diagnostics = append(diagnostics, d) })
+
+	declared := posOf(t, pass, src, "T struct")
+	NewReporter(config.Empty(), pass, nil).ReportViolations([]Violation{
+		relatedViolation{
+			MockViolation: MockViolation{code: "IMM01", pos: posOf(t, pass, src, "t.x = 1"), message: "write"},
+			related:       []RelatedLocation{{Pos: declared, Message: "declared here"}},
+		},
+		MockViolation{code: "IMM01", pos: posOf(t, pass, src, "t.x = 1"), message: "plain"},
+	})
+
+	require.Len(t, diagnostics, 2)
+	assert.Equal(t, []analysis.RelatedInformation{{Pos: declared, Message: "declared here"}}, diagnostics[0].Related)
+	assert.Empty(t, diagnostics[1].Related, "plain violations have no related information")
+}
+
+func TestReportViolationsIgnoreMessagePatterns(t *testing.T) {
+	const src = `package p
+
+func F(a, b, c *int) {
+	*a = 1
+	*b = 2
+	*c = 3
+}
+`
+	var diagnostics []analysis.Diagnostic
+	pass := parseSuggestPass(t, src, func(d analysis.Diagnostic) { diagnostics = append(diagnostics, d) })
+
+	violations := []Violation{
+		MockViolation{code: "IMM01", pos: posOf(t, pass, src, "*a = 1"), message: `cannot assign to field "legacyName"`},
+		MockViolation{code: "IMM01", pos: posOf(t, pass, src, "*b = 2"), message: `cannot assign to field "name"`},
+		MockViolation{code: "CTOR01", pos: posOf(t, pass, src, "*c = 3"), message: `field "legacyID" set outside constructor`},
+	}
+
+	cfg := config.Empty().WithStableMessages(true).WithIgnoreMessagePatterns([]string{`field "legacy.*"`})
+	NewReporter(cfg, pass, nil).ReportViolations(violations)
+
+	require.Len(t, diagnostics, 1, "matching findings are suppressed whatever their code or position")
+	assert.Equal(t, `error: [IMM01] cannot assign to field "name"`, diagnostics[0].Message)
+
+	t.Run("single violations", func(t *testing.T) {
+		diagnostics = nil
+		reporter := NewReporter(cfg, pass, nil)
+		for _, violation := range violations {
+			reporter.ReportViolation(violation)
+		}
+		require.Len(t, diagnostics, 1)
+		assert.Contains(t, diagnostics[0].Message, `field "name"`)
+	})
+}
+
+func TestReportViolationsFailFast(t *testing.T) {
+	ResetRun()
+	t.Cleanup(ResetRun)
+
+	const src = `package p
+
+func F(a, b, c *int) {
+	*a = 1
+	*b = 2
+	*c = 3
+}
+`
+	var diagnostics []analysis.Diagnostic
+	pass := parseSuggestPass(t, src, func(d analysis.Diagnostic) { diagnostics = append(diagnostics, d) })
+
+	first := MockViolation{code: "IMM01", pos: posOf(t, pass, src, "*a = 1"), message: "first"}
+	second := MockViolation{code: "IMM01", pos: posOf(t, pass, src, "*b = 2"), message: "second"}
+	third := MockViolation{code: "CTOR01", pos: posOf(t, pass, src, "*c = 3"), message: "third"}
+
+	cfg := config.Empty().WithFailFast(true)
+	assert.False(t, FailFastTriggered(cfg))
+
+	NewReporter(cfg, pass, nil).ReportViolations([]Violation{second, first})
+	require.Len(t, diagnostics, 1)
+	assert.Contains(t, diagnostics[0].Message, "first", "the first finding in source order is reported")
+	assert.True(t, FailFastTriggered(cfg))
+	assert.False(t, FailFastTriggered(config.Empty()), "only fail-fast runs stop early")
+
+	// Another checker of the same run reports nothing more
+	other := NewReporter(cfg, pass, nil)
+	other.ReportViolations([]Violation{third})
+	other.ReportViolation(third)
+	assert.Len(t, diagnostics, 1)
+}
+
+func TestReportViolationsPublicOnly(t *testing.T) {
+	const src = `package p
+
+type record struct{ n int }
+
+func Exported(r *record) {
+	r.n = 1
+}
+
+func helper(r *record) {
+	r.n = 2
+}
+
+func (r *record) Set() {
+	r.n = 3
+}
+
+type Record struct{ n int }
+
+func (r *Record) Set() {
+	r.n = 4
+}
+`
+	var diagnostics []analysis.Diagnostic
+	pass := parseSuggestPass(t, src, func(d analysis.Diagnostic) { diagnostics = append(diagnostics, d) })
+
+	violations := []Violation{
+		MockViolation{code: "IMM01", pos: posOf(t, pass, src, "r.n = 1"), message: "in function"},
+		MockViolation{code: "IMM01", pos: posOf(t, pass, src, "r.n = 2"), message: "in unexported function"},
+		MockViolation{code: "IMM01", pos: posOf(t, pass, src, "r.n = 3"), message: "in method of unexported type"},
+		MockViolation{code: "IMM01", pos: posOf(t, pass, src, "r.n = 4"), message: "in method"},
+		MockViolation{code: "IMM01", pos: posOf(t, pass, src, "n int }\n\nfunc Exported"), message: "in unexported type"},
+	}
+
+	NewReporter(config.Empty().WithPublicOnly(true).WithStableMessages(true), pass, nil).ReportViolations(violations)
+	require.Len(t, diagnostics, 2, "findings inside unexported declarations are hidden")
+	assert.Equal(t, "error: [IMM01] in function", diagnostics[0].Message)
+	assert.Equal(t, "error: [IMM01] in method", diagnostics[1].Message)
+
+	diagnostics = nil
+	NewReporter(config.Empty(), pass, nil).ReportViolations(violations)
+	assert.Len(t, diagnostics, len(violations), "every declaration is checked by default")
+}
+
+func TestFailFastScopedToRun(t *testing.T) {
+	ResetRun()
+	t.Cleanup(ResetRun)
+
+	const src = `package p
+
+func F(a *int) {
+	*a = 1
+}
+`
+	var diagnostics []analysis.Diagnostic
+	pass := parseSuggestPass(t, src, func(d analysis.Diagnostic) { diagnostics = append(diagnostics, d) })
+	violation := MockViolation{code: "IMM01", pos: posOf(t, pass, src, "*a = 1"), message: "first"}
+	cfg := config.Empty().WithFailFast(true)
+
+	// Two runs in one process, as editors calling CheckFile do
+	for run := 1; run <= 2; run++ {
+		ResetRun()
+		assert.False(t, FailFastTriggered(cfg), "run %d starts with no finding reported", run)
+		NewReporter(cfg, pass, nil).ReportViolations([]Violation{violation})
+		assert.Len(t, diagnostics, run, "run %d reports its first finding", run)
+		assert.True(t, FailFastTriggered(cfg))
+	}
+}
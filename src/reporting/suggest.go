@@ -0,0 +1,76 @@
+package reporting
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// suggestIgnore builds a fix that suppresses violation with an @ignore for its
+// code, placed so the ignore reader scopes it over the violation's position:
+//   - a violation inside a comment (e.g. on an annotation) gets a block @ignore
+//     on a new line above that comment
+//   - a line ending with a // comment gets /* @ignore CODE */ inserted before
+//     it, so the existing comment (possibly another @ignore) keeps its meaning
+//   - any other line gets // @ignore CODE appended
+func (r *Reporter) suggestIgnore(violation Violation) (analysis.SuggestedFix, bool) {
+	pos := violation.GetPos()
+	tokFile := r.pass.Fset.File(pos)
+	if tokFile == nil {
+		return analysis.SuggestedFix{}, false
+	}
+
+	code := violation.GetCode()
+	line := tokFile.Line(pos)
+	lines := r.getFileLines(tokFile.Name())
+	if line < 1 || line > len(lines) {
+		return analysis.SuggestedFix{}, false
+	}
+	lineStart := tokFile.LineStart(line)
+
+	var edit analysis.TextEdit
+	enclosing, trailing := r.commentsOnLine(tokFile, pos, line)
+	switch {
+	case enclosing != nil:
+		commentLine := tokFile.Line(enclosing.Pos())
+		text := lines[commentLine-1]
+		indent := text[:len(text)-len(strings.TrimLeft(text, " \t"))]
+		start := tokFile.LineStart(commentLine)
+		edit = analysis.TextEdit{Pos: start, End: start, NewText: fmt.Appendf(nil, "%s// @ignore %s\n", indent, code)}
+	case trailing != nil:
+		edit = analysis.TextEdit{Pos: trailing.Pos(), End: trailing.Pos(), NewText: fmt.Appendf(nil, "/* @ignore %s */ ", code)}
+	default:
+		end := lineStart + token.Pos(len(lines[line-1]))
+		edit = analysis.TextEdit{Pos: end, End: end, NewText: fmt.Appendf(nil, " // @ignore %s", code)}
+	}
+
+	return analysis.SuggestedFix{
+		Message:   fmt.Sprintf("Add @ignore %s", code),
+		TextEdits: []analysis.TextEdit{edit},
+	}, true
+}
+
+// commentsOnLine returns the comment containing pos, if any, and otherwise the
+// first // comment that starts after pos on the same line
+func (r *Reporter) commentsOnLine(tokFile *token.File, pos token.Pos, line int) (enclosing *ast.Comment, trailing *ast.Comment) {
+	for _, file := range r.pass.Files {
+		if file.FileStart > pos || pos > file.FileEnd {
+			continue
+		}
+		for _, group := range file.Comments {
+			for _, comment := range group.List {
+				if comment.Pos() <= pos && pos < comment.End() {
+					return comment, nil
+				}
+				if trailing == nil && comment.Pos() > pos &&
+					tokFile.Line(comment.Pos()) == line && strings.HasPrefix(comment.Text, "//") {
+					trailing = comment
+				}
+			}
+		}
+	}
+	return nil, trailing
+}
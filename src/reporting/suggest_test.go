@@ -0,0 +1,158 @@
+package reporting
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/a14e/gogreement/src/config"
+	"github.com/a14e/gogreement/src/ignore"
+)
+
+const suggestSource = `package p
+
+// T is a test type
+// @immutable
+type T struct{ Name string }
+
+func F(t *T) {
+	t.Name = "plain"
+	t.Name = "commented" // keep this note
+}
+`
+
+// parseSuggestPass builds a pass over a single in-memory file
+func parseSuggestPass(t *testing.T, src string, report func(analysis.Diagnostic)) *analysis.Pass {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "p.go", src, parser.ParseComments)
+	require.NoError(t, err)
+
+	return &analysis.Pass{
+		Fset:     fset,
+		Files:    []*ast.File{file},
+		ReadFile: func(string) ([]byte, error) { return []byte(src), nil },
+		Report:   report,
+	}
+}
+
+// posOf returns the position of the first occurrence of substr in the file
+func posOf(t *testing.T, pass *analysis.Pass, src, substr string) token.Pos {
+	offset := strings.Index(src, substr)
+	require.GreaterOrEqual(t, offset, 0, "substring %q not found", substr)
+	return pass.Fset.File(pass.Files[0].Pos()).Pos(offset)
+}
+
+func TestSuggestIgnoreFix(t *testing.T) {
+	tests := []struct {
+		name      string
+		at        string
+		code      string
+		wantLine  string
+		wantAfter string
+	}{
+		{
+			name:     "appended to a plain line",
+			at:       `t.Name = "plain"`,
+			code:     "IMM01",
+			wantLine: `	t.Name = "plain" // @ignore IMM01`,
+		},
+		{
+			name:     "inserted before an existing trailing comment",
+			at:       `t.Name = "commented"`,
+			code:     "IMM01",
+			wantLine: `	t.Name = "commented" /* @ignore IMM01 */ // keep this note`,
+		},
+		{
+			name:      "new line above a violation inside a comment",
+			at:        "@immutable",
+			code:      "SINCE01",
+			wantLine:  "// @ignore SINCE01",
+			wantAfter: "// @immutable",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diagnostics []analysis.Diagnostic
+			pass := parseSuggestPass(t, suggestSource, func(d analysis.Diagnostic) { diagnostics = append(diagnostics, d) })
+			pos := posOf(t, pass, suggestSource, tt.at)
+
+			reporter := NewReporter(config.Empty().WithSuggestIgnores(true), pass, nil)
+			reporter.ReportViolation(MockViolation{code: tt.code, pos: pos, message: "test"})
+
+			require.Len(t, diagnostics, 1)
+			require.Len(t, diagnostics[0].SuggestedFixes, 1)
+			fix := diagnostics[0].SuggestedFixes[0]
+			require.Len(t, fix.TextEdits, 1)
+			assert.Contains(t, fix.Message, tt.code)
+
+			fixed := applyEdit(pass, suggestSource, fix.TextEdits[0])
+			assert.Contains(t, fixed, tt.wantLine+"\n")
+			if tt.wantAfter != "" {
+				assert.Contains(t, fixed, tt.wantLine+"\n"+tt.wantAfter+"\n")
+			}
+
+			// The fixed file must still parse and its @ignore must cover the
+			// violation at its (possibly shifted) new position.
+			fixedPass := parseSuggestPass(t, fixed, nil)
+			ignoreSet := ignore.ReadIgnoreAnnotations(config.Empty(), fixedPass)
+			offset := strings.Index(fixed, tt.at)
+			fixedPos := fixedPass.Fset.File(fixedPass.Files[0].Pos()).Pos(offset)
+			assert.True(t, ignoreSet.Contains(tt.code, fixedPos), "suggested @ignore should suppress the violation")
+		})
+	}
+
+	t.Run("no fix unless enabled", func(t *testing.T) {
+		var diagnostics []analysis.Diagnostic
+		pass := parseSuggestPass(t, suggestSource, func(d analysis.Diagnostic) { diagnostics = append(diagnostics, d) })
+		pos := posOf(t, pass, suggestSource, `t.Name = "plain"`)
+
+		NewReporter(config.Empty(), pass, nil).ReportViolation(MockViolation{code: "IMM01", pos: pos})
+
+		require.Len(t, diagnostics, 1)
+		assert.Empty(t, diagnostics[0].SuggestedFixes)
+	})
+}
+
+// applyEdit returns src with edit applied
+func applyEdit(pass *analysis.Pass, src string, edit analysis.TextEdit) string {
+	tokFile := pass.Fset.File(edit.Pos)
+	start := tokFile.Offset(edit.Pos)
+	end := tokFile.Offset(edit.End)
+	return src[:start] + string(edit.NewText) + src[end:]
+}
+
+// fixableViolation carries its own fix
+type fixableViolation struct {
+	MockViolation
+	fix analysis.SuggestedFix
+}
+
+func (v fixableViolation) GetSuggestedFixes() []analysis.SuggestedFix {
+	return []analysis.SuggestedFix{v.fix}
+}
+
+func TestViolationFixes(t *testing.T) {
+	var diagnostics []analysis.Diagnostic
+	pass := parseSuggestPass(t, suggestSource, func(d analysis.Diagnostic) { diagnostics = append(diagnostics, d) })
+	pos := posOf(t, pass, suggestSource, `t.Name = "plain"`)
+	own := analysis.SuggestedFix{Message: "Rename", TextEdits: []analysis.TextEdit{{Pos: pos, End: pos, NewText: []byte("_ = ")}}}
+
+	NewReporter(config.Empty().WithSuggestIgnores(true), pass, nil).
+		ReportViolation(fixableViolation{MockViolation: MockViolation{code: "IMM01", pos: pos}, fix: own})
+	NewReporter(config.Empty(), pass, nil).
+		ReportViolation(fixableViolation{MockViolation: MockViolation{code: "IMM01", pos: pos}, fix: own})
+
+	require.Len(t, diagnostics, 2)
+	require.Len(t, diagnostics[0].SuggestedFixes, 2)
+	assert.Equal(t, "Rename", diagnostics[0].SuggestedFixes[0].Message, "the violation's own fix comes first")
+	assert.Equal(t, "Add @ignore IMM01", diagnostics[0].SuggestedFixes[1].Message)
+	require.Len(t, diagnostics[1].SuggestedFixes, 1, "own fixes do not depend on --suggest-ignores")
+	assert.Equal(t, "Rename", diagnostics[1].SuggestedFixes[0].Message)
+}
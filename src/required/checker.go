@@ -0,0 +1,110 @@
+package required
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/a14e/gogreement/src/annotations"
+	"github.com/a14e/gogreement/src/codes"
+	"github.com/a14e/gogreement/src/config"
+	"github.com/a14e/gogreement/src/indexing"
+)
+
+// CheckRequired reports composite literals of struct types that leave one of
+// the type's @required fields unset. A field counts as set when the literal
+// names it as a key, whatever the value; a non-empty positional literal sets
+// every field. Only types declared in the module of the checked package are
+// considered, so the contract does not leak to other modules.
+func CheckRequired(
+	cfg *config.Config,
+	pass *analysis.Pass,
+	packageAnnotations *annotations.PackageAnnotations,
+) []RequiredViolation {
+	var violations []RequiredViolation
+
+	requiredFields := indexing.BuildRequiredFieldsIndex[*annotations.RequiredCheckerFact](pass, packageAnnotations)
+	if requiredFields.Empty() {
+		return violations
+	}
+
+	for file := range cfg.FilterFilesForCheck(pass, codes.RequiredCategoryPrefix) {
+		ast.Inspect(file, func(n ast.Node) bool {
+			lit, ok := n.(*ast.CompositeLit)
+			if !ok {
+				return true
+			}
+
+			// The type is taken from the literal itself, so elided element
+			// types ([]Server{{...}}) are covered as well
+			named, ok := types.Unalias(pass.TypesInfo.TypeOf(lit)).(*types.Named)
+			if !ok || named.Obj().Pkg() == nil {
+				return true
+			}
+			if _, ok := named.Underlying().(*types.Struct); !ok {
+				return true
+			}
+
+			obj := named.Origin().Obj()
+			pkgPath := obj.Pkg().Path()
+			fields := requiredFields.GetAssociated(pkgPath, obj.Name())
+			if len(fields) == 0 || !inDeclaringModule(pass, pkgPath) {
+				return true
+			}
+
+			// Positional literals must list every field; only T{} sets none
+			if len(lit.Elts) > 0 && !isKeyed(lit) {
+				return true
+			}
+
+			set := make(map[string]bool, len(lit.Elts))
+			for _, elt := range lit.Elts {
+				if kv, ok := elt.(*ast.KeyValueExpr); ok {
+					if key, ok := kv.Key.(*ast.Ident); ok {
+						set[key.Name] = true
+					}
+				}
+			}
+
+			var missing []string
+			for _, field := range fields {
+				if !set[field] {
+					missing = append(missing, field)
+				}
+			}
+			if len(missing) == 0 {
+				return true
+			}
+
+			violations = append(violations, RequiredViolation{
+				TypeName:    obj.Name(),
+				TypePkgPath: pkgPath,
+				Fields:      missing,
+				Code:        codes.RequiredFieldMissing,
+				Pos:         lit.Pos(),
+			})
+			return true
+		})
+	}
+
+	return violations
+}
+
+// isKeyed reports whether the literal uses field: value elements
+func isKeyed(lit *ast.CompositeLit) bool {
+	_, ok := lit.Elts[0].(*ast.KeyValueExpr)
+	return ok
+}
+
+// inDeclaringModule reports whether the package pkgPath belongs to the module
+// of the checked package. Drivers that provide no module information get
+// every package checked.
+func inDeclaringModule(pass *analysis.Pass, pkgPath string) bool {
+	if pkgPath == pass.Pkg.Path() || pass.Module == nil || pass.Module.Path == "" {
+		return true
+	}
+	modulePath := pass.Module.Path
+	return pkgPath == modulePath || strings.HasPrefix(pkgPath, modulePath+"/")
+}
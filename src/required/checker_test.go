@@ -0,0 +1,63 @@
+package required
+
+import (
+	"testing"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/a14e/gogreement/src/annotations"
+	"github.com/a14e/gogreement/src/codes"
+	"github.com/a14e/gogreement/src/config"
+	"github.com/a14e/gogreement/src/testutil/testfacts"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckRequired_ForeignLiterals(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "requiredconsumer", "requiredsource")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+
+	violations := CheckRequired(cfg, pass, &packageAnnotations)
+
+	var messages []string
+	for _, v := range violations {
+		assert.Equal(t, codes.RequiredFieldMissing, v.GetCode())
+		messages = append(messages, v.GetMessage())
+	}
+
+	assert.Equal(t, []string{
+		`composite literal of type "Server" does not set required field "Handler"`,
+		`composite literal of type "Server" does not set required fields "Addr", "Handler"`,
+		`composite literal of type "Server" does not set required field "Handler"`,
+	}, messages, "keyed zero values, positional literals and types without @required fields are not reported")
+}
+
+func TestCheckRequired_DeclaringPackage(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "requiredsource")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+
+	assert.Len(t, packageAnnotations.RequiredAnnotations, 3, "Addr, Handler and x are @required")
+
+	var messages []string
+	for _, v := range CheckRequired(cfg, pass, &packageAnnotations) {
+		messages = append(messages, v.GetMessage())
+	}
+	assert.Equal(t, []string{
+		`composite literal of type "Point" does not set required field "x"`,
+	}, messages, "the declaring package is checked as well")
+}
+
+func TestCheckRequired_OtherModule(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "requiredconsumer", "requiredsource")
+	pass.Module = &analysis.Module{Path: "example.com/elsewhere"}
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+
+	assert.Empty(t, CheckRequired(cfg, pass, &packageAnnotations),
+		"@required applies within the declaring module only")
+
+	pass.Module = &analysis.Module{Path: "github.com/a14e/gogreement"}
+	assert.Len(t, CheckRequired(cfg, pass, &packageAnnotations), 3)
+}
@@ -0,0 +1,74 @@
+package required
+
+import (
+	"fmt"
+	"go/token"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/a14e/gogreement/src/config"
+	"github.com/a14e/gogreement/src/reporting"
+	"github.com/a14e/gogreement/src/trace"
+	"github.com/a14e/gogreement/src/util"
+)
+
+// RequiredViolation represents a composite literal that does not set one of
+// the @required fields of its type
+// @immutable
+// implements reporting.Violation
+// implements reporting.ContractViolation
+type RequiredViolation struct {
+	TypeName    string
+	TypePkgPath string   // Package path where the type is declared
+	Fields      []string // Required fields the literal does not set, in declaration order
+	Code        string   // Error code from codes package
+	Pos         token.Pos
+}
+
+// GetCode returns the error code for this violation
+func (v RequiredViolation) GetCode() string {
+	return v.Code
+}
+
+// GetPos returns the position of the violation
+func (v RequiredViolation) GetPos() token.Pos {
+	return v.Pos
+}
+
+// GetMessage returns the main error message without formatting
+func (v RequiredViolation) GetMessage() string {
+	quoted := make([]string, len(v.Fields))
+	for i, field := range v.Fields {
+		quoted[i] = fmt.Sprintf("%q", field)
+	}
+
+	noun := "field"
+	if len(v.Fields) > 1 {
+		noun = "fields"
+	}
+	return fmt.Sprintf("composite literal of type %q does not set required %s %s",
+		v.TypeName, noun, strings.Join(quoted, ", "))
+}
+
+// GetContracts names the @required annotation of every field the literal
+// does not set
+func (v RequiredViolation) GetContracts() []trace.Key {
+	keys := make([]trace.Key, 0, len(v.Fields))
+	for _, field := range v.Fields {
+		keys = append(keys, trace.Key{Kind: "required", Package: v.TypePkgPath, Name: v.TypeName + "." + field})
+	}
+	return keys
+}
+
+// ReportViolations reports required field violations using the new pretty formatter
+func ReportViolations(cfg *config.Config, pass *analysis.Pass, violations []RequiredViolation, ignoreSet *util.IgnoreSet) {
+	reporter := reporting.NewReporter(cfg, pass, ignoreSet)
+
+	// Convert to generic violations and report
+	generic := make([]reporting.Violation, 0, len(violations))
+	for _, violation := range violations {
+		generic = append(generic, violation)
+	}
+	reporter.ReportViolations(generic)
+}
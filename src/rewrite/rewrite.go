@@ -0,0 +1,102 @@
+// Package rewrite migrates annotation comments to their current compact form
+// for `gogreement --rewrite`. Only the text of annotation lines changes, so a
+// gofmt-formatted file stays formatted.
+package rewrite
+
+import (
+	"go/ast"
+	"go/token"
+	"slices"
+	"strings"
+
+	"github.com/a14e/gogreement/src/annotations"
+)
+
+// Edit replaces the bytes [Start, End) of a file with New
+// @immutable
+type Edit struct {
+	Start int
+	End   int
+	New   string
+}
+
+// Annotations returns the edits that normalize the annotations in the doc
+// comments of the type declarations of file: @constructor lists are written
+// as "New, Create" without a trailing comma, and an @implements line that
+// repeats an earlier one of the same type is removed. src is the content of
+// the file.
+func Annotations(fset *token.FileSet, file *ast.File, src []byte) []Edit {
+	var edits []Edit
+
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+
+		edits = append(edits, commentEdits(fset, genDecl.Doc, src)...)
+		for _, spec := range genDecl.Specs {
+			if typeSpec, ok := spec.(*ast.TypeSpec); ok {
+				edits = append(edits, commentEdits(fset, typeSpec.Doc, src)...)
+			}
+		}
+	}
+
+	return edits
+}
+
+// commentEdits returns the edits of the annotation lines of one doc comment
+func commentEdits(fset *token.FileSet, group *ast.CommentGroup, src []byte) []Edit {
+	if group == nil {
+		return nil
+	}
+
+	var edits []Edit
+	seenImplements := make(map[string]bool)
+	for _, comment := range group.List {
+		if !strings.HasPrefix(comment.Text, "//") {
+			continue
+		}
+		start := fset.Position(comment.Pos()).Offset
+		end := fset.Position(comment.End()).Offset
+
+		if key, ok := annotations.ImplementsKey(comment.Text); ok {
+			if seenImplements[key] {
+				lineStart, lineEnd := lineBounds(src, start, end)
+				edits = append(edits, Edit{Start: lineStart, End: lineEnd})
+				continue
+			}
+			seenImplements[key] = true
+		}
+
+		if normalized, ok := annotations.NormalizeConstructorComment(comment.Text); ok && normalized != comment.Text {
+			edits = append(edits, Edit{Start: start, End: end, New: normalized})
+		}
+	}
+
+	return edits
+}
+
+// lineBounds widens [start, end) to the whole line, including its
+// indentation and line break
+func lineBounds(src []byte, start, end int) (int, int) {
+	for start > 0 && (src[start-1] == ' ' || src[start-1] == '\t') {
+		start--
+	}
+	if end < len(src) && src[end] == '\n' {
+		end++
+	}
+	return start, end
+}
+
+// Apply returns src with edits applied. Edits must not overlap.
+func Apply(src []byte, edits []Edit) []byte {
+	sorted := slices.Clone(edits)
+	slices.SortFunc(sorted, func(a, b Edit) int { return b.Start - a.Start })
+
+	result := slices.Clone(src)
+	for _, edit := range sorted {
+		result = slices.Concat(result[:edit.Start], []byte(edit.New), result[edit.End:])
+	}
+	return result
+}
@@ -0,0 +1,85 @@
+package rewrite
+
+import (
+	"go/format"
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func rewriteSource(t *testing.T, src string) string {
+	t.Helper()
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "example.go", src, parser.ParseComments)
+	require.NoError(t, err)
+	return string(Apply([]byte(src), Annotations(fset, file, []byte(src))))
+}
+
+func TestAnnotations(t *testing.T) {
+	src := `package example
+
+import "io"
+
+// Buffer collects bytes
+// @implements io.Writer
+// @implements &io.Reader
+// @implements  io.Writer
+// @constructor NewBuffer ,NewEmptyBuffer,
+type Buffer struct{}
+
+type (
+	// Pool reuses buffers
+	//@constructor-local NewPool ,NewSizedPool
+	Pool struct{}
+
+	// Plain is left alone
+	// @constructor NewPlain, NewPlainSized for tests
+	Plain struct{}
+)
+
+// @constructor NotAType,
+func helper() {}
+
+var _ io.Writer
+`
+
+	want := `package example
+
+import "io"
+
+// Buffer collects bytes
+// @implements io.Writer
+// @implements &io.Reader
+// @constructor NewBuffer, NewEmptyBuffer
+type Buffer struct{}
+
+type (
+	// Pool reuses buffers
+	// @constructor-local NewPool, NewSizedPool
+	Pool struct{}
+
+	// Plain is left alone
+	// @constructor NewPlain, NewPlainSized for tests
+	Plain struct{}
+)
+
+// @constructor NotAType,
+func helper() {}
+
+var _ io.Writer
+`
+
+	got := rewriteSource(t, src)
+	assert.Equal(t, want, got)
+
+	formatted, err := format.Source([]byte(got))
+	require.NoError(t, err)
+	assert.Equal(t, got, string(formatted), "the rewrite keeps the file gofmt-formatted")
+
+	t.Run("normalized annotations are left alone", func(t *testing.T) {
+		assert.Equal(t, want, rewriteSource(t, want))
+	})
+}
@@ -0,0 +1,40 @@
+package since
+
+import (
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/a14e/gogreement/src/annotations"
+	"github.com/a14e/gogreement/src/codes"
+	"github.com/a14e/gogreement/src/config"
+)
+
+// CheckSince validates the @since versions declared in the current package.
+// Versions must be full semantic versions ("v1.2.0"); anything else is reported
+// so that the versions carried in facts can be compared reliably later.
+func CheckSince(
+	cfg *config.Config,
+	pass *analysis.Pass,
+	packageAnnotations *annotations.PackageAnnotations,
+) []SinceViolation {
+	var violations []SinceViolation
+
+	if !cfg.CheckSince {
+		return violations
+	}
+
+	for _, annot := range packageAnnotations.SinceAnnotations {
+		if annot.HasValidVersion() {
+			continue
+		}
+
+		violations = append(violations, SinceViolation{
+			ObjectName:   annot.ObjectName,
+			ReceiverType: annot.ReceiverType,
+			Version:      annot.Version,
+			Code:         codes.SinceMalformedVersion,
+			Pos:          annot.Pos,
+		})
+	}
+
+	return violations
+}
@@ -0,0 +1,48 @@
+package since
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/a14e/gogreement/src/annotations"
+	"github.com/a14e/gogreement/src/codes"
+	"github.com/a14e/gogreement/src/config"
+	"github.com/a14e/gogreement/src/testutil/testfacts"
+)
+
+func TestCheckSince(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "sincetests")
+	packageAnnotations := annotations.ReadAllAnnotations(config.Empty(), pass)
+
+	t.Run("disabled by default", func(t *testing.T) {
+		violations := CheckSince(config.Empty(), pass, &packageAnnotations)
+		assert.Empty(t, violations, "validation only runs with --check-since")
+	})
+
+	t.Run("reports malformed and missing versions", func(t *testing.T) {
+		cfg := config.Empty().WithCheckSince(true)
+		violations := CheckSince(cfg, pass, &packageAnnotations)
+
+		reported := make(map[string]string)
+		for _, v := range violations {
+			assert.Equal(t, codes.SinceMalformedVersion, v.GetCode())
+			t.Logf("Violation: %s", v.GetMessage())
+			reported[v.ObjectName] = v.Version
+		}
+
+		assert.Equal(t, map[string]string{
+			"Retry":   "1.3.0",
+			"Timeout": "v1.4",
+			"Options": "",
+		}, reported)
+	})
+}
+
+func TestSinceViolation_GetMessage(t *testing.T) {
+	method := SinceViolation{ObjectName: "Retry", ReceiverType: "Client", Version: "1.3.0", Code: codes.SinceMalformedVersion}
+	assert.Contains(t, method.GetMessage(), `"Client.Retry" has malformed version "1.3.0"`)
+
+	missing := SinceViolation{ObjectName: "Options", Code: codes.SinceMalformedVersion}
+	assert.Contains(t, missing.GetMessage(), `"Options" has no version`)
+}
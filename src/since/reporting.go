@@ -0,0 +1,70 @@
+package since
+
+import (
+	"fmt"
+	"go/token"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/a14e/gogreement/src/config"
+	"github.com/a14e/gogreement/src/reporting"
+	"github.com/a14e/gogreement/src/trace"
+	"github.com/a14e/gogreement/src/util"
+)
+
+// SinceViolation represents an invalid @since annotation
+// @immutable
+// implements reporting.Violation
+// implements reporting.ContractViolation
+type SinceViolation struct {
+	ObjectName   string
+	ReceiverType string // Receiver type for methods (empty for types/functions)
+	Version      string // Version as written, empty when missing
+	Code         string // Error code from codes package
+	Pos          token.Pos
+}
+
+// GetCode returns the error code for this violation
+func (v SinceViolation) GetCode() string {
+	return v.Code
+}
+
+// GetPos returns the position of the violation
+func (v SinceViolation) GetPos() token.Pos {
+	return v.Pos
+}
+
+// GetMessage returns the main error message without formatting
+func (v SinceViolation) GetMessage() string {
+	name := v.ObjectName
+	if v.ReceiverType != "" {
+		name = v.ReceiverType + "." + v.ObjectName
+	}
+
+	if v.Version == "" {
+		return fmt.Sprintf("@since on %q has no version (expected e.g. v1.2.0)", name)
+	}
+	return fmt.Sprintf("@since on %q has malformed version %q (expected a semantic version like v1.2.0)", name, v.Version)
+}
+
+// GetContracts names the malformed @since annotation, which belongs to the
+// package being analyzed
+func (v SinceViolation) GetContracts() []trace.Key {
+	name := v.ObjectName
+	if v.ReceiverType != "" {
+		name = v.ReceiverType + "." + v.ObjectName
+	}
+	return []trace.Key{{Kind: "since", Name: name, Argument: v.Version}}
+}
+
+// ReportViolations reports since violations using the new pretty formatter
+func ReportViolations(cfg *config.Config, pass *analysis.Pass, violations []SinceViolation, ignoreSet *util.IgnoreSet) {
+	reporter := reporting.NewReporter(cfg, pass, ignoreSet)
+
+	// Convert to generic violations and report
+	generic := make([]reporting.Violation, 0, len(violations))
+	for _, violation := range violations {
+		generic = append(generic, violation)
+	}
+	reporter.ReportViolations(generic)
+}
@@ -0,0 +1,170 @@
+package singleton
+
+import (
+	"cmp"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"maps"
+	"slices"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/a14e/gogreement/src/annotations"
+	"github.com/a14e/gogreement/src/codes"
+	"github.com/a14e/gogreement/src/config"
+	"github.com/a14e/gogreement/src/indexing"
+)
+
+// SitesFact records the construction sites of @singleton types found in a
+// package and in every package it imports, so counts add up across packages.
+// Keys are "importpath.TypeName".
+// @implements &analysis.Fact
+type SitesFact struct {
+	Sites map[string][]Site
+}
+
+func (*SitesFact) AFact() {}
+
+// Site is the position of one construction of a @singleton type
+// @immutable
+type Site struct {
+	Package string // Import path of the package constructing the type
+	File    string
+	Line    int
+	Column  int
+}
+
+// CheckSingleton reports every construction of a @singleton type after its
+// first one: composite literals (T{}, &T{}, elided elements), new(T) and
+// zero-value var declarations. Sites recorded in the facts of imported
+// packages come first, then the sites of this package in source order. The
+// returned fact holds all of them and must be exported by the caller.
+// Packages that do not import each other do not see each other's sites.
+func CheckSingleton(
+	cfg *config.Config,
+	pass *analysis.Pass,
+	packageAnnotations *annotations.PackageAnnotations,
+) ([]SingletonViolation, *SitesFact) {
+	var violations []SingletonViolation
+
+	fact := importedSites(pass)
+	// Positions of first constructions found in this package, for types
+	// no imported package constructs
+	localFirst := make(map[string]token.Pos)
+
+	singletons := indexing.BuildSingletonTypesIndex[*annotations.SingletonCheckerFact](pass, packageAnnotations)
+	if singletons.Empty() {
+		return violations, fact
+	}
+
+	record := func(t types.Type, pos token.Pos) {
+		named, ok := types.Unalias(t).(*types.Named)
+		if !ok || named.Obj().Pkg() == nil {
+			return
+		}
+		obj := named.Origin().Obj()
+		if !singletons.Contains(obj.Pkg().Path(), obj.Name()) {
+			return
+		}
+
+		key := obj.Pkg().Path() + "." + obj.Name()
+		position := pass.Fset.Position(pos)
+		site := Site{Package: pass.Pkg.Path(), File: position.Filename, Line: position.Line, Column: position.Column}
+		if slices.Contains(fact.Sites[key], site) {
+			return
+		}
+		if len(fact.Sites[key]) == 0 {
+			localFirst[key] = pos
+		} else {
+			violations = append(violations, SingletonViolation{
+				TypeName: obj.Name(),
+				First:    fact.Sites[key][0],
+				FirstPos: localFirst[key],
+				Code:     codes.SingletonConstructedAgain,
+				Pos:      pos,
+			})
+		}
+		fact.Sites[key] = append(fact.Sites[key], site)
+	}
+
+	for file := range cfg.FilterFilesForCheck(pass, codes.SingletonCategoryPrefix) {
+		ast.Inspect(file, func(n ast.Node) bool {
+			switch node := n.(type) {
+			case *ast.CompositeLit:
+				// Taken from the literal itself, so &T{} and elided
+				// element types ([]T{{...}}) are covered as well
+				record(pass.TypesInfo.TypeOf(node), node.Pos())
+
+			case *ast.CallExpr:
+				// new(*T) allocates a nil *T and constructs no T
+				if ident, ok := node.Fun.(*ast.Ident); ok && ident.Name == "new" && len(node.Args) == 1 {
+					if _, isBuiltin := pass.TypesInfo.Uses[ident].(*types.Builtin); isBuiltin {
+						record(pass.TypesInfo.TypeOf(node.Args[0]), node.Pos())
+					}
+				}
+
+			case *ast.GenDecl:
+				if node.Tok == token.VAR {
+					for _, name := range zeroValueNames(node) {
+						record(pass.TypesInfo.TypeOf(name), name.Pos())
+					}
+				}
+			}
+			return true
+		})
+	}
+
+	return violations, fact
+}
+
+// zeroValueNames returns the names of a var declaration without values:
+// var x T declares a zero value, while var x = ... is covered by the
+// expression on the right
+func zeroValueNames(decl *ast.GenDecl) []*ast.Ident {
+	var names []*ast.Ident
+	for _, spec := range decl.Specs {
+		valueSpec, ok := spec.(*ast.ValueSpec)
+		if !ok || len(valueSpec.Values) > 0 {
+			continue
+		}
+		for _, name := range valueSpec.Names {
+			if name.Name != "_" {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
+
+// importedSites merges the sites facts of the direct imports. Each of them
+// already holds the sites of its own imports, and a site reached through
+// several imports is kept once.
+func importedSites(pass *analysis.Pass) *SitesFact {
+	fact := &SitesFact{Sites: make(map[string][]Site)}
+
+	for _, imp := range pass.Pkg.Imports() {
+		var imported SitesFact
+		if !pass.ImportPackageFact(imp, &imported) {
+			continue
+		}
+		for key, sites := range imported.Sites {
+			for _, site := range sites {
+				if !slices.Contains(fact.Sites[key], site) {
+					fact.Sites[key] = append(fact.Sites[key], site)
+				}
+			}
+		}
+	}
+
+	// Import order is arbitrary; sort so that the first site is stable
+	for key := range maps.Keys(fact.Sites) {
+		slices.SortFunc(fact.Sites[key], compareSites)
+	}
+
+	return fact
+}
+
+func compareSites(a, b Site) int {
+	return cmp.Or(cmp.Compare(a.File, b.File), cmp.Compare(a.Line, b.Line), cmp.Compare(a.Column, b.Column))
+}
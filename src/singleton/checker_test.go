@@ -0,0 +1,52 @@
+package singleton
+
+import (
+	"testing"
+
+	"github.com/a14e/gogreement/src/annotations"
+	"github.com/a14e/gogreement/src/codes"
+	"github.com/a14e/gogreement/src/config"
+	"github.com/a14e/gogreement/src/testutil/testfacts"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckSingleton(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "singletontests")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+
+	assert.Len(t, packageAnnotations.SingletonAnnotations, 2, "Registry and Level are @singleton")
+
+	violations, sites := CheckSingleton(cfg, pass, &packageAnnotations)
+
+	var messages []string
+	for _, v := range violations {
+		assert.Equal(t, codes.SingletonConstructedAgain, v.GetCode())
+		messages = append(messages, v.GetMessage())
+
+		related := v.GetRelated()
+		require.Len(t, related, 1, "the first construction is in this package")
+		assert.Equal(t, 18, pass.Fset.Position(related[0].Pos).Line)
+		assert.Equal(t, `@singleton type "Registry" is first constructed here`, related[0].Message)
+	}
+	first := `@singleton type "Registry" is constructed more than once`
+	assert.Equal(t, []string{first, first, first}, messages,
+		"the composite literal, new() and the zero value after the first construction are reported")
+
+	registrySites := sites.Sites["github.com/a14e/gogreement/testdata/unit/singletontests.Registry"]
+	require.Len(t, registrySites, 4, "the fact records every construction site")
+	assert.Equal(t, 18, registrySites[0].Line)
+	assert.NotContains(t, sites.Sites, "github.com/a14e/gogreement/testdata/unit/singletontests.Level")
+}
+
+func TestSingletonViolationMessage(t *testing.T) {
+	imported := SingletonViolation{
+		TypeName: "Registry",
+		First:    Site{Package: "example.com/plugins", File: "/src/plugins/registry.go", Line: 9, Column: 10},
+	}
+	assert.Equal(t, `@singleton type "Registry" is constructed more than once; it is first constructed in package "example.com/plugins"`,
+		imported.GetMessage(), "a first construction in another package is named by its import path, not its position")
+	assert.Empty(t, imported.GetRelated())
+}
@@ -0,0 +1,70 @@
+package singleton
+
+import (
+	"fmt"
+	"go/token"
+
+	"golang.org/x/tools/go/analysis"
+
+	"github.com/a14e/gogreement/src/config"
+	"github.com/a14e/gogreement/src/reporting"
+	"github.com/a14e/gogreement/src/util"
+)
+
+// SingletonViolation represents a construction of a @singleton type after its
+// first one
+// @immutable
+// implements reporting.Violation
+// implements reporting.RelatedViolation
+type SingletonViolation struct {
+	TypeName string
+	First    Site      // Site of the first construction of the type
+	FirstPos token.Pos // Position of First when it is in this package, NoPos otherwise
+	Code     string    // Error code from codes package
+	Pos      token.Pos
+}
+
+// GetCode returns the error code for this violation
+func (v SingletonViolation) GetCode() string {
+	return v.Code
+}
+
+// GetPos returns the position of the violation
+func (v SingletonViolation) GetPos() token.Pos {
+	return v.Pos
+}
+
+// GetMessage returns the main error message without formatting. A first
+// construction in this package is pointed at by GetRelated; one in another
+// package is named by its import path, so messages carry no positions.
+func (v SingletonViolation) GetMessage() string {
+	if v.FirstPos.IsValid() || v.First.Package == "" {
+		return fmt.Sprintf("@singleton type %q is constructed more than once", v.TypeName)
+	}
+	return fmt.Sprintf("@singleton type %q is constructed more than once; it is first constructed in package %q",
+		v.TypeName, v.First.Package)
+}
+
+// GetRelated points at the first construction of the type when it is in
+// this package
+func (v SingletonViolation) GetRelated() []reporting.RelatedLocation {
+	if !v.FirstPos.IsValid() {
+		return nil
+	}
+	return []reporting.RelatedLocation{{
+		Pos:     v.FirstPos,
+		Message: fmt.Sprintf("@singleton type %q is first constructed here", v.TypeName),
+	}}
+}
+
+// ReportViolations reports singleton violations using the new pretty formatter
+func ReportViolations(cfg *config.Config, pass *analysis.Pass, violations []SingletonViolation, ignoreSet *util.IgnoreSet) {
+	reporter := reporting.NewReporter(cfg, pass, ignoreSet)
+
+	// Convert to generic violations and report
+	generic := make([]reporting.Violation, 0, len(violations))
+	for _, violation := range violations {
+		generic = append(generic, violation)
+	}
+	reporter.ReportViolations(generic)
+}
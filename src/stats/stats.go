@@ -0,0 +1,162 @@
+// Package stats collects run counters for the --stats flag: files scanned,
+// annotations parsed per kind, interfaces and types loaded, and violations
+// reported per code.
+//
+// Analyzers run once per package, possibly concurrently, so every pass records
+// its own delta and Record merges it into a process-wide total. multichecker
+// exits the process as soon as analysis finishes, therefore the gogreement
+// command runs the analysis in a child process with SinkEnv set: each delta is
+// also appended to that file as one JSON line, and the parent aggregates the
+// file with ReadSink once the child exits.
+package stats
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"sync"
+)
+
+// SinkEnv names the environment variable holding the file that Record appends
+// deltas to. It is set by the gogreement command for its child process.
+const SinkEnv = "GOGREEMENT_STATS_FILE"
+
+// Counters holds the counters of a run, or the delta recorded by one pass
+type Counters struct {
+	Files       int            `json:"files"`
+	Annotations map[string]int `json:"annotations"`
+	Interfaces  int            `json:"interfaces"`
+	Types       int            `json:"types"`
+	Violations  map[string]int `json:"violations"`
+}
+
+// Add merges other into c
+func (c *Counters) Add(other Counters) {
+	c.Files += other.Files
+	c.Interfaces += other.Interfaces
+	c.Types += other.Types
+	c.Annotations = addCounts(c.Annotations, other.Annotations)
+	c.Violations = addCounts(c.Violations, other.Violations)
+}
+
+func addCounts(dst, src map[string]int) map[string]int {
+	for key, n := range src {
+		if n == 0 {
+			continue
+		}
+		if dst == nil {
+			dst = make(map[string]int)
+		}
+		dst[key] += n
+	}
+	return dst
+}
+
+var (
+	mu    sync.Mutex
+	total Counters
+)
+
+// Record merges delta into the process-wide total and, when SinkEnv is set,
+// appends it to the sink file
+func Record(delta Counters) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	total.Add(delta)
+
+	path := os.Getenv(SinkEnv)
+	if path == "" {
+		return
+	}
+	line, err := json.Marshal(delta)
+	if err != nil {
+		return
+	}
+	sink, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return
+	}
+	defer sink.Close()
+	_, _ = sink.Write(append(line, '\n'))
+}
+
+// Total returns a copy of the counters recorded in this process
+func Total() Counters {
+	mu.Lock()
+	defer mu.Unlock()
+
+	var result Counters
+	result.Add(total)
+	return result
+}
+
+// Reset clears the counters recorded in this process
+func Reset() {
+	mu.Lock()
+	defer mu.Unlock()
+
+	total = Counters{}
+}
+
+// ReadSink aggregates the deltas appended to the sink file at path
+func ReadSink(path string) (Counters, error) {
+	var result Counters
+
+	file, err := os.Open(path)
+	if err != nil {
+		return result, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var delta Counters
+		if err := json.Unmarshal(scanner.Bytes(), &delta); err != nil {
+			return result, fmt.Errorf("parse %s: %w", path, err)
+		}
+		result.Add(delta)
+	}
+	return result, scanner.Err()
+}
+
+// WriteText writes c in a human-readable form, with keys sorted
+func WriteText(w io.Writer, c Counters) error {
+	var err error
+	printf := func(format string, args ...any) {
+		if err == nil {
+			_, err = fmt.Fprintf(w, format, args...)
+		}
+	}
+
+	printf("gogreement stats:\n")
+	printf("  files scanned:     %d\n", c.Files)
+	printf("  interfaces loaded: %d\n", c.Interfaces)
+	printf("  types loaded:      %d\n", c.Types)
+	printf("  annotations:\n")
+	for _, kind := range slices.Sorted(maps.Keys(c.Annotations)) {
+		printf("    %-12s %d\n", kind, c.Annotations[kind])
+	}
+	printf("  violations:\n")
+	for _, code := range slices.Sorted(maps.Keys(c.Violations)) {
+		printf("    %-12s %d\n", code, c.Violations[code])
+	}
+	return err
+}
+
+// WriteJSON writes c as an indented JSON object
+func WriteJSON(w io.Writer, c Counters) error {
+	if c.Annotations == nil {
+		c.Annotations = map[string]int{}
+	}
+	if c.Violations == nil {
+		c.Violations = map[string]int{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(c)
+}
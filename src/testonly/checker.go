@@ -37,8 +37,8 @@ func CheckTestOnly(
 
 	currentPkgPath := pass.Pkg.Path()
 
-	// Check all files (but skip test files as they can use @testonly items)
-	filesToCheck := cfg.FilterFiles(pass)
+	// Check all files in scope (but skip test files as they can use @testonly items)
+	filesToCheck := cfg.FilterFilesForCheck(pass, codes.TestOnlyCategoryPrefix)
 
 	context := testOnlyContext{
 		pass:            pass,
@@ -48,14 +48,20 @@ func CheckTestOnly(
 		testOnlyTypes:   &testOnlyTypes,
 	}
 
+	// productionFiles are the checked files that are neither tests nor test
+	// support code
+	productionFiles := make(map[string]bool)
+
 	for file := range filesToCheck {
 		fileName := pass.Fset.Position(file.Pos()).Filename
 		context.fileName = &fileName
 
-		// Check if this is a test file
-		if isTestFile(fileName) {
+		// Check if this is a test file. A file-level @testonly file is test
+		// support code itself, so its declarations may use each other.
+		if isTestFile(fileName) || annotations.IsTestOnlyFile(file) {
 			continue // Test files can use @testonly items
 		}
+		productionFiles[fileName] = true
 
 		// Track reported type violations per file to avoid spam. The key is the
 		// package-qualified type identity so equally named @testonly types from
@@ -91,6 +97,7 @@ func CheckTestOnly(
 				if isInTestOnlyContext(&context, node) {
 					return false // Don't inspect the body of @testonly functions
 				}
+				context.dynamicTypes = soleDynamicTypes(pass, node.Body)
 				return true
 
 			case *ast.CallExpr:
@@ -130,9 +137,88 @@ func CheckTestOnly(
 		})
 	}
 
+	for _, v := range findInterfaceExposures(&context, packageAnnotations, productionFiles) {
+		if !ignoreSet.Contains(v.Code, v.Pos) {
+			violations = append(violations, v)
+		}
+	}
+
 	return violations
 }
 
+// findInterfaceExposures reports the TONL20 advisory for production types
+// declared with @implements whose @testonly method implements a method of the
+// interface. Code holding the type as the interface calls the method without
+// naming it, which no TONL03 check sees. Only @implements interfaces are
+// considered, not every interface the type happens to satisfy; @testonly
+// types are test support as a whole and are left out.
+func findInterfaceExposures(
+	context *testOnlyContext,
+	packageAnnotations *annotations.PackageAnnotations,
+	productionFiles map[string]bool,
+) []TestOnlyViolation {
+	var violations []TestOnlyViolation
+
+	for _, annot := range packageAnnotations.ImplementsAnnotations {
+		fileName := context.pass.Fset.Position(annot.OnTypePos).Filename
+		if !productionFiles[fileName] || context.testOnlyTypes.Contains(*context.currentPkgPath, annot.OnType) {
+			continue
+		}
+
+		iface := lookupInterface(context.pass, annot.PackageFullPath, annot.InterfaceName)
+		if iface == nil {
+			continue
+		}
+
+		interfaceName := annot.InterfaceName
+		if annot.PackageName != "" {
+			interfaceName = annot.PackageName + "." + annot.InterfaceName
+		}
+
+		for method := range iface.Methods() {
+			if !context.testOnlyMethods.Match(*context.currentPkgPath, method.Name(), annot.OnType) {
+				continue
+			}
+			violations = append(violations, TestOnlyViolation{
+				Pos:         annot.OnTypePos,
+				TestOnlyObj: annot.OnType + "." + method.Name(),
+				Kind:        annotations.TestOnlyOnMethod,
+				UsedInFile:  fileName,
+				Reason: fmt.Sprintf("advisory: method %s on %s is marked @testonly and implements %s.%s, so production code can call it through the interface",
+					method.Name(), annot.OnType, interfaceName, method.Name()),
+				Code: codes.TestOnlyInterfaceMethod,
+			})
+		}
+	}
+
+	return violations
+}
+
+// lookupInterface returns the interface named name in the package pkgPath,
+// the current package or one of its imports, or nil when there is none
+func lookupInterface(pass *analysis.Pass, pkgPath string, name string) *types.Interface {
+	pkg := pass.Pkg
+	if pkgPath != "" && pkgPath != pkg.Path() {
+		pkg = nil
+		for _, imported := range pass.Pkg.Imports() {
+			if imported.Path() == pkgPath {
+				pkg = imported
+				break
+			}
+		}
+		if pkg == nil {
+			return nil
+		}
+	}
+
+	typeName, ok := pkg.Scope().Lookup(name).(*types.TypeName)
+	if !ok {
+		return nil
+	}
+	iface, _ := typeName.Type().Underlying().(*types.Interface)
+	return iface
+}
+
 type testOnlyContext struct {
 	pass            *analysis.Pass
 	testOnlyFuncs   *util.TypeAssociationRegistry
@@ -140,6 +226,9 @@ type testOnlyContext struct {
 	testOnlyTypes   *util.TypesMap
 	currentPkgPath  *string
 	fileName        *string
+	// dynamicTypes maps the interface-typed locals of the current function to
+	// the only concrete type ever assigned to them
+	dynamicTypes map[types.Object]types.Type
 }
 
 // isInTestOnlyContext checks if we're currently inside a @testonly function or method
@@ -218,8 +307,14 @@ func findFunctionCallViolation(
 			}
 		}
 
-		// Check if it's a method call (obj.Method)
-		typeInfo := util.ExtractTypeInfo(ctx.pass.TypesInfo.TypeOf(fun.X))
+		// Check if it's a method call (obj.Method). A call through an
+		// interface variable is resolved to the variable's sole dynamic type.
+		receiverType := ctx.pass.TypesInfo.TypeOf(fun.X)
+		via := ""
+		if types.IsInterface(receiverType) {
+			receiverType, via = ctx.dynamicTypeOf(fun.X), fmt.Sprintf(" (called through %s)", types.ExprString(fun.X))
+		}
+		typeInfo := util.ExtractTypeInfo(receiverType)
 		if typeInfo != nil {
 			methodName := fun.Sel.Name
 			if ctx.testOnlyMethods.Match(typeInfo.PkgPath, methodName, typeInfo.TypeName) {
@@ -228,7 +323,7 @@ func findFunctionCallViolation(
 					TestOnlyObj: fmt.Sprintf("%s.%s", typeInfo.TypeName, methodName),
 					Kind:        annotations.TestOnlyOnMethod,
 					UsedInFile:  *ctx.fileName,
-					Reason:      fmt.Sprintf("method %s on %s is marked @testonly and can only be called in test files", methodName, typeInfo.TypeName),
+					Reason:      fmt.Sprintf("method %s on %s is marked @testonly and can only be called in test files%s", methodName, typeInfo.TypeName, via),
 					Code:        codes.TestOnlyMethodCall,
 				}
 			}
@@ -237,6 +332,88 @@ func findFunctionCallViolation(
 	return nil
 }
 
+// dynamicTypeOf returns the sole concrete type held by the interface-typed
+// local expr, or nil when it is unknown
+func (ctx *testOnlyContext) dynamicTypeOf(expr ast.Expr) types.Type {
+	ident, ok := ast.Unparen(expr).(*ast.Ident)
+	if !ok {
+		return nil
+	}
+	return ctx.dynamicTypes[ctx.pass.TypesInfo.ObjectOf(ident)]
+}
+
+// soleDynamicTypes finds the interface-typed local variables of body that are
+// only ever assigned values of one concrete type (var x Iface = svc), so
+// calls through them can be resolved to that type's methods. A variable
+// assigned an interface value, or concrete values of different types, or
+// assigned from a multi-value call, has no known dynamic type.
+func soleDynamicTypes(pass *analysis.Pass, body *ast.BlockStmt) map[types.Object]types.Type {
+	if body == nil {
+		return nil
+	}
+
+	result := make(map[types.Object]types.Type)
+	unknown := make(map[types.Object]bool)
+
+	record := func(lhs ast.Expr, rhs ast.Expr) {
+		ident, ok := ast.Unparen(lhs).(*ast.Ident)
+		if !ok {
+			return
+		}
+		obj, ok := pass.TypesInfo.ObjectOf(ident).(*types.Var)
+		if !ok || !types.IsInterface(obj.Type()) || unknown[obj] {
+			return
+		}
+
+		var concrete types.Type
+		if rhs != nil {
+			concrete = pass.TypesInfo.TypeOf(rhs)
+		}
+		// Assigning nil leaves no value to call methods on
+		if basic, ok := concrete.(*types.Basic); ok && basic.Kind() == types.UntypedNil {
+			return
+		}
+		if concrete == nil || types.IsInterface(concrete) {
+			unknown[obj] = true
+			delete(result, obj)
+			return
+		}
+		if previous, ok := result[obj]; ok && !types.Identical(previous, concrete) {
+			unknown[obj] = true
+			delete(result, obj)
+			return
+		}
+		result[obj] = concrete
+	}
+
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			for i, lhs := range node.Lhs {
+				var rhs ast.Expr
+				if len(node.Lhs) == len(node.Rhs) {
+					rhs = node.Rhs[i]
+				}
+				record(lhs, rhs)
+			}
+		case *ast.ValueSpec:
+			for i, name := range node.Names {
+				if len(node.Values) == 0 {
+					continue // a zero value is nil, which has no methods
+				}
+				var value ast.Expr
+				if len(node.Names) == len(node.Values) {
+					value = node.Values[i]
+				}
+				record(name, value)
+			}
+		}
+		return true
+	})
+
+	return result
+}
+
 // findTypeLiteralViolation checks composite literals for @testonly types,
 // including slice/array/map element types (e.g. []TestHelper{...}).
 func findTypeLiteralViolation(
@@ -1,8 +1,12 @@
 package testonly
 
 import (
+	"fmt"
+
 	"github.com/a14e/gogreement/src/annotations"
+	"github.com/a14e/gogreement/src/codes"
 	"github.com/a14e/gogreement/src/config"
+	"github.com/a14e/gogreement/src/ignore"
 	"github.com/a14e/gogreement/src/testutil/testfacts"
 	"testing"
 
@@ -237,3 +241,76 @@ func TestCheckTestOnlyWithNoAnnotations(t *testing.T) {
 
 	assert.Empty(t, violations, "should have no violations when no @testonly annotations")
 }
+
+func TestFileLevelTestOnly(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "testonlyfile")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+
+	t.Run("annotations are synthesized for exported declarations", func(t *testing.T) {
+		var names []string
+		for _, a := range packageAnnotations.TestonlyAnnotations {
+			names = append(names, a.ObjectName)
+		}
+		assert.ElementsMatch(t, []string{"Fixture", "NewFixture", "Reset", "Seed"}, names,
+			"every exported declaration appears once; unexported ones are not covered")
+	})
+
+	violations := CheckTestOnly(cfg, pass, &packageAnnotations, nil)
+
+	var found []string
+	for _, v := range violations {
+		t.Logf("%s %s at %s", v.Code, v.TestOnlyObj, pass.Fset.Position(v.Pos))
+		assert.Contains(t, pass.Fset.Position(v.Pos).Filename, "consumer.go",
+			"uses inside the @testonly file itself are allowed")
+		found = append(found, v.Code+" "+v.TestOnlyObj)
+	}
+
+	assert.ElementsMatch(t, []string{
+		"TONL02 NewFixture",
+		"TONL03 Fixture.Reset",
+		"TONL02 Seed",
+		"TONL01 Fixture",
+	}, found)
+}
+
+func TestTestOnlyMethodThroughInterface(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "testonlyviolations")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+	violations := CheckTestOnly(cfg, pass, &packageAnnotations, nil)
+
+	var reasons []string
+	for _, v := range violations {
+		if v.TestOnlyObj == "Store.Reset" {
+			assert.Equal(t, codes.TestOnlyMethodCall, v.Code)
+			reasons = append(reasons, v.Reason)
+		}
+	}
+
+	assert.ElementsMatch(t, []string{
+		"method Reset on Store is marked @testonly and can only be called in test files",
+		"method Reset on Store is marked @testonly and can only be called in test files (called through r)",
+	}, reasons, "calls through a parameter or a variable holding several types are not resolved")
+}
+
+func TestTestOnlyMethodImplementsInterface(t *testing.T) {
+	pass := testfacts.CreateTestPassWithFacts(t, "testonlyiface")
+	cfg := config.Empty()
+	packageAnnotations := annotations.ReadAllAnnotations(cfg, pass)
+	ignoreSet := ignore.ReadIgnoreAnnotations(cfg, pass)
+
+	var found []string
+	for _, v := range CheckTestOnly(cfg, pass, &packageAnnotations, ignoreSet) {
+		if v.Code == codes.TestOnlyInterfaceMethod {
+			found = append(found, fmt.Sprintf("%d %s: %s", pass.Fset.Position(v.Pos).Line, v.TestOnlyObj, v.Reason))
+		}
+	}
+
+	// Cache.Reset is not @testonly, Buffer.Snapshot is not in io.Writer, Fake
+	// is a @testonly type and Legacy is ignored
+	assert.Equal(t, []string{
+		"19 Store.Reset: advisory: method Reset on Store is marked @testonly and implements Resetter.Reset, so production code can call it through the interface",
+		"35 Conn.Close: advisory: method Close on Conn is marked @testonly and implements io.Closer.Close, so production code can call it through the interface",
+	}, found)
+}
@@ -7,6 +7,7 @@ import (
 	"golang.org/x/tools/go/analysis"
 
 	"github.com/a14e/gogreement/src/annotations"
+	"github.com/a14e/gogreement/src/config"
 	"github.com/a14e/gogreement/src/reporting"
 )
 
@@ -40,11 +41,13 @@ func (v TestOnlyViolation) GetMessage() string {
 
 // ReportViolations reports testonly violations using the new pretty formatter
 // NOTE: violations should already be filtered by @ignore directives in CheckTestOnly
-func ReportViolations(pass *analysis.Pass, violations []TestOnlyViolation) {
-	reporter := reporting.NewReporter(pass, nil) // No ignore set needed, already filtered
+func ReportViolations(cfg *config.Config, pass *analysis.Pass, violations []TestOnlyViolation) {
+	reporter := reporting.NewReporter(cfg, pass, nil) // No ignore set needed, already filtered
 
 	// Convert to generic violations and report
+	generic := make([]reporting.Violation, 0, len(violations))
 	for _, violation := range violations {
-		reporter.ReportViolation(violation)
+		generic = append(generic, violation)
 	}
+	reporter.ReportViolations(generic)
 }
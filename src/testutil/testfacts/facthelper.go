@@ -28,14 +28,22 @@ func CreateTestPassWithFacts(t *testing.T, pkgName string, additionalPkgs ...str
 	}
 
 	pass.ImportPackageFact = func(pkg *types.Package, fact analysis.Fact) bool {
+		// The consolidated fact is derived from the package's annotations
+		if contracts, ok := fact.(*annotations.ContractsFact); ok {
+			var packageAnnotations annotations.PackageAnnotations
+			if !pass.ImportPackageFact(pkg, &packageAnnotations) {
+				return false
+			}
+			*contracts = annotations.NewContractsFact(&packageAnnotations)
+			return true
+		}
+
 		// Handle all fact types
 		var targetAnnotations *annotations.PackageAnnotations
 
 		switch ptr := fact.(type) {
 		case *annotations.ImmutableCheckerFact:
 			targetAnnotations = (*annotations.PackageAnnotations)(ptr)
-		case *annotations.ConstructorCheckerFact:
-			targetAnnotations = (*annotations.PackageAnnotations)(ptr)
 		case *annotations.TestOnlyCheckerFact:
 			targetAnnotations = (*annotations.PackageAnnotations)(ptr)
 		case *annotations.PackageOnlyCheckerFact:
@@ -44,6 +52,20 @@ func CreateTestPassWithFacts(t *testing.T, pkgName string, additionalPkgs ...str
 			targetAnnotations = (*annotations.PackageAnnotations)(ptr)
 		case *annotations.ImplementsCheckerFact:
 			targetAnnotations = (*annotations.PackageAnnotations)(ptr)
+		case *annotations.SinceCheckerFact:
+			targetAnnotations = (*annotations.PackageAnnotations)(ptr)
+		case *annotations.EnumCheckerFact:
+			targetAnnotations = (*annotations.PackageAnnotations)(ptr)
+		case *annotations.RequiredCheckerFact:
+			targetAnnotations = (*annotations.PackageAnnotations)(ptr)
+		case *annotations.SingletonCheckerFact:
+			targetAnnotations = (*annotations.PackageAnnotations)(ptr)
+		case *annotations.MustReturnCheckerFact:
+			targetAnnotations = (*annotations.PackageAnnotations)(ptr)
+		case *annotations.ExperimentalCheckerFact:
+			targetAnnotations = (*annotations.PackageAnnotations)(ptr)
+		case *annotations.DeprecatedPackageCheckerFact:
+			targetAnnotations = (*annotations.PackageAnnotations)(ptr)
 		case *annotations.PackageAnnotations:
 			targetAnnotations = ptr
 		default:
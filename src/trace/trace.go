@@ -0,0 +1,226 @@
+// Package trace links annotations to the findings reported against them for
+// the --trace-contracts flag.
+//
+// The annotation reader records every annotation of a package with its
+// position, and the reporter records each finding of a violation that names
+// the annotation it breaks. Like the stats package, every record is kept in a
+// process-wide list and, when SinkEnv is set, appended to that file as one
+// JSON line; the gogreement command aggregates the file with ReadSink once its
+// child process exits.
+package trace
+
+import (
+	"bufio"
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"sync"
+)
+
+// SinkEnv names the environment variable holding the file that Add appends
+// records to. It is set by the gogreement command for its child process.
+const SinkEnv = "GOGREEMENT_TRACE_FILE"
+
+// Key identifies an annotation: its kind ("immutable"), the package and name
+// of the declaration it is written on ("Config", "Config.Name" for fields and
+// methods, empty for the package itself) and, for annotations naming something, what they name as written
+// ("io.Reader" for @implements)
+type Key struct {
+	Kind     string `json:"kind"`
+	Package  string `json:"package"`
+	Name     string `json:"name"`
+	Argument string `json:"argument,omitempty"`
+}
+
+// Position is a position in a source file
+type Position struct {
+	File   string `json:"file"`
+	Line   int    `json:"line"`
+	Column int    `json:"column"`
+}
+
+// Finding is a reported violation of an annotation
+type Finding struct {
+	Code     string   `json:"code"`
+	Message  string   `json:"message"`
+	Position Position `json:"position"`
+}
+
+// Record is one entry of a run: an annotation declared at Declared, or a
+// Finding attributed to the annotation
+type Record struct {
+	Key      Key       `json:"key"`
+	Declared *Position `json:"declared,omitempty"`
+	Finding  *Finding  `json:"finding,omitempty"`
+}
+
+// Contract is an annotation with the findings attributed to it
+type Contract struct {
+	Kind     string    `json:"kind"`
+	Target   string    `json:"target"`
+	Argument string    `json:"argument,omitempty"`
+	Position Position  `json:"position"`
+	Findings []Finding `json:"findings"`
+}
+
+var (
+	mu      sync.Mutex
+	records []Record
+)
+
+// Add keeps records in the process-wide list and, when SinkEnv is set,
+// appends them to the sink file
+func Add(batch ...Record) {
+	if len(batch) == 0 {
+		return
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	records = append(records, batch...)
+
+	path := os.Getenv(SinkEnv)
+	if path == "" {
+		return
+	}
+	var lines []byte
+	for _, record := range batch {
+		line, err := json.Marshal(record)
+		if err != nil {
+			return
+		}
+		lines = append(append(lines, line...), '\n')
+	}
+	sink, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return
+	}
+	defer sink.Close()
+	_, _ = sink.Write(lines)
+}
+
+// Total returns the contracts recorded in this process
+func Total() []Contract {
+	mu.Lock()
+	defer mu.Unlock()
+
+	return Aggregate(records)
+}
+
+// Reset clears the records of this process
+func Reset() {
+	mu.Lock()
+	defer mu.Unlock()
+
+	records = nil
+}
+
+// ReadSink aggregates the records appended to the sink file at path
+func ReadSink(path string) ([]Contract, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var result []Record
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		var record Record
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			return nil, fmt.Errorf("parse %s: %w", path, err)
+		}
+		result = append(result, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return Aggregate(result), nil
+}
+
+// Aggregate attaches every finding to the annotations with its key and
+// returns the annotations sorted by position. Packages analyzed twice (as
+// test variants) record the same annotations and findings twice; duplicates
+// are dropped. Findings of annotations that were not recorded are dropped too.
+func Aggregate(records []Record) []Contract {
+	type declared struct {
+		key      Key
+		position Position
+	}
+
+	var result []Contract
+	seen := make(map[declared]bool)
+	byKey := make(map[Key][]int)
+	for _, record := range records {
+		if record.Declared == nil {
+			continue
+		}
+		decl := declared{key: record.Key, position: *record.Declared}
+		if seen[decl] {
+			continue
+		}
+		seen[decl] = true
+
+		byKey[record.Key] = append(byKey[record.Key], len(result))
+		result = append(result, Contract{
+			Kind:     record.Key.Kind,
+			Target:   target(record.Key),
+			Argument: record.Key.Argument,
+			Position: *record.Declared,
+			Findings: []Finding{},
+		})
+	}
+
+	attributed := make(map[Key]map[Finding]bool)
+	for _, record := range records {
+		if record.Finding == nil || attributed[record.Key][*record.Finding] {
+			continue
+		}
+		if attributed[record.Key] == nil {
+			attributed[record.Key] = make(map[Finding]bool)
+		}
+		attributed[record.Key][*record.Finding] = true
+
+		for _, i := range byKey[record.Key] {
+			result[i].Findings = append(result[i].Findings, *record.Finding)
+		}
+	}
+
+	for i := range result {
+		slices.SortFunc(result[i].Findings, func(a, b Finding) int {
+			return cmp.Or(comparePositions(a.Position, b.Position), cmp.Compare(a.Code, b.Code), cmp.Compare(a.Message, b.Message))
+		})
+	}
+	slices.SortStableFunc(result, func(a, b Contract) int {
+		return cmp.Or(comparePositions(a.Position, b.Position), cmp.Compare(a.Kind, b.Kind), cmp.Compare(a.Argument, b.Argument))
+	})
+	return result
+}
+
+// target is the declaration a key names, or its package for annotations on
+// the package itself
+func target(key Key) string {
+	if key.Name == "" {
+		return key.Package
+	}
+	return key.Package + "." + key.Name
+}
+
+func comparePositions(a, b Position) int {
+	return cmp.Or(cmp.Compare(a.File, b.File), cmp.Compare(a.Line, b.Line), cmp.Compare(a.Column, b.Column))
+}
+
+// WriteJSON writes contracts as an indented JSON array
+func WriteJSON(w io.Writer, contracts []Contract) error {
+	if contracts == nil {
+		contracts = []Contract{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(contracts)
+}
@@ -0,0 +1,26 @@
+package util
+
+import (
+	"go/ast"
+	"go/types"
+)
+
+// CalledFunction returns the function denoted by fun, the callee expression of
+// a call, unwrapping parentheses and explicit instantiations. It returns nil
+// when fun is not a named function or method, such as a function value held
+// in a variable.
+func CalledFunction(info *types.Info, fun ast.Expr) *types.Func {
+	switch f := ast.Unparen(fun).(type) {
+	case *ast.Ident:
+		fn, _ := info.Uses[f].(*types.Func)
+		return fn
+	case *ast.SelectorExpr:
+		fn, _ := info.Uses[f.Sel].(*types.Func)
+		return fn
+	case *ast.IndexExpr:
+		return CalledFunction(info, f.X)
+	case *ast.IndexListExpr:
+		return CalledFunction(info, f.X)
+	}
+	return nil
+}
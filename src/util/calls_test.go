@@ -0,0 +1,59 @@
+package util
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const callsSource = `package calls
+
+type T struct{}
+
+func (T) M() int { return 0 }
+
+func F() int { return 0 }
+
+func G[X any](x X) X { return x }
+
+func calls(t T) {
+	F()
+	(F)()
+	t.M()
+	G[int](1)
+	G(2)
+	v := F
+	v()
+	func() {}()
+}
+`
+
+func TestCalledFunction(t *testing.T) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "calls.go", callsSource, 0)
+	require.NoError(t, err)
+
+	info := &types.Info{Uses: map[*ast.Ident]types.Object{}}
+	_, err = new(types.Config).Check("calls", fset, []*ast.File{file}, info)
+	require.NoError(t, err)
+
+	var called []string
+	ast.Inspect(file, func(n ast.Node) bool {
+		if call, ok := n.(*ast.CallExpr); ok {
+			name := "<nil>"
+			if fn := CalledFunction(info, call.Fun); fn != nil {
+				name = fn.Name()
+			}
+			called = append(called, name)
+		}
+		return true
+	})
+
+	assert.Equal(t, []string{"F", "F", "M", "G", "G", "<nil>", "<nil>"}, called,
+		"parentheses and instantiations are unwrapped; function values and literals are not named functions")
+}
@@ -1,6 +1,10 @@
 package util
 
-import "strings"
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
 
 // NormalizeCommentText rewrites a single-line block comment (/* @x ... */) into
 // the line-comment form (// @x ...) so annotation regexes that require a "//"
@@ -12,3 +16,53 @@ func NormalizeCommentText(text string) string {
 	}
 	return text
 }
+
+// CommentLines returns the lines of a comment in the line-comment form that
+// annotation regexes expect. A line comment or single-line block comment is
+// one line (see NormalizeCommentText); a multi-line block comment yields one
+// "// ..." line per non-blank line, with the leading " * " of the common
+// boxed style removed, so an annotation on any of its lines is recognized.
+// Unicode spaces are replaced first (see NormalizeSpaces).
+func CommentLines(text string) []string {
+	text = NormalizeSpaces(text)
+	if !strings.HasPrefix(text, "/*") || !strings.Contains(text, "\n") {
+		return []string{NormalizeCommentText(text)}
+	}
+
+	inner := strings.TrimSuffix(strings.TrimPrefix(text, "/*"), "*/")
+	var lines []string
+	for _, line := range strings.Split(inner, "\n") {
+		line = strings.TrimSpace(line)
+		if stripped, ok := strings.CutPrefix(line, "*"); ok && !strings.HasPrefix(stripped, "/") {
+			line = strings.TrimSpace(stripped)
+		}
+		if line != "" {
+			lines = append(lines, "// "+line)
+		}
+	}
+	return lines
+}
+
+// NormalizeSpaces replaces the Unicode spaces that copy-pasted comments pick
+// up, such as the no-break space U+00A0 and the zero-width space U+200B, with
+// a plain space. Annotation regexes match \s, which is ASCII only, so
+// "//\u00a0@immutable" would otherwise not be an annotation. Other characters
+// are kept as they are.
+func NormalizeSpaces(text string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= utf8.RuneSelf && (unicode.IsSpace(r) || isZeroWidth(r)) {
+			return ' '
+		}
+		return r
+	}, text)
+}
+
+// isZeroWidth reports whether r is one of the invisible format characters
+// that separate words without taking up space
+func isZeroWidth(r rune) bool {
+	switch r {
+	case '\u200B', '\u200C', '\u200D', '\u2060', '\uFEFF':
+		return true
+	}
+	return false
+}
@@ -16,11 +16,17 @@ func NewTypeAssociationRegistry() TypeAssociationRegistry {
 // pkgPath: package path (use "" for current package)
 // associatedName: name of the associated item (function, field, method, etc.)
 // typeName: name of the type this item relates to
+// Adding an association that already exists is a no-op, so a type declared in
+// several build-tagged files is indexed once.
 func (tar TypeAssociationRegistry) Add(pkgPath string, associatedName string, typeName string) {
 	if tar[pkgPath] == nil {
 		tar[pkgPath] = make(map[string][]string)
 	}
 
+	if tar.Match(pkgPath, associatedName, typeName) {
+		return
+	}
+
 	tar[pkgPath][typeName] = append(tar[pkgPath][typeName], associatedName)
 }
 
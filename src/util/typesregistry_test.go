@@ -174,9 +174,10 @@ func TestFuncMap_DuplicateAdd(t *testing.T) {
 	fm.Add("pkg", "NewUser", "User")
 	fm.Add("pkg", "NewUser", "User") // Duplicate
 
-	// Should have both entries
+	// Duplicates (e.g. one declaration per build-tagged file) are stored once
 	constructors := fm.GetAssociated("pkg", "User")
-	assert.Equal(t, 2, len(constructors))
+	assert.Equal(t, []string{"NewUser"}, constructors)
+	assert.Equal(t, 1, fm.Len())
 
 	// Match should still work
 	assert.True(t, fm.Match("pkg", "NewUser", "User"))
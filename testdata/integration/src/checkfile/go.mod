@@ -0,0 +1,3 @@
+module checkfile
+
+go 1.23
@@ -0,0 +1,8 @@
+package modA
+
+// Point is declared in another package than its users
+// @immutable
+type Point struct {
+	X int
+	Y int
+}
@@ -0,0 +1,8 @@
+package modB
+
+import "checkfile/modA"
+
+// Shift is in the checked file, so its finding is reported
+func Shift(p *modA.Point) {
+	p.X = 1 // IMM01 from the imported contract
+}
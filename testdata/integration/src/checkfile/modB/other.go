@@ -0,0 +1,8 @@
+package modB
+
+import "checkfile/modA"
+
+// Lift is in another file of the package, so its finding is left out
+func Lift(p *modA.Point) {
+	p.Y = 2
+}
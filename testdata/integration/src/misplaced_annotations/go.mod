@@ -0,0 +1,3 @@
+module misplaced_annotations
+
+go 1.23
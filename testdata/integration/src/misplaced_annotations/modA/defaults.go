@@ -0,0 +1,18 @@
+package modA
+
+type Settings struct {
+	Name string
+}
+
+// Defaults builds the default settings
+// @immutable
+func Defaults() Settings { // want `\[ANN01\] @immutable has no effect on functions and is ignored; it belongs on types`
+	return Settings{Name: "default"}
+}
+
+// Legacy builds the settings of the old format
+// @immutable
+// @ignore ANN01
+func Legacy() Settings {
+	return Settings{Name: "legacy"}
+}
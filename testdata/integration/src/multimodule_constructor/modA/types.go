@@ -1,4 +1,4 @@
-package modA // want package:"package modA"
+package modA
 
 // User represents a user entity
 // @immutable
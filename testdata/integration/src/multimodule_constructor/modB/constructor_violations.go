@@ -1,4 +1,4 @@
-package modB // want package:"package modB"
+package modB
 
 import "multimodule_constructor/modA"
 
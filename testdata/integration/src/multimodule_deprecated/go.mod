@@ -0,0 +1,3 @@
+module multimodule_deprecated
+
+go 1.23
@@ -0,0 +1,6 @@
+package modA // want package:"package modA"
+
+// Fetch returns the resource at url
+func Fetch(url string) string {
+	return "v1:" + url
+}
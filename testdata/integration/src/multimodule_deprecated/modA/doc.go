@@ -0,0 +1,4 @@
+// Package modA is the first version of the client
+//
+//gogreement:deprecated use multimodule_deprecated/modC
+package modA
@@ -0,0 +1,10 @@
+package modB // want package:"package modB"
+
+import (
+	"multimodule_deprecated/modA" // want `\[DEPPKG01\] package "multimodule_deprecated/modA" is deprecated; use "multimodule_deprecated/modC" instead`
+	"multimodule_deprecated/modC"
+)
+
+func Load() string {
+	return modA.Fetch("a") + modC.Fetch("c")
+}
@@ -0,0 +1,9 @@
+package modB
+
+// The migration compares both versions until modA is removed
+// @ignore DEPPKG01
+import "multimodule_deprecated/modA"
+
+func Compare(url string) bool {
+	return modA.Fetch(url) == url
+}
@@ -0,0 +1,7 @@
+// Package modC replaces modA
+package modC
+
+// Fetch returns the resource at url
+func Fetch(url string) string {
+	return "v2:" + url
+}
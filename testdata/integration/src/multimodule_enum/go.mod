@@ -0,0 +1,3 @@
+module multimodule_enum
+
+go 1.23
@@ -0,0 +1,16 @@
+package modA // want package:"package modA"
+
+// Status of an order
+// @enum
+type Status int
+
+const (
+	Pending Status = iota
+	Shipped
+	Delivered
+)
+
+// Draft is created locally, which the declaring package may do
+func Draft() Status {
+	return Status(-1)
+}
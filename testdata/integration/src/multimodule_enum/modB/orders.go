@@ -0,0 +1,21 @@
+package modB // want package:"package modB"
+
+import "multimodule_enum/modA"
+
+func Ship() modA.Status {
+	return modA.Shipped
+}
+
+func FromCode() modA.Status {
+	return modA.Status(2)
+}
+
+func Invalid() modA.Status {
+	return modA.Status(7) // want `\[ENUM01\] value 7 is not a declared constant of @enum type "Status"`
+}
+
+// Legacy values are accepted here on purpose
+// @ignore ENUM01
+func Legacy() modA.Status {
+	return modA.Status(9)
+}
@@ -0,0 +1,3 @@
+module multimodule_experimental
+
+go 1.23
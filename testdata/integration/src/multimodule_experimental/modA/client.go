@@ -0,0 +1,15 @@
+package modA // want package:"package modA"
+
+// Client talks to the service
+type Client struct{}
+
+// Send is stable API
+func (c *Client) Send(msg string) error {
+	return nil
+}
+
+// Subscribe is the new streaming API
+// @experimental
+func (c *Client) Subscribe(topic string) <-chan string {
+	return make(chan string)
+}
@@ -0,0 +1,17 @@
+package modB // want package:"package modB"
+
+import "multimodule_experimental/modA"
+
+func Publish(c *modA.Client) error {
+	return c.Send("hello")
+}
+
+func Stream(c *modA.Client) <-chan string {
+	return c.Subscribe("news") // want `\[EXP01\] "Client.Subscribe" is marked @experimental and may change; use it only in files built with the "experimental" tag \(//go:build experimental\)`
+}
+
+// The preview is behind a feature switch
+// @ignore EXP01
+func Preview(c *modA.Client) <-chan string {
+	return c.Subscribe("preview")
+}
@@ -57,3 +57,9 @@ type TestHelper struct {
 func CreateTestData() string {
 	return "test"
 }
+
+// Rename mutates User inside its own package, which is reported without the
+// external-package wording
+func Rename(u *User, name string) {
+	u.Name = name // want `immutability violation in type "User": cannot assign to field "Name"`
+}
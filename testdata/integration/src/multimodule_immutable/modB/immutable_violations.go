@@ -3,7 +3,7 @@ package modB // want package:"package modB"
 import "multimodule_immutable/modA"
 
 func MutateUser(u *modA.User) {
-	u.Name = "modified" // want "cannot assign to field"
+	u.Name = "modified" // want `immutability violation: external package mutates immutable type "multimodule_immutable/modA.User": cannot assign to field "Name"`
 }
 
 func MutateConfig(cfg *modA.Config) {
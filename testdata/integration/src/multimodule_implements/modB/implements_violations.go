@@ -94,3 +94,38 @@ type MultipleIgnoredType struct {
 type AllIgnoredType struct {
 	data string
 }
+
+// === signature mismatch (IMPL04) ===
+
+// MismatchedReader has Read, but with the wrong parameter type
+// @implements io.Reader
+type MismatchedReader struct { // want "\\[IMPL04\\].*does not implement interface.*io.Reader"
+	data string
+}
+
+func (r MismatchedReader) Read(p string) (n int, err error) {
+	return 0, nil
+}
+
+// IgnoredMismatchedReader should not report IMPL04 due to @ignore
+// @ignore IMPL04
+// @implements io.Reader
+type IgnoredMismatchedReader struct {
+	data string
+}
+
+func (r IgnoredMismatchedReader) Read(p string) (n int, err error) {
+	return 0, nil
+}
+
+// IgnoredMismatchMissingClose ignores only the signature mismatch of Read;
+// the missing Close is still reported as IMPL03
+// @ignore IMPL04
+// @implements io.ReadCloser
+type IgnoredMismatchMissingClose struct { // want "\\[IMPL03\\].*does not implement interface.*io.ReadCloser"
+	data string
+}
+
+func (r IgnoredMismatchMissingClose) Read(p string) (n int, err error) {
+	return 0, nil
+}
@@ -0,0 +1,3 @@
+module multimodule_localonly
+
+go 1.23
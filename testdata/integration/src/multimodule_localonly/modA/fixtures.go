@@ -0,0 +1,13 @@
+package modA // want package:"package modA"
+
+// CreateFixture builds test data
+// @testonly
+func CreateFixture() string {
+	return "fixture"
+}
+
+// Seed uses the fixture outside tests; @testonly is still checked within
+// its own package
+func Seed() string {
+	return CreateFixture() // want "marked @testonly"
+}
@@ -0,0 +1,9 @@
+package modB // want package:"package modB"
+
+import "multimodule_localonly/modA"
+
+// Load calls the fixture from another package. @testonly is local-only, so
+// modA does not export it and nothing is reported here.
+func Load() string {
+	return modA.CreateFixture()
+}
@@ -0,0 +1,3 @@
+module multimodule_mustreturn
+
+go 1.23
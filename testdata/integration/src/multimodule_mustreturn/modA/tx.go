@@ -0,0 +1,18 @@
+package modA // want package:"package modA"
+
+import "errors"
+
+// Tx is a database transaction
+type Tx struct {
+	done bool
+}
+
+// Commit finishes the transaction; the error tells whether it was applied
+// @mustreturn
+func (t *Tx) Commit() error {
+	if t.done {
+		return errors.New("transaction already finished")
+	}
+	t.done = true
+	return nil
+}
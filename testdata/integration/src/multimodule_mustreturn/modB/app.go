@@ -0,0 +1,17 @@
+package modB // want package:"package modB"
+
+import "multimodule_mustreturn/modA"
+
+func Save(tx *modA.Tx) error {
+	return tx.Commit()
+}
+
+func SaveBlindly(tx *modA.Tx) {
+	tx.Commit() // want `\[RET01\] result of method Commit on Tx is discarded; it is marked @mustreturn and must be used`
+}
+
+// The transaction is known to be open here
+// @ignore RET01
+func SaveOnce(tx *modA.Tx) {
+	_ = tx.Commit()
+}
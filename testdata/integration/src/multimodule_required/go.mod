@@ -0,0 +1,3 @@
+module multimodule_required
+
+go 1.23
@@ -0,0 +1,13 @@
+package modA // want package:"package modA"
+
+// Config must always name its environment
+type Config struct {
+	// @required
+	Env string
+
+	Debug bool
+}
+
+func Default() Config {
+	return Config{Env: "dev"}
+}
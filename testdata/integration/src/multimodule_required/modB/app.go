@@ -0,0 +1,17 @@
+package modB // want package:"package modB"
+
+import "multimodule_required/modA"
+
+func Production() modA.Config {
+	return modA.Config{Env: "prod"}
+}
+
+func Debug() modA.Config {
+	return modA.Config{Debug: true} // want `\[REQ01\] composite literal of type "Config" does not set required field "Env"`
+}
+
+// Tests fill in the environment later
+// @ignore REQ01
+func Blank() modA.Config {
+	return modA.Config{}
+}
@@ -0,0 +1,3 @@
+module multimodule_requireimpl
+
+go 1.23
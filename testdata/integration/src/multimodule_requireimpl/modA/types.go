@@ -0,0 +1,41 @@
+package modA // want package:"package modA"
+
+import (
+	"fmt"
+	"io"
+)
+
+// Buffer reads through its pointer method set but does not declare it
+type Buffer struct { // want `\[IMPL06\] type "Buffer" implements "io.Reader" but does not declare it; add "// @implements &io.Reader" to its doc comment`
+	data []byte
+}
+
+func (b *Buffer) Read(p []byte) (int, error) {
+	n := copy(p, b.data)
+	b.data = b.data[n:]
+	return n, io.EOF
+}
+
+// Name is a fmt.Stringer through its value method set
+type Name string // want `\[IMPL06\] type "Name" implements "fmt.Stringer" but does not declare it; add "// @implements fmt.Stringer" to its doc comment`
+
+func (n Name) String() string { return string(n) }
+
+// Sink documents its contract, so it is not reported
+// @implements &io.Writer
+type Sink struct{}
+
+func (s *Sink) Write(p []byte) (int, error) { return len(p), nil }
+
+// Either is covered by its @implements-oneof group
+// @implements-oneof fmt.Stringer, io.Reader
+type Either struct{}
+
+func (Either) String() string { return "either" }
+
+// quiet is unexported, so it is not part of the API
+type quiet struct{}
+
+func (quiet) String() string { return "quiet" }
+
+var _ = fmt.Sprint(quiet{})
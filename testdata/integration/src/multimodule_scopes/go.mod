@@ -0,0 +1,3 @@
+module multimodule_scopes
+
+go 1.23
@@ -0,0 +1,9 @@
+package handlers // want package:"package handlers"
+
+import "multimodule_scopes/pkg/domain"
+
+// Reset mutates Order outside the immutable checker's scope, so nothing is
+// reported here
+func Reset(o *domain.Order) {
+	o.Total = 0
+}
@@ -0,0 +1,12 @@
+package domain // want package:"package domain"
+
+// Order is immutable, but the immutable checker is scoped to pkg/domain/**
+// @immutable
+type Order struct {
+	Total int
+}
+
+// Discount is inside the scope, so the mutation is reported
+func Discount(o *Order) {
+	o.Total = o.Total / 2 // want "cannot assign to field"
+}
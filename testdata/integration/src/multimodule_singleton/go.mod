@@ -0,0 +1,3 @@
+module multimodule_singleton
+
+go 1.23
@@ -0,0 +1,9 @@
+package modA // want package:"package modA" package:"package modA"
+
+// Registry holds every plugin of the process
+// @singleton
+type Registry struct {
+	Plugins []string
+}
+
+var Global = &Registry{}
@@ -0,0 +1,13 @@
+package modB // want package:"package modB" package:"package modB"
+
+import "multimodule_singleton/modA"
+
+func Fresh() *modA.Registry {
+	return &modA.Registry{} // want `\[SGL01\] @singleton type "Registry" is constructed more than once; it is first constructed in package "multimodule_singleton/modA"`
+}
+
+// Tests build their own registry
+// @ignore SGL01
+func ForTest() *modA.Registry {
+	return &modA.Registry{}
+}
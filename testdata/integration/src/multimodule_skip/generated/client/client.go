@@ -0,0 +1,9 @@
+package client // want package:"package client"
+
+import "multimodule_skip/modA"
+
+// Refresh mutates Token, but the package is in SkipPackages, so nothing is
+// reported here
+func Refresh(t *modA.Token) {
+	t.Value = "refreshed"
+}
@@ -0,0 +1,3 @@
+module multimodule_skip
+
+go 1.23
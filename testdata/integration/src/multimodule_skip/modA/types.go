@@ -0,0 +1,13 @@
+package modA // want package:"package modA"
+
+// Token is an immutable value shared by the generated client and modB
+// @immutable
+// @constructor NewToken
+type Token struct {
+	Value string
+}
+
+// NewToken creates a new Token
+func NewToken(value string) *Token {
+	return &Token{Value: value}
+}
@@ -0,0 +1,8 @@
+package modB // want package:"package modB"
+
+import "multimodule_skip/modA"
+
+// Refresh is checked normally: its sibling package is skipped, not this one
+func Refresh(t *modA.Token) {
+	t.Value = "refreshed" // want "cannot assign to field"
+}
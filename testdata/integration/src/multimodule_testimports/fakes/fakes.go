@@ -0,0 +1,8 @@
+package fakes
+
+import "time"
+
+// Clock is only imported by test files of modA
+type Clock interface {
+	Now() time.Time
+}
@@ -0,0 +1,3 @@
+module multimodule_testimports
+
+go 1.23
@@ -0,0 +1,6 @@
+package modA
+
+// Service does not import fakes; only its tests do
+type Service struct {
+	name string
+}
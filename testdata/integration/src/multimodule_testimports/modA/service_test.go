@@ -0,0 +1,27 @@
+package modA
+
+import (
+	"testing"
+	"time"
+
+	"multimodule_testimports/fakes"
+)
+
+// fixedClock resolves fakes.Clock through the test-only import
+// @implements fakes.Clock
+type fixedClock struct {
+	at time.Time
+}
+
+func (c fixedClock) Now() time.Time { return c.at }
+
+// brokenClock lacks Now, which proves the interface was loaded
+// @implements fakes.Clock
+type brokenClock struct{} // ❌ VIOLATION (IMPL03)
+
+func TestService(t *testing.T) {
+	var clock fakes.Clock = fixedClock{}
+	_ = clock
+	_ = brokenClock{}
+	_ = Service{name: "test"}
+}
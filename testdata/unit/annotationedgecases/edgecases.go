@@ -10,11 +10,57 @@ type (
 	}
 )
 
+// A group doc comment applies to every type of the group.
+// @immutable
+type (
+	GroupedFirst struct {
+		Name string
+	}
+	GroupedSecond struct {
+		Value int
+	}
+)
+
+// A spec doc comment applies to its own type only.
+type (
+	// @immutable
+	GroupedAnnotatedSpec struct {
+		Name string
+	}
+	GroupedPlainSpec struct {
+		Name string
+	}
+)
+
 /* @immutable */
 type BlockCommented struct {
 	Value int
 }
 
+/*
+MultiLineBlock is documented in a multi-line block comment, as code
+generators often emit.
+
+@immutable
+@constructor NewMultiLineBlock
+*/
+type MultiLineBlock struct {
+	/* @mutable */
+	Hits int
+}
+
+/*
+ * BoxedBlock uses the boxed style with a leading asterisk on every line.
+ * @immutable
+ */
+type BoxedBlock struct{}
+
+/*
+NewMultiLineBlock builds a MultiLineBlock.
+@testonly
+*/
+func NewMultiLineBlock() MultiLineBlock { return MultiLineBlock{} }
+
 // The same annotation on both the group and the spec must be counted once.
 // @immutable
 type (
@@ -0,0 +1,33 @@
+package anonimmutable
+
+// Point is immutable
+// @immutable
+type Point struct {
+	X, Y int
+}
+
+// Shape is an immutable interface
+// @immutable
+type Shape interface {
+	Area() int
+}
+
+type square struct {
+	side int
+}
+
+func (s square) Area() int { return s.side * s.side }
+
+// Assign stores anonymous struct values in variables of immutable types
+func Assign() (Point, Shape) {
+	var p Point
+	p = struct{ X, Y int }{1, 2}               // IMM190: converts to Point
+	var q Point = struct{ X, Y int }{3, 4}     // IMM190
+	var s Shape = &struct{ square }{square{2}} // IMM190: satisfies Shape through square
+	var named Point = Point{X: 5, Y: 6}        // OK: a Point
+	raw := struct{ X, Y int }{7, 8}            // OK: not an immutable variable
+	converted := Point(raw)                    // OK: the conversion yields a Point
+	var shape Shape = square{side: 3}          // OK: a named type
+	_, _, _, _ = q, named, converted, shape
+	return p, s
+}
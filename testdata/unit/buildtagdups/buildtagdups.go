@@ -0,0 +1,6 @@
+package buildtagdups
+
+// Closer is implemented by Handle on every platform.
+type Closer interface {
+	Close() error
+}
@@ -0,0 +1,23 @@
+//go:build !linux
+
+package buildtagdups
+
+// Handle is declared once per platform. Only one of the files is part of any
+// given build, but both carry the same annotations.
+// @immutable
+// @constructor NewHandle
+// @implements Closer
+type Handle struct {
+	fd uintptr
+	// @mutable
+	closed bool
+}
+
+func NewHandle(fd uintptr) *Handle {
+	return &Handle{fd: fd}
+}
+
+func (h *Handle) Close() error {
+	h.closed = true
+	return nil
+}
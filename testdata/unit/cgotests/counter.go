@@ -0,0 +1,17 @@
+package cgotests
+
+// Counter is immutable and declared next to a cgo file
+// @immutable
+// @constructor NewCounter
+type Counter struct {
+	value int
+}
+
+func NewCounter(value int) *Counter {
+	return &Counter{value: value}
+}
+
+// Grow mutates the counter
+func (c *Counter) Grow() {
+	c.value = Twice(c.value) // ❌ VIOLATION: cannot assign to field of immutable type
+}
@@ -0,0 +1,35 @@
+package conflicttests
+
+import "io"
+
+// File claims and denies io.Reader
+// @implements io.Reader
+// @notimplements io.Reader
+type File struct{} // ❌ VIOLATION: ANN02 at both annotations
+
+// Buffer implements io.Writer through its pointer only
+// @implements &io.Writer
+// @notimplements io.Writer
+type Buffer struct{} // ✅ OK: *Buffer implements io.Writer, Buffer does not
+
+func (*Buffer) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+// Conn denies that even *Conn implements io.Closer
+// @implements io.Closer
+// @notimplements &io.Closer
+type Conn struct{} // ❌ VIOLATION: ANN02, *Conn has every method of Conn
+
+func (Conn) Close() error {
+	return nil
+}
+
+// Plain only denies
+// @notimplements io.Reader
+type Plain struct{} // ✅ OK: nothing claims the opposite
+
+// Read makes File an io.Reader
+func (File) Read(p []byte) (int, error) {
+	return 0, io.EOF
+}
@@ -41,6 +41,10 @@ func MakeConfigWrong() *Config {
 	return &Config{Host: "test"} // ❌ VIOLATION
 }
 
+func OverrideFreshConfig() {
+	(&Config{}).Host = "test" // ❌ VIOLATION: the addressed literal is built outside a constructor
+}
+
 // Database with pointer literal
 // @constructor NewDatabase
 type Database struct {
@@ -167,3 +171,108 @@ func NewGadget() *Gadget {
 }
 
 var packageGadget = Gadget{Name: "pkg"} // ❌ VIOLATION: package-level instantiation (no constructor leak)
+
+// Holder embeds and nests Gadget. Building a Holder must not become a way
+// around Gadget's constructor, however deep the Gadget literal sits.
+type Holder struct {
+	Gadget
+	Extra  *Gadget
+	Items  []Gadget
+	ByName map[string]*Gadget
+}
+
+// Shelf nests a Holder, putting Gadget two literals deep.
+type Shelf struct {
+	Holder Holder
+}
+
+func MakeHolder() Holder {
+	return Holder{
+		Gadget: Gadget{Name: "embedded"},    // ❌ VIOLATION: keyed embedded field
+		Extra:  &Gadget{Name: "extra"},      // ❌ VIOLATION: nested pointer literal
+		Items:  []Gadget{{Name: "item"}},    // ❌ VIOLATION: elided element type
+		ByName: map[string]*Gadget{"a": {}}, // ❌ VIOLATION: elided &Gadget{} map value
+	}
+}
+
+func MakeHolderPositional() Holder {
+	return Holder{Gadget{Name: "positional"}, nil, nil, nil} // ❌ VIOLATION: positional embedded field
+}
+
+func MakeShelf() Shelf {
+	return Shelf{Holder: Holder{Gadget: Gadget{}}} // ❌ VIOLATION: two levels deep
+}
+
+func NewGadgetHolder() Holder {
+	return Holder{Gadget: *NewGadget()} // ✅ OK: Gadget built by its constructor
+}
+
+// Settings delegates parts of its construction to helpers.
+// @constructor NewSettings, DefaultSettings, NewSettingsRef, NewSettingsCopy
+type Settings struct {
+	Name    string
+	Retries int
+}
+
+func NewSettings(name string) Settings {
+	s := buildSettings(name) // ❌ ADVISORY (CTOR20): buildSettings is an unofficial constructor
+	s.Retries = 3
+	return s
+}
+
+func DefaultSettings() (Settings, error) {
+	return NewSettings("default"), nil // ✅ OK: delegates to a declared constructor
+}
+
+func NewSettingsRef() *Settings {
+	s := loadSettingsRef() // ✅ OK: a pointer result does not produce a new value
+	return s
+}
+
+func NewSettingsCopy(s Settings) Settings {
+	return firstOf(s, s) // ✅ OK: generic helper returning its type parameter
+}
+
+func buildSettings(name string) Settings {
+	return Settings{Name: name} // ❌ VIOLATION: not a declared constructor
+}
+
+func loadSettingsRef() *Settings {
+	return nil
+}
+
+func firstOf[T any](a, _ T) T {
+	return a
+}
+
+// Report has a constructor function and an unrelated method sharing its name;
+// only the function is the constructor
+// @constructor Build
+type Report struct {
+	Title string
+}
+
+func Build(title string) *Report {
+	return &Report{Title: title} // ✅ OK: the package-level Build
+}
+
+// Builder declares methods named like constructors
+type Builder struct{}
+
+func (Builder) Build(title string) *Report {
+	return &Report{Title: title} // ❌ VIOLATION: a method is never a constructor
+}
+
+// Draft names a constructor that only exists as a method
+// @constructor Compose
+type Draft struct { // ❌ VIOLATION (CTOR25): Compose is a method of Builder
+	Body string
+}
+
+func (Builder) Compose() Draft {
+	return Draft{} // ❌ VIOLATION: a method is never a constructor
+}
+
+// Memo names a constructor that does not exist
+// @constructor NewMemo
+type Memo struct{} // ❌ VIOLATION (CTOR25): NewMemo is not declared
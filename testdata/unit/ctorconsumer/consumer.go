@@ -8,3 +8,23 @@ import "github.com/a14e/gogreement/testdata/unit/ctorsource"
 func NewWidget() ctorsource.Widget {
 	return ctorsource.Widget{Value: 1} // ❌ VIOLATION: cross-package instantiation
 }
+
+// MakeGauge constructs a @constructor-local type from another package, which
+// is allowed.
+func MakeGauge() *ctorsource.Gauge {
+	return &ctorsource.Gauge{Level: 3} // ✅ OK: restriction is local to ctorsource
+}
+
+// Panel exposes a Widget through an exported field
+type Panel struct {
+	Title string
+	Inner ctorsource.Widget
+}
+
+// MakePanel constructs a Widget through the keyed field of the Panel literal
+func MakePanel() Panel {
+	return Panel{
+		Title: "main",
+		Inner: ctorsource.Widget{Value: 2}, // ❌ VIOLATION: reported at the inner literal
+	}
+}
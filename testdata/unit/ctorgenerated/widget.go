@@ -0,0 +1,16 @@
+package ctorgenerated
+
+// Widget is built by its constructor or by the generated builders
+// @constructor NewWidget
+type Widget struct {
+	name string
+}
+
+func NewWidget(name string) *Widget {
+	return &Widget{name: name}
+}
+
+// handmade is not generated and not a constructor
+func handmade() Widget {
+	return Widget{name: "handmade"} // ❌ VIOLATION: not a constructor
+}
@@ -0,0 +1,16 @@
+package ctorsource
+
+// Gauge may be constructed freely by other packages, but code in this package
+// must use NewGauge.
+// @constructor-local NewGauge
+type Gauge struct {
+	Level int
+}
+
+func NewGauge(level int) *Gauge {
+	return &Gauge{Level: level} // ✅ OK: in the declared constructor
+}
+
+func defaultGauge() Gauge {
+	return Gauge{} // ❌ VIOLATION: internal literal outside the constructor (CTOR01)
+}
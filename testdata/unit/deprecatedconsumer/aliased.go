@@ -0,0 +1,7 @@
+package deprecatedconsumer
+
+import old "github.com/a14e/gogreement/testdata/unit/deprecatedsource" // ❌ VIOLATION: DEPPKG01, renamed imports too
+
+func FetchTwice() error {
+	return old.Retry(2, func() error { return nil })
+}
@@ -0,0 +1,11 @@
+package deprecatedconsumer
+
+import (
+	"errors"
+
+	"github.com/a14e/gogreement/testdata/unit/deprecatedsource" // ❌ VIOLATION: DEPPKG01
+)
+
+func Fetch() error {
+	return deprecatedsource.Retry(3, func() error { return errors.New("unavailable") })
+}
@@ -0,0 +1,9 @@
+package deprecatedconsumer
+
+import (
+	"github.com/a14e/gogreement/testdata/unit/deprecatedsource" // @ignore DEPPKG01
+)
+
+func FetchOnce() error {
+	return deprecatedsource.Retry(1, func() error { return nil })
+}
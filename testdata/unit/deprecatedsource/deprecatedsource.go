@@ -0,0 +1,11 @@
+// Package deprecatedsource is the old home of the retry helpers
+//
+//gogreement:deprecated use github.com/a14e/gogreement/testdata/unit/deprecatedsource/v2
+package deprecatedsource
+
+import "github.com/a14e/gogreement/testdata/unit/deprecatedsource/internal/legacy"
+
+// Retry calls f up to attempts times
+func Retry(attempts int, f func() error) error {
+	return legacy.Retry(attempts, f)
+}
@@ -0,0 +1,12 @@
+package legacy
+
+// Retry calls f up to attempts times
+func Retry(attempts int, f func() error) error {
+	var err error
+	for range attempts {
+		if err = f(); err == nil {
+			return nil
+		}
+	}
+	return err
+}
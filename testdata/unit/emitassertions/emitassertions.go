@@ -0,0 +1,53 @@
+package emitassertions
+
+import (
+	_ "fmt" // referenced only by annotations
+	_ "io"
+	"strings"
+)
+
+// Sizer is a local interface
+type Sizer interface {
+	Size() int
+}
+
+// Buffer writes through a pointer receiver
+// @implements &io.Writer
+type Buffer struct {
+	data []byte
+}
+
+func (b *Buffer) Write(p []byte) (int, error) {
+	b.data = append(b.data, p...)
+	return len(p), nil
+}
+
+// Name is a value implementing two interfaces
+// @implements fmt.Stringer
+// @implements Sizer
+type Name string
+
+func (n Name) String() string { return string(n) }
+func (n Name) Size() int      { return len(n) }
+
+// Upper declares its receiver form explicitly
+// @implements fmt.Stringer via *Upper
+type Upper struct {
+	value string
+}
+
+func (u *Upper) String() string { return strings.ToUpper(u.value) }
+
+// Box is generic, which cannot be asserted without type arguments
+// @implements Sizer
+type Box[T any] struct {
+	items []T
+}
+
+func (b Box[T]) Size() int { return len(b.items) }
+
+// Either has no compile-time form for "one of"
+// @implements-oneof io.Reader, Sizer
+type Either struct{}
+
+func (Either) Size() int { return 0 }
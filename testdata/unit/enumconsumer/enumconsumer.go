@@ -0,0 +1,32 @@
+package enumconsumer
+
+import "github.com/a14e/gogreement/testdata/unit/enumsource"
+
+func useDeclaredConstants() []enumsource.Color {
+	return []enumsource.Color{enumsource.Red, enumsource.Blue} // ✅ Declared constants
+}
+
+func convertDeclaredValue() enumsource.Color {
+	return enumsource.Color(1) // ✅ 1 is the value of Green
+}
+
+func convertOutOfRange() enumsource.Color {
+	return enumsource.Color(99) // ❌ VIOLATION: ENUM01, 99 is not a declared Color
+}
+
+func convertUndeclaredString() enumsource.Level {
+	return enumsource.Level("trace") // ❌ VIOLATION: ENUM01, "trace" is not a declared Level
+}
+
+func convertConstantExpression() enumsource.Color {
+	const offset = 40
+	return enumsource.Color(offset + 2) // ❌ VIOLATION: ENUM01, 42 is not a declared Color
+}
+
+func convertVariable(n int) enumsource.Color {
+	return enumsource.Color(n) // ✅ Only constant arguments are checked
+}
+
+func convertNonEnum() enumsource.Weight {
+	return enumsource.Weight(99) // ✅ Weight is not an @enum
+}
@@ -0,0 +1,28 @@
+package enumsource
+
+// Color is an enum: its values are the constants below
+// @enum
+type Color int
+
+const (
+	Red Color = iota
+	Green
+	Blue
+)
+
+// Level is an enum with string values
+// @enum
+type Level string
+
+const (
+	Debug Level = "debug"
+	Info  Level = "info"
+)
+
+// Weight is not an enum, any value may be constructed
+type Weight int
+
+// Unknown is built inside the declaring package, which may create any value
+func Unknown() Color {
+	return Color(99)
+}
@@ -0,0 +1,22 @@
+package experimentalconsumer
+
+import "github.com/a14e/gogreement/testdata/unit/experimentalsource"
+
+func Publish(c *experimentalsource.Client) error {
+	return c.Send("hello") // ✅ OK: stable API
+}
+
+func Stream(c *experimentalsource.Client) <-chan string {
+	return c.Subscribe("news") // ❌ VIOLATION: EXP01
+}
+
+func Deliver(c *experimentalsource.Client, batch experimentalsource.Batch) error { // ❌ VIOLATION: EXP01
+	return experimentalsource.Retry(3, func() error { // ❌ VIOLATION: EXP01
+		return c.Send(batch.Requests[0])
+	})
+}
+
+// @ignore EXP01
+func Preview(c *experimentalsource.Client) <-chan string {
+	return c.Subscribe("preview") // ✅ OK: ignored
+}
@@ -0,0 +1,11 @@
+//go:build experimental
+
+package experimentalconsumer
+
+import "github.com/a14e/gogreement/testdata/unit/experimentalsource"
+
+// Files built only with the opt-in tag may use @experimental declarations
+
+func StreamAll(c *experimentalsource.Client) (<-chan string, experimentalsource.Batch) { // ✅ OK
+	return c.Subscribe("all"), experimentalsource.Batch{} // ✅ OK
+}
@@ -0,0 +1,50 @@
+package experimentalsource
+
+import "errors"
+
+// Client talks to the service
+type Client struct {
+	topics []string
+}
+
+// NewClient creates a Client
+func NewClient() *Client {
+	return &Client{}
+}
+
+// Send is stable API
+func (c *Client) Send(msg string) error {
+	if msg == "" {
+		return errors.New("empty message")
+	}
+	return nil
+}
+
+// Subscribe is the new streaming API
+// @experimental
+func (c *Client) Subscribe(topic string) <-chan string {
+	c.topics = append(c.topics, topic)
+	return make(chan string)
+}
+
+// Batch groups requests; its shape may still change
+// @experimental
+type Batch struct {
+	Requests []string
+}
+
+// Retry calls f until it succeeds, at most attempts times
+// @experimental
+func Retry(attempts int, f func() error) error {
+	var err error
+	for range attempts {
+		if err = f(); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+func internalUse() error {
+	return Retry(1, func() error { return nil }) // ✅ OK: the declaring package
+}
@@ -0,0 +1,21 @@
+package ignorecodes
+
+// Config is a plain type assigned below
+type Config struct {
+	Name string
+}
+
+// @ignore IMM01
+func Rename(c *Config) {
+	c.Name = "renamed"
+}
+
+// @ignore IMM99 there is no such code
+func Reset(c *Config) {
+	c.Name = ""
+}
+
+func Update(c *Config) {
+	c.Name = "a" // @ignore IMM, ALL
+	c.Name = "b" // @ignore CTOR01, BOGUS1
+}
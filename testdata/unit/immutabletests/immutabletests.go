@@ -1,6 +1,13 @@
 package immutabletests
 
 import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+	"unsafe"
+
 	"github.com/a14e/gogreement/testdata/unit/interfacesforloading"
 )
 
@@ -347,3 +354,835 @@ func (s *Shadower) NoFalsePositive() {
 var _ = func(p *Person) {
 	p.Name = "from-package-literal" // ❌ VIOLATION: mutation in a package-level func literal
 }
+
+// Watcher stores a callback that mutates the watcher itself
+// @immutable
+// @constructor NewWatcher
+type Watcher struct {
+	count    int
+	onChange func()
+}
+
+func NewWatcher() *Watcher {
+	w := &Watcher{}
+	// The closure escapes through the field and runs after construction,
+	// so the constructor exemption does not cover its body.
+	w.onChange = func() { w.count++ } // ✅ OK: assignment in constructor, ❌ VIOLATION: w.count++
+	return w
+}
+
+// Rewire replaces the callback of an immutable Watcher
+func Rewire(w *Watcher) {
+	w.onChange = func() { w.count++ } // ❌ VIOLATION: assignment to onChange and w.count++
+}
+
+// Test that mutations through a local holding the address of an immutable
+// value are detected
+
+// Settings is an immutable value type mutated through pointer aliases
+// @immutable
+// @constructor NewSettings
+type Settings struct {
+	Level int
+	EmbeddedInner
+}
+
+func NewSettings() Settings {
+	s := Settings{}
+	ptr := &s
+	ptr.Level = 1 // ✅ OK: in constructor
+	return s
+}
+
+func MutateSettingsThroughAlias(s Settings) {
+	ptr := &s
+	ptr.Level = 2 // ❌ VIOLATION: field write through alias of immutable value (IMM01)
+}
+
+func OverwriteSettingsThroughAlias(s Settings) {
+	var ptr *Settings
+	ptr = &s
+	*ptr = Settings{} // ❌ VIOLATION: overwrite through alias of immutable value (IMM01)
+}
+
+func MutateSettingsEmbeddedThroughAlias(s Settings) {
+	inner := &s.EmbeddedInner
+	inner.Field = 3 // ❌ VIOLATION: write to embedded storage of immutable value (IMM01)
+}
+
+func ReuseSettingsAlias(s Settings) {
+	inner := &s.EmbeddedInner
+	_ = inner
+	inner = &EmbeddedInner{}
+	inner.Field = 4 // ✅ OK: alias was rebound to an unrelated value
+}
+
+// Test that go and defer statements are covered
+
+// Session is mutated by deferred calls and goroutines
+// @immutable
+// @constructor NewSession
+type Session struct {
+	hits   int
+	closed bool
+}
+
+// reset mutates the receiver, so calling it via go/defer is reported
+func (s *Session) reset() {
+	s.hits = 0 // ❌ VIOLATION: assignment in a method of an immutable type (IMM01)
+}
+
+// Hits only reads the receiver
+func (s *Session) Hits() int {
+	return s.hits
+}
+
+func NewSession() *Session {
+	s := &Session{}
+	defer s.reset() // ✅ OK: deferred call runs inside the constructor
+	go func() {     // the goroutine may outlive the constructor
+		s.closed = true // ❌ VIOLATION: mutation in a goroutine started by the constructor
+	}()
+	return s
+}
+
+func UseSession(s *Session) {
+	defer s.reset() // ❌ VIOLATION: deferred call to a mutator method
+	go s.reset()    // ❌ VIOLATION: goroutine call to a mutator method
+	defer s.Hits()  // ✅ OK: Hits does not mutate
+	go func() {
+		s.hits++ // ❌ VIOLATION: goroutine mutating an immutable field (IMM03)
+	}()
+	defer func() {
+		s.closed = true // ❌ VIOLATION: deferred closure mutating an immutable field
+	}()
+}
+
+// Tally is mutated by mutator methods called in method-expression form
+// @immutable
+// @constructor NewTally
+type Tally struct {
+	count int
+}
+
+// clear mutates the receiver
+func (t *Tally) clear() {
+	t.count = 0 // ❌ VIOLATION: assignment in a method of an immutable type (IMM01)
+}
+
+// Count only reads the receiver
+func (t Tally) Count() int {
+	return t.count
+}
+
+func NewTally() *Tally {
+	t := &Tally{}
+	defer (*Tally).clear(t) // ✅ OK: deferred call runs inside the constructor
+	return t
+}
+
+func UseTally(t *Tally) {
+	defer (*Tally).clear(t)   // ❌ VIOLATION: deferred method expression mutating the explicit receiver
+	go (*Tally).clear(t)      // ❌ VIOLATION: goroutine method expression mutating the explicit receiver
+	defer (*Tally).Count(t)   // ✅ OK: Count does not mutate
+	defer Tally.Count(*t)     // ✅ OK: value method expression that only reads
+	defer ((*Tally).clear)(t) // ❌ VIOLATION: parenthesized method expression
+}
+
+// Badge is used to check writes through value and pointer receivers
+// @immutable
+type Badge struct {
+	Label string
+	Uses  int
+	Meta  BadgeMeta
+}
+
+type BadgeMeta struct {
+	Color string
+}
+
+// Touch writes fields of its own copy of the receiver
+func (b Badge) Touch() Badge {
+	b.Label = "touched"  // ✅ OK: value receiver, the write affects a copy
+	b.Uses++             // ✅ OK: value receiver copy
+	b.Meta.Color = "red" // ✅ OK: nested field of the copy
+	return b
+}
+
+// Retouch writes fields through a pointer receiver
+func (b *Badge) Retouch() {
+	b.Label = "touched" // ❌ VIOLATION: pointer receiver writes the caller's value
+	b.Uses++            // ❌ VIOLATION
+}
+
+// Endpoint is stored in interface values and asserted back before mutation
+// @immutable
+// @constructor NewEndpoint
+type Endpoint struct {
+	URL     string
+	Retries int
+}
+
+func NewEndpoint(url string) any {
+	return &Endpoint{URL: url}
+}
+
+// MutateAssertedEndpoint mutates an Endpoint recovered from an interface value
+func MutateAssertedEndpoint(v any) {
+	ep := v.(*Endpoint)
+	ep.URL = "http://other" // ❌ VIOLATION: asserted pointer to an immutable type
+
+	if ep2, ok := v.(*Endpoint); ok {
+		ep2.Retries++ // ❌ VIOLATION: comma-ok assertion
+	}
+
+	v.(*Endpoint).Retries = 3 // ❌ VIOLATION: write directly through the assertion
+
+	switch e := v.(type) {
+	case *Endpoint:
+		e.URL = "" // ❌ VIOLATION: type switch binding
+	}
+}
+
+// Tree is a recursive immutable type
+// @immutable
+// @constructor NewTree
+type Tree struct {
+	value    int
+	children []*Tree
+}
+
+func NewTree(value int, children ...*Tree) *Tree {
+	t := &Tree{}
+	t.value = value       // ✅ OK: in constructor
+	t.children = children // ✅ OK: in constructor
+	return t
+}
+
+// Chain embeds a pointer to itself
+// @immutable
+type Chain struct {
+	*Chain
+	id int
+}
+
+// MutateTree mutates a recursive immutable type at several depths
+func MutateTree(t *Tree, c *Chain) int {
+	t.children = nil                       // ❌ VIOLATION: field of the root
+	t.children[0] = nil                    // ❌ VIOLATION: element of the root's slice
+	t.children[0].value = 1                // ❌ VIOLATION: field of a child
+	t.children[0].children[1].value++      // ❌ VIOLATION: field of a grandchild
+	c.Chain.Chain.id = 2                   // ❌ VIOLATION: through the embedded self pointer
+	return t.children[0].children[0].value // ✅ OK: read only
+}
+
+// Meter holds its counter behind a pointer. Reassigning the field is a
+// violation; writing the pointee is only reported with --check-pointees.
+// @immutable
+// @constructor NewMeter
+type Meter struct {
+	counterPtr *int
+	// @mutable
+	scratchPtr *int
+}
+
+func NewMeter() *Meter {
+	n := 0
+	m := &Meter{counterPtr: &n, scratchPtr: &n}
+	*m.counterPtr = 1 // ✅ OK: inside constructor
+	return m
+}
+
+func UseMeter(m *Meter) {
+	n := 5
+	m.counterPtr = &n  // ❌ VIOLATION (IMM01): reassigns the field
+	*m.counterPtr += 1 // ⚠️ ADVISORY (IMM130): mutates the pointee
+	*m.counterPtr = 7  // ⚠️ ADVISORY (IMM130): mutates the pointee
+	(*m.counterPtr)++  // ⚠️ ADVISORY (IMM130): mutates the pointee
+	*m.scratchPtr = 0  // ✅ OK: @mutable field
+	total := *m.counterPtr + 1
+	_ = total
+}
+
+// Rewind works on a copy of the Meter, but the copy shares its pointees
+func (m Meter) Rewind() {
+	*m.counterPtr = 0  // ⚠️ ADVISORY (IMM130): the pointee is not part of the copy
+	m.counterPtr = nil // ✅ OK: value receiver, the write affects the copy
+}
+
+// Handler is an immutable func type. It has no fields, so the only mutation
+// is reassigning the receiver itself.
+// @immutable
+type Handler func(string) error
+
+// Replace swaps the handler behind the pointer
+func (h *Handler) Replace(next Handler) {
+	*h = next // ❌ VIOLATION: reassigns the immutable receiver
+}
+
+// Later returns a closure that clears the captured receiver when run
+func (h *Handler) Later() func() {
+	return func() {
+		*h = nil // ❌ VIOLATION: the closure mutates the captured receiver
+	}
+}
+
+// Serve only calls the receiver
+func (h Handler) Serve(path string) error {
+	return h(path) // ✅ OK: calling a func value does not mutate it
+}
+
+// ServePtr calls the receiver through its pointer
+func (h *Handler) ServePtr(path string) error {
+	next := *h        // ✅ OK: reading the receiver
+	_ = next          // ✅ OK
+	return (*h)(path) // ✅ OK: calling through the pointer
+}
+
+// Ledger has a constructor Open and a method Open; only the function is
+// exempt from the immutability checks
+// @immutable
+// @constructor Open
+type Ledger struct {
+	entries int
+}
+
+func Open() *Ledger {
+	l := &Ledger{}
+	l.entries = 1 // ✅ OK: inside the constructor
+	return l
+}
+
+func (l *Ledger) Open() {
+	l.entries = 0 // ❌ VIOLATION: the method is not the constructor
+}
+
+// OverrideFresh writes fields of freshly built Config literals outside any
+// constructor. The literals themselves also bypass the constructors.
+func OverrideFresh(host string) {
+	(&Config{}).host = host // ❌ VIOLATION: field of an addressed literal
+	(&Config{}).port++      // ❌ VIOLATION: field of an addressed literal
+}
+
+// Scratchpad is immutable in name only: its sole field is @mutable
+// @immutable
+type Scratchpad struct { // ⚠️ ADVISORY (MUT03): the only field is @mutable
+	// @mutable
+	notes []string
+}
+
+// Buffers marks every field @mutable
+// @immutable
+type Buffers struct { // ⚠️ ADVISORY (MUT03): every field is @mutable
+	// @mutable
+	in []byte
+	// @mutable
+	out []byte
+	_   int
+}
+
+// Mailbox is written from switch cases and select comm clauses
+// @immutable
+// @constructor NewMailbox
+type Mailbox struct {
+	last  string
+	count int
+}
+
+func NewMailbox(ch chan string) *Mailbox {
+	m := &Mailbox{}
+	select {
+	case m.last = <-ch: // ✅ OK: inside the constructor
+	default:
+	}
+	return m
+}
+
+func Receive(m *Mailbox, ch chan string) {
+	select {
+	case m.last = <-ch: // ❌ VIOLATION: assignment in the comm clause itself
+	case v := <-ch: // ✅ OK: declares a local
+		m.count = len(v) // ❌ VIOLATION: assignment in the clause body
+	}
+}
+
+func Apply(m *Mailbox, command string, arg any) {
+	switch command {
+	case "reset":
+		m.count = 0 // ❌ VIOLATION: assignment in a case body
+	}
+
+	switch v := arg.(type) {
+	case int:
+		m.count += v // ❌ VIOLATION: compound assignment in a type switch case
+	}
+}
+
+// Setting is held in slices and arrays whose elements are addressed
+// @immutable
+type Setting struct {
+	key   string
+	value int
+}
+
+func TuneAll(settings []Setting, fixed *[4]Setting) {
+	for i := range settings {
+		p := &settings[i]
+		p.value++       // ❌ VIOLATION: field write through the element's address
+		p.key = "tuned" // ❌ VIOLATION: field write through the element's address
+		*p = Setting{}  // ❌ VIOLATION: overwrite through the element's address
+		copied := settings[i]
+		copied.value = 0 // ❌ VIOLATION: a copy is a Setting like any other
+	}
+
+	q := &fixed[0]
+	q.value = 1 // ❌ VIOLATION: element of an array behind a pointer
+}
+
+func ReadAll(settings []Setting) int {
+	total := 0
+	for i := range settings {
+		p := &settings[i]
+		total += p.value // ✅ OK: only reads
+	}
+	return total
+}
+
+// Cursor is written inside labeled loops and goto targets
+// @immutable
+// @constructor NewCursor
+type Cursor struct {
+	offset int
+	line   int
+}
+
+func NewCursor(lines [][]byte) *Cursor {
+	c := &Cursor{}
+outer:
+	for i, line := range lines {
+		for _, b := range line {
+			if b == '\n' {
+				c.line = i // ✅ OK: labeled loop inside the constructor
+				continue outer
+			}
+		}
+	}
+	return c
+}
+
+func Seek(c *Cursor, lines [][]byte) {
+scan:
+	for i := range lines {
+		if len(lines[i]) == 0 {
+			c.offset = i // ❌ VIOLATION: assignment in a labeled loop
+			break scan
+		}
+	}
+
+	if c.line > 0 {
+		goto reset
+	}
+	return
+reset:
+	c.line = 0 // ❌ VIOLATION: assignment after a goto target
+}
+
+// Registry holds a map and a slice emptied with the clear builtin
+// @immutable
+// @constructor NewRegistry
+type Registry struct {
+	entries map[string]int
+	order   []string
+	// @mutable
+	scratch map[string]int
+}
+
+func NewRegistry(seed map[string]int) *Registry {
+	r := &Registry{entries: seed, scratch: map[string]int{}}
+	clear(r.order) // ✅ OK: inside the constructor
+	return r
+}
+
+func (r Registry) Reset() {
+	clear(r.entries) // ❌ VIOLATION: the copy shares its map with the original
+}
+
+func Flush(r *Registry) {
+	clear(r.order)   // ❌ VIOLATION: clearing a slice field
+	clear(r.scratch) // ✅ OK: @mutable field
+	local := map[string]int{}
+	clear(local) // ✅ OK: not a field
+}
+
+// Handle exposes itself through an identity-returning helper
+// @immutable
+type Handle struct {
+	Name    string
+	Version int
+}
+
+func (h *Handle) self() *Handle { return h }
+
+func (h *Handle) checked() *Handle {
+	if h == nil {
+		panic("nil handle")
+	}
+	return h
+}
+
+func Rename(h *Handle) {
+	h.self().Name = "renamed"   // ❌ VIOLATION: IMM140, the helper returns the receiver
+	(h.self()).Version++        // ❌ VIOLATION: IMM140
+	h.self().self().Name += "!" // ❌ VIOLATION: IMM140
+	h.checked().Name = "again"  // ❌ VIOLATION: IMM01, the body is more than the return
+}
+
+func lookupHandle(handles map[string]*Handle, name string) *Handle { return handles[name] }
+
+func Retitle(handles map[string]*Handle) {
+	lookupHandle(handles, "a").Name += "!" // ❌ VIOLATION: any call returning *Handle
+}
+
+// Frame is pooled, which contradicts its immutability
+// @immutable
+type Frame struct {
+	payload []byte
+}
+
+var framePool = sync.Pool{
+	New: func() any {
+		return &Frame{} // ❌ ADVISORY: the pool creates immutable values
+	},
+}
+
+var bufferPool = sync.Pool{
+	New: func() any { return new([]byte) }, // ✅ OK: not an immutable type
+}
+
+func Release(f *Frame, buf *[]byte) {
+	framePool.Put(f)    // ❌ ADVISORY: an immutable value goes back to the pool
+	bufferPool.Put(buf) // ✅ OK: not an immutable type
+}
+
+// Knob is written through the copy returned by a generic helper
+// @immutable
+type Knob struct {
+	Level int
+}
+
+// Ptr returns the address of a copy of v
+func Ptr[T any](v T) *T {
+	return &v
+}
+
+func TurnKnob(k Knob) {
+	k.Level = 1      // ❌ VIOLATION: a write to the immutable value
+	Ptr(k).Level = 2 // ✅ OK: Ptr(k) points at Ptr's own copy of k
+}
+
+// Window keeps its samples in a fixed-size array behind a pointer
+// @immutable
+type Window struct {
+	samples *[4]int
+}
+
+// Reset writes every element through the pointer; indexing a pointer to an
+// array dereferences it implicitly
+func (w *Window) Reset() {
+	for i := range w.samples {
+		w.samples[i] = 0 // ❌ VIOLATION: IMM04
+	}
+}
+
+// ResetExplicit spells out the dereference
+func (w Window) ResetExplicit() {
+	for i := range w.samples {
+		(*w.samples)[i] = 0 // ❌ VIOLATION: IMM04, the value receiver shares the array
+	}
+}
+
+// Sum only reads the elements
+func (w *Window) Sum() int {
+	total := 0
+	for i := range w.samples {
+		total += w.samples[i] // ✅ OK: read
+	}
+	return total
+}
+
+// Gauge is mutated through method values stored in collections
+// @immutable
+type Gauge struct {
+	value int
+}
+
+// zero mutates the receiver
+func (g *Gauge) zero() {
+	g.value = 0 // ❌ VIOLATION: assignment in a method of an immutable type (IMM01)
+}
+
+// Value only reads the receiver
+func (g *Gauge) Value() int {
+	return g.value
+}
+
+func RegisterGauge(g *Gauge) []func() {
+	handlers := map[string]func(){}
+	handlers["zero"] = g.zero // ❌ VIOLATION: IMM160, a mutator stored in a map
+	handlers["zero"]()
+
+	hooks := []func(){g.zero}     // ❌ VIOLATION: IMM160, a mutator in a slice literal
+	hooks = append(hooks, g.zero) // ❌ VIOLATION: IMM160, a mutator appended to a slice
+
+	readers := map[string]func() int{"value": g.Value} // ✅ OK: Value does not mutate
+	_ = readers
+
+	reset := g.zero // ✅ OK: not stored in a collection
+	_ = reset
+	return hooks
+}
+
+// Engine is immutable and reached through a pointer field of a mutable type
+// @immutable
+type Engine struct {
+	Power int
+}
+
+// EngineAlias names Engine under another name
+type EngineAlias = Engine
+
+// Car is not immutable; its fields may be reassigned
+type Car struct {
+	engine *Engine
+	spare  *EngineAlias
+	Name   string
+}
+
+func TuneCar(c *Car) {
+	c.Name = "tuned"       // ✅ OK: Car is not immutable
+	c.engine = &Engine{}   // ✅ OK: reassigning Car's own field
+	c.engine.Power = 200   // ❌ VIOLATION: writes a field of the immutable Engine
+	c.spare.Power = 100    // ❌ VIOLATION: the alias resolves to Engine
+	(*c.engine).Power += 1 // ❌ VIOLATION: explicit dereference of the pointer field
+	cars := []Car{*c}
+	cars[0].engine.Power = 0 // ❌ VIOLATION: through an indexed Car
+}
+
+// Profile is immutable and loaded from JSON in its constructor
+// @immutable
+// @constructor LoadProfile
+type Profile struct {
+	Limits  map[string]int
+	Retries int
+	// @mutable
+	Overrides map[string]int
+}
+
+func LoadProfile(data []byte) (*Profile, error) {
+	s := &Profile{}
+	if err := json.Unmarshal(data, &s.Limits); err != nil { // ✅ OK: in constructor
+		return nil, err
+	}
+	return s, nil
+}
+
+func ReloadProfile(s *Profile, data []byte, line string) {
+	_ = json.Unmarshal(data, &s.Limits)        // ❌ VIOLATION: IMM170, json.Unmarshal writes the field
+	_, _ = fmt.Sscan(line, &s.Retries)         // ❌ VIOLATION: IMM170, fmt.Sscan writes the field
+	_ = json.Unmarshal(data, &s.Overrides)     // ✅ OK: @mutable field
+	_ = json.NewDecoder(nil).Decode(&s.Limits) // ❌ VIOLATION: IMM170, the decoder writes the field
+	var copied Profile
+	_ = json.Unmarshal(data, &copied) // ✅ OK: only field addresses are checked
+	_ = fmt.Sprint(&s.Retries)        // ✅ OK: fmt.Sprint only reads
+}
+
+// Ticket is immutable; renaming it must produce a new value
+// @immutable
+type Ticket struct {
+	ID   int
+	Name string
+}
+
+// Rename rebuilds the receiver from its own fields, which still overwrites it
+func (t *Ticket) Rename(name string) {
+	*t = Ticket{ID: t.ID, Name: name} // ❌ VIOLATION: rebuilding the receiver from a literal
+}
+
+// Clear reassigns the receiver from another value
+func (t *Ticket) Clear(empty Ticket) {
+	*t = empty // ❌ VIOLATION: cannot reassign immutable receiver
+}
+
+// Renamed returns the rebuilt value instead
+func (t *Ticket) Renamed(name string) Ticket {
+	return Ticket{ID: t.ID, Name: name} // ✅ OK: a new value
+}
+
+// WordCount counts words; its map and slice stay fixed after construction
+// @immutable
+// @constructor NewWordCount
+type WordCount struct {
+	counts map[string]int
+	items  []int
+}
+
+func NewWordCount(words []string) *WordCount {
+	t := &WordCount{counts: make(map[string]int), items: make([]int, len(words))}
+	for i, word := range words {
+		t.counts[word]++ // ✅ OK: in constructor
+		t.items[i]--     // ✅ OK: in constructor
+	}
+	return t
+}
+
+// Observe increments elements of the immutable fields
+func (t *WordCount) Observe(word string, i int) {
+	t.counts[word]++ // ❌ VIOLATION: increment of a map element (IMM04)
+	t.items[i]--     // ❌ VIOLATION: decrement of a slice element (IMM04)
+}
+
+// Quota is immutable; its getters must not hand out field addresses
+// @immutable
+type Quota struct {
+	limits map[string]int
+	burst  int
+	// @mutable
+	hits int
+}
+
+// Limits returns the address of a field directly
+func (q *Quota) Limits() *map[string]int { // ❌ VIOLATION: IMM180
+	return &q.limits
+}
+
+// Burst returns the address of a field through a named result
+func (q *Quota) Burst() (ret *int) { // ❌ VIOLATION: IMM180, the named result holds &q.burst
+	ret = &q.burst
+	return
+}
+
+// BurstOrNil returns the named result explicitly
+func (q *Quota) BurstOrNil(ok bool) (ret *int) { // ❌ VIOLATION: IMM180
+	if ok {
+		ret = &q.burst
+	}
+	return ret
+}
+
+// BurstCopy returns the address of a copy
+func (q *Quota) BurstCopy() (ret *int) { // ✅ OK: a copy of the field
+	burst := q.burst
+	ret = &burst
+	return
+}
+
+// Hits returns the address of a @mutable field
+func (q *Quota) Hits() *int { // ✅ OK: @mutable field
+	return &q.hits
+}
+
+// ValueBurst has a value receiver and returns the address of its copy
+func (q Quota) ValueBurst() *int { // ✅ OK: value receiver
+	return &q.burst
+}
+
+// Upstream is immutable and kept by pointer in a plain map
+// @immutable
+type Upstream struct {
+	Address string
+	Weight  int
+}
+
+func Reweight(upstreams map[string]*Upstream, name string) {
+	upstreams[name].Address = "10.0.0.1" // ❌ VIOLATION: the map holds pointers to immutable values
+	(upstreams[name]).Weight += 1        // ❌ VIOLATION: compound assignment through the map
+	upstreams[name] = &Upstream{}        // ✅ OK: replaces the map element, not a field
+}
+
+// viewportLayout has the same layout as Viewport and no contract of its own
+type viewportLayout struct {
+	Width  int
+	Height int
+}
+
+// Viewport is immutable; converting it to a layout-compatible type does not lift that
+// @immutable
+type Viewport struct {
+	Width  int
+	Height int
+}
+
+// Resize writes through a conversion of the receiver
+func (w *Viewport) Resize(width int) {
+	(*viewportLayout)(w).Width = width               // ❌ VIOLATION: the conversion points at w itself
+	(*viewportLayout)(unsafe.Pointer(w)).Height += 1 // ❌ VIOLATION: through unsafe.Pointer as well
+	layout := viewportLayout(*w)                     // ✅ OK: a converted copy
+	layout.Width = width                             // ✅ OK: writes the copy
+	_ = layout
+}
+
+func Stretch(w *Viewport) {
+	((*viewportLayout)(w)).Height = 2 * w.Height // ❌ VIOLATION: parenthesized conversion
+	(*viewportLayout)(w).Width++                 // ❌ VIOLATION: inc/dec through the conversion
+	scale(w).Height = 0                          // ✅ OK: a call, not a conversion
+}
+
+func scale(w *Viewport) *viewportLayout {
+	return &viewportLayout{Width: w.Width, Height: w.Height}
+}
+
+// Leaderboard is immutable; sorting its slices in place reorders shared storage
+// @immutable
+// @constructor NewLeaderboard
+type Leaderboard struct {
+	Scores []int
+	Names  []string
+	Title  string
+}
+
+func NewLeaderboard(scores []int, names []string) *Leaderboard {
+	board := &Leaderboard{Scores: scores, Names: names}
+	slices.Sort(board.Scores) // ✅ OK: inside constructor
+	return board
+}
+
+// Ranked sorts the scores it was built with
+func (l Leaderboard) Ranked() []int {
+	slices.Sort(l.Scores) // ❌ VIOLATION: the copy shares the backing array
+	return l.Scores
+}
+
+func Shuffle(l *Leaderboard) {
+	slices.Reverse(l.Scores)                                                   // ❌ VIOLATION: reverses the field in place
+	sort.Strings(l.Names)                                                      // ❌ VIOLATION: sorts the field in place
+	slices.SortFunc(l.Names, func(a, b string) int { return len(a) - len(b) }) // ❌ VIOLATION
+	sorted := slices.Clone(l.Scores)                                           // ✅ OK: sorts a copy
+	slices.Sort(sorted)                                                        // ✅ OK
+	_ = slices.Contains(l.Names, l.Title)                                      // ✅ OK: does not write
+}
+
+// Lookup is immutable; a comma-ok form writes its field next to a local
+// @immutable
+type Lookup struct {
+	cache string
+	hits  int
+}
+
+func Refresh(p *Lookup, someMap map[string]string, k string) bool {
+	var ok bool
+	p.cache, ok = someMap[k] // ❌ VIOLATION: p.cache only, ok is a local
+	var local Lookup
+	_, ok = someMap[local.cache] // ✅ OK: reads the field
+	return ok
+}
+
+// Keep returns v itself: its result is the caller's value, not a copy
+func Keep[T any](v *T) *T {
+	return v
+}
+
+func TurnKnobCopies(k Knob, knobs []Knob) {
+	Ptr(k).Level++          // ✅ OK: a copy, as in TurnKnob
+	Ptr(knobs[0]).Level = 3 // ✅ OK: a copy of the element
+	Keep(&k).Level = 4      // ❌ VIOLATION: Keep takes *T, so its result may be the caller's value
+}
@@ -0,0 +1,24 @@
+package implementsedgecases
+
+import "fmt"
+
+// Consumer accepts any value.
+type Consumer interface {
+	Accept(value any) error
+	AcceptAll(values map[string][]any)
+}
+
+// StringerConsumer takes a fmt.Stringer, which any would accept, but parameter
+// types must be identical, so this does NOT implement Consumer.
+// @implements Consumer
+type StringerConsumer struct{}
+
+func (StringerConsumer) Accept(value fmt.Stringer) error   { return nil }
+func (StringerConsumer) AcceptAll(values map[string][]any) {}
+
+// EmptyInterfaceConsumer spells any as interface{}, which is the same type.
+// @implements Consumer
+type EmptyInterfaceConsumer struct{}
+
+func (EmptyInterfaceConsumer) Accept(value interface{}) error            { return nil }
+func (EmptyInterfaceConsumer) AcceptAll(values map[string][]interface{}) {}
@@ -0,0 +1,27 @@
+package implementsedgecases
+
+import "context"
+
+// BlankDoer names its parameters with the blank identifier, including a
+// grouped pair; only the parameter types take part in matching.
+type BlankDoer interface {
+	Do(_ context.Context, x int) error
+	Merge(_, _ string, n int) (_ int, err error)
+}
+
+// NamedDoer implements BlankDoer with fully named parameters.
+// @implements BlankDoer
+type NamedDoer struct{}
+
+func (NamedDoer) Do(ctx context.Context, x int) error { return nil }
+
+func (NamedDoer) Merge(a, b string, n int) (int, error) { return n, nil }
+
+// ShortMergeDoer drops one of the grouped blank parameters, so it must not
+// match BlankDoer.
+// @implements BlankDoer
+type ShortMergeDoer struct{}
+
+func (ShortMergeDoer) Do(_ context.Context, _ int) error { return nil }
+
+func (ShortMergeDoer) Merge(a string, n int) (int, error) { return n, nil }
@@ -0,0 +1,43 @@
+package implementsedgecases
+
+import (
+	"context"
+	"time"
+)
+
+// DoneContext implements context.Context; Done returns the receive-only
+// channel the interface requires.
+// @implements context.Context
+type DoneContext struct {
+	done chan struct{}
+}
+
+func (c DoneContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (c DoneContext) Done() <-chan struct{}       { return c.done }
+func (c DoneContext) Err() error                  { return nil }
+func (c DoneContext) Value(key any) any           { return nil }
+
+// BidirectionalContext returns a bidirectional channel from Done, which does
+// NOT match <-chan struct{}.
+// @implements context.Context
+type BidirectionalContext struct {
+	done chan struct{}
+}
+
+func (c BidirectionalContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (c BidirectionalContext) Done() chan struct{}         { return c.done }
+func (c BidirectionalContext) Err() error                  { return nil }
+func (c BidirectionalContext) Value(key any) any           { return nil }
+
+// SendOnlyContext returns a send-only channel from Done.
+// @implements context.Context
+type SendOnlyContext struct {
+	done chan struct{}
+}
+
+func (c SendOnlyContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (c SendOnlyContext) Done() chan<- struct{}       { return c.done }
+func (c SendOnlyContext) Err() error                  { return nil }
+func (c SendOnlyContext) Value(key any) any           { return nil }
+
+var _ context.Context = DoneContext{}
@@ -0,0 +1,32 @@
+//go:build go1.18
+
+package implementsedgecases
+
+// ByteSink writes byte slices.
+type ByteSink interface {
+	Write(p []byte) (int, error)
+}
+
+// ExactBuffer's element type can only be []byte, so Write(p E) takes a []byte
+// in every instantiation and ExactBuffer implements ByteSink.
+// @implements &ByteSink
+type ExactBuffer[E []byte] struct {
+	chunks []E
+}
+
+func (b *ExactBuffer[E]) Write(p E) (int, error) {
+	b.chunks = append(b.chunks, p)
+	return len(p), nil
+}
+
+// TildeBuffer's element type may be any named byte slice type, which is not
+// identical to []byte, so it does NOT implement ByteSink.
+// @implements &ByteSink
+type TildeBuffer[E ~[]byte] struct {
+	chunks []E
+}
+
+func (b *TildeBuffer[E]) Write(p E) (int, error) {
+	b.chunks = append(b.chunks, p)
+	return len(p), nil
+}
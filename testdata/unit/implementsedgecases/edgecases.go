@@ -1,5 +1,7 @@
 package implementsedgecases
 
+import "io"
+
 // Reader is satisfied by a single Foo() method.
 type Reader interface {
 	Foo()
@@ -47,3 +49,88 @@ type DoublePtr interface {
 type SinglePtrImpl struct{}
 
 func (SinglePtrImpl) Take(*int) {}
+
+// ReaderFromImpl implements io.ReaderFrom, whose ReadFrom parameter is written
+// as the unqualified Reader inside package io. The parameter must resolve to
+// io.Reader so the explicit io.Reader here is not a false mismatch.
+// @implements io.ReaderFrom
+type ReaderFromImpl struct{}
+
+func (ReaderFromImpl) ReadFrom(r io.Reader) (n int64, err error) {
+	return 0, nil
+}
+
+// ViaPointerImpl only has Foo on *ViaPointerImpl. The "via *T" clause checks
+// the pointer method set, so Reader is satisfied without writing &Reader.
+// @implements Reader via *ViaPointerImpl
+type ViaPointerImpl struct{}
+
+func (*ViaPointerImpl) Foo() {}
+
+// ViaValueImpl also only has Foo on the pointer receiver. "via T" forces the
+// value method set even though &Reader is written, so Foo is missing.
+// @implements &Reader via ViaValueImpl
+type ViaValueImpl struct{}
+
+func (*ViaValueImpl) Foo() {}
+
+// ViaRenamed was renamed from Old, but its via clause still names the old
+// type. The clause is reported and Reader is still checked: Foo is missing.
+// @implements Reader via *Old
+type ViaRenamed struct{}
+
+// Store has several methods so a mismatch on one of them is easier to read
+// with the full method sets printed.
+type Store interface {
+	Get(key string) ([]byte, error)
+	Put(key string, value []byte) error
+	Close() error
+}
+
+// PartialStore gets Put's value type wrong and only has Close on the pointer
+// receiver, so it does NOT implement Store.
+// @implements Store
+type PartialStore struct{}
+
+func (PartialStore) Get(key string) ([]byte, error) {
+	return nil, nil
+}
+
+func (PartialStore) Put(key string, value string) error {
+	return nil
+}
+
+func (*PartialStore) Close() error {
+	return nil
+}
+
+// ReaderWrapper satisfies io.Reader only through the embedded interface, so
+// the annotation is trivially true and Read panics while the field is nil.
+// @implements io.Reader
+type ReaderWrapper struct {
+	io.Reader
+}
+
+// CountingReader embeds io.Reader but overrides Read, so the annotation
+// describes its own method and is not redundant.
+// @implements io.Reader
+type CountingReader struct {
+	io.Reader
+	n int
+}
+
+func (c *CountingReader) Read(p []byte) (int, error) {
+	c.n += len(p)
+	return c.Reader.Read(p)
+}
+
+// ReadCloserImpl gets Read from the embedded io.Reader but declares Close
+// itself, so not every method comes from the embedded interface.
+// @implements &io.ReadCloser
+type ReadCloserImpl struct {
+	io.Reader
+}
+
+func (*ReadCloserImpl) Close() error {
+	return nil
+}
@@ -0,0 +1,21 @@
+package implementsedgecases
+
+// CodedError embeds the predeclared error interface, so Error() string is
+// part of its method set.
+type CodedError interface {
+	error
+	Code() int
+}
+
+// NotFound has both methods.
+// @implements CodedError
+type NotFound struct{}
+
+func (NotFound) Error() string { return "not found" }
+func (NotFound) Code() int     { return 404 }
+
+// CodeOnly lacks the Error method inherited from error.
+// @implements CodedError
+type CodeOnly struct{}
+
+func (CodeOnly) Code() int { return 500 }
@@ -0,0 +1,23 @@
+package implementsedgecases
+
+// Lookup is the public contract of a key-value cache.
+type Lookup interface {
+	Get(key string) (string, bool)
+	Len() int
+}
+
+// getter is unexported, but its methods are exported, so a struct embedding
+// it gets Get promoted into its method set.
+type getter interface {
+	Get(key string) (string, bool)
+}
+
+// CachedLookup gets Get from the unexported embedded interface and declares
+// Len itself.
+// @implements Lookup
+type CachedLookup struct {
+	getter
+	size int
+}
+
+func (c CachedLookup) Len() int { return c.size }
@@ -0,0 +1,28 @@
+package implementsedgecases
+
+// Builder is a fluent interface: its methods return the interface itself.
+type Builder interface {
+	With(n int) Builder
+	Build() int
+}
+
+// FluentBuilder returns Builder from With, as the interface requires.
+// @implements &Builder
+type FluentBuilder struct {
+	total int
+}
+
+func (b *FluentBuilder) With(n int) Builder { b.total += n; return b }
+func (b *FluentBuilder) Build() int         { return b.total }
+
+// ConcreteBuilder returns its own type from With. Go result types must be
+// identical, so this does NOT implement Builder.
+// @implements &Builder
+type ConcreteBuilder struct {
+	total int
+}
+
+func (b *ConcreteBuilder) With(n int) *ConcreteBuilder { b.total += n; return b }
+func (b *ConcreteBuilder) Build() int                  { return b.total }
+
+var _ Builder = (*FluentBuilder)(nil)
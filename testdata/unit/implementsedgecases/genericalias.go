@@ -0,0 +1,37 @@
+package implementsedgecases
+
+// Sink is a generic interface.
+type Sink[T any] interface {
+	Write(v T) error
+}
+
+// SinkOf is a generic type alias (Go 1.24+) for Sink.
+type SinkOf[T any] = Sink[T]
+
+// StringSink instantiates the generic alias; @implements must follow it to
+// the instantiated Sink[string] interface.
+type StringSink = SinkOf[string]
+
+// IntSink aliases a plain instantiation of the generic interface.
+type IntSink = Sink[int]
+
+// StringWriter writes strings, so it implements StringSink.
+// @implements StringSink
+type StringWriter struct{}
+
+func (StringWriter) Write(v string) error {
+	return nil
+}
+
+// IntWriterMismatch writes strings, so it does NOT implement IntSink.
+// @implements IntSink
+type IntWriterMismatch struct{}
+
+func (IntWriterMismatch) Write(v string) error {
+	return nil
+}
+
+// GenericAliasTarget names the generic alias without type arguments, which
+// cannot be resolved to an interface.
+// @implements SinkOf
+type GenericAliasTarget struct{}
@@ -0,0 +1,37 @@
+package implementsedgecases
+
+// Container is a generic interface whose methods use its element type.
+type Container[T any] interface {
+	Get(i int) T
+	Put(v T)
+	Len() int
+}
+
+// IntBag embeds an instantiation of Container; its method set is Container's
+// with T = int, plus Reset.
+type IntBag interface {
+	Container[int]
+	Reset()
+}
+
+// SliceBag stores ints, so it implements IntBag.
+// @implements &IntBag
+type SliceBag struct {
+	items []int
+}
+
+func (b *SliceBag) Get(i int) int { return b.items[i] }
+func (b *SliceBag) Put(v int)     { b.items = append(b.items, v) }
+func (b *SliceBag) Len() int      { return len(b.items) }
+func (b *SliceBag) Reset()        { b.items = nil }
+
+// StringBag stores strings, so Get and Put do NOT match Container[int].
+// @implements &IntBag
+type StringBag struct {
+	items []string
+}
+
+func (b *StringBag) Get(i int) string { return b.items[i] }
+func (b *StringBag) Put(v string)     { b.items = append(b.items, v) }
+func (b *StringBag) Len() int         { return len(b.items) }
+func (b *StringBag) Reset()           { b.items = nil }
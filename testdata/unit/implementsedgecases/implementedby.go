@@ -0,0 +1,39 @@
+package implementsedgecases
+
+import (
+	"bytes"
+	"strings"
+)
+
+// Repository lists its implementers. ReadOnlyRepository lacks Put and Ghost is not
+// declared anywhere.
+// @implementedby MemRepository, *FileRepository, ReadOnlyRepository, Ghost
+type Repository interface {
+	Get(key string) (string, bool)
+	Put(key, value string)
+}
+
+type MemRepository struct{ data map[string]string }
+
+func (s MemRepository) Get(key string) (string, bool) { v, ok := s.data[key]; return v, ok }
+func (s MemRepository) Put(key, value string)         { s.data[key] = value }
+
+type FileRepository struct{ path string }
+
+func (s *FileRepository) Get(key string) (string, bool) { return s.path, false }
+func (s *FileRepository) Put(key, value string)         { s.path = value }
+
+type ReadOnlyRepository struct{}
+
+func (ReadOnlyRepository) Get(key string) (string, bool) { return "", false }
+
+// TextSink lists implementers from imported packages. The value method set
+// of bytes.Buffer has no WriteString.
+// @implementedby *strings.Builder, *bytes.Buffer, bytes.Buffer
+type TextSink interface {
+	WriteString(s string) (int, error)
+	String() string
+}
+
+// upper keeps the imports of the listed types
+func upper(b *bytes.Buffer) string { return strings.ToUpper(b.String()) }
@@ -0,0 +1,15 @@
+package implementsedgecases
+
+import "io"
+
+// PluginReader implements io.Reader but not io.Writer, which satisfies the group.
+// @implements-oneof io.Reader, io.Writer
+type PluginReader struct{}
+
+func (PluginReader) Read(p []byte) (int, error) { return 0, io.EOF }
+
+// PluginNone implements neither io.Reader nor io.Writer.
+// @implements-oneof io.Reader, io.Writer
+type PluginNone struct{}
+
+func (PluginNone) Close() error { return nil }
@@ -0,0 +1,12 @@
+package implementsedgecases
+
+// Cursor only has Foo on the pointer receiver, so the value-mode annotation
+// fails and writing &Reader would fix it.
+// @implements Reader
+type Cursor struct{}
+
+func (*Cursor) Foo() {}
+
+// Slider lacks Foo on both receivers, so adding & would not help.
+// @implements Reader
+type Slider struct{}
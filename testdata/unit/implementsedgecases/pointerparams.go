@@ -0,0 +1,23 @@
+package implementsedgecases
+
+// Job is handed to a Runner.
+type Job struct {
+	ID int
+}
+
+// Runner takes its job by pointer.
+type Runner interface {
+	Run(job *Job, retries int) error
+}
+
+// ValueRunner takes the job by value, which is a different parameter type.
+// @implements Runner
+type ValueRunner struct{}
+
+func (ValueRunner) Run(job Job, retries int) error { return nil }
+
+// PointerRunner matches Runner exactly.
+// @implements Runner
+type PointerRunner struct{}
+
+func (PointerRunner) Run(job *Job, retries int) error { return nil }
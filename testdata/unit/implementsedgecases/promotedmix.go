@@ -0,0 +1,33 @@
+package implementsedgecases
+
+import "io"
+
+// closer provides Close, and a Read that does not match io.Reader.
+type closer struct{}
+
+func (closer) Close() error       { return nil }
+func (closer) Read(p []byte) bool { return false }
+
+// FileHandle declares Read itself and gets Close promoted from closer. Its own
+// Read shadows closer's, so it implements io.ReadCloser.
+// @implements io.ReadCloser
+type FileHandle struct {
+	closer
+	data []byte
+}
+
+func (h FileHandle) Read(p []byte) (int, error) { return copy(p, h.data), nil }
+
+// The compiler agrees
+var _ io.ReadCloser = FileHandle{}
+
+// ShadowedHandle declares a Close that does not match io.Closer. It shadows
+// the promoted Close, so it does NOT implement io.ReadCloser.
+// @implements io.ReadCloser
+type ShadowedHandle struct {
+	closer
+	data []byte
+}
+
+func (h ShadowedHandle) Read(p []byte) (int, error) { return copy(p, h.data), nil }
+func (h ShadowedHandle) Close()                     {}
@@ -0,0 +1,19 @@
+package implementsedgecases
+
+// Methods of the types declared in splittype.go
+
+func (l Ledger) Append(entry string) int { return len(l.entries) + 1 }
+func (l Ledger) Entries() []string       { return l.entries }
+
+func (l *PointerLedger) Append(entry string) int {
+	l.entries = append(l.entries, entry)
+	return len(l.entries)
+}
+
+func (l *PointerLedger) Entries() []string { return l.entries }
+
+// The compiler agrees
+var (
+	_ Journal = Ledger{}
+	_ Journal = &PointerLedger{}
+)
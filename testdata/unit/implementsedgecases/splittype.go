@@ -0,0 +1,20 @@
+package implementsedgecases
+
+// Journal is implemented by types whose methods live in splitmethods.go
+type Journal interface {
+	Append(entry string) int
+	Entries() []string
+}
+
+// Ledger is declared here; all of its methods are in splitmethods.go.
+// @implements Journal
+type Ledger struct {
+	entries []string
+}
+
+// PointerLedger is declared here; its pointer-receiver methods are in
+// splitmethods.go.
+// @implements &Journal
+type PointerLedger struct {
+	entries []string
+}
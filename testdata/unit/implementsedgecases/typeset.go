@@ -0,0 +1,34 @@
+package implementsedgecases
+
+import "fmt"
+
+// Numeric is a type set: it constrains type parameters and has no methods
+type Numeric = interface {
+	~int | ~float64
+}
+
+// Ordered is a defined constraint interface with a method on top of its terms
+type Ordered interface {
+	~int | ~string
+	fmt.Stringer
+}
+
+// Celsius is annotated with a type set by mistake
+// @implements Numeric
+type Celsius float64 // ❌ VIOLATION: IMPL80, Numeric only constrains type parameters
+
+// Rank has both the underlying type and the method of Ordered, but a type
+// set is still not implementable
+// @implements Ordered
+type Rank int // ❌ VIOLATION: IMPL80
+
+func (r Rank) String() string { return fmt.Sprint(int(r)) }
+
+// Sum accepts any Numeric type, which is how the type set is meant to be used
+func Sum[T Numeric](values ...T) T {
+	var total T
+	for _, v := range values {
+		total += v
+	}
+	return total
+}
@@ -0,0 +1,32 @@
+package inventorytests
+
+import "io"
+
+// Document is listed with all of its annotations
+// @implements &io.Reader
+// @immutable
+// @constructor NewDocument, ParseDocument
+type Document struct {
+	title string
+
+	// @mutable
+	views int
+}
+
+// NewDocument creates a Document
+func NewDocument(title string) *Document {
+	return &Document{title: title}
+}
+
+// ParseDocument creates a Document from its text
+func ParseDocument(text string) *Document {
+	return &Document{title: text}
+}
+
+func (d *Document) Read(p []byte) (int, error) {
+	return copy(p, d.title), io.EOF
+}
+
+// Reset is only called by tests
+// @testonly
+func (d *Document) Reset() {}
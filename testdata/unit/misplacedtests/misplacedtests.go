@@ -0,0 +1,47 @@
+package misplacedtests
+
+// Settings is an immutable type with a mutable cache field
+// @immutable
+type Settings struct {
+	Name string
+
+	// @mutable
+	cache map[string]string // ✅ OK: @mutable belongs on fields
+
+	// @immutable
+	Limits []int // ❌ VIOLATION: ANN01, @immutable on a field
+}
+
+// Handler is annotated with a field annotation
+// @mutable
+type Handler struct{} // ❌ VIOLATION: ANN01, @mutable on a type
+
+// Reader is not an interface, so it cannot list implementers
+// @implementedby Handler
+type Reader struct{} // ❌ VIOLATION: ANN01, @implementedby on a struct type
+
+// Defaults builds the default settings
+// @immutable
+func Defaults() Settings { // ❌ VIOLATION: ANN01, @immutable on a function
+	return Settings{Name: "default"}
+}
+
+// Describe is only used by tests
+// @testonly
+// @since v1.0.0
+func (s Settings) Describe() string { // ✅ OK: @testonly and @since apply to methods
+	return s.Name
+}
+
+// Render implements fmt.Stringer
+// @implements fmt.Stringer
+func (h Handler) Render() string { // ❌ VIOLATION: ANN01, @implements on a method
+	return ""
+}
+
+// Legacy keeps the old behavior
+// @ignore ANN01
+// @constructor Legacy
+func Legacy() Handler { // ✅ OK: suppressed, and @ignore itself applies everywhere
+	return Handler{}
+}
@@ -0,0 +1,65 @@
+package mustreturntests
+
+import "strings"
+
+// Normalize returns a normalized copy of name; name itself is unchanged
+// @mustreturn
+func Normalize(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// Pair returns its arguments in order
+// @mustreturn
+func Pair(a, b int) (int, int) {
+	return min(a, b), max(a, b)
+}
+
+// Set is a persistent set: Add returns a new set
+type Set struct {
+	items []string
+}
+
+// Add returns a copy of the set with item added
+// @mustreturn
+func (s *Set) Add(item string) *Set {
+	return &Set{items: append(append([]string(nil), s.items...), item)}
+}
+
+// Len is not @mustreturn
+func (s *Set) Len() int {
+	return len(s.items)
+}
+
+func discard(s *Set) {
+	Normalize(" Name ") // ❌ VIOLATION: RET01, result discarded
+	_ = Normalize("x")  // ❌ VIOLATION: RET01, result assigned to _
+	_, _ = Pair(2, 1)   // ❌ VIOLATION: RET01, every result assigned to _
+	s.Add("a")          // ❌ VIOLATION: RET01, method result discarded
+	(s.Add)("b")        // ❌ VIOLATION: RET01, parenthesized method value
+	s.Len()             // ✅ OK: not @mustreturn
+}
+
+func use(s *Set) string {
+	name := Normalize(" Name ") // ✅ OK: result is used
+	low, _ := Pair(2, 1)        // ✅ OK: one result is used
+	s = s.Add(name)             // ✅ OK: result is used
+	_ = s.Len()
+	return name + strings.Repeat("!", low)
+}
+
+func shadowed() {
+	Normalize := func(string) string { return "" }
+	Normalize("x") // ✅ OK: local function, not the @mustreturn one
+}
+
+var _ = Normalize("package") // ❌ VIOLATION: RET01, package-level blank declaration
+
+func discardInStatements(s *Set) {
+	go Normalize("x")          // ❌ VIOLATION: RET01, result of a goroutine is lost
+	defer s.Add("c")           // ❌ VIOLATION: RET01, result of a deferred call is lost
+	var _ = Normalize("y")     // ❌ VIOLATION: RET01, declared to _
+	var _, _ = Pair(3, 4)      // ❌ VIOLATION: RET01, every result declared to _
+	var kept, _ = Pair(3, 4)   // ✅ OK: one result is kept
+	go func() { _ = kept }()   // ✅ OK: not @mustreturn
+	defer func() { s.Len() }() // ✅ OK: not @mustreturn
+}
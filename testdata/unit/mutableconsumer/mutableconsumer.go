@@ -0,0 +1,11 @@
+package mutableconsumer
+
+import "github.com/a14e/gogreement/testdata/unit/mutablesource"
+
+func Touch(a *mutablesource.Account) {
+	a.Hits = 0          // ✅ OK: @mutable field, also from another package
+	a.Hits += 2         // ✅ OK: @mutable field
+	a.Tags = nil        // ✅ OK: @mutable field
+	a.Owner = "someone" // ❌ VIOLATION: IMM01, Owner is not @mutable
+	a.Balance++         // ❌ VIOLATION: IMM03, Balance is not @mutable
+}
@@ -0,0 +1,36 @@
+package mutablesource
+
+// Account is immutable apart from its @mutable cache fields
+// @immutable
+// @constructor NewAccount
+type Account struct {
+	Owner   string
+	Balance int
+	// @mutable
+	Hits int
+	// @mutable
+	Tags []string
+}
+
+func NewAccount(owner string) *Account {
+	return &Account{Owner: owner}
+}
+
+// Note is not immutable, so its @mutable field changes nothing
+type Note struct {
+	// @mutable
+	Text string
+	Seen bool
+}
+
+func (a *Account) Visit() {
+	a.Hits++                     // ✅ OK: @mutable field
+	a.Tags = append(a.Tags, "v") // ✅ OK: @mutable field
+	a.Tags[0] = "first"          // ✅ OK: index write into a @mutable field
+	a.Balance = 0                // ❌ VIOLATION: IMM01, Balance is not @mutable
+}
+
+func Annotate(n *Note) {
+	n.Text = "read" // ✅ OK: Note is not immutable
+	n.Seen = true   // ✅ OK: Note is not immutable
+}
@@ -50,3 +50,24 @@ type RegularStruct struct {
 func (s *RegularStruct) RegularMethod() string {
 	return s.data
 }
+
+// Function values of @packageonly functions stored in exported variables
+// would let any package call them.
+
+var ExportedFunctionRef = PackageOnlyFunction // ❌ VIOLATION: PKGO04, exported var holds the function value
+
+var localFunctionRef = PackageOnlyFunction // ✅ OK: unexported variable
+
+var ExportedResult = PackageOnlyFunction() // ✅ OK: stores the result, not the function
+
+var ExportedRegularRef = RegularFunction // ✅ OK: no @packageonly restriction
+
+var ExportedAssignedLater func() int
+
+func init() {
+	ExportedAssignedLater = PackageOnlyFunction // ❌ VIOLATION: PKGO04, assigned to exported var
+
+	local := PackageOnlyFunction // ✅ OK: local variable
+	_ = local
+	_ = localFunctionRef
+}
@@ -55,3 +55,7 @@ func useRegularItems() {
 	var regularStruct packageonlysource.RegularStruct
 	regularStruct.RegularMethod() // This should be fine
 }
+
+// A forbidden package re-exporting the function value is already a PKGO02
+// reference; it must not additionally be reported as PKGO04.
+var Reexported = packageonlysource.PackageOnlyFunction
@@ -0,0 +1,44 @@
+package publiconlytests
+
+// Config is part of the exported API
+// @immutable
+type Config struct {
+	Name string
+}
+
+// settings is an implementation detail
+// @immutable
+type settings struct {
+	name string
+}
+
+// Rename mutates the exported immutable type
+func Rename(c *Config) {
+	c.Name = "renamed" // IMM01: reported
+}
+
+// rename mutates the unexported immutable type
+func rename(s *settings) {
+	s.name = "renamed" // not enforced under --public-only
+}
+
+// Reset is an exported method of an unexported type
+// @testonly
+func (s *settings) Reset() {
+	s.name = ""
+}
+
+// Build is exported
+// @testonly
+func Build() *Config {
+	return &Config{}
+}
+
+// build is unexported
+// @testonly
+func build() *settings {
+	return &settings{}
+}
+
+var _ = rename
+var _ = build
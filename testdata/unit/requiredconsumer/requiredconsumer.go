@@ -0,0 +1,34 @@
+package requiredconsumer
+
+import "github.com/a14e/gogreement/testdata/unit/requiredsource"
+
+func complete() requiredsource.Server {
+	return requiredsource.Server{Addr: ":8080", Handler: "api"} // ✅ OK: every required field is set
+}
+
+func zeroValueIsStillSet() requiredsource.Server {
+	return requiredsource.Server{Addr: "", Handler: ""} // ✅ OK: presence by key, the value may be zero
+}
+
+func positional() requiredsource.Server {
+	return requiredsource.Server{":8080", "api", 30} // ✅ OK: positional literals set every field
+}
+
+func missingHandler() *requiredsource.Server {
+	return &requiredsource.Server{Addr: ":8080", Timeout: 5} // ❌ VIOLATION: REQ01, Handler is not set
+}
+
+func empty() requiredsource.Server {
+	return requiredsource.Server{} // ❌ VIOLATION: REQ01, Addr and Handler are not set
+}
+
+func elided() []requiredsource.Server {
+	return []requiredsource.Server{
+		{Addr: ":1", Handler: "a"}, // ✅ OK
+		{Addr: ":2"},               // ❌ VIOLATION: REQ01, Handler is not set in an elided literal
+	}
+}
+
+func noRequiredFields() requiredsource.Options {
+	return requiredsource.Options{} // ✅ OK: no @required fields
+}
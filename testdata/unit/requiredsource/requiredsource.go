@@ -0,0 +1,32 @@
+package requiredsource
+
+// Server must be built with an address and a handler name
+type Server struct {
+	// @required
+	Addr string
+
+	// @required
+	Handler string
+
+	Timeout int
+}
+
+// Point has a required coordinate in an unexported field
+type Point struct {
+	// @required
+	x int
+	y int
+}
+
+// Options has no required fields
+type Options struct {
+	Verbose bool
+}
+
+func NewPoint(x, y int) Point {
+	return Point{x: x, y: y} // ✅ OK: both fields set
+}
+
+func origin() Point {
+	return Point{y: 0} // ❌ VIOLATION: REQ01, x is not set
+}
@@ -0,0 +1,36 @@
+package sincetests
+
+// Client was added in the first release.
+// @since v1.0.0
+type Client struct {
+	addr string
+}
+
+// NewClient creates a Client.
+// @since v1.0.0
+func NewClient(addr string) *Client {
+	return &Client{addr: addr}
+}
+
+// Ping was added in a pre-release.
+// @since v1.2.0-rc.1
+func (c *Client) Ping() error {
+	return nil
+}
+
+// Retry has a version without the "v" prefix.
+// @since 1.3.0
+func (c *Client) Retry() {} // ❌ VIOLATION: SINCE01, not a Go semantic version
+
+// Timeout has a shorthand version.
+// @since v1.4
+func Timeout() int { // ❌ VIOLATION: SINCE01, missing patch component
+	return 0
+}
+
+// Options has no version at all.
+// @since
+type Options struct{} // ❌ VIOLATION: SINCE01, missing version
+
+// Legacy has no @since annotation.
+func Legacy() {}
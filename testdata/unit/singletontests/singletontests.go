@@ -0,0 +1,45 @@
+package singletontests
+
+// Registry holds every plugin of the process
+// @singleton
+type Registry struct {
+	plugins []string
+}
+
+// Level is a @singleton type that is never constructed twice
+// @singleton
+type Level int
+
+// Options is not a @singleton
+type Options struct {
+	Verbose bool
+}
+
+var registry = &Registry{} // ✅ OK: first construction
+
+func Default() *Registry {
+	return registry
+}
+
+func rebuild() *Registry {
+	return &Registry{plugins: []string{"a"}} // ❌ VIOLATION: SGL01, second construction
+}
+
+func allocate() *Registry {
+	return new(Registry) // ❌ VIOLATION: SGL01, new() constructs a Registry
+}
+
+func zero() Registry {
+	var r Registry // ❌ VIOLATION: SGL01, zero value declaration
+	return r
+}
+
+func pointers() **Registry {
+	var p *Registry // ✅ OK: a nil pointer constructs nothing
+	_ = p
+	return new(*Registry) // ✅ OK: new(*T) allocates a nil pointer
+}
+
+func options() []Options {
+	return []Options{{}, {Verbose: true}} // ✅ OK: not a @singleton
+}
@@ -0,0 +1,15 @@
+package testonlyfile
+
+// Production code consuming symbols from the file-level @testonly file
+
+func UseFixtures() {
+	f := NewFixture("prod") // ❌ VIOLATION: NewFixture is test-only (TONL02)
+	f.Reset()               // ❌ VIOLATION: Reset is test-only (TONL03)
+	_ = Seed()              // ❌ VIOLATION: Seed is test-only (TONL02)
+	_ = newHelper()         // ✅ OK: unexported, not covered by the file-level annotation
+}
+
+func DeclareFixture() {
+	var f Fixture // ❌ VIOLATION: Fixture is test-only (TONL01)
+	_ = f
+}
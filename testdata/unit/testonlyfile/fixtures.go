@@ -0,0 +1,32 @@
+// Fixtures for tests. Every exported declaration in this file is test-only.
+// @testonly
+package testonlyfile
+
+// Fixture is test-only through the file-level annotation
+type Fixture struct {
+	Name  string
+	inner helper
+}
+
+// helper is unexported, so the file-level annotation does not cover it
+type helper struct{}
+
+// NewFixture is test-only through the file-level annotation
+func NewFixture(name string) *Fixture {
+	return &Fixture{Name: name, inner: helper{}} // ✅ OK: usage inside the @testonly file
+}
+
+// Reset is test-only through the file-level annotation
+func (f *Fixture) Reset() {
+	f.Name = ""
+}
+
+// Seed carries its own @testonly as well; it must not be duplicated
+// @testonly
+func Seed() []Fixture {
+	return []Fixture{*NewFixture("seed")} // ✅ OK: usage inside the @testonly file
+}
+
+func newHelper() helper {
+	return helper{}
+}
@@ -0,0 +1,82 @@
+package testonlyiface
+
+import "io"
+
+// Resetter clears accumulated state
+type Resetter interface {
+	Reset()
+}
+
+// ResetAll is production code; it only sees the interface
+func ResetAll(resetters []Resetter) {
+	for _, r := range resetters {
+		r.Reset() // reaches Store.Reset without naming it
+	}
+}
+
+// Store is a production type whose Reset is meant for tests only
+// @implements &Resetter
+type Store struct { // ❌ VIOLATION: TONL20, Reset implements Resetter.Reset
+	items []string
+}
+
+func (s *Store) Add(item string) {
+	s.items = append(s.items, item)
+}
+
+// Reset clears the store between tests
+// @testonly
+func (s *Store) Reset() {
+	s.items = nil
+}
+
+// Conn exposes a @testonly method through an imported interface
+// @implements &io.Closer
+type Conn struct{} // ❌ VIOLATION: TONL20, Close implements io.Closer.Close
+
+// Close drops the connection of a test
+// @testonly
+func (c *Conn) Close() error {
+	return nil
+}
+
+// Shutdown is production code closing any io.Closer
+func Shutdown(closer io.Closer) error {
+	return closer.Close()
+}
+
+// Cache implements Resetter with a production method
+// @implements &Resetter
+type Cache struct{} // ✅ OK: Reset is not @testonly
+
+func (c *Cache) Reset() {}
+
+// Buffer has a @testonly method that is not part of io.Writer
+// @implements &io.Writer
+type Buffer struct{} // ✅ OK: Snapshot is not an interface method
+
+func (b *Buffer) Write(p []byte) (int, error) {
+	return len(p), nil
+}
+
+// Snapshot returns the written bytes for assertions
+// @testonly
+func (b *Buffer) Snapshot() []byte {
+	return nil
+}
+
+// Fake is test support as a whole
+// @testonly
+// @implements &Resetter
+type Fake struct{} // ✅ OK: a @testonly type
+
+// @testonly
+func (f *Fake) Reset() {}
+
+// Legacy keeps its test-only Reset for now
+// @ignore TONL20
+// @implements &Resetter
+type Legacy struct{} // ✅ OK: ignored
+
+// @testonly
+func (l *Legacy) Reset() {}
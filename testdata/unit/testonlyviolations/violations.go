@@ -125,3 +125,41 @@ type GenericMock[T any] struct {
 func UseGenericTestOnlyType() {
 	_ = GenericMock[int]{} // VIOLATION: @testonly generic type used (TONL01)
 }
+
+// Resetter is satisfied by Store, whose Reset is @testonly.
+type Resetter interface {
+	Reset()
+}
+
+type Store struct{}
+
+// @testonly
+func (s *Store) Reset() {}
+
+// Cache also implements Resetter; its Reset is production code.
+type Cache struct{}
+
+func (c *Cache) Reset() {}
+
+func ResetThroughConcrete() {
+	s := &Store{}
+	s.Reset() // VIOLATION: concrete-typed variable (TONL03)
+}
+
+func ResetThroughInterface() {
+	var r Resetter = &Store{}
+	r.Reset() // VIOLATION: the only dynamic type is *Store (TONL03)
+	r = nil
+}
+
+func ResetThroughParameter(r Resetter) {
+	r.Reset() // NOT a violation: dynamic type unknown
+}
+
+func ResetThroughMixed(useCache bool) {
+	var r Resetter = &Store{}
+	if useCache {
+		r = &Cache{}
+	}
+	r.Reset() // NOT a violation: *Store or *Cache
+}